@@ -17,6 +17,7 @@ import (
 	"github.com/hortbot/hortbot/internal/cbp"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
+	"github.com/hortbot/hortbot/internal/pkg/jsonx"
 	"github.com/hortbot/hortbot/internal/pkg/must"
 	"github.com/hortbot/hortbot/internal/pkg/stringsx"
 	"github.com/zikaeroh/ctxlog"
@@ -78,6 +79,7 @@ func init() {
 	addExact("SUBMODE_ON", actionSubmode)
 	addExact("SUBMODE_OFF", actionSubmode)
 	addExact("SILENT", actionSilent)
+	addExact("REPLY", actionReply)
 	addExact("NUMCHANNELS", actionNumChannels)
 	addExact("PURGE", actionPurge)
 	addExact("TIMEOUT", actionTimeout)
@@ -102,6 +104,12 @@ func init() {
 	addExact("TWEET_URL", actionTweet)
 	addExact("BOT_HELP", actionBotHelp)
 	addExact("GAME_LINK", actionGameLink)
+	addExact("QUEUE_NEXT", actionQueueNext)
+	addExact("QUEUE_SIZE", actionQueueSize)
+	addExact("QUEUE_POSITION", actionQueuePosition)
+	addExact("RAID_VIEWERS", actionRaidViewers)
+	addExact("FOLLOW_AGE", actionFollowAge)
+	addExact("ACCOUNT_AGE", actionAccountAge)
 
 	addPrefix("PARAMETER_", actionParameterIndex)
 	addPrefix("P_", actionParameterIndex)
@@ -117,13 +125,17 @@ func init() {
 	addPrefix("UNTILSHORT_", actionUntil)
 	addPrefix("RANDOM_", actionRandom)
 	addPrefix("VARS_", actionVars)
+	addPrefix("UVARS_", actionUserVars)
 	addPrefix("COMMAND_", actionCommand)
 	addPrefix("LIST_", actionList)
 	addPrefix("TEXTAPI_", actionTextAPI)
+	addPrefix("JSONAPI_", actionJSONAPI)
 	addPrefix("PESC_", actionPathEscape)
 	addPrefix("QESC_", actionQueryEscape)
 	addPrefix("CAPS_", actionCaps)
 	addPrefix("QUIET_", actionQuiet)
+	addPrefix("LAST_SEEN_", actionLastSeen)
+	addPrefix("MATCH_", actionMatch)
 }
 
 func findAction(action string) actionTopFunc {
@@ -339,6 +351,24 @@ func actionParameterIndex(ctx context.Context, s *session, actionName, value str
 	return "", nil
 }
 
+func actionMatch(ctx context.Context, s *session, actionName, value string) (string, error) {
+	m := s.autoreplyMatch
+	if m == nil {
+		return actionMsgError, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		i = m.re.SubexpIndex(value)
+	}
+
+	if i < 0 || i >= len(m.groups) {
+		return actionMsgError, nil
+	}
+
+	return m.groups[i], nil
+}
+
 func actionMessageCount(ctx context.Context, s *session, actionName, value string) (string, error) {
 	return strconv.FormatInt(s.Channel.MessageCount, 10), nil
 }
@@ -393,6 +423,40 @@ func actionQuote(ctx context.Context, s *session, actionName, value string) (str
 	return q.Quote, nil
 }
 
+func actionQueueNext(ctx context.Context, s *session, actionName, value string) (string, error) {
+	entries, err := s.QueueNext(ctx, 1)
+	if err != nil {
+		return "", err
+	}
+
+	if len(entries) == 0 {
+		return "(Queue empty)", nil
+	}
+
+	return formatQueueEntry(entries[0]), nil
+}
+
+func actionQueueSize(ctx context.Context, s *session, actionName, value string) (string, error) {
+	count, err := s.Queries.CountQueueEntries(ctx, s.Channel.ID)
+	if err != nil {
+		return "", fmt.Errorf("counting queue entries: %w", err)
+	}
+	return strconv.FormatInt(count, 10), nil
+}
+
+func actionQueuePosition(ctx context.Context, s *session, actionName, value string) (string, error) {
+	position, ok, err := s.queuePosition(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		return "(Not in queue)", nil
+	}
+
+	return strconv.FormatInt(position, 10), nil
+}
+
 func actionUser(ctx context.Context, s *session, actionName, value string) (string, error) {
 	return s.User, nil
 }
@@ -401,6 +465,10 @@ func actionUserDisplay(ctx context.Context, s *session, actionName, value string
 	return s.UserDisplay, nil
 }
 
+func actionRaidViewers(ctx context.Context, s *session, actionName, value string) (string, error) {
+	return strconv.Itoa(s.RaidViewers), nil
+}
+
 func actionChannelURL(ctx context.Context, s *session, actionName, value string) (string, error) {
 	return "twitch.tv/" + s.Channel.Name, nil
 }
@@ -422,6 +490,11 @@ func actionSilent(ctx context.Context, s *session, actionName, value string) (st
 	return "", nil
 }
 
+func actionReply(ctx context.Context, s *session, actionName, value string) (string, error) {
+	s.replyMode = replyModeReply
+	return "", nil
+}
+
 func actionNumChannels(ctx context.Context, s *session, actionName, value string) (string, error) {
 	counts, err := s.Queries.CountActiveChannelAssignments(ctx)
 	if err != nil {
@@ -563,10 +636,61 @@ func actionViewers(ctx context.Context, s *session, actionName, value string) (s
 	return strconv.FormatInt(viewers, 10), nil
 }
 
+func actionFollowAge(ctx context.Context, s *session, actionName, value string) (string, error) {
+	if s.UserID == 0 || s.UserID == s.Channel.TwitchID {
+		return actionMsgError, nil
+	}
+
+	since, following, err := followedAt(ctx, s, s.UserID)
+	if err != nil {
+		if _, ok := apiclient.AsError(err); ok {
+			return actionMsgError, nil
+		}
+		return "", err
+	}
+
+	if !following {
+		return "(not following)", nil
+	}
+
+	return chatterAge(since), nil
+}
+
+func actionAccountAge(ctx context.Context, s *session, actionName, value string) (string, error) {
+	u, err := s.Deps.Twitch.GetUserByUsername(ctx, s.User)
+	if err != nil || u.CreatedAt.IsZero() {
+		return actionMsgError, nil //nolint:nilerr
+	}
+	return chatterAge(u.CreatedAt), nil
+}
+
+func actionLastSeen(ctx context.Context, s *session, actionName, value string) (string, error) {
+	name := cleanUsername(value)
+	if name == "" {
+		return actionMsgError, nil
+	}
+
+	chatter, found, err := findChatter(ctx, s, name)
+	if err != nil {
+		return "", err
+	}
+
+	if !found {
+		return "(never)", nil
+	}
+
+	return chatterAge(chatter.LastSeen.Time), nil
+}
+
 func actionUntil(ctx context.Context, s *session, actionName, value string) (string, error) {
 	short := strings.HasSuffix(actionName, "SHORT_")
 
-	t, err := parseUntilTimestamp(value)
+	loc := easternTime
+	if s.Channel.Timezone != "" {
+		loc = s.Channel.Location()
+	}
+
+	t, err := parseUntilTimestamp(value, loc)
 	if err != nil {
 		return actionMsgError, nil //nolint:nilerr
 	}
@@ -759,43 +883,61 @@ func actionVars(ctx context.Context, s *session, actionName, value string) (stri
 		return actionMsgError, nil
 	}
 
-	switch {
-	case value == "GET":
-		v, _, err := s.VarGet(ctx, name)
-		return v, err
-
-	case strings.HasPrefix(value, "GET_"):
-		ch := strings.TrimPrefix(value, "GET_")
+	if ch, ok := strings.CutPrefix(value, "GET_"); ok {
 		if ch == "" {
 			return actionMsgError, nil
 		}
 
 		v, _, err := s.VarGetByChannel(ctx, ch, name)
 		return v, err
+	}
 
-	case strings.HasPrefix(value, "SET_"):
-		value := strings.TrimPrefix(value, "SET_")
+	return actionVarOp(ctx, s, 0, name, value)
+}
+
+func actionUserVars(ctx context.Context, s *session, actionName, value string) (string, error) {
+	name, value := stringsx.SplitByte(value, '_')
+	if name == "" || value == "" || s.UserID == 0 {
+		return actionMsgError, nil
+	}
 
-		if err := s.VarSet(ctx, name, value); err != nil {
+	return actionVarOp(ctx, s, s.UserID, name, value)
+}
+
+func actionVarOp(ctx context.Context, s *session, userID int64, name, op string) (string, error) {
+	switch {
+	case op == "GET":
+		v, _, err := s.VarGet(ctx, userID, name)
+		return v, err
+
+	case strings.HasPrefix(op, "SET_"):
+		value := strings.TrimPrefix(op, "SET_")
+
+		value, _, badValue, err := s.VarSet(ctx, userID, name, value)
+		if err != nil {
 			return "", err
 		}
 
+		if badValue {
+			return actionMsgError, nil
+		}
+
 		return value, nil
 
-	case strings.HasPrefix(value, "INCREMENT_"):
-		incStr := strings.TrimPrefix(value, "INCREMENT_")
-		return actionVarInc(ctx, s, name, incStr, false)
+	case strings.HasPrefix(op, "INCREMENT_"):
+		incStr := strings.TrimPrefix(op, "INCREMENT_")
+		return actionVarInc(ctx, s, userID, name, incStr, false)
 
-	case strings.HasPrefix(value, "DECREMENT_"):
-		decStr := strings.TrimPrefix(value, "DECREMENT_")
-		return actionVarInc(ctx, s, name, decStr, true)
+	case strings.HasPrefix(op, "DECREMENT_"):
+		decStr := strings.TrimPrefix(op, "DECREMENT_")
+		return actionVarInc(ctx, s, userID, name, decStr, true)
 
 	default:
 		return actionMsgError, nil
 	}
 }
 
-func actionVarInc(ctx context.Context, s *session, name, incStr string, dec bool) (string, error) {
+func actionVarInc(ctx context.Context, s *session, userID int64, name, incStr string, dec bool) (string, error) {
 	if incStr == "" {
 		return actionMsgError, nil
 	}
@@ -809,7 +951,7 @@ func actionVarInc(ctx context.Context, s *session, name, incStr string, dec bool
 		inc = 0 - inc
 	}
 
-	v, badVar, err := s.VarIncrement(ctx, name, inc)
+	v, badVar, err := s.VarIncrement(ctx, userID, name, inc)
 	if err != nil {
 		return "", err
 	}
@@ -818,14 +960,16 @@ func actionVarInc(ctx context.Context, s *session, name, incStr string, dec bool
 		return actionMsgError, nil
 	}
 
-	return strconv.FormatInt(v, 10), nil
+	return v, nil
 }
 
 func actionTime(timeFormat string) actionFunc {
 	return func(ctx context.Context, s *session, actionName, value string) (string, error) {
-		loc, err := time.LoadLocation(value)
-		if err != nil {
-			loc = time.UTC // TODO: Default to a per-channel timezone.
+		loc := s.Channel.Location()
+		if value != "" {
+			if l, err := time.LoadLocation(value); err == nil {
+				loc = l
+			}
 		}
 
 		now := time.Now().In(loc)
@@ -849,7 +993,7 @@ func trimSeconds(d string) string {
 
 var easternTime = must.Must(time.LoadLocation("America/New_York"))
 
-func parseUntilTimestamp(timestamp string) (time.Time, error) {
+func parseUntilTimestamp(timestamp string, loc *time.Location) (time.Time, error) {
 	if timestamp == "" {
 		return time.Time{}, errors.New("empty timestamp")
 	}
@@ -864,8 +1008,8 @@ func parseUntilTimestamp(timestamp string) (time.Time, error) {
 	}
 
 	// CoeBot would parse using a not-quite RFC3339 string in the host system's timezone.
-	// Do that here, assuming an Eastern time zone.
-	return dateparse.ParseIn(timestamp, easternTime) //nolint:wrapcheck
+	// Do that here, assuming the channel's time zone (Eastern if unset).
+	return dateparse.ParseIn(timestamp, loc) //nolint:wrapcheck
 }
 
 type commandGuard string
@@ -887,11 +1031,11 @@ func actionCommand(ctx context.Context, s *session, prefix, name string) (string
 
 	ctx = withCommandGuard(ctx, name)
 
-	_, commandMsg, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, false)
+	info, commandMsg, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, false)
 	if err != nil {
 		return "", fmt.Errorf("finding command: %w", err)
 	}
-	if !found {
+	if !found || !info.Enabled {
 		return "", nil
 	}
 
@@ -920,7 +1064,7 @@ func actionList(ctx context.Context, s *session, prefix, name string) (string, e
 	if err != nil {
 		return "", fmt.Errorf("finding command info: %w", err)
 	}
-	if !found || !info.CommandListID.Valid {
+	if !found || !info.Enabled || !info.CommandListID.Valid {
 		return actionMsgError, nil
 	}
 
@@ -944,21 +1088,114 @@ func actionTextAPI(ctx context.Context, s *session, prefix, u string) (string, e
 		return actionMsgError, nil
 	}
 
-	body, err := s.Deps.Simple.Plaintext(ctx, u)
+	body, ok, err := s.fetchAPI(ctx, "text", u, s.Deps.Simple.Plaintext)
+	if err != nil || !ok {
+		return actionMsgError, err
+	}
+
+	return flattenSpace(body), nil
+}
+
+func actionJSONAPI(ctx context.Context, s *session, prefix, value string) (string, error) {
+	if s.Type == sessionAutoreply {
+		return actionMsgError, nil
+	}
+
+	u, path := value, ""
+	if i := strings.LastIndexByte(value, '|'); i >= 0 {
+		u, path = value[:i], value[i+1:]
+	}
+
+	body, ok, err := s.fetchAPI(ctx, "json", u, func(ctx context.Context, u string) (string, error) {
+		b, err := s.Deps.Simple.JSON(ctx, u)
+		return string(b), err
+	})
+	if err != nil || !ok {
+		return actionMsgError, err
+	}
+
+	v, err := jsonx.Extract([]byte(body), path)
+	if err != nil {
+		return actionMsgError, nil
+	}
+
+	return flattenSpace(v), nil
+}
+
+// fetchAPI fetches a URL for the API actions, checking it against the host
+// allowlist and the channel's response cache. If the URL cannot be fetched,
+// ok will be false.
+func (s *session) fetchAPI(ctx context.Context, kind, u string, fetch func(ctx context.Context, u string) (string, error)) (body string, ok bool, err error) {
+	allowed, err := s.apiHostAllowed(ctx, u)
+	if err != nil || !allowed {
+		return "", false, err
+	}
+
+	cacheKey := kind + ":" + u
+	cacheFor := time.Duration(s.Channel.APICacheSeconds) * time.Second
+
+	if cacheFor > 0 {
+		body, ok, err := s.GetAPICache(ctx, cacheKey)
+		if err != nil || ok {
+			return body, ok, err
+		}
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, s.Deps.APITimeout)
+	defer cancel()
+
+	body, err = fetch(fetchCtx, u)
 	if err != nil {
-		// Plaintext will never return an error for a status code.
 		ctxlog.Error(ctx, "error fetching API", ctxlog.PlainError(err))
-		return actionMsgError, nil
+		return "", false, nil
+	}
+
+	if cacheFor > 0 {
+		if err := s.SetAPICache(ctx, cacheKey, body, cacheFor); err != nil {
+			return "", false, err
+		}
 	}
 
-	body = strings.Map(func(r rune) rune {
+	return body, true, nil
+}
+
+// apiHostAllowed reports whether the API actions may fetch the given URL.
+// If no hosts have been allowlisted, all hosts are allowed.
+func (s *session) apiHostAllowed(ctx context.Context, u string) (bool, error) {
+	hosts, err := s.Queries.ListAPIHosts(ctx)
+	if err != nil {
+		return false, fmt.Errorf("listing api hosts: %w", err)
+	}
+
+	if len(hosts) == 0 {
+		return true, nil
+	}
+
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return false, nil
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+
+	for _, allowed := range hosts {
+		if host == allowed || strings.HasSuffix(host, "."+allowed) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func flattenSpace(s string) string {
+	s = strings.Map(func(r rune) rune {
 		if unicode.IsSpace(r) {
 			return ' '
 		}
 		return r
-	}, body)
+	}, s)
 
-	return strings.TrimSpace(body), nil
+	return strings.TrimSpace(s)
 }
 
 func actionPathEscape(ctx context.Context, s *session, actionName, value string) (string, error) {
@@ -2,78 +2,147 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hortbot/hortbot/internal/db/dbsql"
-	"github.com/zikaeroh/ctxlog"
-	"go.uber.org/zap"
 )
 
-func tryAutoreplies(ctx context.Context, s *session) (bool, error) {
-	autoreplies, err := s.Queries.ListAutoreplyMatchers(ctx, s.Channel.ID)
+// autoreplyMatch is the trigger match of the running autoreply, used by the
+// MATCH actions.
+type autoreplyMatch struct {
+	re     *regexp.Regexp
+	groups []string
+}
+
+func tryAutoreplies(ctx context.Context, s *session) (handled bool, err error) {
+	m, err := s.Deps.Matchers.Autoreplies(ctx, s.Queries, s.Channel.ID)
 	if err != nil {
-		return true, fmt.Errorf("querying for autoreplies: %w", err)
+		return true, err
 	}
 
-	for _, autoreply := range autoreplies {
-		re, err := s.Deps.ReCache.Compile(autoreply.Trigger)
-		if err != nil {
-			ctxlog.Warn(ctx, "failed to compile regex", zap.Error(err))
-			continue
-		}
+	m.set.Each(s.Message, func(i int, re *regexp.Regexp, groups []string) bool {
+		handled, err = runAutoreply(ctx, s, &m.autoreplies[i], re, groups)
+		return !handled && err == nil
+	})
+
+	if err != nil {
+		return true, err
+	}
+	return handled, nil
+}
 
-		if !re.MatchString(s.Message) {
-			continue
+// runAutoreply runs an autoreply whose trigger matched, reporting whether it
+// was allowed to run.
+func runAutoreply(ctx context.Context, s *session, autoreply *dbsql.ListAutoreplyMatchersRow, re *regexp.Regexp, groups []string) (bool, error) {
+	msg := autoreply.Response
+
+	if strings.Contains(msg, "(_REGULARS_ONLY_)") {
+		if !s.UserLevel.CanAccess(AccessLevelSubscriber) {
+			return false, nil
 		}
+		msg = strings.ReplaceAll(msg, "(_REGULARS_ONLY_)", "")
+	}
 
-		msg := autoreply.Response
+	allowed, err := autoreplyAllowed(ctx, s, autoreply)
+	if err != nil {
+		return true, err
+	}
 
-		if strings.Contains(msg, "(_REGULARS_ONLY_)") {
-			if !s.UserLevel.CanAccess(AccessLevelSubscriber) {
-				continue
-			}
-			msg = strings.ReplaceAll(msg, "(_REGULARS_ONLY_)", "")
+	if !allowed {
+		// Allow further autoreplies to match.
+		return false, nil
+	}
+
+	// The cached autoreply's count may be stale, so increment it in place.
+	if err := s.Queries.UpdateAutoreplyCount(ctx, autoreply.ID); err != nil {
+		return true, fmt.Errorf("updating autoreply count: %w", err)
+	}
+
+	oldType := s.Type
+	s.Type = sessionAutoreply
+	s.autoreplyMatch = &autoreplyMatch{re: re, groups: groups}
+	defer func() {
+		s.Type = oldType
+		s.autoreplyMatch = nil
+	}()
+
+	reply, err := processCommand(ctx, s, msg)
+	if err != nil {
+		return true, err
+	}
+
+	if err := s.Reply(ctx, reply); err != nil {
+		return true, err
+	}
+
+	metricAutoreplies.Inc()
+
+	return true, nil
+}
+
+// autoreplyAllowed checks an autoreply's restrictions against the current
+// message, marking its cooldowns if it may run. The cheaper checks are done
+// first so that the Twitch API is only queried when needed.
+func autoreplyAllowed(ctx context.Context, s *session, autoreply *dbsql.ListAutoreplyMatchersRow) (bool, error) {
+	if !s.UserLevel.CanAccessPG(autoreply.AccessLevel) {
+		return false, nil
+	}
+
+	if !newAccessLevel(autoreply.MaxAccessLevel).CanAccess(s.UserLevel) {
+		return false, nil
+	}
+
+	if autoreply.LiveOnly {
+		isLive, err := s.IsLive(ctx)
+		if err != nil || !isLive {
+			return false, nil //nolint:nilerr
 		}
+	}
 
-		allowed, err := s.AutoreplyAllowed(ctx, autoreply.ID, 30*time.Second)
+	if len(autoreply.Games) != 0 {
+		ch, err := s.TwitchChannel(ctx)
 		if err != nil {
-			return true, err
+			return false, nil //nolint:nilerr
 		}
 
-		if !allowed {
-			// Allow further autoreplies to match.
-			continue
+		if !slices.ContainsFunc(autoreply.Games, func(game string) bool { return strings.EqualFold(game, ch.Game) }) {
+			return false, nil
 		}
+	}
 
-		autoreply.Count++
-		if err := s.Queries.UpdateAutoreplyCount(ctx, dbsql.UpdateAutoreplyCountParams{
-			Count: autoreply.Count,
-			ID:    autoreply.ID,
-		}); err != nil {
-			return true, fmt.Errorf("updating autoreply count: %w", err)
+	// The per-user cooldown is checked before the global cooldown, so that a
+	// user on cooldown does not start the cooldown for everyone else, but is
+	// only marked once the global cooldown allows the autoreply to run.
+	var userKey string
+	if autoreply.UserCooldown > 0 && s.UserID != 0 {
+		userKey = "autoreply_" + strconv.FormatInt(autoreply.ID, 10) + "_user_" + strconv.FormatInt(s.UserID, 10)
+		active, err := s.cooldownActive(ctx, userKey)
+		if err != nil || active {
+			return false, err
 		}
+	}
 
-		oldType := s.Type
-		s.Type = sessionAutoreply
-		defer func() {
-			s.Type = oldType
-		}()
-
-		reply, err := processCommand(ctx, s, msg)
-		if err != nil {
-			return true, err
+	if autoreply.Cooldown > 0 {
+		allowed, err := s.AutoreplyAllowed(ctx, autoreply.ID, time.Duration(autoreply.Cooldown)*time.Second)
+		if err != nil || !allowed {
+			return false, err
 		}
+	}
 
-		if err := s.Reply(ctx, reply); err != nil {
-			return true, err
+	if userKey != "" {
+		if err := s.tryCooldown(ctx, userKey, int(autoreply.UserCooldown), false); err != nil {
+			if errors.Is(err, errInCooldown) {
+				return false, nil
+			}
+			return false, err
 		}
-
-		metricAutoreplies.Inc()
-
-		return true, nil
 	}
 
-	return false, nil
+	return true, nil
 }
@@ -0,0 +1,183 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/dbx"
+	"github.com/jackc/pgx/v5"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// Statuses of a ban list entry in a subscribed channel.
+const (
+	banListStatusQueued   = "queued"
+	banListStatusBanned   = "banned"
+	banListStatusPending  = "pending"
+	banListStatusRejected = "rejected"
+	banListStatusFailed   = "failed"
+)
+
+// banListAttempts is how many times a channel's queued ban list bans are
+// tried before being left for the next attempt.
+const banListAttempts = 2
+
+// banListResult counts what happened to a ban list entry across the channels
+// subscribed to its list.
+type banListResult struct {
+	queued  int
+	pending int
+}
+
+// canMaintainBanList returns true if the user may edit the ban list from this
+// channel.
+func (s *session) canMaintainBanList(list *dbsql.BanList) bool {
+	if s.UserLevel.CanAccess(AccessLevelAdmin) {
+		return true
+	}
+	return list.MaintainerChannelID.Valid && list.MaintainerChannelID.Int64 == s.Channel.ID
+}
+
+// propagateBan records a new ban list entry in every channel subscribed to
+// the list which the bot moderates. Channels which review the list get a
+// pending ban; the others get a queued ban, which is applied once the
+// session's transaction commits.
+func propagateBan(ctx context.Context, s *session, list *dbsql.BanList, userID int64, userName string, reason string) (banListResult, error) {
+	var result banListResult
+
+	subscribers, err := s.Queries.ListBanListSubscribers(ctx, list.ID)
+	if err != nil {
+		return result, fmt.Errorf("listing ban list subscribers: %w", err)
+	}
+
+	for _, sub := range subscribers {
+		channel, err := s.Queries.GetChannelByID(ctx, sub.ChannelID)
+		if err != nil {
+			return result, fmt.Errorf("getting channel: %w", err)
+		}
+
+		if !channel.Active || !channel.ShouldModerate {
+			continue
+		}
+
+		status := banListStatusPending
+
+		if sub.Review {
+			result.pending++
+		} else {
+			status = banListStatusQueued
+			result.queued++
+			s.banListChannels = append(s.banListChannels, channel.ID)
+		}
+
+		if err := s.Queries.UpsertBanListBan(ctx, dbsql.UpsertBanListBanParams{
+			ChannelID: channel.ID,
+			BanListID: list.ID,
+			ListName:  list.Name,
+			UserID:    userID,
+			UserName:  userName,
+			Reason:    reason,
+			Status:    status,
+		}); err != nil {
+			return result, fmt.Errorf("recording ban list ban: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func banListBanReason(list string, reason string) string {
+	return fmt.Sprintf("Ban list %s: %s", list, reason)
+}
+
+// applyBanListBans applies the queued ban list bans of each channel, one
+// channel at a time.
+func (b *Bot) applyBanListBans(ctx context.Context, channelIDs []int64) {
+	for _, id := range channelIDs {
+		var err error
+		for range banListAttempts {
+			if err = b.applyChannelBanListBans(ctx, id); err == nil || ctx.Err() != nil {
+				break
+			}
+		}
+		if err != nil {
+			ctxlog.Error(ctx, "error applying ban list bans", zap.Error(err), zap.Int64("channel_id", id))
+		}
+	}
+}
+
+// applyChannelBanListBans applies a channel's queued ban list bans, holding
+// the channel's lock so they are serialized with its chat messages.
+func (b *Bot) applyChannelBanListBans(ctx context.Context, channelID int64) error {
+	return dbx.Transact(ctx, b.db,
+		dbx.SetLocalLockTimeout(5*time.Second),
+		func(ctx context.Context, tx pgx.Tx) error {
+			queries := dbsql.New(tx)
+
+			channel, err := queries.GetChannelByID(ctx, channelID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil
+				}
+				return fmt.Errorf("getting channel: %w", err)
+			}
+
+			if err := pgLock(ctx, queries, channel.TwitchID); err != nil {
+				return err
+			}
+
+			bans, err := queries.ListQueuedBanListBansForUpdate(ctx, channel.ID)
+			if err != nil {
+				return fmt.Errorf("listing queued ban list bans: %w", err)
+			}
+
+			s := &session{
+				Type:        sessionRepeat,
+				Deps:        b.deps,
+				Queries:     queries,
+				Start:       time.Now(),
+				UserLevel:   AccessLevelEveryone,
+				Channel:     &channel,
+				BotLogin:    channel.BotName,
+				ChannelName: channel.Name,
+				RoomID:      channel.TwitchID,
+				RoomIDOrig:  channel.TwitchID,
+			}
+
+			ctx = ctxlog.With(ctx, zap.Int64("roomID", s.RoomID), zap.String("channel", s.ChannelName))
+
+			for _, ban := range bans {
+				status := banListStatusBanned
+
+				if err := s.BanByID(ctx, ban.UserID, 0, banListBanReason(ban.ListName, ban.Reason)); err != nil {
+					ctxlog.Warn(ctx, "error applying ban list ban", zap.Error(err), zap.String("list", ban.ListName))
+					status = banListStatusFailed
+				}
+
+				if err := queries.UpdateBanListBanStatus(ctx, dbsql.UpdateBanListBanStatusParams{
+					Status: status,
+					ID:     ban.ID,
+				}); err != nil {
+					return fmt.Errorf("updating ban list ban: %w", err)
+				}
+			}
+
+			return nil
+		})
+}
+
+// applyLeftoverBanListBans applies ban list bans which were queued but never
+// applied, for example if the bot stopped before it could apply them.
+func (b *Bot) applyLeftoverBanListBans(ctx context.Context) error {
+	channelIDs, err := b.queries.ListQueuedBanListBanChannels(ctx)
+	if err != nil {
+		return fmt.Errorf("listing channels with queued ban list bans: %w", err)
+	}
+
+	b.applyBanListBans(ctx, channelIDs)
+	return nil
+}
@@ -56,7 +56,7 @@ func BenchmarkHandleNop(b *testing.B) {
 		State:                  state,
 		EventsubUpdateNotifier: nopNotifier{},
 		Twitch: &twitchmocks.APIMock{
-			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string) (*oauth2.Token, error) {
+			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
 				return nil, nil //nolint:nilnil
 			},
 		},
@@ -97,7 +97,7 @@ func BenchmarkHandleNopParallel(b *testing.B) {
 		State:                  state,
 		EventsubUpdateNotifier: nopNotifier{},
 		Twitch: &twitchmocks.APIMock{
-			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string) (*oauth2.Token, error) {
+			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
 				return nil, nil //nolint:nilnil
 			},
 		},
@@ -140,7 +140,7 @@ func BenchmarkHandleCustomCommand(b *testing.B) {
 		State:                  state,
 		EventsubUpdateNotifier: nopNotifier{},
 		Twitch: &twitchmocks.APIMock{
-			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string) (*oauth2.Token, error) {
+			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
 				return nil, nil //nolint:nilnil
 			},
 		},
@@ -182,7 +182,7 @@ func BenchmarkHandleMixed(b *testing.B) {
 		State:                  state,
 		EventsubUpdateNotifier: nopNotifier{},
 		Twitch: &twitchmocks.APIMock{
-			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string) (*oauth2.Token, error) {
+			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
 				return nil, nil //nolint:nilnil
 			},
 		},
@@ -242,7 +242,7 @@ func BenchmarkHandleManyBannedPhrases(b *testing.B) {
 		State:                  state,
 		EventsubUpdateNotifier: nopNotifier{},
 		Twitch: &twitchmocks.APIMock{
-			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string) (*oauth2.Token, error) {
+			SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
 				return nil, nil //nolint:nilnil
 			},
 		},
@@ -269,6 +269,79 @@ func BenchmarkHandleManyBannedPhrases(b *testing.B) {
 	b.StopTimer()
 }
 
+// BenchmarkHandleRules measures the per-message cost of channels with many
+// autoreplies or banned phrases, none of which match the message.
+func BenchmarkHandleRules(b *testing.B) {
+	kinds := []struct {
+		name  string
+		setup []string
+		add   func(i int) string
+	}{
+		{
+			name: "autoreplies",
+			add:  func(i int) string { return fmt.Sprintf("!autoreply add *phrase%d* reply %d", i, i) },
+		},
+		{
+			name:  "bannedphrases",
+			setup: []string{"!filter on", "!filter banphrase on"},
+			add:   func(i int) string { return fmt.Sprintf("!filter banphrase add phrase%d", i) },
+		},
+	}
+
+	for _, kind := range kinds {
+		for _, n := range []int{1, 100, 1000} {
+			b.Run(fmt.Sprintf("%s-%d", kind.name, n), func(b *testing.B) {
+				const botName = "hortbot"
+
+				db := pool.FreshDB(b)
+				defer db.Close()
+
+				ctx := b.Context()
+
+				clk := newBenchClock()
+				state := botstate.New(botstate.WithNow(clk.Now))
+
+				userID, name := getNextUserID()
+
+				config := &bot.Config{
+					DB:                     db,
+					State:                  state,
+					EventsubUpdateNotifier: nopNotifier{},
+					Twitch: &twitchmocks.APIMock{
+						SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
+							return nil, nil //nolint:nilnil
+						},
+					},
+					Simple:     &simplemocks.APIMock{},
+					HLTB:       &hltbmocks.APIMock{},
+					PublicJoin: true,
+				}
+
+				bb := bot.New(config)
+				assert.NilError(b, bb.Init(ctx))
+
+				bb.Handle(ctx, chatMessage(botName, botName, 1, name, userID, "!join"))
+
+				for _, text := range kind.setup {
+					bb.Handle(ctx, chatMessage(botName, name, userID, name, userID, text))
+				}
+
+				for i := range n {
+					bb.Handle(ctx, chatMessage(botName, name, userID, name, userID, kind.add(i)))
+				}
+
+				m := chatMessage(botName, name, userID, "someone", 9999999, "this is a perfectly normal chat message which matches nothing")
+
+				for b.Loop() {
+					bb.Handle(ctx, m)
+					clk.Advance(time.Minute)
+				}
+				b.StopTimer()
+			})
+		}
+	}
+}
+
 var nextUserID atomic.Int64
 
 func init() {
@@ -327,6 +400,7 @@ func (m benchmarkMessage) Chatter() bot.ChatIdentity     { return m.chatter }
 func (m benchmarkMessage) Text() string                  { return m.text }
 func (benchmarkMessage) IsAction() bool                  { return false }
 func (benchmarkMessage) CountEmotes() int                { return 0 }
+func (benchmarkMessage) Emotes() []string                { return nil }
 func (m benchmarkMessage) ChatterAccessLevel() bot.AccessLevel {
 	if m.broadcaster.ID == m.chatter.ID {
 		return bot.AccessLevelBroadcaster
@@ -8,11 +8,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/hortbot/hortbot/internal/db/botstate"
 	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/emotes"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/extralife"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/hltb"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/lastfm"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/redirects"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/simple"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/steam"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/tinyurl"
@@ -29,6 +32,8 @@ import (
 const (
 	// DefaultBullet is the default bullet used when the channel's bullet is unset.
 	DefaultBullet = "[HB]"
+
+	defaultAPITimeout = 5 * time.Second
 )
 
 // Config configures the bot.
@@ -48,6 +53,10 @@ type Config struct {
 	Urban     urban.API
 	Simple    simple.API
 	HLTB      hltb.API
+	Emotes    emotes.API
+	Redirects redirects.API
+
+	APITimeout time.Duration
 
 	BulletMap map[string]string
 	Cooldown  int
@@ -74,6 +83,15 @@ type Config struct {
 type CronConfig struct {
 	ValidateTokens          bool
 	UpdateModeratedChannels bool
+
+	// Backups enables daily channel backups, which are deleted once they are
+	// older than BackupRetention.
+	Backups         bool
+	BackupRetention time.Duration
+
+	// RefreshEmotes periodically refreshes the third-party emotes of channels
+	// which filter or track emotes.
+	RefreshEmotes bool
 }
 
 // Bot is the chat bot. It should only be used once.
@@ -87,12 +105,20 @@ type Bot struct {
 	deps    *sharedDeps
 	rep     *repeat.Repeater
 
+	raffleRep   *repeat.Repeater
+	chatModeRep *repeat.Repeater
+
 	validateTokensTicker *time.Ticker
 	validateTokensManual chan struct{}
 
 	updateModeratedChannelsTicker *time.Ticker
 	updateModeratedChannelsManual chan struct{}
 
+	backupsTicker   *time.Ticker
+	backupRetention time.Duration
+
+	refreshEmotesTicker *time.Ticker
+
 	testingHelper *testingHelper
 
 	passthroughPanics bool
@@ -130,6 +156,9 @@ func New(config *Config) *Bot {
 		Urban:                  config.Urban,
 		Simple:                 config.Simple,
 		HLTB:                   config.HLTB,
+		Emotes:                 config.Emotes,
+		Redirects:              config.Redirects,
+		APITimeout:             config.APITimeout,
 		ReCache:                recache.New(),
 		Admins:                 make(map[string]bool),
 		SuperAdmins:            make(map[string]bool),
@@ -161,6 +190,15 @@ func New(config *Config) *Bot {
 		deps.GlobalIgnore[name] = true
 	}
 
+	if deps.APITimeout <= 0 {
+		deps.APITimeout = defaultAPITimeout
+	}
+
+	deps.Matchers = newMatcherCache(deps.ReCache)
+	deps.ChatRates = newChatRateTracker()
+	deps.RecentMessages = newRecentMessageBuffer()
+	deps.RedirectCache = expirable.NewLRU[string, string](redirectCacheSize, nil, redirectCacheTTL)
+
 	if config.Rand != nil {
 		deps.Rand = config.Rand
 	} else {
@@ -172,6 +210,8 @@ func New(config *Config) *Bot {
 		queries:                       dbsql.New(config.DB),
 		deps:                          deps,
 		rep:                           repeat.New(),
+		raffleRep:                     repeat.New(),
+		chatModeRep:                   repeat.New(),
 		validateTokensManual:          make(chan struct{}, 1),
 		updateModeratedChannelsManual: make(chan struct{}, 1),
 		passthroughPanics:             config.PassthroughPanics,
@@ -185,12 +225,27 @@ func New(config *Config) *Bot {
 		b.updateModeratedChannelsTicker = time.NewTicker(time.Hour)
 	}
 
+	if config.Cron.Backups {
+		b.backupsTicker = time.NewTicker(time.Hour)
+		b.backupRetention = config.Cron.BackupRetention
+	}
+
+	if config.Cron.RefreshEmotes && config.Emotes != nil {
+		b.refreshEmotesTicker = time.NewTicker(time.Hour)
+	}
+
 	deps.AddRepeat = b.addRepeat
 	deps.RemoveRepeat = b.removeRepeat
 	deps.AddScheduled = b.addScheduled
 	deps.RemoveScheduled = b.removeScheduled
 	deps.ReloadRepeats = b.loadRepeats
 	deps.CountRepeats = b.rep.Count
+	deps.ScheduleRaffle = b.scheduleRaffle
+	deps.UnscheduleRaffle = b.unscheduleRaffle
+	deps.ScheduleChatModeRevert = b.scheduleChatModeRevert
+	deps.UnscheduleChatModeRevert = b.unscheduleChatModeRevert
+	deps.AddChatModeSchedule = b.addChatModeSchedule
+	deps.RemoveChatModeSchedule = b.removeChatModeSchedule
 	deps.TriggerValidateTokens = b.triggerValidateTokensNow
 	deps.UpdateModeratedChannels = b.updateModeratedChannelsNow
 
@@ -206,13 +261,28 @@ func New(config *Config) *Bot {
 func (b *Bot) Init(ctx context.Context) error {
 	b.g = errgroupx.FromContext(ctx)
 	b.g.Go(b.rep.Run)
+	b.g.Go(b.raffleRep.Run)
+	b.g.Go(b.chatModeRep.Run)
 	b.g.Go(b.runValidateTokens)
 	b.g.Go(b.runUpdateModeratedChannels)
+	b.g.Go(b.runBackups)
+	b.g.Go(b.runRefreshEmotes)
+	b.g.Go(b.listenMatchers)
 
 	if err := b.loadRepeats(ctx); err != nil {
 		return err
 	}
 
+	if err := b.loadRaffleTimers(ctx); err != nil {
+		return err
+	}
+
+	if err := b.loadChatModeTimers(ctx); err != nil {
+		return err
+	}
+
+	b.g.Go(b.applyLeftoverBanListBans)
+
 	b.initialized = true
 	return nil
 }
@@ -229,5 +299,11 @@ func (b *Bot) Stop() {
 		if t := b.updateModeratedChannelsTicker; t != nil {
 			t.Stop()
 		}
+		if t := b.backupsTicker; t != nil {
+			t.Stop()
+		}
+		if t := b.refreshEmotesTicker; t != nil {
+			t.Stop()
+		}
 	})
 }
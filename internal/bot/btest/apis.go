@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/emotes"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/hltb"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/lastfm"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/steam"
@@ -119,6 +120,71 @@ func (st *scriptTester) extraLifeAmounts(t testing.TB, _, args string, lineNum i
 	})
 }
 
+func (st *scriptTester) noEmotes(t testing.TB, _, _ string, _ int) {
+	st.addAction(func(_ context.Context) {
+		assert.Assert(t, st.b == nil, "bot has already been created, cannot disable emotes")
+		st.bc.Emotes = nil
+	})
+}
+
+func (st *scriptTester) emotesGlobal(t testing.TB, _, args string, lineNum int) {
+	var v map[emotes.Provider][]string
+
+	err := json.Unmarshal([]byte(args), &v)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(_ context.Context) {
+		st.emotes.GlobalEmotesFunc = func(_ context.Context, provider emotes.Provider) ([]string, error) {
+			names, ok := v[provider]
+			if !ok {
+				return nil, apiclient.NewStatusError(string(provider), 500)
+			}
+			return names, nil
+		}
+	})
+}
+
+func (st *scriptTester) emotesChannel(t testing.TB, _, args string, lineNum int) {
+	var v map[emotes.Provider]map[string][]string
+
+	err := json.Unmarshal([]byte(args), &v)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(_ context.Context) {
+		st.emotes.ChannelEmotesFunc = func(_ context.Context, provider emotes.Provider, twitchID int64) ([]string, error) {
+			channels, ok := v[provider]
+			if !ok {
+				return nil, apiclient.NewStatusError(string(provider), 500)
+			}
+			return channels[strconv.FormatInt(twitchID, 10)], nil
+		}
+	})
+}
+
+func (st *scriptTester) noRedirects(t testing.TB, _, _ string, _ int) {
+	st.addAction(func(_ context.Context) {
+		assert.Assert(t, st.b == nil, "bot has already been created, cannot disable redirects")
+		st.bc.Redirects = nil
+	})
+}
+
+func (st *scriptTester) redirectsResolve(t testing.TB, _, args string, lineNum int) {
+	var v map[string]string
+
+	err := json.Unmarshal([]byte(args), &v)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(_ context.Context) {
+		st.redirects.ResolveFunc = func(_ context.Context, link string) (string, error) {
+			resolved, ok := v[link]
+			if !ok {
+				return "", apiclient.NewStatusError("redirects", 404)
+			}
+			return resolved, nil
+		}
+	})
+}
+
 func (st *scriptTester) noSteam(t testing.TB, _, _ string, _ int) {
 	st.addAction(func(_ context.Context) {
 		assert.Assert(t, st.b == nil, "bot has already been created, cannot disable Steam")
@@ -286,6 +352,36 @@ func (st *scriptTester) simplePlaintext(t testing.TB, _, args string, lineNum in
 	})
 }
 
+func (st *scriptTester) simpleJSON(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		URL string
+
+		Body       string
+		StatusCode int
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(_ context.Context) {
+		st.simple.JSONFunc = func(_ context.Context, u string) ([]byte, error) {
+			assert.Equal(t, u, call.URL, "line %d", lineNum)
+
+			var err error
+
+			switch call.StatusCode {
+			case 0, 200:
+			case 777:
+				err = errors.New("testing error")
+			default:
+				err = apiclient.NewStatusError("simple", call.StatusCode)
+			}
+
+			return []byte(call.Body), err
+		}
+	})
+}
+
 func (st *scriptTester) hltbSearch(t testing.TB, _, args string, lineNum int) {
 	var call struct {
 		Query string
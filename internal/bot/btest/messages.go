@@ -19,16 +19,7 @@ import (
 )
 
 func (st *scriptTester) handle(t testing.TB, directive, directiveArgs string, lineNum int) {
-	if st.needNoSend {
-		st.noSend(t, "", "", lineNum)
-	}
-
-	if st.needNoNotifyEventsubUpdatesCalls {
-		st.noNotifyEventsubUpdatesCalls(t, "", "", lineNum)
-	}
-
-	st.needNoSend = true
-	st.needNoNotifyEventsubUpdatesCalls = true
+	st.beginHandle(t, lineNum)
 
 	if strings.HasSuffix(directiveArgs, " nil") {
 		st.handleM(t, nil)
@@ -71,6 +62,9 @@ func (st *scriptTester) handle(t testing.TB, directive, directiveArgs string, li
 			emotes, err := strconv.Atoi(value)
 			assert.NilError(t, err, "line %d", lineNum)
 			m.emoteCount = emotes
+		case "emotes":
+			m.emotes = strings.Split(value, ",")
+			m.emoteCount = len(m.emotes)
 		case "access":
 			m.accessLevel = parseAccessLevel(t, value, lineNum)
 		default:
@@ -90,6 +84,7 @@ type testChatMessage struct {
 	text        string
 	action      bool
 	emoteCount  int
+	emotes      []string
 	accessLevel bot.AccessLevel
 }
 
@@ -125,6 +120,7 @@ func (m *testChatMessage) Chatter() bot.ChatIdentity     { return m.chatter }
 func (m *testChatMessage) Text() string                  { return m.text }
 func (m *testChatMessage) IsAction() bool                { return m.action }
 func (m *testChatMessage) CountEmotes() int              { return m.emoteCount }
+func (m *testChatMessage) Emotes() []string              { return m.emotes }
 func (m *testChatMessage) ChatterAccessLevel() bot.AccessLevel {
 	if m.accessLevel != bot.AccessLevelUnknown {
 		return m.accessLevel
@@ -181,6 +177,82 @@ func (st *scriptTester) handleM(t testing.TB, m bot.Message) {
 	})
 }
 
+// beginHandle checks that the previous message or event sent nothing
+// unexpected before a new one is handled.
+func (st *scriptTester) beginHandle(t testing.TB, lineNum int) {
+	if st.needNoSend {
+		st.noSend(t, "", "", lineNum)
+	}
+
+	if st.needNoNotifyEventsubUpdatesCalls {
+		st.noNotifyEventsubUpdatesCalls(t, "", "", lineNum)
+	}
+
+	st.needNoSend = true
+	st.needNoNotifyEventsubUpdatesCalls = true
+}
+
+func (st *scriptTester) raid(t testing.TB, _, args string, lineNum int) {
+	st.beginHandle(t, lineNum)
+
+	fields := strings.Fields(args)
+	assert.Assert(t, len(fields) == 3, "line %d", lineNum)
+
+	viewers, err := strconv.Atoi(fields[2])
+	assert.NilError(t, err, "line %d", lineNum)
+
+	r := &bot.Raid{
+		Broadcaster: parseIdentity(t, fields[0], lineNum),
+		Raider:      parseIdentity(t, fields[1], lineNum),
+		Viewers:     viewers,
+	}
+
+	st.addAction(func(ctx context.Context) {
+		st.ensureBot(ctx, t)
+		st.doCheckpoint()
+		assert.NilError(t, st.b.HandleRaid(ctx, r), "line %d", lineNum)
+	})
+}
+
+func (st *scriptTester) pollEnd(t testing.TB, _, args string, lineNum int) {
+	st.beginHandle(t, lineNum)
+
+	var p bot.PollResult
+	assert.NilError(t, json.Unmarshal([]byte(args), &p), "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.ensureBot(ctx, t)
+		st.doCheckpoint()
+		assert.NilError(t, st.b.HandlePollEnd(ctx, &p), "line %d", lineNum)
+	})
+}
+
+func (st *scriptTester) predictionEnd(t testing.TB, _, args string, lineNum int) {
+	st.beginHandle(t, lineNum)
+
+	var p bot.PredictionResult
+	assert.NilError(t, json.Unmarshal([]byte(args), &p), "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.ensureBot(ctx, t)
+		st.doCheckpoint()
+		assert.NilError(t, st.b.HandlePredictionEnd(ctx, &p), "line %d", lineNum)
+	})
+}
+
+func (st *scriptTester) streamOffline(t testing.TB, _, args string, lineNum int) {
+	st.beginHandle(t, lineNum)
+
+	var o bot.StreamOffline
+	assert.NilError(t, json.Unmarshal([]byte(args), &o), "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.ensureBot(ctx, t)
+		st.doCheckpoint()
+		assert.NilError(t, st.b.HandleStreamOffline(ctx, &o), "line %d", lineNum)
+	})
+}
+
 func (st *scriptTester) send(t testing.TB, _, args string, lineNum int) {
 	callNum := st.counts[countSend]
 	st.counts[countSend]++
@@ -19,9 +19,11 @@ import (
 	"github.com/hortbot/hortbot/internal/bot/botmocks"
 	"github.com/hortbot/hortbot/internal/db/botstate"
 	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/emotes/emotesmocks"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/extralife/extralifemocks"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/hltb/hltbmocks"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/lastfm/lastfmmocks"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/redirects/redirectsmocks"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/simple/simplemocks"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/steam/steammocks"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/tinyurl/tinyurlmocks"
@@ -91,6 +93,8 @@ type scriptTester struct {
 	urban     *urbanmocks.APIMock
 	simple    *simplemocks.APIMock
 	hltb      *hltbmocks.APIMock
+	emotes    *emotesmocks.APIMock
+	redirects *redirectsmocks.APIMock
 
 	bc bot.Config
 	b  *bot.Bot
@@ -147,6 +151,23 @@ type intnAdapter struct{ *rand.Rand }
 
 func (r intnAdapter) IntN(n int) int { return r.Intn(n) }
 
+// sendWhisper passes a whisper to the sender, addressed to "@user".
+func (st *scriptTester) sendWhisper(ctx context.Context, fromID, toID int64, message string) error {
+	if fromID == 0 {
+		panic("fromID is 0")
+	}
+
+	origin := st.idToName[fromID]
+	if origin == "" {
+		origin = "@" + strconv.FormatInt(fromID, 10)
+	}
+	target := st.idToName[toID]
+	if target == "" {
+		target = strconv.FormatInt(toID, 10)
+	}
+	return st.sender.SendMessage(ctx, origin, "@"+target, message)
+}
+
 func (st *scriptTester) test(t testing.TB) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -169,7 +190,7 @@ func (st *scriptTester) test(t testing.TB) {
 	st.xkcd = &xkcdmocks.APIMock{}
 	st.extraLife = &extralifemocks.APIMock{}
 	st.twitch = &twitchmocks.APIMock{
-		SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string) (*oauth2.Token, error) {
+		SendChatMessageFunc: func(ctx context.Context, broadcasterID, modID int64, modToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
 			if modID == 0 {
 				panic("modID is 0")
 			}
@@ -183,14 +204,23 @@ func (st *scriptTester) test(t testing.TB) {
 			if target == "#" {
 				target = "@" + strconv.FormatInt(broadcasterID, 10)
 			}
+			// Threaded replies are sent to "#channel>parent-id".
+			if replyParentID != "" {
+				target += ">" + replyParentID
+			}
 			return nil, st.sender.SendMessage(ctx, origin, target, message)
 		},
+		SendWhisperFunc: func(ctx context.Context, fromID, toID int64, fromToken *oauth2.Token, message string) (*oauth2.Token, error) {
+			return nil, st.sendWhisper(ctx, fromID, toID, message)
+		},
 	}
 	st.steam = &steammocks.APIMock{}
 	st.tinyURL = &tinyurlmocks.APIMock{}
 	st.urban = &urbanmocks.APIMock{}
 	st.simple = &simplemocks.APIMock{}
 	st.hltb = &hltbmocks.APIMock{}
+	st.emotes = &emotesmocks.APIMock{}
+	st.redirects = &redirectsmocks.APIMock{}
 
 	logger, stop := testutil.Logger(t)
 	t.Cleanup(stop)
@@ -209,6 +239,8 @@ func (st *scriptTester) test(t testing.TB) {
 		Urban:                  st.urban,
 		Simple:                 st.simple,
 		HLTB:                   st.hltb,
+		Emotes:                 st.emotes,
+		Redirects:              st.redirects,
 		PublicJoin:             true,
 	}
 
@@ -370,6 +402,10 @@ var directiveFuncs = map[string]func(st *scriptTester, t testing.TB, directive,
 	"checkpoint":                    (*scriptTester).checkpoint,
 	"handle":                        (*scriptTester).handle,
 	"handle_me":                     (*scriptTester).handle,
+	"raid":                          (*scriptTester).raid,
+	"poll_end":                      (*scriptTester).pollEnd,
+	"prediction_end":                (*scriptTester).predictionEnd,
+	"stream_offline":                (*scriptTester).streamOffline,
 	"send":                          (*scriptTester).send,
 	"send_match":                    (*scriptTester).sendMatch,
 	"send_any":                      (*scriptTester).sendAny,
@@ -399,7 +435,13 @@ var directiveFuncs = map[string]func(st *scriptTester, t testing.TB, directive,
 	"no_urban":                      (*scriptTester).noUrban,
 	"urban_define":                  (*scriptTester).urbanDefine,
 	"simple_plaintext":              (*scriptTester).simplePlaintext,
+	"simple_json":                   (*scriptTester).simpleJSON,
 	"hltb_search":                   (*scriptTester).hltbSearch,
+	"no_emotes":                     (*scriptTester).noEmotes,
+	"emotes_global":                 (*scriptTester).emotesGlobal,
+	"emotes_channel":                (*scriptTester).emotesChannel,
+	"no_redirects":                  (*scriptTester).noRedirects,
+	"redirects_resolve":             (*scriptTester).redirectsResolve,
 	"twitch_modify_channel":         (*scriptTester).twitchModifyChannel,
 	"twitch_get_game_by_name":       (*scriptTester).twitchGetGameByName,
 	"twitch_get_game_by_id":         (*scriptTester).twitchGetGameByID,
@@ -408,10 +450,25 @@ var directiveFuncs = map[string]func(st *scriptTester, t testing.TB, directive,
 	"twitch_get_stream_by_username": (*scriptTester).twitchGetStreamByUsername,
 	"twitch_get_game_links":         (*scriptTester).twitchGetGameLinks,
 	"twitch_ban":                    (*scriptTester).twitchBan,
+	"twitch_bans":                   (*scriptTester).twitchBans,
+	"twitch_ban_users":              (*scriptTester).twitchBanUsers,
 	"twitch_unban":                  (*scriptTester).twitchUnban,
+	"twitch_get_chat_settings":      (*scriptTester).twitchGetChatSettings,
 	"twitch_update_chat_settings":   (*scriptTester).twitchUpdateChatSettings,
 	"twitch_set_chat_color":         (*scriptTester).twitchSetChatColor,
 	"twitch_delete_chat_message":    (*scriptTester).twitchDeleteChatMessage,
+	"twitch_delete_chat_messages":   (*scriptTester).twitchDeleteChatMessages,
 	"twitch_clear_chat":             (*scriptTester).twitchClearChat,
 	"twitch_announce":               (*scriptTester).twitchAnnounce,
+	"twitch_send_shoutout":          (*scriptTester).twitchSendShoutout,
+	"twitch_start_raid":             (*scriptTester).twitchStartRaid,
+	"twitch_cancel_raid":            (*scriptTester).twitchCancelRaid,
+	"twitch_create_poll":            (*scriptTester).twitchCreatePoll,
+	"twitch_get_latest_poll":        (*scriptTester).twitchGetLatestPoll,
+	"twitch_end_poll":               (*scriptTester).twitchEndPoll,
+	"twitch_create_prediction":      (*scriptTester).twitchCreatePrediction,
+	"twitch_get_latest_prediction":  (*scriptTester).twitchGetLatestPrediction,
+	"twitch_end_prediction":         (*scriptTester).twitchEndPrediction,
+	"twitch_send_whisper":           (*scriptTester).twitchSendWhisper,
+	"twitch_get_channel_follower":   (*scriptTester).twitchGetChannelFollower,
 }
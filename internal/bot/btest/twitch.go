@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	gocmp "github.com/google/go-cmp/cmp"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient"
@@ -249,6 +250,60 @@ func (st *scriptTester) twitchBan(t testing.TB, _, args string, lineNum int) {
 	})
 }
 
+func (st *scriptTester) twitchBans(t testing.TB, _, args string, lineNum int) {
+	var calls map[idstr.IDStr]struct {
+		ModID int64
+		Tok   *oauth2.Token
+		Req   *twitch.BanRequest
+
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &calls)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.BanFunc = func(_ context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, req *twitch.BanRequest) (newToken *oauth2.Token, err error) {
+			call, ok := calls[idstr.IDStr(broadcasterID)]
+			assert.Assert(t, ok, `unknown broadcaster "%v": line %d`, broadcasterID, lineNum)
+			assert.Equal(t, modID, call.ModID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(modToken, call.Tok, tokenCmp), "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(req, call.Req), "line %d", lineNum)
+
+			return call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchBanUsers(t testing.TB, _, args string, lineNum int) {
+	var calls map[idstr.IDStr]struct {
+		BroadcasterID int64
+		ModID         int64
+		Tok           *oauth2.Token
+		Req           *twitch.BanRequest
+
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &calls)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.BanFunc = func(_ context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, req *twitch.BanRequest) (newToken *oauth2.Token, err error) {
+			call, ok := calls[req.UserID]
+			assert.Assert(t, ok, `unknown user "%v": line %d`, req.UserID, lineNum)
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Equal(t, modID, call.ModID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(modToken, call.Tok, tokenCmp), "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(req, call.Req), "line %d", lineNum)
+
+			return call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
 func (st *scriptTester) twitchUnban(t testing.TB, _, args string, lineNum int) {
 	var call struct {
 		BroadcasterID int64
@@ -276,6 +331,31 @@ func (st *scriptTester) twitchUnban(t testing.TB, _, args string, lineNum int) {
 	})
 }
 
+func (st *scriptTester) twitchGetChatSettings(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		ModID         int64
+		Tok           *oauth2.Token
+
+		Settings *twitch.ChatSettings
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.GetChatSettingsFunc = func(_ context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (settings *twitch.ChatSettings, newToken *oauth2.Token, err error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Equal(t, modID, call.ModID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(modToken, call.Tok, tokenCmp), "line %d", lineNum)
+
+			return call.Settings, call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
 func (st *scriptTester) twitchUpdateChatSettings(t testing.TB, _, args string, lineNum int) {
 	var call struct {
 		BroadcasterID int64
@@ -354,6 +434,32 @@ func (st *scriptTester) twitchDeleteChatMessage(t testing.TB, _, args string, li
 	})
 }
 
+func (st *scriptTester) twitchDeleteChatMessages(t testing.TB, _, args string, lineNum int) {
+	var calls map[string]struct {
+		BroadcasterID int64
+		ModID         int64
+		Tok           *oauth2.Token
+
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &calls)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.DeleteChatMessageFunc = func(_ context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, id string) (newToken *oauth2.Token, err error) {
+			call, ok := calls[id]
+			assert.Assert(t, ok, `unknown message "%v": line %d`, id, lineNum)
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Equal(t, modID, call.ModID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(modToken, call.Tok, tokenCmp), "line %d", lineNum)
+
+			return call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
 func (st *scriptTester) twitchClearChat(t testing.TB, _, args string, lineNum int) {
 	var call struct {
 		BroadcasterID int64
@@ -407,3 +513,285 @@ func (st *scriptTester) twitchAnnounce(t testing.TB, _, args string, lineNum int
 		}
 	})
 }
+
+func (st *scriptTester) twitchSendShoutout(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		ToID          int64
+		ModID         int64
+		Tok           *oauth2.Token
+
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.SendShoutoutFunc = func(_ context.Context, broadcasterID int64, toID int64, modID int64, modToken *oauth2.Token) (newToken *oauth2.Token, err error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Equal(t, toID, call.ToID, "line %d", lineNum)
+			assert.Equal(t, modID, call.ModID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(modToken, call.Tok, tokenCmp), "line %d", lineNum)
+
+			return call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchStartRaid(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		ToID          int64
+		Tok           *oauth2.Token
+
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.StartRaidFunc = func(_ context.Context, broadcasterID int64, toID int64, userToken *oauth2.Token) (newToken *oauth2.Token, err error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Equal(t, toID, call.ToID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(userToken, call.Tok, tokenCmp), "line %d", lineNum)
+
+			return call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchCancelRaid(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		Tok           *oauth2.Token
+
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.CancelRaidFunc = func(_ context.Context, broadcasterID int64, userToken *oauth2.Token) (newToken *oauth2.Token, err error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(userToken, call.Tok, tokenCmp), "line %d", lineNum)
+
+			return call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchCreatePoll(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		Tok           *oauth2.Token
+		Title         string
+		Choices       []string
+		Seconds       int
+
+		Poll     *twitch.Poll
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.CreatePollFunc = func(_ context.Context, broadcasterID int64, userToken *oauth2.Token, title string, choices []string, duration time.Duration) (*twitch.Poll, *oauth2.Token, error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(userToken, call.Tok, tokenCmp), "line %d", lineNum)
+			assert.Equal(t, title, call.Title, "line %d", lineNum)
+			assert.DeepEqual(t, choices, call.Choices)
+			assert.Equal(t, duration, time.Duration(call.Seconds)*time.Second, "line %d", lineNum)
+
+			return call.Poll, call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchGetLatestPoll(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		Tok           *oauth2.Token
+
+		Poll     *twitch.Poll
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.GetLatestPollFunc = func(_ context.Context, broadcasterID int64, userToken *oauth2.Token) (*twitch.Poll, *oauth2.Token, error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(userToken, call.Tok, tokenCmp), "line %d", lineNum)
+
+			return call.Poll, call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchEndPoll(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		Tok           *oauth2.Token
+		ID            string
+
+		Poll     *twitch.Poll
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.EndPollFunc = func(_ context.Context, broadcasterID int64, userToken *oauth2.Token, id string) (*twitch.Poll, *oauth2.Token, error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(userToken, call.Tok, tokenCmp), "line %d", lineNum)
+			assert.Equal(t, id, call.ID, "line %d", lineNum)
+
+			return call.Poll, call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchCreatePrediction(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		Tok           *oauth2.Token
+		Title         string
+		Outcomes      []string
+		Seconds       int
+
+		Prediction *twitch.Prediction
+		NewToken   *oauth2.Token
+		Err        string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.CreatePredictionFunc = func(_ context.Context, broadcasterID int64, userToken *oauth2.Token, title string, outcomes []string, window time.Duration) (*twitch.Prediction, *oauth2.Token, error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(userToken, call.Tok, tokenCmp), "line %d", lineNum)
+			assert.Equal(t, title, call.Title, "line %d", lineNum)
+			assert.DeepEqual(t, outcomes, call.Outcomes)
+			assert.Equal(t, window, time.Duration(call.Seconds)*time.Second, "line %d", lineNum)
+
+			return call.Prediction, call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchGetLatestPrediction(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		Tok           *oauth2.Token
+
+		Prediction *twitch.Prediction
+		NewToken   *oauth2.Token
+		Err        string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.GetLatestPredictionFunc = func(_ context.Context, broadcasterID int64, userToken *oauth2.Token) (*twitch.Prediction, *oauth2.Token, error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(userToken, call.Tok, tokenCmp), "line %d", lineNum)
+
+			return call.Prediction, call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchEndPrediction(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID    int64
+		Tok              *oauth2.Token
+		ID               string
+		Status           string
+		WinningOutcomeID string
+
+		Prediction *twitch.Prediction
+		NewToken   *oauth2.Token
+		Err        string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.EndPredictionFunc = func(_ context.Context, broadcasterID int64, userToken *oauth2.Token, id string, status string, winningOutcomeID string) (*twitch.Prediction, *oauth2.Token, error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(userToken, call.Tok, tokenCmp), "line %d", lineNum)
+			assert.Equal(t, id, call.ID, "line %d", lineNum)
+			assert.Equal(t, status, call.Status, "line %d", lineNum)
+			assert.Equal(t, winningOutcomeID, call.WinningOutcomeID, "line %d", lineNum)
+
+			return call.Prediction, call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
+
+func (st *scriptTester) twitchSendWhisper(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		FromID  int64
+		ToID    int64
+		Message string
+
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.SendWhisperFunc = func(ctx context.Context, fromID int64, toID int64, fromToken *oauth2.Token, message string) (newToken *oauth2.Token, err error) {
+			assert.Equal(t, fromID, call.FromID, "line %d", lineNum)
+			assert.Equal(t, toID, call.ToID, "line %d", lineNum)
+			assert.Equal(t, message, call.Message, "line %d", lineNum)
+
+			if err := twitchErr(t, lineNum, call.Err); err != nil {
+				return call.NewToken, err
+			}
+			return call.NewToken, st.sendWhisper(ctx, fromID, toID, message)
+		}
+	})
+}
+
+func (st *scriptTester) twitchGetChannelFollower(t testing.TB, _, args string, lineNum int) {
+	var call struct {
+		BroadcasterID int64
+		UserID        int64
+		Tok           *oauth2.Token
+
+		Follower *twitch.ChannelFollower
+		NewToken *oauth2.Token
+		Err      string
+	}
+
+	err := json.Unmarshal([]byte(args), &call)
+	assert.NilError(t, err, "line %d", lineNum)
+
+	st.addAction(func(ctx context.Context) {
+		st.twitch.GetChannelFollowerFunc = func(_ context.Context, broadcasterID int64, userID int64, modToken *oauth2.Token) (follower *twitch.ChannelFollower, newToken *oauth2.Token, err error) {
+			assert.Equal(t, broadcasterID, call.BroadcasterID, "line %d", lineNum)
+			assert.Equal(t, userID, call.UserID, "line %d", lineNum)
+			assert.Assert(t, cmp.DeepEqual(modToken, call.Tok, tokenCmp), "line %d", lineNum)
+
+			return call.Follower, call.NewToken, twitchErr(t, lineNum, call.Err)
+		}
+	})
+}
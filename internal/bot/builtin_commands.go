@@ -24,6 +24,9 @@ func init() {
 		"filter":          {fn: cmdFilter, minLevel: AccessLevelModerator},
 		"permit":          {fn: cmdPermit, minLevel: AccessLevelModerator},
 		"allow":           {fn: cmdPermit, minLevel: AccessLevelModerator},
+		"banlist":         {fn: cmdBanList, minLevel: AccessLevelModerator},
+		"chatmode":        {fn: cmdChatMode, minLevel: AccessLevelModerator},
+		"nuke":            {fn: cmdNuke, minLevel: AccessLevelModerator},
 		"leave":           {fn: cmdLeave, minLevel: AccessLevelBroadcaster},
 		"part":            {fn: cmdLeave, minLevel: AccessLevelBroadcaster},
 		"conch":           {fn: cmdConch, minLevel: AccessLevelSubscriber},
@@ -36,6 +39,7 @@ func init() {
 		"autoreply":       {fn: cmdAutoreply, minLevel: AccessLevelModerator},
 		"xkcd":            {fn: cmdXKCD, minLevel: AccessLevelSubscriber, skipCooldown: true},
 		"raffle":          {fn: cmdRaffle, minLevel: AccessLevelEveryone, skipCooldown: true},
+		"queue":           {fn: cmdQueue, minLevel: AccessLevelEveryone, skipCooldown: true},
 		"var":             {fn: cmdVar, minLevel: AccessLevelModerator},
 		"status":          {fn: cmdStatus, minLevel: AccessLevelEveryone},
 		"game":            {fn: cmdGame, minLevel: AccessLevelEveryone},
@@ -46,8 +50,12 @@ func init() {
 		"list":            {fn: cmdList, minLevel: AccessLevelModerator},
 		"random":          {fn: cmdRandom, minLevel: AccessLevelEveryone, skipCooldown: true},
 		"roll":            {fn: cmdRandom, minLevel: AccessLevelEveryone, skipCooldown: true},
-		"host":            {fn: cmdHost, minLevel: AccessLevelEveryone},
-		"unhost":          {fn: cmdUnhost, minLevel: AccessLevelEveryone},
+		"so":              {fn: cmdShoutout, minLevel: AccessLevelModerator},
+		"shoutout":        {fn: cmdShoutout, minLevel: AccessLevelModerator},
+		"raid":            {fn: cmdRaid, minLevel: AccessLevelBroadcaster},
+		"unraid":          {fn: cmdUnraid, minLevel: AccessLevelBroadcaster},
+		"poll":            {fn: cmdPoll, minLevel: AccessLevelModerator},
+		"predict":         {fn: cmdPredict, minLevel: AccessLevelModerator},
 		"whatshouldiplay": {fn: cmdWhatShouldIPlay, minLevel: AccessLevelBroadcaster},
 		"statusgame":      {fn: cmdStatusGame, minLevel: AccessLevelModerator},
 		"steamgame":       {fn: cmdSteamGame, minLevel: AccessLevelModerator},
@@ -63,6 +71,11 @@ func init() {
 		"ht":              {fn: cmdHighlight, minLevel: AccessLevelEveryone, skipCooldown: true},
 		"highlightthat":   {fn: cmdHighlight, minLevel: AccessLevelEveryone, skipCooldown: true},
 		"hltb":            {fn: cmdHLTB, minLevel: AccessLevelSubscriber},
+		"followage":       {fn: cmdFollowAge, minLevel: AccessLevelEveryone},
+		"accountage":      {fn: cmdAccountAge, minLevel: AccessLevelEveryone},
+		"seen":            {fn: cmdSeen, minLevel: AccessLevelEveryone},
+		"library":         {fn: cmdLibrary, minLevel: AccessLevelModerator},
+		"emotes":          {fn: cmdEmotes, minLevel: AccessLevelEveryone},
 	})
 
 	builtinCommands.isBuiltins = true
@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/correlation"
+	"github.com/hortbot/hortbot/internal/pkg/dbx"
+	"github.com/jackc/pgx/v5"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// handleChannelEvent runs fn with a session for the broadcaster's channel,
+// serialized with the channel's chat messages. Events for unknown or inactive
+// channels are ignored.
+func (b *Bot) handleChannelEvent(ctx context.Context, event string, broadcasterID int64, fn func(ctx context.Context, s *session) error) error {
+	ctx = correlation.With(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	if !b.initialized {
+		panic("bot is not initialized")
+	}
+
+	var s *session
+
+	err := dbx.Transact(ctx, b.db,
+		dbx.SetLocalLockTimeout(5*time.Second),
+		func(ctx context.Context, tx pgx.Tx) error {
+			queries := dbsql.New(tx)
+
+			if err := pgLock(ctx, queries, broadcasterID); err != nil {
+				return err
+			}
+
+			channel, err := queries.GetChannelByTwitchIDForUpdate(ctx, broadcasterID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil
+				}
+				return fmt.Errorf("getting channel: %w", err)
+			}
+
+			if !channel.Active {
+				return nil
+			}
+
+			s = &session{
+				Type:        sessionRepeat,
+				Deps:        b.deps,
+				Queries:     queries,
+				Start:       time.Now(),
+				UserLevel:   AccessLevelEveryone,
+				Channel:     &channel,
+				BotLogin:    channel.BotName,
+				ChannelName: channel.Name,
+				RoomID:      channel.TwitchID,
+				RoomIDOrig:  channel.TwitchID,
+			}
+
+			return fn(ctx, s)
+		})
+	if err != nil {
+		metricHandleError.Inc()
+		ctxlog.Error(ctx, "error handling "+event, zap.Error(err))
+		return err
+	}
+
+	if s != nil {
+		b.flushDeferred(ctx, s)
+	}
+
+	return nil
+}
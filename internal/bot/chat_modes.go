@@ -0,0 +1,469 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
+	"github.com/hortbot/hortbot/internal/pkg/dbx"
+	"github.com/hortbot/hortbot/internal/pkg/must"
+	"github.com/hortbot/hortbot/internal/pkg/repeat"
+	"github.com/jackc/pgx/v5"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// Chat mode rule triggers.
+const (
+	chatModeTriggerRaid     = "raid"
+	chatModeTriggerOffline  = "offline"
+	chatModeTriggerRate     = "rate"
+	chatModeTriggerSchedule = "schedule"
+)
+
+// Chat modes which rules may enable.
+const (
+	chatModeEmoteOnly   = "emoteonly"
+	chatModeFollowers   = "followers"
+	chatModeSlow        = "slow"
+	chatModeSubscribers = "subscribers"
+	chatModeUniqueChat  = "uniquechat"
+)
+
+// chatRateWindow is the period over which a channel's message rate is
+// measured for rate rules.
+const chatRateWindow = 5 * time.Second
+
+// HandleStreamOffline applies the channel's offline chat mode rules when its
+// stream ends.
+func (b *Bot) HandleStreamOffline(ctx context.Context, o *StreamOffline) error {
+	if o == nil || o.Broadcaster.ID == 0 {
+		ctxlog.Error(ctx, "invalid stream offline", zap.Any("offline", o))
+		return errInvalidMessage
+	}
+
+	ctx = ctxlog.With(ctx, zap.Int64("roomID", o.Broadcaster.ID))
+
+	return b.handleChannelEvent(ctx, "stream offline", o.Broadcaster.ID, func(ctx context.Context, s *session) error {
+		return s.applyChatModeRules(ctx, chatModeTriggerOffline, 0)
+	})
+}
+
+func (b *Bot) scheduleChatModeRevert(ctx context.Context, id int64, at time.Time) error {
+	now := time.Now()
+	interval := max(at.Sub(now), time.Millisecond)
+	return b.chatModeRep.Add(ctx, id, b.runChatModeRevert, now, interval)
+}
+
+func (b *Bot) unscheduleChatModeRevert(ctx context.Context, id int64) error {
+	return b.chatModeRep.Remove(ctx, id)
+}
+
+// addChatModeSchedule runs the scheduled rule with the same repeater as the
+// reverts, as cron tasks may share IDs with regular repeated tasks.
+func (b *Bot) addChatModeSchedule(ctx context.Context, id int64, expr *repeat.Cron) error {
+	return b.chatModeRep.AddCron(ctx, id, b.runChatModeSchedule, expr)
+}
+
+func (b *Bot) removeChatModeSchedule(ctx context.Context, id int64) error {
+	return b.chatModeRep.RemoveCron(ctx, id)
+}
+
+func (b *Bot) loadChatModeTimers(ctx context.Context) error {
+	if err := b.chatModeRep.Reset(ctx); err != nil {
+		return err
+	}
+
+	scheduled, err := b.queries.ListActiveScheduledChatModeRules(ctx)
+	if err != nil {
+		return fmt.Errorf("getting scheduled chat mode rules: %w", err)
+	}
+
+	for _, row := range scheduled {
+		channel := dbsql.Channel{Timezone: row.Timezone}
+		if err := updateChatModeSchedule(ctx, b.deps, &row.ChatModeRule, channel.Location(), true); err != nil {
+			return err
+		}
+	}
+
+	ids, err := b.queries.ListChatModeRevertIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("getting chat mode reverts: %w", err)
+	}
+
+	// Anything that came due while the bot was down is reverted immediately.
+	now := time.Now()
+	for _, id := range ids {
+		if err := b.scheduleChatModeRevert(ctx, id, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bot) runChatModeRevert(ctx context.Context, id int64) (readd bool) {
+	ctx = ctxlog.With(ctx, zap.Int64("chatModeRule", id))
+
+	_, err := b.runChatModeRule(ctx, id, func(ctx context.Context, s *session, rule *dbsql.ChatModeRule) error {
+		if !rule.RevertAt.Valid {
+			return nil
+		}
+
+		if rule.RevertAt.Time.After(s.Start) {
+			return s.Deps.ScheduleChatModeRevert(ctx, rule.ID, rule.RevertAt.Time)
+		}
+
+		return s.revertChatMode(ctx, rule)
+	})
+	if err != nil {
+		ctxlog.Warn(ctx, "error running chat mode revert", zap.Error(err))
+	}
+
+	return false
+}
+
+func (b *Bot) runChatModeSchedule(ctx context.Context, id int64) (readd bool) {
+	ctx = ctxlog.With(ctx, zap.Int64("chatModeRule", id))
+
+	readd = true
+
+	found, err := b.runChatModeRule(ctx, id, func(ctx context.Context, s *session, rule *dbsql.ChatModeRule) error {
+		if rule.Trigger != chatModeTriggerSchedule || !s.Channel.Active {
+			readd = false
+			return nil
+		}
+
+		if !s.Channel.ShouldModerate {
+			return nil
+		}
+
+		return s.applyChatModeRule(ctx, rule, s.Start)
+	})
+	if err != nil {
+		ctxlog.Warn(ctx, "error running chat mode schedule", zap.Error(err))
+	}
+
+	return readd && found
+}
+
+// runChatModeRule runs fn with the rule and a session for its channel,
+// serialized with the channel's chat messages. If the rule no longer exists,
+// fn is not called and found is false.
+func (b *Bot) runChatModeRule(ctx context.Context, id int64, fn func(ctx context.Context, s *session, rule *dbsql.ChatModeRule) error) (found bool, err error) {
+	err = dbx.Transact(ctx, b.db,
+		dbx.SetLocalLockTimeout(5*time.Second),
+		func(ctx context.Context, tx pgx.Tx) error {
+			queries := dbsql.New(tx)
+
+			rule, err := queries.GetChatModeRule(ctx, id)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil
+				}
+				return fmt.Errorf("getting chat mode rule: %w", err)
+			}
+
+			channel, err := queries.GetChannelByID(ctx, rule.ChannelID)
+			if err != nil {
+				return fmt.Errorf("getting channel: %w", err)
+			}
+
+			// Serialize with chat messages, which may also apply the rule.
+			if err := pgLock(ctx, queries, channel.TwitchID); err != nil {
+				return err
+			}
+
+			rule, err = queries.GetChatModeRule(ctx, id)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil
+				}
+				return fmt.Errorf("getting chat mode rule: %w", err)
+			}
+
+			found = true
+
+			s := &session{
+				Type:        sessionRepeat,
+				Deps:        b.deps,
+				Queries:     queries,
+				Start:       time.Now(),
+				UserLevel:   AccessLevelEveryone,
+				Channel:     &channel,
+				BotLogin:    channel.BotName,
+				ChannelName: channel.Name,
+				RoomID:      channel.TwitchID,
+				RoomIDOrig:  channel.TwitchID,
+			}
+
+			ctx = ctxlog.With(ctx, zap.Int64("roomID", s.RoomID), zap.String("channel", s.ChannelName))
+
+			return fn(ctx, s, &rule)
+		})
+
+	return found, err
+}
+
+// applyChatModeRules applies the channel's rules for the trigger. For rate
+// rules, rate is the channel's current message rate.
+func (s *session) applyChatModeRules(ctx context.Context, trigger string, rate int64) error {
+	if !s.Channel.ShouldModerate {
+		return nil
+	}
+
+	rules, err := s.Queries.ListChatModeRulesByTrigger(ctx, dbsql.ListChatModeRulesByTriggerParams{
+		ChannelID: s.Channel.ID,
+		Trigger:   trigger,
+	})
+	if err != nil {
+		return fmt.Errorf("listing chat mode rules: %w", err)
+	}
+
+	now := time.Now()
+
+	for i := range rules {
+		rule := &rules[i]
+
+		if trigger == chatModeTriggerRate && rate < rule.Threshold {
+			continue
+		}
+
+		if err := s.applyChatModeRule(ctx, rule, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyChatModeRule enables the rule's chat mode, or extends its revert if
+// the mode is already waiting to be reverted. Rules which revert record the
+// mode's prior setting so that it can be restored.
+func (s *session) applyChatModeRule(ctx context.Context, rule *dbsql.ChatModeRule, now time.Time) error {
+	priorEnabled, priorSeconds := rule.PriorEnabled, rule.PriorSeconds
+
+	if !rule.RevertAt.Valid {
+		if rule.RevertSeconds != 0 {
+			var err error
+			priorEnabled, priorSeconds, err = s.priorChatMode(ctx, rule)
+			if err != nil {
+				// Don't return the error; one failing rule shouldn't prevent the others.
+				ctxlog.Warn(ctx, "error getting prior chat mode", zap.Error(err), zap.String("trigger", rule.Trigger), zap.String("mode", rule.Mode))
+				return nil
+			}
+		}
+
+		patch, err := chatModePatch(rule.Mode, rule.ModeSeconds, true)
+		if err == nil {
+			err = s.UpdateChatSettings(ctx, patch)
+		}
+		if err != nil {
+			ctxlog.Warn(ctx, "error applying chat mode rule", zap.Error(err), zap.String("trigger", rule.Trigger), zap.String("mode", rule.Mode))
+			return nil
+		}
+	}
+
+	if rule.RevertSeconds == 0 {
+		return nil
+	}
+
+	revertAt := now.Add(time.Duration(rule.RevertSeconds) * time.Second)
+
+	if err := s.Queries.SetChatModeRuleRevert(ctx, dbsql.SetChatModeRuleRevertParams{
+		RevertAt:     dbsql.TimestamptzFrom(revertAt),
+		PriorEnabled: priorEnabled,
+		PriorSeconds: priorSeconds,
+		ID:           rule.ID,
+	}); err != nil {
+		return fmt.Errorf("updating chat mode rule: %w", err)
+	}
+
+	return s.Deps.ScheduleChatModeRevert(ctx, rule.ID, revertAt)
+}
+
+// priorChatMode gets the setting of the rule's chat mode from before any of
+// the channel's rules changed it. If another rule with the same mode is
+// waiting to be reverted, the setting it recorded is used, as the mode's
+// current setting is that rule's.
+func (s *session) priorChatMode(ctx context.Context, rule *dbsql.ChatModeRule) (enabled bool, seconds int64, err error) {
+	rules, err := s.Queries.ListChatModeRules(ctx, s.Channel.ID)
+	if err != nil {
+		return false, 0, fmt.Errorf("listing chat mode rules: %w", err)
+	}
+
+	for _, other := range rules {
+		if other.ID != rule.ID && other.Mode == rule.Mode && other.RevertAt.Valid {
+			return other.PriorEnabled, other.PriorSeconds, nil
+		}
+	}
+
+	settings, err := s.GetChatSettings(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+
+	switch rule.Mode {
+	case chatModeEmoteOnly:
+		return settings.EmoteMode, 0, nil
+	case chatModeFollowers:
+		return settings.FollowerMode, settings.FollowerModeDuration * 60, nil
+	case chatModeSlow:
+		return settings.SlowMode, settings.SlowModeWaitTime, nil
+	case chatModeSubscribers:
+		return settings.SubscriberMode, 0, nil
+	case chatModeUniqueChat:
+		return settings.UniqueChatMode, 0, nil
+	default:
+		return false, 0, fmt.Errorf("unknown chat mode: %s", rule.Mode)
+	}
+}
+
+// revertChatMode clears the rule's pending revert and restores its chat
+// mode's prior setting, unless another of the channel's rules has changed the
+// same mode until later.
+func (s *session) revertChatMode(ctx context.Context, rule *dbsql.ChatModeRule) error {
+	if err := s.Queries.UpdateChatModeRuleRevertAt(ctx, dbsql.UpdateChatModeRuleRevertAtParams{
+		ID: rule.ID,
+	}); err != nil {
+		return fmt.Errorf("updating chat mode rule: %w", err)
+	}
+
+	if err := s.Deps.UnscheduleChatModeRevert(ctx, rule.ID); err != nil {
+		return err
+	}
+
+	if !s.Channel.Active || !s.Channel.ShouldModerate {
+		return nil
+	}
+
+	rules, err := s.Queries.ListChatModeRules(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("listing chat mode rules: %w", err)
+	}
+
+	for _, other := range rules {
+		if other.ID == rule.ID || other.Mode != rule.Mode || !other.RevertAt.Valid {
+			continue
+		}
+
+		// The other rule will restore the mode when its own revert comes due.
+		if !other.RevertAt.Time.Before(rule.RevertAt.Time) {
+			return nil
+		}
+	}
+
+	patch, err := chatModePatch(rule.Mode, rule.PriorSeconds, rule.PriorEnabled)
+	if err == nil {
+		err = s.UpdateChatSettings(ctx, patch)
+	}
+	if err != nil {
+		ctxlog.Warn(ctx, "error reverting chat mode rule", zap.Error(err), zap.String("mode", rule.Mode))
+	}
+
+	return nil
+}
+
+func updateChatModeSchedules(ctx context.Context, deps *sharedDeps, rules []dbsql.ChatModeRule, loc *time.Location, enable bool) error {
+	for i := range rules {
+		if err := updateChatModeSchedule(ctx, deps, &rules[i], loc, enable); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func updateChatModeSchedule(ctx context.Context, deps *sharedDeps, rule *dbsql.ChatModeRule, loc *time.Location, enable bool) error {
+	if !enable {
+		return deps.RemoveChatModeSchedule(ctx, rule.ID)
+	}
+
+	expr := must.Must(repeat.ParseCronIn(rule.Schedule, loc))
+
+	return deps.AddChatModeSchedule(ctx, rule.ID, expr)
+}
+
+// trackChatRate records the message for the channel's rate rules, applying
+// them once a measurement window has passed.
+func trackChatRate(ctx context.Context, s *session) error {
+	rate, ok := s.Deps.ChatRates.record(s.Channel.ID, time.Now())
+	if !ok || rate == 0 {
+		return nil
+	}
+	return s.applyChatModeRules(ctx, chatModeTriggerRate, rate)
+}
+
+func chatModePatch(mode string, seconds int64, on bool) (*twitch.ChatSettingsPatch, error) {
+	patch := &twitch.ChatSettingsPatch{}
+
+	switch mode {
+	case chatModeEmoteOnly:
+		patch.EmoteMode = &on
+	case chatModeFollowers:
+		patch.FollowerMode = &on
+		if on {
+			patch.FollowerModeDuration = new(seconds / 60)
+		}
+	case chatModeSlow:
+		patch.SlowMode = &on
+		if on {
+			patch.SlowModeWaitTime = &seconds
+		}
+	case chatModeSubscribers:
+		patch.SubscriberMode = &on
+	case chatModeUniqueChat:
+		patch.UniqueChatMode = &on
+	default:
+		return nil, fmt.Errorf("unknown chat mode: %s", mode)
+	}
+
+	return patch, nil
+}
+
+// chatRateTracker measures each channel's chat message rate. It is kept in
+// memory, so each bot process only sees the messages it handles.
+type chatRateTracker struct {
+	mu      sync.Mutex
+	windows map[int64]*chatRateCount
+}
+
+type chatRateCount struct {
+	start time.Time
+	count int64
+}
+
+func newChatRateTracker() *chatRateTracker {
+	return &chatRateTracker{
+		windows: make(map[int64]*chatRateCount),
+	}
+}
+
+// record counts a message sent to the channel. When the message begins a new
+// measurement window, the previous window's rate is returned in messages per
+// second.
+func (t *chatRateTracker) record(channelID int64, now time.Time) (rate int64, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w := t.windows[channelID]
+	if w == nil {
+		t.windows[channelID] = &chatRateCount{start: now, count: 1}
+		return 0, false
+	}
+
+	if now.Sub(w.start) < chatRateWindow {
+		w.count++
+		return 0, false
+	}
+
+	rate = w.count / int64(chatRateWindow/time.Second)
+	w.start = now
+	w.count = 1
+
+	return rate, true
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"runtime"
 	"strconv"
 	"strings"
@@ -13,10 +14,31 @@ import (
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/version"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var adminCommands handlerMap
 
+var adminAPIHostCommands = newHandlerMap(map[string]handlerFunc{
+	"add":    {fn: cmdAdminAPIHostAdd, minLevel: AccessLevelAdmin},
+	"remove": {fn: cmdAdminAPIHostRemove, minLevel: AccessLevelAdmin},
+	"delete": {fn: cmdAdminAPIHostRemove, minLevel: AccessLevelAdmin},
+	"list":   {fn: cmdAdminAPIHostList, minLevel: AccessLevelAdmin},
+})
+
+var adminBlockDomainCommands = newHandlerMap(map[string]handlerFunc{
+	"add":    {fn: cmdAdminBlockDomainAdd, minLevel: AccessLevelAdmin},
+	"remove": {fn: cmdAdminBlockDomainRemove, minLevel: AccessLevelAdmin},
+	"delete": {fn: cmdAdminBlockDomainRemove, minLevel: AccessLevelAdmin},
+	"list":   {fn: cmdAdminBlockDomainList, minLevel: AccessLevelAdmin},
+})
+
+var adminBanListCommands = newHandlerMap(map[string]handlerFunc{
+	"create":     {fn: cmdAdminBanListCreate, minLevel: AccessLevelAdmin},
+	"maintainer": {fn: cmdAdminBanListMaintainer, minLevel: AccessLevelAdmin},
+	"delete":     {fn: cmdAdminBanListDelete, minLevel: AccessLevelAdmin},
+})
+
 func init() {
 	// To prevent initialization loop.
 	adminCommands = newHandlerMap(map[string]handlerFunc{
@@ -29,6 +51,9 @@ func init() {
 		"version":       {fn: cmdAdminVersion, minLevel: AccessLevelAdmin},
 		"changebot":     {fn: cmdAdminChangeBot, minLevel: AccessLevelAdmin},
 		"globalignored": {fn: cmdAdminGlobalIgnored, minLevel: AccessLevelAdmin},
+		"apihost":       {fn: cmdAdminAPIHost, minLevel: AccessLevelAdmin},
+		"blockdomain":   {fn: cmdAdminBlockDomain, minLevel: AccessLevelAdmin},
+		"banlist":       {fn: cmdAdminBanList, minLevel: AccessLevelAdmin},
 
 		"reloadrepeats":           {fn: cmdAdminReloadRepeats, minLevel: AccessLevelSuperAdmin},
 		"deletechannel":           {fn: cmdAdminDeleteChannel, minLevel: AccessLevelSuperAdmin},
@@ -51,7 +76,7 @@ func cmdAdmin(ctx context.Context, s *session, cmd string, args string) error {
 
 	if target, ok := strings.CutPrefix(subcommand, "#"); ok {
 		if s.UserLevel.CanAccess(AccessLevelSuperAdmin) {
-			return s.SendTwitchChatMessage(ctx, target, args)
+			return s.SendTwitchChatMessage(ctx, target, args, "")
 		}
 		return s.Reply(ctx, "Only super admins may directly send messages.")
 	}
@@ -350,3 +375,299 @@ func cmdAdminUpdateModeratedChannels(ctx context.Context, s *session, _ string,
 	s.Deps.UpdateModeratedChannels()
 	return s.Reply(ctx, "Updating moderated channels.")
 }
+
+func cmdAdminAPIHost(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	ok, err := adminAPIHostCommands.Run(ctx, s, subcommand, args)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.ReplyUsage(ctx, "add <host>|remove <host>|list")
+	}
+
+	return nil
+}
+
+func cmdAdminAPIHostAdd(ctx context.Context, s *session, cmd string, args string) error {
+	host := cleanAPIHost(args)
+	if host == "" {
+		return s.ReplyUsage(ctx, "<host>")
+	}
+
+	if err := s.Queries.UpsertAPIHost(ctx, host); err != nil {
+		return fmt.Errorf("upsert api host: %w", err)
+	}
+
+	return s.Replyf(ctx, "API actions may now fetch from %s.", host)
+}
+
+func cmdAdminAPIHostRemove(ctx context.Context, s *session, cmd string, args string) error {
+	host := cleanAPIHost(args)
+	if host == "" {
+		return s.ReplyUsage(ctx, "<host>")
+	}
+
+	n, err := s.Queries.DeleteAPIHost(ctx, host)
+	if err != nil {
+		return fmt.Errorf("delete api host: %w", err)
+	}
+
+	if n == 0 {
+		return s.Replyf(ctx, "%s is not an allowed API host.", host)
+	}
+
+	return s.Replyf(ctx, "%s removed from the allowed API hosts.", host)
+}
+
+func cmdAdminAPIHostList(ctx context.Context, s *session, cmd string, args string) error {
+	hosts, err := s.Queries.ListAPIHosts(ctx)
+	if err != nil {
+		return fmt.Errorf("list api hosts: %w", err)
+	}
+
+	if len(hosts) == 0 {
+		return s.Reply(ctx, "No API hosts are allowlisted; API actions may fetch from any host.")
+	}
+
+	return s.Replyf(ctx, "Allowed API hosts: %s", strings.Join(hosts, ", "))
+}
+
+func cmdAdminBlockDomain(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	ok, err := adminBlockDomainCommands.Run(ctx, s, subcommand, args)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.ReplyUsage(ctx, "add <domain> [reason]|remove <domain>|list")
+	}
+
+	return nil
+}
+
+func cmdAdminBlockDomainAdd(ctx context.Context, s *session, cmd string, args string) error {
+	domain, reason := splitSpace(args)
+	domain = cleanAPIHost(domain)
+	if domain == "" {
+		return s.ReplyUsage(ctx, "<domain> [reason]")
+	}
+
+	// A bare TLD would block far more than intended.
+	if !strings.Contains(domain, ".") {
+		return s.Replyf(ctx, "Domain '%s' is too broad.", domain)
+	}
+
+	if err := s.Queries.UpsertBlockedDomain(ctx, dbsql.UpsertBlockedDomainParams{
+		Domain: domain,
+		Reason: reason,
+	}); err != nil {
+		return fmt.Errorf("upsert blocked domain: %w", err)
+	}
+
+	return s.Replyf(ctx, "Links to %s will now be removed in all channels.", domain)
+}
+
+func cmdAdminBlockDomainRemove(ctx context.Context, s *session, cmd string, args string) error {
+	domain := cleanAPIHost(args)
+	if domain == "" {
+		return s.ReplyUsage(ctx, "<domain>")
+	}
+
+	n, err := s.Queries.DeleteBlockedDomain(ctx, domain)
+	if err != nil {
+		return fmt.Errorf("delete blocked domain: %w", err)
+	}
+
+	if n == 0 {
+		return s.Replyf(ctx, "%s is not a blocked domain.", domain)
+	}
+
+	return s.Replyf(ctx, "%s removed from the blocked domains.", domain)
+}
+
+func cmdAdminBlockDomainList(ctx context.Context, s *session, cmd string, args string) error {
+	domains, err := s.Queries.ListBlockedDomains(ctx)
+	if err != nil {
+		return fmt.Errorf("list blocked domains: %w", err)
+	}
+
+	if len(domains) == 0 {
+		return s.Reply(ctx, "No domains are blocked.")
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Blocked domains: ")
+
+	for i, d := range domains {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+
+		builder.WriteString(d.Domain)
+
+		if d.Reason != "" {
+			builder.WriteString(" (")
+			builder.WriteString(d.Reason)
+			builder.WriteByte(')')
+		}
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+func cmdAdminBanList(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	ok, err := adminBanListCommands.Run(ctx, s, subcommand, args)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.ReplyUsage(ctx, "create <name> [channel]|maintainer <name> <channel>|none|delete <name>")
+	}
+
+	return nil
+}
+
+// banListMaintainer looks up the channel which will maintain a ban list; "none"
+// leaves the list to admins.
+func banListMaintainer(ctx context.Context, s *session, name string) (pgtype.Int8, bool, error) {
+	if name == "" || name == "none" {
+		return pgtype.Int8{}, true, nil
+	}
+
+	channel, err := s.Queries.GetChannelByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.Int8{}, false, s.Replyf(ctx, "Channel %s does not exist.", name)
+		}
+		return pgtype.Int8{}, false, fmt.Errorf("get channel: %w", err)
+	}
+
+	return pgtype.Int8{Int64: channel.ID, Valid: true}, true, nil
+}
+
+func cmdAdminBanListCreate(ctx context.Context, s *session, cmd string, args string) error {
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+	maintainer, _ := splitSpace(args)
+	maintainer = cleanUsername(maintainer)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name> [channel]")
+	}
+
+	if _, err := s.Queries.GetBanListByName(ctx, name); err == nil {
+		return s.Replyf(ctx, "Ban list %s already exists.", name)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("get ban list: %w", err)
+	}
+
+	maintainerID, ok, err := banListMaintainer(ctx, s, maintainer)
+	if !ok {
+		return err
+	}
+
+	if err := s.Queries.InsertBanList(ctx, dbsql.InsertBanListParams{
+		Name:                name,
+		MaintainerChannelID: maintainerID,
+	}); err != nil {
+		return fmt.Errorf("insert ban list: %w", err)
+	}
+
+	if maintainerID.Valid {
+		return s.Replyf(ctx, "Ban list %s created, maintained by %s.", name, maintainer)
+	}
+	return s.Replyf(ctx, "Ban list %s created.", name)
+}
+
+func cmdAdminBanListMaintainer(ctx context.Context, s *session, cmd string, args string) error {
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+	maintainer, _ := splitSpace(args)
+	maintainer = cleanUsername(maintainer)
+
+	if name == "" || maintainer == "" {
+		return s.ReplyUsage(ctx, "<name> <channel>|none")
+	}
+
+	list, err := getBanList(ctx, s, name)
+	if list == nil {
+		return err
+	}
+
+	maintainerID, ok, err := banListMaintainer(ctx, s, maintainer)
+	if !ok {
+		return err
+	}
+
+	if err := s.Queries.UpdateBanListMaintainer(ctx, dbsql.UpdateBanListMaintainerParams{
+		MaintainerChannelID: maintainerID,
+		ID:                  list.ID,
+	}); err != nil {
+		return fmt.Errorf("update ban list maintainer: %w", err)
+	}
+
+	if maintainerID.Valid {
+		return s.Replyf(ctx, "Ban list %s is now maintained by %s.", list.Name, maintainer)
+	}
+	return s.Replyf(ctx, "Ban list %s is now maintained by admins only.", list.Name)
+}
+
+func cmdAdminBanListDelete(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name>")
+	}
+
+	list, err := getBanList(ctx, s, name)
+	if list == nil {
+		return err
+	}
+
+	// The channels' ban list bans are kept as an audit trail, but those not
+	// yet applied never will be.
+	if err := s.Queries.RejectUnappliedBanListBans(ctx, dbsql.RejectUnappliedBanListBansParams{
+		ReviewedBy: s.User,
+		BanListID:  pgtype.Int8{Int64: list.ID, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("rejecting unapplied ban list bans: %w", err)
+	}
+
+	if err := s.Queries.DeleteBanList(ctx, list.ID); err != nil {
+		return fmt.Errorf("delete ban list: %w", err)
+	}
+
+	return s.Replyf(ctx, "Ban list %s deleted.", list.Name)
+}
+
+func cleanAPIHost(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return ""
+		}
+		host = u.Hostname()
+	}
+
+	host = strings.Trim(host, ".")
+
+	if strings.ContainsAny(host, " /:?#@") {
+		return ""
+	}
+
+	return host
+}
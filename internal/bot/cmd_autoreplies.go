@@ -24,6 +24,12 @@ var autoreplyCommands = newHandlerMap(map[string]handlerFunc{
 	"edittrigger":  {fn: cmdAutoreplyEditPattern, minLevel: AccessLevelModerator},
 	"list":         {fn: cmdAutoreplyList, minLevel: AccessLevelSubscriber},
 	"compact":      {fn: cmdAutoreplyCompact, minLevel: AccessLevelModerator},
+	"restrict":     {fn: cmdAutoreplyRestrict, minLevel: AccessLevelModerator},
+	"cooldown":     {fn: cmdAutoreplyCooldown, minLevel: AccessLevelModerator},
+	"liveonly":     {fn: cmdAutoreplyLiveOnly, minLevel: AccessLevelModerator},
+	"games":        {fn: cmdAutoreplyGames, minLevel: AccessLevelModerator},
+	"info":         {fn: cmdAutoreplyInfo, minLevel: AccessLevelModerator},
+	"test":         {fn: cmdAutoreplyTest, minLevel: AccessLevelModerator},
 })
 
 func cmdAutoreply(ctx context.Context, s *session, cmd string, args string) error {
@@ -83,6 +89,8 @@ func cmdAutoreplyAdd(ctx context.Context, s *session, cmd string, args string) e
 		return fmt.Errorf("inserting autoreply: %w", err)
 	}
 
+	s.invalidateMatchers()
+
 	return s.Replyf(ctx, "Autoreply #%d added.%s", nextNum, warning)
 }
 
@@ -117,6 +125,8 @@ func cmdAutoreplyDelete(ctx context.Context, s *session, cmd string, args string
 		return fmt.Errorf("deleting autoreply: %w", err)
 	}
 
+	s.invalidateMatchers()
+
 	return s.Replyf(ctx, "Autoreply #%d has been deleted.", num)
 }
 
@@ -162,6 +172,8 @@ func cmdAutoreplyEditResponse(ctx context.Context, s *session, cmd string, args
 		return fmt.Errorf("updating autoreply: %w", err)
 	}
 
+	s.invalidateMatchers()
+
 	return s.Replyf(ctx, "Autoreply #%d's response has been edited.%s", num, warning)
 }
 
@@ -208,6 +220,8 @@ func cmdAutoreplyEditPattern(ctx context.Context, s *session, cmd string, args s
 		return fmt.Errorf("updating autoreply: %w", err)
 	}
 
+	s.invalidateMatchers()
+
 	return s.Replyf(ctx, "Autoreply #%d's pattern has been edited.", num)
 }
 
@@ -339,5 +353,290 @@ func cmdAutoreplyCompact(ctx context.Context, s *session, cmd string, args strin
 		return fmt.Errorf("compacting autoreplies: %w", err)
 	}
 
+	s.invalidateMatchers()
+
 	return s.Replyf(ctx, "Compacted autoreplies %d and above (%d affected).", num, affected)
 }
+
+// getAutoreplyForEdit parses an autoreply number and fetches it for update. If
+// the number is invalid or the autoreply does not exist, the user is replied
+// to and ok is false.
+func getAutoreplyForEdit(ctx context.Context, s *session, numStr string, usage func() error) (autoreply dbsql.Autoreply, ok bool, err error) {
+	num, err := parseInt32(numStr)
+	if err != nil {
+		return autoreply, false, usage()
+	}
+
+	autoreply, err = s.Queries.GetAutoreplyForUpdate(ctx, dbsql.GetAutoreplyForUpdateParams{
+		ChannelID: s.Channel.ID,
+		Num:       num,
+	})
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return autoreply, false, s.Replyf(ctx, "Autoreply #%d does not exist.", num)
+	}
+
+	if err != nil {
+		return autoreply, false, fmt.Errorf("getting autoreply: %w", err)
+	}
+
+	return autoreply, true, nil
+}
+
+func updateAutoreplyRestrictions(ctx context.Context, s *session, autoreply *dbsql.Autoreply) error {
+	if err := s.Queries.UpdateAutoreplyRestrictions(ctx, dbsql.UpdateAutoreplyRestrictionsParams{
+		AccessLevel:    autoreply.AccessLevel,
+		MaxAccessLevel: autoreply.MaxAccessLevel,
+		Cooldown:       autoreply.Cooldown,
+		UserCooldown:   autoreply.UserCooldown,
+		LiveOnly:       autoreply.LiveOnly,
+		Games:          autoreply.Games,
+		Editor:         s.User,
+		ID:             autoreply.ID,
+	}); err != nil {
+		return fmt.Errorf("updating autoreply: %w", err)
+	}
+
+	s.invalidateMatchers()
+	return nil
+}
+
+func describeAutoreplyAccess(autoreply *dbsql.Autoreply) string {
+	levelName := func(level dbsql.AccessLevel) string {
+		if level == dbsql.AccessLevelEveryone {
+			return "everyone"
+		}
+		return pluralAccessLevel(level)
+	}
+
+	switch {
+	case autoreply.MaxAccessLevel != dbsql.AccessLevelAdmin:
+		return levelName(autoreply.AccessLevel) + " through " + levelName(autoreply.MaxAccessLevel)
+	case autoreply.AccessLevel == dbsql.AccessLevelEveryone:
+		return "everyone"
+	default:
+		return levelName(autoreply.AccessLevel) + " and above"
+	}
+}
+
+func cmdAutoreplyRestrict(ctx context.Context, s *session, cmd string, args string) error {
+	usage := func() error {
+		return s.ReplyUsage(ctx, "<index> <min level> [<max level>]")
+	}
+
+	numStr, args := splitSpace(args)
+	minStr, maxStr := splitSpace(strings.ToLower(args))
+
+	if numStr == "" {
+		return usage()
+	}
+
+	autoreply, ok, err := getAutoreplyForEdit(ctx, s, numStr, usage)
+	if !ok || err != nil {
+		return err
+	}
+
+	if minStr == "" {
+		return s.Replyf(ctx, "Autoreply #%d is restricted to %s.", autoreply.Num, describeAutoreplyAccess(&autoreply))
+	}
+
+	minLevel := parseLevelPG(minStr)
+	if minLevel == "" {
+		return usage()
+	}
+
+	maxLevel := dbsql.AccessLevelAdmin
+	if maxStr != "" {
+		maxLevel = parseLevelPG(maxStr)
+		if maxLevel == "" {
+			return usage()
+		}
+	}
+
+	if !newAccessLevel(maxLevel).CanAccess(newAccessLevel(minLevel)) {
+		return s.Reply(ctx, "The maximum level must not be below the minimum level.")
+	}
+
+	if !s.UserLevel.CanAccess(newAccessLevel(minLevel)) {
+		return s.Replyf(ctx, "Your level is %s; you cannot restrict an autoreply to level %s.", s.UserLevel.PGEnum(), minLevel)
+	}
+
+	autoreply.AccessLevel = minLevel
+	autoreply.MaxAccessLevel = maxLevel
+
+	if err := updateAutoreplyRestrictions(ctx, s, &autoreply); err != nil {
+		return err
+	}
+
+	return s.Replyf(ctx, "Autoreply #%d restricted to %s.", autoreply.Num, describeAutoreplyAccess(&autoreply))
+}
+
+func cmdAutoreplyCooldown(ctx context.Context, s *session, cmd string, args string) error {
+	usage := func() error {
+		return s.ReplyUsage(ctx, "<index> <seconds> [<per-user seconds>]")
+	}
+
+	numStr, args := splitSpace(args)
+	globalStr, userStr := splitSpace(args)
+
+	if numStr == "" {
+		return usage()
+	}
+
+	autoreply, ok, err := getAutoreplyForEdit(ctx, s, numStr, usage)
+	if !ok || err != nil {
+		return err
+	}
+
+	if globalStr == "" {
+		return s.Replyf(ctx, "Autoreply #%d has a cooldown of %d seconds, and %d seconds per user.", autoreply.Num, autoreply.Cooldown, autoreply.UserCooldown)
+	}
+
+	cooldown, err := parseInt32(globalStr)
+	if err != nil || cooldown < 0 {
+		return usage()
+	}
+
+	userCooldown := autoreply.UserCooldown
+	if userStr != "" {
+		userCooldown, err = parseInt32(userStr)
+		if err != nil || userCooldown < 0 {
+			return usage()
+		}
+	}
+
+	autoreply.Cooldown = cooldown
+	autoreply.UserCooldown = userCooldown
+
+	if err := updateAutoreplyRestrictions(ctx, s, &autoreply); err != nil {
+		return err
+	}
+
+	return s.Replyf(ctx, "Autoreply #%d now has a cooldown of %d seconds, and %d seconds per user.", autoreply.Num, cooldown, userCooldown)
+}
+
+func cmdAutoreplyLiveOnly(ctx context.Context, s *session, cmd string, args string) error {
+	usage := func() error {
+		return s.ReplyUsage(ctx, "<index> on|off")
+	}
+
+	numStr, value := splitSpace(args)
+
+	if numStr == "" {
+		return usage()
+	}
+
+	autoreply, ok, err := getAutoreplyForEdit(ctx, s, numStr, usage)
+	if !ok || err != nil {
+		return err
+	}
+
+	switch strings.ToLower(value) {
+	case "":
+		if autoreply.LiveOnly {
+			return s.Replyf(ctx, "Autoreply #%d only triggers while the stream is live.", autoreply.Num)
+		}
+		return s.Replyf(ctx, "Autoreply #%d triggers whether or not the stream is live.", autoreply.Num)
+	case "on", "true", "enabled", "enable":
+		autoreply.LiveOnly = true
+	case "off", "false", "disabled", "disable":
+		autoreply.LiveOnly = false
+	default:
+		return usage()
+	}
+
+	if err := updateAutoreplyRestrictions(ctx, s, &autoreply); err != nil {
+		return err
+	}
+
+	if autoreply.LiveOnly {
+		return s.Replyf(ctx, "Autoreply #%d will now only trigger while the stream is live.", autoreply.Num)
+	}
+	return s.Replyf(ctx, "Autoreply #%d will now trigger whether or not the stream is live.", autoreply.Num)
+}
+
+func cmdAutoreplyGames(ctx context.Context, s *session, cmd string, args string) error {
+	usage := func() error {
+		return s.ReplyUsage(ctx, "<index> <game>[ | <game>...]|clear")
+	}
+
+	numStr, value := splitSpace(args)
+
+	if numStr == "" {
+		return usage()
+	}
+
+	autoreply, ok, err := getAutoreplyForEdit(ctx, s, numStr, usage)
+	if !ok || err != nil {
+		return err
+	}
+
+	if value == "" {
+		if len(autoreply.Games) == 0 {
+			return s.Replyf(ctx, "Autoreply #%d triggers in any game.", autoreply.Num)
+		}
+		return s.Replyf(ctx, "Autoreply #%d only triggers in: %s", autoreply.Num, strings.Join(autoreply.Games, " | "))
+	}
+
+	games := []string{}
+	if !strings.EqualFold(value, "clear") {
+		for game := range strings.SplitSeq(value, "|") {
+			if game = strings.TrimSpace(game); game != "" {
+				games = append(games, game)
+			}
+		}
+	}
+
+	autoreply.Games = games
+
+	if err := updateAutoreplyRestrictions(ctx, s, &autoreply); err != nil {
+		return err
+	}
+
+	if len(games) == 0 {
+		return s.Replyf(ctx, "Autoreply #%d will now trigger in any game.", autoreply.Num)
+	}
+	return s.Replyf(ctx, "Autoreply #%d will now only trigger in: %s", autoreply.Num, strings.Join(games, " | "))
+}
+
+func cmdAutoreplyInfo(ctx context.Context, s *session, cmd string, args string) error {
+	usage := func() error {
+		return s.ReplyUsage(ctx, "<index>")
+	}
+
+	if args == "" {
+		return usage()
+	}
+
+	autoreply, ok, err := getAutoreplyForEdit(ctx, s, args, usage)
+	if !ok || err != nil {
+		return err
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Autoreply #%d: restricted to %s, cooldown %ds, user cooldown %ds", autoreply.Num, describeAutoreplyAccess(&autoreply), autoreply.Cooldown, autoreply.UserCooldown)
+
+	if autoreply.LiveOnly {
+		builder.WriteString(", live only")
+	}
+
+	if len(autoreply.Games) != 0 {
+		builder.WriteString(", games: ")
+		builder.WriteString(strings.Join(autoreply.Games, " | "))
+	}
+
+	fmt.Fprintf(&builder, ", used %d %s.", autoreply.Count, pluralInt(autoreply.Count, "time", "times"))
+
+	return s.Reply(ctx, builder.String())
+}
+
+func cmdAutoreplyTest(ctx context.Context, s *session, cmd string, args string) error {
+	result, err := dryRunAutoreply(ctx, s, args)
+	if errors.Is(err, errDryRunUsage) {
+		return s.ReplyUsage(ctx, "<message>")
+	}
+	if err != nil {
+		return err
+	}
+	return s.Reply(ctx, result)
+}
@@ -0,0 +1,470 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/jackc/pgx/v5"
+)
+
+const banListHistoryMax = 10
+
+var banListCommands = newHandlerMap(map[string]handlerFunc{
+	"list":        {fn: cmdBanListList, minLevel: AccessLevelModerator},
+	"subscribe":   {fn: cmdBanListSubscribe, minLevel: AccessLevelBroadcaster},
+	"unsubscribe": {fn: cmdBanListUnsubscribe, minLevel: AccessLevelBroadcaster},
+	"review":      {fn: cmdBanListReview, minLevel: AccessLevelBroadcaster},
+	"pending":     {fn: cmdBanListPending, minLevel: AccessLevelModerator},
+	"approve":     {fn: cmdBanListApprove, minLevel: AccessLevelModerator},
+	"reject":      {fn: cmdBanListReject, minLevel: AccessLevelModerator},
+	"history":     {fn: cmdBanListHistory, minLevel: AccessLevelModerator},
+	"add":         {fn: cmdBanListAdd, minLevel: AccessLevelModerator},
+	"remove":      {fn: cmdBanListRemove, minLevel: AccessLevelModerator},
+	"delete":      {fn: cmdBanListRemove, minLevel: AccessLevelModerator},
+})
+
+func cmdBanList(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	ok, err := banListCommands.Run(ctx, s, subcommand, args)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.ReplyUsage(ctx, "list|subscribe|unsubscribe|review|pending|approve|reject|history|add|remove ...")
+	}
+
+	return nil
+}
+
+// getBanList finds a ban list by name, replying if it does not exist.
+func getBanList(ctx context.Context, s *session, name string) (*dbsql.BanList, error) {
+	list, err := s.Queries.GetBanListByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, s.Replyf(ctx, "Ban list %s does not exist.", name)
+		}
+		return nil, fmt.Errorf("getting ban list: %w", err)
+	}
+	return &list, nil
+}
+
+func cmdBanListList(ctx context.Context, s *session, cmd string, args string) error {
+	lists, err := s.Queries.ListBanLists(ctx)
+	if err != nil {
+		return fmt.Errorf("listing ban lists: %w", err)
+	}
+
+	if len(lists) == 0 {
+		return s.Reply(ctx, "There are no ban lists.")
+	}
+
+	subs, err := s.Queries.ListChannelBanListSubscriptions(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("listing ban list subscriptions: %w", err)
+	}
+
+	review := make(map[int64]bool, len(subs))
+	for _, sub := range subs {
+		review[sub.BanListID] = sub.Review
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Ban lists: ")
+
+	for i, list := range lists {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+
+		fmt.Fprintf(&builder, "%s (%d %s", list.Name, list.Entries, pluralInt(list.Entries, "user", "users"))
+
+		if r, subscribed := review[list.ID]; subscribed {
+			if r {
+				builder.WriteString(", subscribed with review")
+			} else {
+				builder.WriteString(", subscribed")
+			}
+		}
+
+		builder.WriteByte(')')
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+func cmdBanListSubscribe(ctx context.Context, s *session, cmd string, args string) error {
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+
+	review := false
+
+	switch strings.ToLower(args) {
+	case "":
+	case "review":
+		review = true
+	default:
+		name = ""
+	}
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<list> [review]")
+	}
+
+	list, err := getBanList(ctx, s, name)
+	if list == nil {
+		return err
+	}
+
+	if err := s.Queries.UpsertBanListSubscription(ctx, dbsql.UpsertBanListSubscriptionParams{
+		ChannelID: s.Channel.ID,
+		BanListID: list.ID,
+		Review:    review,
+	}); err != nil {
+		return fmt.Errorf("subscribing to ban list: %w", err)
+	}
+
+	if review {
+		return s.Replyf(ctx, "Subscribed to ban list %s; new entries will wait for a moderator's review.", list.Name)
+	}
+	return s.Replyf(ctx, "Subscribed to ban list %s; new entries will be banned automatically.", list.Name)
+}
+
+func cmdBanListUnsubscribe(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<list>")
+	}
+
+	list, err := getBanList(ctx, s, name)
+	if list == nil {
+		return err
+	}
+
+	n, err := s.Queries.DeleteBanListSubscription(ctx, dbsql.DeleteBanListSubscriptionParams{
+		ChannelID: s.Channel.ID,
+		BanListID: list.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("unsubscribing from ban list: %w", err)
+	}
+
+	if n == 0 {
+		return s.Replyf(ctx, "This channel is not subscribed to ban list %s.", list.Name)
+	}
+
+	return s.Replyf(ctx, "Unsubscribed from ban list %s.", list.Name)
+}
+
+func cmdBanListReview(ctx context.Context, s *session, cmd string, args string) error {
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+
+	var review bool
+
+	switch strings.ToLower(args) {
+	case "on":
+		review = true
+	case "off":
+		review = false
+	default:
+		name = ""
+	}
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<list> on|off")
+	}
+
+	list, err := getBanList(ctx, s, name)
+	if list == nil {
+		return err
+	}
+
+	subs, err := s.Queries.ListChannelBanListSubscriptions(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("listing ban list subscriptions: %w", err)
+	}
+
+	subscribed := false
+	for _, sub := range subs {
+		if sub.BanListID == list.ID {
+			subscribed = true
+			break
+		}
+	}
+
+	if !subscribed {
+		return s.Replyf(ctx, "This channel is not subscribed to ban list %s.", list.Name)
+	}
+
+	if err := s.Queries.UpsertBanListSubscription(ctx, dbsql.UpsertBanListSubscriptionParams{
+		ChannelID: s.Channel.ID,
+		BanListID: list.ID,
+		Review:    review,
+	}); err != nil {
+		return fmt.Errorf("updating ban list subscription: %w", err)
+	}
+
+	if review {
+		return s.Replyf(ctx, "New entries in ban list %s will now wait for a moderator's review.", list.Name)
+	}
+	return s.Replyf(ctx, "New entries in ban list %s will now be banned automatically.", list.Name)
+}
+
+func cmdBanListPending(ctx context.Context, s *session, cmd string, args string) error {
+	pending, err := s.Queries.ListPendingBanListBans(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("listing pending bans: %w", err)
+	}
+
+	if len(pending) == 0 {
+		return s.Reply(ctx, "There are no pending ban list bans.")
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Pending bans: ")
+
+	for i, ban := range pending {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+		fmt.Fprintf(&builder, "%s (%s: %s)", ban.UserName, ban.ListName, ban.Reason)
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+// pendingBansFor returns the channel's pending bans for the user, or all
+// pending bans if the user is "all".
+func pendingBansFor(ctx context.Context, s *session, user string) ([]dbsql.ListPendingBanListBansRow, error) {
+	pending, err := s.Queries.ListPendingBanListBans(ctx, s.Channel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("listing pending bans: %w", err)
+	}
+
+	if user == "all" {
+		return pending, nil
+	}
+
+	var matched []dbsql.ListPendingBanListBansRow
+	for _, ban := range pending {
+		if ban.UserName == user {
+			matched = append(matched, ban)
+		}
+	}
+
+	return matched, nil
+}
+
+func cmdBanListApprove(ctx context.Context, s *session, cmd string, args string) error {
+	user, _ := splitSpace(args)
+	user = cleanUsername(user)
+
+	if user == "" {
+		return s.ReplyUsage(ctx, "<user>|all")
+	}
+
+	pending, err := pendingBansFor(ctx, s, user)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		return s.Reply(ctx, "There are no matching pending bans.")
+	}
+
+	banned := make(map[int64]bool, len(pending))
+
+	for _, ban := range pending {
+		// A user may be pending in more than one list.
+		if !banned[ban.UserID] {
+			if err := s.BanByID(ctx, ban.UserID, 0, banListBanReason(ban.ListName, ban.Reason)); err != nil {
+				if ae, ok := apiclient.AsError(err); ok && ae.IsNotPermitted() {
+					return s.Reply(ctx, "Unable to ban user; is the bot modded?")
+				}
+				return err
+			}
+			banned[ban.UserID] = true
+		}
+
+		if err := s.Queries.UpdateBanListBanStatus(ctx, dbsql.UpdateBanListBanStatusParams{
+			Status:     banListStatusBanned,
+			ReviewedBy: s.User,
+			ID:         ban.ID,
+		}); err != nil {
+			return fmt.Errorf("approving ban: %w", err)
+		}
+	}
+
+	if user == "all" {
+		return s.Replyf(ctx, "Approved %d pending %s.", len(banned), pluralInt(len(banned), "ban", "bans"))
+	}
+	return s.Replyf(ctx, "%s has been banned.", user)
+}
+
+func cmdBanListReject(ctx context.Context, s *session, cmd string, args string) error {
+	user, _ := splitSpace(args)
+	user = cleanUsername(user)
+
+	if user == "" {
+		return s.ReplyUsage(ctx, "<user>|all")
+	}
+
+	pending, err := pendingBansFor(ctx, s, user)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		return s.Reply(ctx, "There are no matching pending bans.")
+	}
+
+	for _, ban := range pending {
+		if err := s.Queries.UpdateBanListBanStatus(ctx, dbsql.UpdateBanListBanStatusParams{
+			Status:     banListStatusRejected,
+			ReviewedBy: s.User,
+			ID:         ban.ID,
+		}); err != nil {
+			return fmt.Errorf("rejecting ban: %w", err)
+		}
+	}
+
+	if user == "all" {
+		return s.Replyf(ctx, "Rejected %d pending %s.", len(pending), pluralInt(len(pending), "ban", "bans"))
+	}
+	return s.Replyf(ctx, "Rejected the pending ban of %s.", user)
+}
+
+func cmdBanListHistory(ctx context.Context, s *session, cmd string, args string) error {
+	bans, err := s.Queries.ListRecentBanListBans(ctx, dbsql.ListRecentBanListBansParams{
+		ChannelID: s.Channel.ID,
+		MaxBans:   banListHistoryMax,
+	})
+	if err != nil {
+		return fmt.Errorf("listing ban list history: %w", err)
+	}
+
+	if len(bans) == 0 {
+		return s.Reply(ctx, "No ban list bans have been applied to this channel.")
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Recent ban list bans: ")
+
+	for i, ban := range bans {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+
+		fmt.Fprintf(&builder, "%s (%s, %s", ban.UserName, ban.ListName, ban.Status)
+
+		if ban.ReviewedBy != "" {
+			builder.WriteString(" by ")
+			builder.WriteString(ban.ReviewedBy)
+		}
+
+		builder.WriteByte(')')
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+func cmdBanListAdd(ctx context.Context, s *session, cmd string, args string) error {
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+	user, reason := splitSpace(args)
+	user = cleanUsername(user)
+
+	if name == "" || user == "" {
+		return s.ReplyUsage(ctx, "<list> <user> [reason]")
+	}
+
+	list, err := getBanList(ctx, s, name)
+	if list == nil {
+		return err
+	}
+
+	if !s.canMaintainBanList(list) {
+		return s.Replyf(ctx, "This channel does not maintain ban list %s.", list.Name)
+	}
+
+	if reason == "" {
+		reason = "added by " + s.UserDisplay
+	}
+
+	userID, err := s.GetUserID(ctx, user)
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok && ae.IsNotFound() {
+			return s.Replyf(ctx, "User %s does not exist.", user)
+		}
+		return err
+	}
+
+	if err := s.Queries.UpsertBanListEntry(ctx, dbsql.UpsertBanListEntryParams{
+		BanListID: list.ID,
+		UserID:    userID,
+		UserName:  user,
+		Reason:    reason,
+		AddedBy:   s.User,
+	}); err != nil {
+		return fmt.Errorf("adding ban list entry: %w", err)
+	}
+
+	result, err := propagateBan(ctx, s, list, userID, user, reason)
+	if err != nil {
+		return err
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s added to ban list %s; banning in %d %s", user, list.Name, result.queued, pluralInt(result.queued, "channel", "channels"))
+
+	if result.pending != 0 {
+		fmt.Fprintf(&builder, ", %d awaiting review", result.pending)
+	}
+
+	builder.WriteByte('.')
+
+	return s.Reply(ctx, builder.String())
+}
+
+func cmdBanListRemove(ctx context.Context, s *session, cmd string, args string) error {
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+	user, _ := splitSpace(args)
+	user = cleanUsername(user)
+
+	if name == "" || user == "" {
+		return s.ReplyUsage(ctx, "<list> <user>")
+	}
+
+	list, err := getBanList(ctx, s, name)
+	if list == nil {
+		return err
+	}
+
+	if !s.canMaintainBanList(list) {
+		return s.Replyf(ctx, "This channel does not maintain ban list %s.", list.Name)
+	}
+
+	n, err := s.Queries.DeleteBanListEntry(ctx, dbsql.DeleteBanListEntryParams{
+		BanListID: list.ID,
+		UserName:  user,
+	})
+	if err != nil {
+		return fmt.Errorf("removing ban list entry: %w", err)
+	}
+
+	if n == 0 {
+		return s.Replyf(ctx, "%s is not on ban list %s.", user, list.Name)
+	}
+
+	return s.Replyf(ctx, "%s removed from ban list %s. Existing bans are not lifted.", user, list.Name)
+}
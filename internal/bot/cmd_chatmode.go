@@ -0,0 +1,343 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/repeat"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	chatModeSlowDefault  = 30 * time.Second
+	chatModeSlowMin      = 3 * time.Second
+	chatModeSlowMax      = 120 * time.Second
+	chatModeFollowersMax = 90 * 24 * time.Hour
+	chatModeRevertMin    = 5 * time.Second
+	chatModeRevertMax    = 24 * time.Hour
+	chatModeRateMax      = 1000
+)
+
+var chatModeCommands = newHandlerMap(map[string]handlerFunc{
+	"rule": {fn: cmdChatModeRule, minLevel: AccessLevelModerator},
+})
+
+var chatModeRuleCommands = newHandlerMap(map[string]handlerFunc{
+	"add":    {fn: cmdChatModeRuleAdd, minLevel: AccessLevelModerator},
+	"delete": {fn: cmdChatModeRuleDelete, minLevel: AccessLevelModerator},
+	"remove": {fn: cmdChatModeRuleDelete, minLevel: AccessLevelModerator},
+	"list":   {fn: cmdChatModeRuleList, minLevel: AccessLevelModerator},
+})
+
+func cmdChatMode(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	ok, err := chatModeCommands.Run(ctx, s, subcommand, args)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.ReplyUsage(ctx, "rule add|delete|list ...")
+	}
+
+	return nil
+}
+
+func cmdChatModeRule(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	ok, err := chatModeRuleCommands.Run(ctx, s, subcommand, args)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.ReplyUsage(ctx, "add|delete|list ...")
+	}
+
+	return nil
+}
+
+func cmdChatModeRuleAdd(ctx context.Context, s *session, cmd string, args string) error {
+	const usage = "<raid|offline|rate=N|schedule=pattern> <emoteonly|followers[=duration]|slow[=duration]|subscribers|uniquechat> [revert after]"
+
+	triggerArg, args := splitSpace(args)
+	modeArg, args := splitSpace(args)
+	revertArg, args := splitSpace(args)
+
+	if modeArg == "" || args != "" {
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	trigger, threshold, schedule, ok := parseChatModeTrigger(triggerArg)
+	if !ok {
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	if trigger == chatModeTriggerRate && (threshold < 1 || threshold > chatModeRateMax) {
+		return s.Replyf(ctx, "Message rate must be between 1 and %d messages per second.", chatModeRateMax)
+	}
+
+	var expr *repeat.Cron
+
+	if trigger == chatModeTriggerSchedule {
+		var err error
+		expr, err = repeat.ParseCronIn(schedule, s.Channel.Location())
+		if err != nil {
+			return s.Replyf(ctx, "Bad cron expression: %s", schedule)
+		}
+	}
+
+	mode, modeDur, hasDur, ok := parseChatMode(modeArg)
+	if !ok {
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	switch mode {
+	case chatModeSlow:
+		if !hasDur {
+			modeDur = chatModeSlowDefault
+		}
+		if modeDur < chatModeSlowMin || modeDur > chatModeSlowMax {
+			return s.Replyf(ctx, "Slow mode duration must be between %s and %s.", durafmt.Parse(chatModeSlowMin), durafmt.Parse(chatModeSlowMax))
+		}
+		modeDur = modeDur.Truncate(time.Second)
+	case chatModeFollowers:
+		if modeDur < 0 || modeDur > chatModeFollowersMax {
+			return s.Replyf(ctx, "Follower mode duration must be at most %s.", durafmt.Parse(chatModeFollowersMax))
+		}
+		modeDur = modeDur.Truncate(time.Minute)
+	default:
+		if hasDur {
+			return s.ReplyUsage(ctx, usage)
+		}
+	}
+
+	var revert time.Duration
+
+	if revertArg != "" {
+		d, err := time.ParseDuration(revertArg)
+		if err != nil {
+			return s.ReplyUsage(ctx, usage)
+		}
+
+		if d < chatModeRevertMin || d > chatModeRevertMax {
+			return s.Replyf(ctx, "Revert duration must be between %s and %s.", durafmt.Parse(chatModeRevertMin), durafmt.Parse(chatModeRevertMax))
+		}
+
+		revert = d.Truncate(time.Second)
+	} else {
+		switch trigger {
+		case chatModeTriggerRate:
+			return s.Reply(ctx, "Rate rules must have a revert duration.")
+		case chatModeTriggerSchedule:
+			return s.Reply(ctx, "Scheduled rules must have a revert duration.")
+		}
+	}
+
+	rule := dbsql.ChatModeRule{
+		ChannelID:     s.Channel.ID,
+		Trigger:       trigger,
+		Threshold:     threshold,
+		Schedule:      schedule,
+		Mode:          mode,
+		ModeSeconds:   int64(modeDur / time.Second),
+		RevertSeconds: int64(revert / time.Second),
+	}
+
+	id, err := s.Queries.UpsertChatModeRule(ctx, dbsql.UpsertChatModeRuleParams{
+		ChannelID:     rule.ChannelID,
+		Trigger:       rule.Trigger,
+		Threshold:     rule.Threshold,
+		Schedule:      rule.Schedule,
+		Mode:          rule.Mode,
+		ModeSeconds:   rule.ModeSeconds,
+		RevertSeconds: rule.RevertSeconds,
+	})
+	if err != nil {
+		return fmt.Errorf("upserting chat mode rule: %w", err)
+	}
+
+	switch trigger {
+	case chatModeTriggerRaid, chatModeTriggerOffline:
+		s.requestEventsubUpdate()
+	case chatModeTriggerSchedule:
+		if err := s.Deps.AddChatModeSchedule(ctx, id, expr); err != nil {
+			return err
+		}
+	}
+
+	return s.Replyf(ctx, "Chat mode rule set: %s.", describeChatModeRule(&rule))
+}
+
+func cmdChatModeRuleDelete(ctx context.Context, s *session, cmd string, args string) error {
+	const usage = "<raid|offline|rate|schedule> <mode>"
+
+	triggerArg, args := splitSpace(args)
+	modeArg, _ := splitSpace(args)
+
+	// Rules are identified by trigger and mode alone; ignore any value given.
+	trigger, _, _ := strings.Cut(strings.ToLower(triggerArg), "=")
+	switch trigger {
+	case chatModeTriggerRaid, chatModeTriggerOffline, chatModeTriggerRate, chatModeTriggerSchedule:
+	default:
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	mode, _, _, ok := parseChatMode(modeArg)
+	if !ok {
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	rule, err := s.Queries.DeleteChatModeRule(ctx, dbsql.DeleteChatModeRuleParams{
+		ChannelID: s.Channel.ID,
+		Trigger:   trigger,
+		Mode:      mode,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return s.Replyf(ctx, "There is no %s rule for %s.", mode, trigger)
+		}
+		return fmt.Errorf("deleting chat mode rule: %w", err)
+	}
+
+	// Don't leave the channel stuck in a mode that will now never be reverted.
+	if rule.RevertAt.Valid {
+		if err := s.revertChatMode(ctx, &rule); err != nil {
+			return err
+		}
+	}
+
+	switch trigger {
+	case chatModeTriggerRaid, chatModeTriggerOffline:
+		s.requestEventsubUpdate()
+	case chatModeTriggerSchedule:
+		if err := s.Deps.RemoveChatModeSchedule(ctx, rule.ID); err != nil {
+			return err
+		}
+	}
+
+	return s.Replyf(ctx, "Chat mode rule deleted: %s.", describeChatModeRule(&rule))
+}
+
+func cmdChatModeRuleList(ctx context.Context, s *session, cmd string, args string) error {
+	rules, err := s.Queries.ListChatModeRules(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("listing chat mode rules: %w", err)
+	}
+
+	if len(rules) == 0 {
+		return s.Reply(ctx, "There are no chat mode rules.")
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Chat mode rules: ")
+
+	for i := range rules {
+		if i != 0 {
+			builder.WriteString("; ")
+		}
+		builder.WriteString(describeChatModeRule(&rules[i]))
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+// parseChatModeTrigger parses a trigger argument, which for rate rules
+// includes the threshold, e.g. "rate=20", and for scheduled rules the cron
+// pattern with underscores for spaces, e.g. "schedule=0_22_*_*_*".
+func parseChatModeTrigger(arg string) (trigger string, threshold int64, schedule string, ok bool) {
+	name, value, hasValue := strings.Cut(arg, "=")
+	name = strings.ToLower(name)
+
+	switch name {
+	case chatModeTriggerRaid, chatModeTriggerOffline:
+		if hasValue {
+			return "", 0, "", false
+		}
+		return name, 0, "", true
+	case chatModeTriggerRate:
+		if !hasValue {
+			return "", 0, "", false
+		}
+		threshold, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", 0, "", false
+		}
+		return name, threshold, "", true
+	case chatModeTriggerSchedule:
+		if value == "" {
+			return "", 0, "", false
+		}
+		return name, 0, strings.ReplaceAll(value, "_", " "), true
+	default:
+		return "", 0, "", false
+	}
+}
+
+// parseChatMode parses a mode argument, which for slow and follower mode may
+// include a duration, e.g. "slow=10s".
+func parseChatMode(arg string) (mode string, dur time.Duration, hasDur bool, ok bool) {
+	arg = strings.ToLower(arg)
+	name, value, hasValue := strings.Cut(arg, "=")
+
+	switch name {
+	case chatModeEmoteOnly, chatModeFollowers, chatModeSlow, chatModeSubscribers, chatModeUniqueChat:
+	default:
+		return "", 0, false, false
+	}
+
+	if !hasValue {
+		return name, 0, false, true
+	}
+
+	dur, err := time.ParseDuration(value)
+	if err != nil {
+		return "", 0, false, false
+	}
+
+	return name, dur, true, true
+}
+
+func describeChatModeRule(rule *dbsql.ChatModeRule) string {
+	var builder strings.Builder
+
+	switch rule.Trigger {
+	case chatModeTriggerRaid:
+		builder.WriteString("on raid, ")
+	case chatModeTriggerOffline:
+		builder.WriteString("when offline, ")
+	case chatModeTriggerRate:
+		fmt.Fprintf(&builder, "at %d messages/second, ", rule.Threshold)
+	case chatModeTriggerSchedule:
+		fmt.Fprintf(&builder, "on schedule '%s', ", rule.Schedule)
+	}
+
+	builder.WriteString(rule.Mode)
+
+	modeDur := time.Duration(rule.ModeSeconds) * time.Second
+
+	switch rule.Mode {
+	case chatModeSlow:
+		fmt.Fprintf(&builder, " (%s)", durafmt.Parse(modeDur))
+	case chatModeFollowers:
+		if modeDur > 0 {
+			fmt.Fprintf(&builder, " (%s)", durafmt.Parse(modeDur))
+		}
+	}
+
+	if rule.RevertSeconds > 0 {
+		fmt.Fprintf(&builder, " for %s", durafmt.Parse(time.Duration(rule.RevertSeconds)*time.Second))
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,196 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
+	"github.com/jackc/pgx/v5"
+)
+
+const chatterDateFormat = "Jan 2, 2006"
+
+func cmdFollowAge(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanUsername(name)
+
+	userID, display := s.UserID, s.UserDisplay
+	if name != "" && name != s.User {
+		u, replied, err := twitchUser(ctx, s, name)
+		if replied || err != nil {
+			return err
+		}
+		userID, display = int64(u.ID), u.DispName()
+	}
+
+	if userID == s.Channel.TwitchID {
+		return s.Replyf(ctx, "%s is the broadcaster.", display)
+	}
+
+	since, following, err := followedAt(ctx, s, userID)
+	if err != nil {
+		return replyFollowerError(ctx, s, err)
+	}
+
+	if !following {
+		return s.Replyf(ctx, "%s is not following.", display)
+	}
+
+	return s.Replyf(ctx, "%s has been following for %s (since %s).", display, chatterAge(since), since.In(s.Channel.Location()).Format(chatterDateFormat))
+}
+
+func cmdAccountAge(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanUsername(name)
+
+	if name == "" {
+		name = s.User
+	}
+
+	u, replied, err := twitchUser(ctx, s, name)
+	if replied || err != nil {
+		return err
+	}
+
+	if u.CreatedAt.IsZero() {
+		return s.Replyf(ctx, "The account age of %s is unknown.", u.DispName())
+	}
+
+	return s.Replyf(ctx, "%s created their account %s ago (on %s).", u.DispName(), chatterAge(u.CreatedAt), u.CreatedAt.In(s.Channel.Location()).Format(chatterDateFormat))
+}
+
+func cmdSeen(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanUsername(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<user>")
+	}
+
+	chatter, found, err := findChatter(ctx, s, name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return s.Replyf(ctx, "%s has not been seen in this channel.", name)
+	}
+
+	display := chatter.UserDisplay
+	if display == "" {
+		display = chatter.UserName
+	}
+
+	return s.Replyf(ctx, "%s was last seen %s ago. First seen %s, with %d %s total.",
+		display,
+		chatterAge(chatter.LastSeen.Time),
+		chatter.FirstSeen.Time.In(s.Channel.Location()).Format(chatterDateFormat),
+		chatter.MessageCount,
+		pluralInt(chatter.MessageCount, "message", "messages"),
+	)
+}
+
+// trackChatter records a message from the current user, returning true if
+// this is the first time the user has been seen in the channel.
+func trackChatter(ctx context.Context, s *session) (bool, error) {
+	if s.UserID == 0 || s.Imp {
+		return false, nil
+	}
+
+	count, err := s.Queries.UpsertChatter(ctx, dbsql.UpsertChatterParams{
+		ChannelID:   s.Channel.ID,
+		UserID:      s.UserID,
+		UserName:    s.User,
+		UserDisplay: s.UserDisplay,
+		Now:         dbsql.TimestamptzFrom(time.Now()),
+	})
+	if err != nil {
+		return false, fmt.Errorf("tracking chatter: %w", err)
+	}
+
+	return count == 1, nil
+}
+
+// tryFirstChatCommand runs the channel's first chat command, if one is set,
+// as a greeting for a first-time chatter.
+func tryFirstChatCommand(ctx context.Context, s *session) error {
+	name := s.Channel.FirstChatCommand
+	if name == "" {
+		return nil
+	}
+
+	info, commandMsg, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, false)
+	if err != nil {
+		return fmt.Errorf("finding first chat command: %w", err)
+	}
+	if !found || !info.Enabled || !commandMsg.Valid {
+		return nil
+	}
+
+	if err := s.tryCooldown(ctx, "first_chat_command", int(s.Channel.FirstChatCooldown), false); err != nil {
+		if errors.Is(err, errInCooldown) {
+			return nil
+		}
+		return err
+	}
+
+	// The greeting shouldn't silence the rest of the message's handling.
+	silent := s.Silent
+	defer func() {
+		s.Silent = silent
+	}()
+
+	return runCommandAndCount(ctx, s, info, commandMsg.String, true)
+}
+
+func followedAt(ctx context.Context, s *session, userID int64) (since time.Time, following bool, err error) {
+	follower, err := s.GetChannelFollower(ctx, userID)
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok && ae.IsNotFound() {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, err
+	}
+	return follower.FollowedAt, true, nil
+}
+
+func replyFollowerError(ctx context.Context, s *session, err error) error {
+	ae, ok := apiclient.AsError(err)
+	if !ok {
+		return err
+	}
+
+	if ae.IsNotPermitted() || errors.Is(err, twitch.ErrDeadToken) {
+		return s.Reply(ctx, "The bot must be a moderator to look up followers.")
+	}
+
+	if ae.IsServerError() {
+		return s.Reply(ctx, twitchServerErrorReply)
+	}
+
+	return s.Reply(ctx, "A Twitch error occurred looking up followers.")
+}
+
+func findChatter(ctx context.Context, s *session, name string) (dbsql.Chatter, bool, error) {
+	chatter, err := s.Queries.GetChatterByName(ctx, dbsql.GetChatterByNameParams{
+		ChannelID: s.Channel.ID,
+		UserName:  name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return chatter, false, nil
+		}
+		return chatter, false, fmt.Errorf("getting chatter: %w", err)
+	}
+	return chatter, true, nil
+}
+
+// chatterAge formats the time since t to its two largest units.
+func chatterAge(t time.Time) string {
+	return durafmt.Parse(time.Since(t).Truncate(time.Second)).LimitFirstN(2).String()
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/gobuffalo/flect"
 	"github.com/hortbot/hortbot/internal/cbp"
@@ -48,6 +49,25 @@ var ccCommands = newHandlerMap(map[string]handlerFunc{
 	"get":             {fn: cmdCommandGet, minLevel: AccessLevelModerator},
 	"clone":           {fn: cmdCommandClone, minLevel: AccessLevelModerator},
 	"exec":            {fn: cmdCommandExec, minLevel: AccessLevelModerator},
+	"enable":          {fn: cmdCommandEnable, minLevel: AccessLevelModerator},
+	"disable":         {fn: cmdCommandEnable, minLevel: AccessLevelModerator},
+	"alias":           {fn: cmdCommandAlias, minLevel: AccessLevelModerator},
+	"replymode":       {fn: cmdCommandReplyMode, minLevel: AccessLevelModerator},
+	"describe":        {fn: cmdCommandMetadata, minLevel: AccessLevelModerator},
+	"description":     {fn: cmdCommandMetadata, minLevel: AccessLevelModerator},
+	"usage":           {fn: cmdCommandMetadata, minLevel: AccessLevelModerator},
+	"category":        {fn: cmdCommandMetadata, minLevel: AccessLevelModerator},
+	"hide":            {fn: cmdCommandHide, minLevel: AccessLevelModerator},
+	"unhide":          {fn: cmdCommandHide, minLevel: AccessLevelModerator},
+	"test":            {fn: cmdCommandTest, minLevel: AccessLevelModerator},
+})
+
+var ccAliasCommands = newHandlerMap(map[string]handlerFunc{
+	"add":    {fn: cmdCommandAliasAdd, minLevel: AccessLevelModerator},
+	"delete": {fn: cmdCommandAliasRemove, minLevel: AccessLevelModerator},
+	"remove": {fn: cmdCommandAliasRemove, minLevel: AccessLevelModerator},
+	"rm":     {fn: cmdCommandAliasRemove, minLevel: AccessLevelModerator},
+	"list":   {fn: cmdCommandAliasList, minLevel: AccessLevelModerator},
 })
 
 func cmdCommand(ctx context.Context, s *session, cmd string, args string) error {
@@ -232,6 +252,10 @@ func cmdCommandDelete(ctx context.Context, s *session, cmd string, args string)
 		return s.Replyf(ctx, "Command '%s' does not exist.", name)
 	}
 
+	if info.Name != name {
+		return s.replyIsAlias(ctx, name, info.Name)
+	}
+
 	if command == nil {
 		return s.Replyf(ctx, "'%s' is not a custom command.", name)
 	}
@@ -388,6 +412,10 @@ func cmdCommandRename(ctx context.Context, s *session, cmd string, args string)
 		return s.Replyf(ctx, "Command '%s' does not exist.", oldName)
 	}
 
+	if info.Name != oldName {
+		return s.replyIsAlias(ctx, oldName, info.Name)
+	}
+
 	if !info.CustomCommandID.Valid {
 		return s.Replyf(ctx, "'%s' is not a custom command.", oldName)
 	}
@@ -554,10 +582,378 @@ func findCustomCommand(ctx context.Context, s *session, name string, forUpdate b
 	}, nil
 }
 
+func cmdCommandEnable(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name>")
+	}
+
+	info, _, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, true)
+	if err != nil {
+		return fmt.Errorf("getting command info: %w", err)
+	}
+	if !found {
+		return s.Replyf(ctx, "Command '%s' does not exist.", name)
+	}
+
+	if !s.UserLevel.CanAccessPG(info.AccessLevel) {
+		return s.Replyf(ctx, "Your level is %s; you cannot %s a command with level %s.", s.UserLevel.PGEnum(), cmd, info.AccessLevel)
+	}
+
+	enable := cmd == "enable"
+
+	if info.Enabled == enable {
+		return s.Replyf(ctx, "Command '%s' is already %sd.", info.Name, cmd)
+	}
+
+	info.Enabled = enable
+	info.Editor = s.User
+
+	if err := s.Queries.UpdateCommandInfoEnabled(ctx, dbsql.UpdateCommandInfoEnabledParams{
+		Enabled: info.Enabled,
+		Editor:  info.Editor,
+		ID:      info.ID,
+	}); err != nil {
+		return fmt.Errorf("updating command info: %w", err)
+	}
+
+	return s.Replyf(ctx, "Command '%s' %sd.", info.Name, cmd)
+}
+
+func cmdCommandReplyMode(ctx context.Context, s *session, cmd string, args string) error {
+	const usage = "<name> [default|chat|reply|whisper]"
+
+	name, mode := splitSpace(args)
+	name = cleanCommandName(name)
+	mode = strings.ToLower(mode)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	info, _, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, true)
+	if err != nil {
+		return fmt.Errorf("getting command info: %w", err)
+	}
+	if !found {
+		return s.Replyf(ctx, "Command '%s' does not exist.", name)
+	}
+
+	switch mode {
+	case "":
+		if info.ReplyMode == "" {
+			return s.Replyf(ctx, "Command '%s' uses the channel's default reply mode.", info.Name)
+		}
+		return s.Replyf(ctx, "Command '%s' uses reply mode %s.", info.Name, info.ReplyMode)
+	case "default":
+		mode = ""
+	case replyModeChat, replyModeReply, replyModeWhisper:
+	default:
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	if !s.UserLevel.CanAccessPG(info.AccessLevel) {
+		return s.Replyf(ctx, "Your level is %s; you cannot edit a command with level %s.", s.UserLevel.PGEnum(), info.AccessLevel)
+	}
+
+	info.ReplyMode = mode
+	info.Editor = s.User
+
+	if err := s.Queries.UpdateCommandInfoReplyMode(ctx, dbsql.UpdateCommandInfoReplyModeParams{
+		ReplyMode: info.ReplyMode,
+		Editor:    info.Editor,
+		ID:        info.ID,
+	}); err != nil {
+		return fmt.Errorf("updating command info: %w", err)
+	}
+
+	if mode == "" {
+		return s.Replyf(ctx, "Command '%s' now uses the channel's default reply mode.", info.Name)
+	}
+	return s.Replyf(ctx, "Command '%s' now uses reply mode %s.", info.Name, info.ReplyMode)
+}
+
+const (
+	commandMaxDescription = 200
+	commandMaxUsage       = 100
+	commandMaxCategory    = 32
+)
+
+func cmdCommandMetadata(ctx context.Context, s *session, cmd string, args string) error {
+	if cmd == "description" {
+		cmd = "describe"
+	}
+
+	var label string
+	var maxLen int
+
+	switch cmd {
+	case "describe":
+		label, maxLen = "description", commandMaxDescription
+	case "usage":
+		label, maxLen = "usage", commandMaxUsage
+	case "category":
+		label, maxLen = "category", commandMaxCategory
+	default:
+		panic("unreachable")
+	}
+
+	name, value := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name> [<"+label+">|clear]")
+	}
+
+	info, _, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, true)
+	if err != nil {
+		return fmt.Errorf("getting command info: %w", err)
+	}
+	if !found {
+		return s.Replyf(ctx, "Command '%s' does not exist.", name)
+	}
+
+	var field *string
+	switch cmd {
+	case "describe":
+		field = &info.Description
+	case "usage":
+		field = &info.Usage
+	case "category":
+		field = &info.Category
+	}
+
+	if value == "" {
+		if *field == "" {
+			return s.Replyf(ctx, "Command '%s' has no %s.", info.Name, label)
+		}
+		return s.Replyf(ctx, "Command '%s' %s: %s", info.Name, label, *field)
+	}
+
+	if !s.UserLevel.CanAccessPG(info.AccessLevel) {
+		return s.Replyf(ctx, "Your level is %s; you cannot edit a command with level %s.", s.UserLevel.PGEnum(), info.AccessLevel)
+	}
+
+	if strings.EqualFold(value, "clear") {
+		value = ""
+	}
+
+	if utf8.RuneCountInString(value) > maxLen {
+		return s.Replyf(ctx, "A command's %s may be at most %d characters.", label, maxLen)
+	}
+
+	*field = value
+	info.Editor = s.User
+
+	if err := updateCommandInfoMetadata(ctx, s, info); err != nil {
+		return err
+	}
+
+	if value == "" {
+		return s.Replyf(ctx, "Command '%s' %s cleared.", info.Name, label)
+	}
+	return s.Replyf(ctx, "Command '%s' %s updated.", info.Name, label)
+}
+
+func cmdCommandHide(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name>")
+	}
+
+	info, _, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, true)
+	if err != nil {
+		return fmt.Errorf("getting command info: %w", err)
+	}
+	if !found {
+		return s.Replyf(ctx, "Command '%s' does not exist.", name)
+	}
+
+	if !s.UserLevel.CanAccessPG(info.AccessLevel) {
+		return s.Replyf(ctx, "Your level is %s; you cannot %s a command with level %s.", s.UserLevel.PGEnum(), cmd, info.AccessLevel)
+	}
+
+	hide := cmd == "hide"
+
+	if info.Hidden == hide {
+		if hide {
+			return s.Replyf(ctx, "Command '%s' is already hidden.", info.Name)
+		}
+		return s.Replyf(ctx, "Command '%s' is not hidden.", info.Name)
+	}
+
+	info.Hidden = hide
+	info.Editor = s.User
+
+	if err := updateCommandInfoMetadata(ctx, s, info); err != nil {
+		return err
+	}
+
+	if hide {
+		return s.Replyf(ctx, "Command '%s' is now hidden from the website.", info.Name)
+	}
+	return s.Replyf(ctx, "Command '%s' is now shown on the website.", info.Name)
+}
+
+func updateCommandInfoMetadata(ctx context.Context, s *session, info *dbsql.CommandInfo) error {
+	if err := s.Queries.UpdateCommandInfoMetadata(ctx, dbsql.UpdateCommandInfoMetadataParams{
+		Description: info.Description,
+		Usage:       info.Usage,
+		Category:    info.Category,
+		Hidden:      info.Hidden,
+		Editor:      info.Editor,
+		ID:          info.ID,
+	}); err != nil {
+		return fmt.Errorf("updating command info: %w", err)
+	}
+	return nil
+}
+
+func cmdCommandAlias(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	ok, err := ccAliasCommands.Run(ctx, s, subcommand, args)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.ReplyUsage(ctx, "add <alias> <name>|remove <alias>|list <name>")
+	}
+
+	return nil
+}
+
+func cmdCommandAliasAdd(ctx context.Context, s *session, cmd string, args string) error {
+	alias, args := splitSpace(args)
+	name, _ := splitSpace(args)
+
+	alias = cleanCommandName(alias)
+	name = cleanCommandName(name)
+
+	if alias == "" || name == "" {
+		return s.ReplyUsage(ctx, "<alias> <name>")
+	}
+
+	if reservedCommandNames[alias] {
+		return s.Replyf(ctx, "Command name '%s' is reserved.", alias)
+	}
+
+	exists, err := s.Queries.CommandInfoExists(ctx, dbsql.CommandInfoExistsParams{
+		ChannelID: s.Channel.ID,
+		Name:      alias,
+	})
+	if err != nil {
+		return fmt.Errorf("checking command exists: %w", err)
+	}
+	if exists {
+		return s.Replyf(ctx, "A command or list with name '%s' already exists.", alias)
+	}
+
+	info, _, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, true)
+	if err != nil {
+		return fmt.Errorf("getting command info: %w", err)
+	}
+	if !found {
+		return s.Replyf(ctx, "Command '%s' does not exist.", name)
+	}
+
+	if !s.UserLevel.CanAccessPG(info.AccessLevel) {
+		return s.Replyf(ctx, "Your level is %s; you cannot alias a command with level %s.", s.UserLevel.PGEnum(), info.AccessLevel)
+	}
+
+	if _, err := s.Queries.InsertCommandAlias(ctx, dbsql.InsertCommandAliasParams{
+		ChannelID:     s.Channel.ID,
+		CommandInfoID: info.ID,
+		Name:          alias,
+		Creator:       s.User,
+	}); err != nil {
+		return fmt.Errorf("inserting command alias: %w", err)
+	}
+
+	var warning string
+	if isBuiltinName(alias) {
+		warning = " Warning: '" + alias + "' is a builtin command and will now only be accessible via " + s.Channel.Prefix + "builtin " + alias + "."
+	}
+
+	return s.Replyf(ctx, "'%s' is now an alias of '%s'.%s", alias, info.Name, warning)
+}
+
+func cmdCommandAliasRemove(ctx context.Context, s *session, cmd string, args string) error {
+	alias, _ := splitSpace(args)
+	alias = cleanCommandName(alias)
+
+	if alias == "" {
+		return s.ReplyUsage(ctx, "<alias>")
+	}
+
+	deleted, err := s.Queries.DeleteCommandAlias(ctx, dbsql.DeleteCommandAliasParams{
+		ChannelID: s.Channel.ID,
+		Name:      alias,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting command alias: %w", err)
+	}
+
+	if deleted == 0 {
+		return s.Replyf(ctx, "Alias '%s' does not exist.", alias)
+	}
+
+	return s.Replyf(ctx, "Alias '%s' removed.", alias)
+}
+
+func cmdCommandAliasList(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name>")
+	}
+
+	info, _, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, false)
+	if err != nil {
+		return fmt.Errorf("getting command info: %w", err)
+	}
+	if !found {
+		return s.Replyf(ctx, "Command '%s' does not exist.", name)
+	}
+
+	aliases, err := s.Queries.ListCommandAliasNames(ctx, info.ID)
+	if err != nil {
+		return fmt.Errorf("listing command aliases: %w", err)
+	}
+
+	if len(aliases) == 0 {
+		return s.Replyf(ctx, "Command '%s' has no aliases.", info.Name)
+	}
+
+	return s.Replyf(ctx, "Aliases of '%s': %s", info.Name, strings.Join(aliases, ", "))
+}
+
+func (s *session) replyIsAlias(ctx context.Context, alias, name string) error {
+	return s.Replyf(ctx, "'%s' is an alias of '%s'; use %scommand alias remove %s to remove it.", alias, name, s.Channel.Prefix, alias)
+}
+
 func init() {
 	flect.AddPlural("everyone", "everyone")
 }
 
+func cmdCommandTest(ctx context.Context, s *session, _ string, args string) error {
+	result, err := dryRunCommand(ctx, s, args)
+	if errors.Is(err, errDryRunUsage) {
+		return s.ReplyUsage(ctx, "<name> [args]")
+	}
+	if err != nil {
+		return err
+	}
+	return s.Reply(ctx, result)
+}
+
 func cmdCommandExec(ctx context.Context, s *session, _ string, args string) error {
 	if args == "" {
 		return s.ReplyUsage(ctx, "<command string>")
@@ -0,0 +1,158 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/emotes"
+)
+
+const (
+	emoteTopDefault = 5
+	emoteTopMax     = 10
+)
+
+var emoteCommands = newHandlerMap(map[string]handlerFunc{
+	"top":     {fn: cmdEmotesTop, minLevel: AccessLevelEveryone},
+	"track":   {fn: cmdEmotesTrack, minLevel: AccessLevelModerator},
+	"reset":   {fn: cmdEmotesReset, minLevel: AccessLevelModerator},
+	"refresh": {fn: cmdEmotesRefresh, minLevel: AccessLevelModerator},
+})
+
+func cmdEmotes(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	if subcommand == "" {
+		subcommand = "top"
+	}
+
+	ok, err := emoteCommands.RunWithCooldown(ctx, s, subcommand, args)
+	if ok || err != nil {
+		return err
+	}
+
+	return s.ReplyUsage(ctx, "top|track|reset|refresh")
+}
+
+func cmdEmotesTop(ctx context.Context, s *session, cmd string, args string) error {
+	count := int64(emoteTopDefault)
+
+	if args != "" {
+		n, err := strconv.ParseInt(args, 10, 64)
+		if err != nil || n <= 0 {
+			return s.ReplyUsage(ctx, "top [count]")
+		}
+		count = min(n, emoteTopMax)
+	}
+
+	top, err := s.Queries.ListTopChannelEmotes(ctx, dbsql.ListTopChannelEmotesParams{
+		ChannelID: s.Channel.ID,
+		MaxEmotes: count,
+	})
+	if err != nil {
+		return fmt.Errorf("listing top emotes: %w", err)
+	}
+
+	if len(top) == 0 {
+		if !s.Channel.TrackEmotes {
+			return s.Reply(ctx, "Emote usage is not being tracked.")
+		}
+		return s.Reply(ctx, "No emotes have been used yet.")
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Top emotes: ")
+
+	for i, row := range top {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+		fmt.Fprintf(&builder, "%s (%d)", row.Emote, row.Uses)
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+func cmdEmotesTrack(ctx context.Context, s *session, cmd string, args string) error {
+	enable := false
+
+	switch strings.ToLower(args) {
+	case "":
+		if s.Channel.TrackEmotes {
+			return s.Reply(ctx, "Emote usage is being tracked.")
+		}
+		return s.Reply(ctx, "Emote usage is not being tracked.")
+	case "on":
+		enable = true
+	case "off":
+		// Do nothing.
+	default:
+		return s.ReplyUsage(ctx, "track on|off")
+	}
+
+	if s.Channel.TrackEmotes == enable {
+		if enable {
+			return s.Reply(ctx, "Emote usage is already being tracked.")
+		}
+		return s.Reply(ctx, "Emote usage is already not being tracked.")
+	}
+
+	s.Channel.TrackEmotes = enable
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return err
+	}
+
+	if enable {
+		return s.Reply(ctx, "Emote usage is now being tracked.")
+	}
+	return s.Reply(ctx, "Emote usage is no longer being tracked.")
+}
+
+func cmdEmotesReset(ctx context.Context, s *session, cmd string, args string) error {
+	if err := s.Queries.DeleteChannelEmoteUsageByChannel(ctx, s.Channel.ID); err != nil {
+		return fmt.Errorf("resetting emote usage: %w", err)
+	}
+	return s.Reply(ctx, "Emote usage has been reset.")
+}
+
+func cmdEmotesRefresh(ctx context.Context, s *session, cmd string, args string) error {
+	if s.Deps.Emotes == nil {
+		return errBuiltinDisabled
+	}
+
+	globals := fetchGlobalEmotes(ctx, s.Deps.Emotes)
+	sets := fetchEmoteSets(ctx, s.Deps.Emotes, s.Channel.TwitchID, globals)
+
+	if len(sets) == 0 {
+		return s.Reply(ctx, "Third-party emotes could not be fetched; try again later.")
+	}
+
+	if err := saveEmoteSets(ctx, s.Queries, s.Channel.ID, sets); err != nil {
+		return err
+	}
+
+	s.invalidateMatchers()
+
+	var counts []string
+	var failed []string
+
+	for _, provider := range emotes.Providers {
+		if names, ok := sets[provider]; ok {
+			counts = append(counts, fmt.Sprintf("%s %d", provider, len(names)))
+		} else {
+			failed = append(failed, provider.String())
+		}
+	}
+
+	message := "Refreshed third-party emotes: " + strings.Join(counts, ", ") + "."
+	if len(failed) != 0 {
+		message += " Could not fetch: " + strings.Join(failed, ", ") + "."
+	}
+
+	return s.Reply(ctx, message)
+}
@@ -2,11 +2,14 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/pkg/linkmatch"
 )
 
@@ -14,16 +17,20 @@ var filterCommands = newHandlerMap(map[string]handlerFunc{
 	"status":        {fn: cmdFilterStatus, minLevel: AccessLevelModerator},
 	"on":            {fn: cmdFilterOnOff(true), minLevel: AccessLevelModerator},
 	"off":           {fn: cmdFilterOnOff(false), minLevel: AccessLevelModerator},
-	"links":         {fn: cmdFilterLinks, minLevel: AccessLevelModerator},
+	"links":         {fn: withFilterSettings("links", cmdFilterLinks), minLevel: AccessLevelModerator},
 	"pd":            {fn: cmdFilterPermittedLinks, minLevel: AccessLevelModerator},
 	"pl":            {fn: cmdFilterPermittedLinks, minLevel: AccessLevelModerator},
-	"caps":          {fn: cmdFilterCaps, minLevel: AccessLevelModerator},
-	"symbols":       {fn: cmdFilterSymbols, minLevel: AccessLevelModerator},
-	"me":            {fn: cmdFilterMe, minLevel: AccessLevelModerator},
-	"messagelength": {fn: cmdFilterMessageLength, minLevel: AccessLevelModerator},
-	"emotes":        {fn: cmdFilterEmotes, minLevel: AccessLevelModerator},
-	"banphrase":     {fn: cmdFilterBanPhrase, minLevel: AccessLevelModerator},
+	"bl":            {fn: cmdFilterBlockedLinks, minLevel: AccessLevelModerator},
+	"caps":          {fn: withFilterSettings("caps", cmdFilterCaps), minLevel: AccessLevelModerator},
+	"symbols":       {fn: withFilterSettings("symbols", cmdFilterSymbols), minLevel: AccessLevelModerator},
+	"me":            {fn: withFilterSettings("me", cmdFilterMe), minLevel: AccessLevelModerator},
+	"messagelength": {fn: withFilterSettings("messagelength", cmdFilterMessageLength), minLevel: AccessLevelModerator},
+	"emotes":        {fn: withFilterSettings("emotes", cmdFilterEmotes), minLevel: AccessLevelModerator},
+	"banphrase":     {fn: withFilterSettings("banphrase", cmdFilterBanPhrase), minLevel: AccessLevelModerator},
 	"exempt":        {fn: cmdFilterExemptLevel, minLevel: AccessLevelModerator},
+	"test":          {fn: cmdFilterTest, minLevel: AccessLevelModerator},
+	"shadow":        {fn: cmdFilterShadow, minLevel: AccessLevelModerator},
+	"report":        {fn: cmdFilterReport, minLevel: AccessLevelModerator},
 })
 
 func cmdFilter(ctx context.Context, s *session, cmd string, args string) error {
@@ -80,6 +87,11 @@ func cmdFilterStatus(ctx context.Context, s *session, cmd string, args string) e
 	writeBool(builder, s.Channel.FilterSymbols)
 	fmt.Fprintf(builder, " {%d%%, %d}", s.Channel.FilterSymbolsPercentage, s.Channel.FilterSymbolsMinSymbols)
 
+	if len(s.Channel.FilterShadow) != 0 {
+		builder.WriteString(", shadow: ")
+		builder.WriteString(filterShadowList(s.Channel.FilterShadow))
+	}
+
 	return s.Reply(ctx, builder.String())
 }
 
@@ -106,6 +118,26 @@ func cmdFilterOnOff(enable bool) func(ctx context.Context, s *session, cmd strin
 }
 
 func cmdFilterLinks(ctx context.Context, s *session, cmd string, args string) error {
+	option, rest := splitSpace(args)
+
+	switch option {
+	case "on", "off":
+		if rest != "" {
+			break
+		}
+		return cmdFilterLinksToggle(ctx, s, option, "Link filter", &s.Channel.FilterLinks)
+	case "resolve":
+		defer s.UsageContext(option)()
+		return cmdFilterLinksToggle(ctx, s, rest, "Link resolution", &s.Channel.FilterLinksResolve)
+	case "firstmessage":
+		defer s.UsageContext(option)()
+		return cmdFilterLinksToggle(ctx, s, rest, "First message link filter", &s.Channel.FilterLinksFirstMessage)
+	}
+
+	return s.ReplyUsage(ctx, "on|off|resolve|firstmessage ...")
+}
+
+func cmdFilterLinksToggle(ctx context.Context, s *session, args string, name string, setting *bool) error {
 	enable := false
 
 	switch args {
@@ -117,26 +149,36 @@ func cmdFilterLinks(ctx context.Context, s *session, cmd string, args string) er
 		return s.ReplyUsage(ctx, "on|off")
 	}
 
-	if s.Channel.FilterLinks == enable {
+	if *setting == enable {
 		if enable {
-			return s.Reply(ctx, "Link filter is already enabled.")
+			return s.Replyf(ctx, "%s is already enabled.", name)
 		}
-		return s.Reply(ctx, "Link filter is already disabled.")
+		return s.Replyf(ctx, "%s is already disabled.", name)
 	}
 
-	s.Channel.FilterLinks = enable
+	*setting = enable
 
 	if err := s.updateChannelSettings(ctx); err != nil {
 		return fmt.Errorf("updating channel: %w", err)
 	}
 
 	if enable {
-		return s.Reply(ctx, "Link filter is now enabled.")
+		return s.Replyf(ctx, "%s is now enabled.", name)
 	}
-	return s.Reply(ctx, "Link filter is now disabled.")
+	return s.Replyf(ctx, "%s is now disabled.", name)
 }
 
 func cmdFilterPermittedLinks(ctx context.Context, s *session, cmd string, args string) error {
+	return cmdFilterLinkPatterns(ctx, s, args, "permitted", &s.Channel.PermittedLinks)
+}
+
+func cmdFilterBlockedLinks(ctx context.Context, s *session, cmd string, args string) error {
+	return cmdFilterLinkPatterns(ctx, s, args, "blocked", &s.Channel.BlockedLinks)
+}
+
+// cmdFilterLinkPatterns manages one of the channel's lists of link patterns,
+// described to the user by kind.
+func cmdFilterLinkPatterns(ctx context.Context, s *session, args string, kind string, patterns *[]string) error {
 	usage := func() error {
 		return s.ReplyUsage(ctx, "add|delete|list ...")
 	}
@@ -149,18 +191,19 @@ func cmdFilterPermittedLinks(ctx context.Context, s *session, cmd string, args s
 	subcommand = strings.ToLower(subcommand)
 	args = strings.ToLower(args)
 
+	title := strings.ToUpper(kind[:1]) + kind[1:]
+
 	switch subcommand {
 	case "list":
-		permitted := s.Channel.PermittedLinks
-
-		if len(permitted) == 0 {
-			return s.Reply(ctx, "There are no permitted link patterns.")
+		if len(*patterns) == 0 {
+			return s.Replyf(ctx, "There are no %s link patterns.", kind)
 		}
 
 		var builder strings.Builder
-		builder.WriteString("Permitted link patterns: ")
+		builder.WriteString(title)
+		builder.WriteString(" link patterns: ")
 
-		for i, p := range permitted {
+		for i, p := range *patterns {
 			if i != 0 {
 				builder.WriteString(", ")
 			}
@@ -173,23 +216,22 @@ func cmdFilterPermittedLinks(ctx context.Context, s *session, cmd string, args s
 		return s.Reply(ctx, builder.String())
 
 	case "add":
-		pd, args := splitSpace(args)
+		pattern, args := splitSpace(args)
 		if args != "" {
 			return s.ReplyUsage(ctx, subcommand+" <link pattern>")
 		}
 
-		if linkmatch.IsBadPattern(pd) {
-			return s.Replyf(ctx, "Pattern '%s' is too permissive.", pd)
+		if linkmatch.IsBadPattern(pattern) {
+			return s.Replyf(ctx, "Pattern '%s' is too permissive.", pattern)
 		}
 
-		s.Channel.PermittedLinks = append(s.Channel.PermittedLinks, pd)
+		*patterns = append(*patterns, pattern)
 
 		if err := s.updateChannelSettings(ctx); err != nil {
 			return fmt.Errorf("updating channel: %w", err)
 		}
 
-		n := len(s.Channel.PermittedLinks)
-		return s.Replyf(ctx, "Permitted link pattern #%d added.", n)
+		return s.Replyf(ctx, "%s link pattern #%d added.", title, len(*patterns))
 
 	case "delete", "remove":
 		n, err := strconv.Atoi(args)
@@ -199,20 +241,20 @@ func cmdFilterPermittedLinks(ctx context.Context, s *session, cmd string, args s
 
 		i := n - 1
 
-		old := s.Channel.PermittedLinks
+		old := *patterns
 
 		if i >= len(old) {
-			return s.Replyf(ctx, "Permitted link pattern #%d does not exist.", n)
+			return s.Replyf(ctx, "%s link pattern #%d does not exist.", title, n)
 		}
 
 		oldP := old[i]
-		s.Channel.PermittedLinks = append(old[:i], old[i+1:]...) //nolint:gocritic
+		*patterns = append(old[:i], old[i+1:]...) //nolint:gocritic
 
 		if err := s.updateChannelSettings(ctx); err != nil {
 			return fmt.Errorf("updating channel: %w", err)
 		}
 
-		return s.Replyf(ctx, "Permitted link pattern #%d deleted; was '%s'.", n, oldP)
+		return s.Replyf(ctx, "%s link pattern #%d deleted; was '%s'.", title, n, oldP)
 
 	default:
 		return usage()
@@ -563,6 +605,8 @@ func cmdFilterBanPhrase(ctx context.Context, s *session, cmd string, args string
 		return s.ReplyUsage(ctx, "on|off|add|delete|clear|list")
 	}
 
+	s.invalidateMatchers()
+
 	if err := s.updateChannelSettings(ctx); err != nil {
 		return fmt.Errorf("updating channel: %w", err)
 	}
@@ -592,5 +636,294 @@ func cmdFilterExemptLevel(ctx context.Context, s *session, cmd string, args stri
 		return fmt.Errorf("updating channel: %w", err)
 	}
 
+	// For historical reasons, link filtering is controlled by subsMayLink.
+	others := make([]string, 0, len(filterKeys))
+	for _, filter := range filterKeys {
+		if filter != "links" {
+			others = append(others, filter)
+		}
+	}
+
+	if err := updateFiltersExemptLevel(ctx, s, newLevelPG, others...); err != nil {
+		return err
+	}
+
 	return s.Replyf(ctx, "Filter exempt level set to %s.", pluralAccessLevel(newLevelPG))
 }
+
+func cmdFilterTest(ctx context.Context, s *session, cmd string, args string) error {
+	result, err := dryRunFilters(ctx, s, args)
+	if errors.Is(err, errDryRunUsage) {
+		return s.ReplyUsage(ctx, "<message>")
+	}
+	if err != nil {
+		return err
+	}
+	return s.Reply(ctx, result)
+}
+
+func cmdFilterShadow(ctx context.Context, s *session, cmd string, args string) error {
+	name, args := splitSpace(args)
+	name = strings.ToLower(name)
+
+	if name == "" || name == "list" {
+		if len(s.Channel.FilterShadow) == 0 {
+			return s.Reply(ctx, "No filters are in shadow mode.")
+		}
+		return s.Replyf(ctx, "Filters in shadow mode: %s", filterShadowList(s.Channel.FilterShadow))
+	}
+
+	filter, ok := filterKeys[name]
+	if !ok {
+		return s.ReplyUsage(ctx, "<me|messagelength|links|emotes|caps|symbols|banphrase> on|off")
+	}
+
+	enable := false
+
+	switch strings.ToLower(args) {
+	case "on":
+		enable = true
+	case "off":
+		// Do nothing.
+	default:
+		return s.ReplyUsage(ctx, name+" on|off")
+	}
+
+	display := filterDisplayName(filter)
+	shadowed := slices.Contains(s.Channel.FilterShadow, filter)
+
+	if shadowed == enable {
+		if enable {
+			return s.Replyf(ctx, "The %s filter is already in shadow mode.", display)
+		}
+		return s.Replyf(ctx, "The %s filter is not in shadow mode.", display)
+	}
+
+	if enable {
+		s.Channel.FilterShadow = append(s.Channel.FilterShadow, filter)
+	} else {
+		s.Channel.FilterShadow = slices.DeleteFunc(s.Channel.FilterShadow, func(f string) bool { return f == filter })
+	}
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	if enable {
+		return s.Replyf(ctx, "The %s filter is now in shadow mode; matches will be logged without any action taken.", display)
+	}
+	return s.Replyf(ctx, "The %s filter is no longer in shadow mode.", display)
+}
+
+const (
+	filterReportDefaultHours = 24
+	filterReportMaxHours     = 7 * 24
+)
+
+func cmdFilterReport(ctx context.Context, s *session, cmd string, args string) error {
+	hours := int32(filterReportDefaultHours)
+
+	if args != "" {
+		var err error
+		hours, err = parseInt32(args)
+		if err != nil || hours <= 0 || hours > filterReportMaxHours {
+			return s.ReplyUsage(ctx, "[hours]")
+		}
+	}
+
+	counts, err := s.Queries.CountFilterShadowHits(ctx, dbsql.CountFilterShadowHitsParams{
+		ChannelID: s.Channel.ID,
+		Hours:     hours,
+	})
+	if err != nil {
+		return fmt.Errorf("counting shadow filter hits: %w", err)
+	}
+
+	period := "hour"
+	if hours != 1 {
+		period = strconv.Itoa(int(hours)) + " hours"
+	}
+
+	if len(counts) == 0 {
+		return s.Replyf(ctx, "No shadow filter hits in the last %s.", period)
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Shadow filter hits in the last %s: ", period)
+
+	for i, count := range counts {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+
+		users := "users"
+		if count.Users == 1 {
+			users = "user"
+		}
+
+		fmt.Fprintf(&builder, "%s %d (%d %s)", filterDisplayName(count.Filter), count.Hits, count.Users, users)
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+func filterShadowList(filters []string) string {
+	names := make([]string, len(filters))
+	for i, filter := range filters {
+		names[i] = filterDisplayName(filter)
+	}
+	return strings.Join(names, ", ")
+}
+
+// withFilterSettings handles the exempt, action, and message options which
+// every filter has, passing anything else to fn.
+func withFilterSettings(name string, fn func(ctx context.Context, s *session, cmd string, args string) error) func(ctx context.Context, s *session, cmd string, args string) error {
+	filter := filterKeys[name]
+
+	return func(ctx context.Context, s *session, cmd string, args string) error {
+		option, rest := splitSpace(args)
+		option = strings.ToLower(option)
+
+		var setting func(ctx context.Context, s *session, settings dbsql.ChannelFilter, args string) error
+
+		switch option {
+		case "exempt":
+			setting = cmdFilterSettingExempt
+		case "action":
+			setting = cmdFilterSettingAction
+		case "message":
+			setting = cmdFilterSettingMessage
+		default:
+			return fn(ctx, s, cmd, args)
+		}
+
+		defer s.UsageContext(option)()
+
+		settings, err := filterSettings(ctx, s, filter)
+		if err != nil {
+			return err
+		}
+
+		return setting(ctx, s, settings, rest)
+	}
+}
+
+func cmdFilterSettingExempt(ctx context.Context, s *session, settings dbsql.ChannelFilter, args string) error {
+	display := filterDisplayName(settings.Filter)
+
+	if args == "" {
+		return s.Replyf(ctx, "The %s filter's exempt level is set to %s.", display, pluralAccessLevel(settings.ExemptLevel))
+	}
+
+	newLevel := parseLevel(args)
+	if newLevel == AccessLevelUnknown || !AccessLevelModerator.CanAccess(newLevel) {
+		return s.Reply(ctx, "Invalid level.")
+	}
+
+	settings.ExemptLevel = newLevel.PGEnum()
+
+	if err := saveFilterSettings(ctx, s, settings); err != nil {
+		return err
+	}
+
+	return s.Replyf(ctx, "The %s filter's exempt level set to %s.", display, pluralAccessLevel(settings.ExemptLevel))
+}
+
+func cmdFilterSettingAction(ctx context.Context, s *session, settings dbsql.ChannelFilter, args string) error {
+	display := filterDisplayName(settings.Filter)
+
+	if args == "" {
+		return s.Replyf(ctx, "The %s filter's action is %s.", display, describeFilterAction(settings))
+	}
+
+	action, args := splitSpace(args)
+	action = strings.ToLower(action)
+
+	switch action {
+	case filterActionDelete, filterActionBan:
+		if args != "" {
+			return s.ReplyUsage(ctx, "delete|timeout [seconds]|ban")
+		}
+
+	case filterActionTimeout:
+		if args != "" {
+			duration, err := parseInt32(args)
+			if err != nil || duration < 0 {
+				return s.ReplyUsage(ctx, "timeout [seconds]")
+			}
+			settings.Duration = duration
+		}
+
+	default:
+		return s.ReplyUsage(ctx, "delete|timeout [seconds]|ban")
+	}
+
+	settings.Action = action
+
+	if err := saveFilterSettings(ctx, s, settings); err != nil {
+		return err
+	}
+
+	return s.Replyf(ctx, "The %s filter's action set to %s.", display, describeFilterAction(settings))
+}
+
+func cmdFilterSettingMessage(ctx context.Context, s *session, settings dbsql.ChannelFilter, args string) error {
+	display := filterDisplayName(settings.Filter)
+
+	switch args {
+	case "":
+		if settings.Message == "" {
+			return s.Replyf(ctx, "The %s filter uses its default message.", display)
+		}
+		return s.Replyf(ctx, "The %s filter's message is: %s", display, settings.Message)
+
+	case "reset":
+		settings.Message = ""
+
+	default:
+		settings.Message = args
+	}
+
+	if err := saveFilterSettings(ctx, s, settings); err != nil {
+		return err
+	}
+
+	if settings.Message == "" {
+		return s.Replyf(ctx, "The %s filter's message reset to the default.", display)
+	}
+	return s.Replyf(ctx, "The %s filter's message set to: %s", display, settings.Message)
+}
+
+func saveFilterSettings(ctx context.Context, s *session, settings dbsql.ChannelFilter) error {
+	err := s.Queries.UpsertChannelFilter(ctx, dbsql.UpsertChannelFilterParams{
+		ChannelID:   s.Channel.ID,
+		Filter:      settings.Filter,
+		ExemptLevel: settings.ExemptLevel,
+		Action:      settings.Action,
+		Duration:    settings.Duration,
+		Message:     settings.Message,
+	})
+	if err != nil {
+		return fmt.Errorf("saving filter settings: %w", err)
+	}
+
+	s.invalidateMatchers()
+	return nil
+}
+
+// updateFiltersExemptLevel sets the exempt level of the channel's filters
+// which have been configured separately; the rest follow the channel-wide
+// settings.
+func updateFiltersExemptLevel(ctx context.Context, s *session, level dbsql.AccessLevel, filters ...string) error {
+	err := s.Queries.UpdateChannelFiltersExemptLevel(ctx, dbsql.UpdateChannelFiltersExemptLevelParams{
+		ExemptLevel: level,
+		ChannelID:   s.Channel.ID,
+		Filters:     filters,
+	})
+	if err != nil {
+		return fmt.Errorf("updating filter exempt levels: %w", err)
+	}
+
+	s.invalidateMatchers()
+	return nil
+}
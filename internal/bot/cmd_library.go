@@ -0,0 +1,604 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/confimport"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/repeat"
+	"github.com/jackc/pgx/v5"
+)
+
+const libraryMaxItems = 50
+
+var libraryCommands = newHandlerMap(map[string]handlerFunc{
+	"publish":   {fn: cmdLibraryPublish, minLevel: AccessLevelBroadcaster},
+	"describe":  {fn: cmdLibraryDescribe, minLevel: AccessLevelBroadcaster},
+	"unpublish": {fn: cmdLibraryUnpublish, minLevel: AccessLevelBroadcaster},
+	"install":   {fn: cmdLibraryInstall, minLevel: AccessLevelModerator},
+	"update":    {fn: cmdLibraryUpdate, minLevel: AccessLevelModerator},
+	"uninstall": {fn: cmdLibraryUninstall, minLevel: AccessLevelModerator},
+	"list":      {fn: cmdLibraryList, minLevel: AccessLevelModerator},
+	"info":      {fn: cmdLibraryInfo, minLevel: AccessLevelModerator},
+})
+
+func cmdLibrary(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	ok, err := libraryCommands.Run(ctx, s, subcommand, args)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.ReplyUsage(ctx, "install|update|uninstall|list|info|publish|describe|unpublish ...")
+	}
+
+	return nil
+}
+
+func cmdLibraryPublish(ctx context.Context, s *session, cmd string, args string) error {
+	usage := func() error {
+		return s.ReplyUsage(ctx, "<library> <command> [<command>|#<autoreply> ...]")
+	}
+
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+
+	items := strings.Fields(args)
+	if name == "" || len(items) == 0 {
+		return usage()
+	}
+
+	if len(items) > libraryMaxItems {
+		return s.Replyf(ctx, "A library may contain at most %d commands and autoreplies.", libraryMaxItems)
+	}
+
+	var names []string
+	var nums []int32
+
+	for _, item := range items {
+		if numStr, ok := strings.CutPrefix(item, "#"); ok {
+			num, err := parseInt32(numStr)
+			if err != nil || num < 1 {
+				return usage()
+			}
+			nums = append(nums, num)
+			continue
+		}
+
+		commandName := cleanCommandName(item)
+		if commandName == "" {
+			return usage()
+		}
+		names = append(names, commandName)
+	}
+
+	existing, found, err := getLibrary(ctx, s, name)
+	if err != nil {
+		return err
+	}
+
+	if found && existing.ChannelID != s.Channel.ID {
+		return s.Replyf(ctx, "Library '%s' is published by another channel.", name)
+	}
+
+	library, missing, err := confimport.NewLibrary(ctx, s.Queries, *s.Channel, names, nums)
+	if err != nil {
+		return fmt.Errorf("building library: %w", err)
+	}
+
+	if len(missing) != 0 {
+		return s.Replyf(ctx, "Cannot publish library '%s'; these do not exist: %s.", name, strings.Join(missing, ", "))
+	}
+
+	data, err := json.Marshal(library)
+	if err != nil {
+		return fmt.Errorf("marshaling library: %w", err)
+	}
+
+	params := library.Parameters()
+
+	var version int32 = 1
+
+	if found {
+		version, err = s.Queries.UpdateCommandLibraryData(ctx, dbsql.UpdateCommandLibraryDataParams{
+			Parameters: params,
+			Data:       data,
+			Editor:     s.User,
+			ID:         existing.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("updating library: %w", err)
+		}
+	} else {
+		_, err = s.Queries.InsertCommandLibrary(ctx, dbsql.InsertCommandLibraryParams{
+			ChannelID:  s.Channel.ID,
+			Name:       name,
+			Parameters: params,
+			Creator:    s.User,
+			Data:       data,
+		})
+		if err != nil {
+			return fmt.Errorf("inserting library: %w", err)
+		}
+	}
+
+	var paramStr string
+	if len(params) != 0 {
+		paramStr = " Parameters: " + strings.Join(params, ", ") + "."
+	}
+
+	return s.Replyf(ctx, "Library '%s' version %d published with %s.%s", name, version, libraryContents(len(library.Commands), len(library.Autoreplies)), paramStr)
+}
+
+func cmdLibraryDescribe(ctx context.Context, s *session, cmd string, args string) error {
+	name, description := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" || description == "" {
+		return s.ReplyUsage(ctx, "<library> <description>")
+	}
+
+	library, ok, err := getOwnLibrary(ctx, s, name)
+	if !ok || err != nil {
+		return err
+	}
+
+	err = s.Queries.UpdateCommandLibraryDescription(ctx, dbsql.UpdateCommandLibraryDescriptionParams{
+		Description: description,
+		Editor:      s.User,
+		ID:          library.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("updating library description: %w", err)
+	}
+
+	return s.Replyf(ctx, "Library '%s' description updated.", name)
+}
+
+func cmdLibraryUnpublish(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<library>")
+	}
+
+	library, ok, err := getOwnLibrary(ctx, s, name)
+	if !ok || err != nil {
+		return err
+	}
+
+	if err := s.Queries.DeleteCommandLibrary(ctx, library.ID); err != nil {
+		return fmt.Errorf("deleting library: %w", err)
+	}
+
+	return s.Replyf(ctx, "Library '%s' unpublished. Channels which installed it keep its commands.", name)
+}
+
+func cmdLibraryInstall(ctx context.Context, s *session, cmd string, args string) error {
+	usage := func() error {
+		return s.ReplyUsage(ctx, "<library> [<parameter>=<value> ...]")
+	}
+
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return usage()
+	}
+
+	libArgs, ok := parseLibraryArguments(args)
+	if !ok {
+		return usage()
+	}
+
+	lib, found, err := getLibrary(ctx, s, name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return s.Replyf(ctx, "Library '%s' does not exist.", name)
+	}
+
+	_, installed, err := getLibraryInstall(ctx, s, lib.ID)
+	if err != nil {
+		return err
+	}
+
+	if installed {
+		return s.Replyf(ctx, "Library '%s' is already installed; use update to pull a new version.", name)
+	}
+
+	library, err := confimport.LoadLibrary(lib.Data)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if missing := library.MissingArguments(libArgs); len(missing) != 0 {
+		return s.Replyf(ctx, "Library '%s' needs values for: %s (given as parameter=value).", name, strings.Join(missing, ", "))
+	}
+
+	result, err := installLibrary(ctx, s, library, libArgs)
+	if err != nil {
+		return err
+	}
+
+	arguments, err := confimport.MarshalArguments(libArgs)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	err = s.Queries.InsertCommandLibraryInstall(ctx, dbsql.InsertCommandLibraryInstallParams{
+		ChannelID:      s.Channel.ID,
+		LibraryID:      lib.ID,
+		Version:        lib.Version,
+		Arguments:      arguments,
+		CommandInfoIds: result.CommandInfoIDs(),
+		AutoreplyIds:   result.AutoreplyIDs,
+		Creator:        s.User,
+	})
+	if err != nil {
+		return fmt.Errorf("inserting library install: %w", err)
+	}
+
+	return s.Replyf(ctx, "Library '%s' version %d installed with %s.%s", name, lib.Version, libraryContents(len(result.Commands), len(result.AutoreplyIDs)), libraryWarnings(result.Warnings))
+}
+
+func cmdLibraryUpdate(ctx context.Context, s *session, cmd string, args string) error {
+	usage := func() error {
+		return s.ReplyUsage(ctx, "<library> [<parameter>=<value> ...]")
+	}
+
+	name, args := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return usage()
+	}
+
+	newArgs, ok := parseLibraryArguments(args)
+	if !ok {
+		return usage()
+	}
+
+	lib, found, err := getLibrary(ctx, s, name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return s.Replyf(ctx, "Library '%s' does not exist.", name)
+	}
+
+	install, installed, err := getLibraryInstall(ctx, s, lib.ID)
+	if err != nil {
+		return err
+	}
+
+	if !installed {
+		return s.Replyf(ctx, "Library '%s' is not installed.", name)
+	}
+
+	if install.Version == lib.Version && len(newArgs) == 0 {
+		return s.Replyf(ctx, "Library '%s' is already up to date (version %d).", name, lib.Version)
+	}
+
+	libArgs, err := confimport.UnmarshalArguments(install.Arguments)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	for k, v := range newArgs {
+		libArgs[k] = v
+	}
+
+	library, err := confimport.LoadLibrary(lib.Data)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if missing := library.MissingArguments(libArgs); len(missing) != 0 {
+		return s.Replyf(ctx, "Version %d of library '%s' needs values for: %s (given as parameter=value).", lib.Version, name, strings.Join(missing, ", "))
+	}
+
+	if err := uninstallLibrary(ctx, s, &install); err != nil {
+		return err
+	}
+
+	result, err := installLibrary(ctx, s, library, libArgs)
+	if err != nil {
+		return err
+	}
+
+	arguments, err := confimport.MarshalArguments(libArgs)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	err = s.Queries.UpdateCommandLibraryInstall(ctx, dbsql.UpdateCommandLibraryInstallParams{
+		Version:        lib.Version,
+		Arguments:      arguments,
+		CommandInfoIds: result.CommandInfoIDs(),
+		AutoreplyIds:   result.AutoreplyIDs,
+		Editor:         s.User,
+		ID:             install.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("updating library install: %w", err)
+	}
+
+	contents := libraryContents(len(result.Commands), len(result.AutoreplyIDs))
+	warnings := libraryWarnings(result.Warnings)
+
+	if install.Version == lib.Version {
+		return s.Replyf(ctx, "Library '%s' version %d reinstalled with %s.%s", name, lib.Version, contents, warnings)
+	}
+
+	return s.Replyf(ctx, "Library '%s' updated from version %d to %d, with %s.%s", name, install.Version, lib.Version, contents, warnings)
+}
+
+func cmdLibraryUninstall(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<library>")
+	}
+
+	lib, found, err := getLibrary(ctx, s, name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return s.Replyf(ctx, "Library '%s' does not exist.", name)
+	}
+
+	install, installed, err := getLibraryInstall(ctx, s, lib.ID)
+	if err != nil {
+		return err
+	}
+
+	if !installed {
+		return s.Replyf(ctx, "Library '%s' is not installed.", name)
+	}
+
+	if err := uninstallLibrary(ctx, s, &install); err != nil {
+		return err
+	}
+
+	if err := s.Queries.DeleteCommandLibraryInstall(ctx, install.ID); err != nil {
+		return fmt.Errorf("deleting library install: %w", err)
+	}
+
+	return s.Replyf(ctx, "Library '%s' uninstalled.", name)
+}
+
+func cmdLibraryList(ctx context.Context, s *session, cmd string, args string) error {
+	installs, err := s.Queries.ListCommandLibraryInstalls(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("listing library installs: %w", err)
+	}
+
+	if len(installs) == 0 {
+		return s.Reply(ctx, "No libraries are installed.")
+	}
+
+	var builder strings.Builder
+	builder.WriteString("Installed libraries: ")
+
+	for i, install := range installs {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(install.Name)
+		builder.WriteString(" (v")
+		builder.WriteString(strconv.Itoa(int(install.Version)))
+		if install.LatestVersion != install.Version {
+			builder.WriteString(", v")
+			builder.WriteString(strconv.Itoa(int(install.LatestVersion)))
+			builder.WriteString(" available")
+		}
+		builder.WriteByte(')')
+	}
+
+	builder.WriteByte('.')
+
+	return s.Reply(ctx, builder.String())
+}
+
+func cmdLibraryInfo(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<library>")
+	}
+
+	lib, found, err := getLibrary(ctx, s, name)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return s.Replyf(ctx, "Library '%s' does not exist.", name)
+	}
+
+	publisher, err := s.Queries.GetChannelByID(ctx, lib.ChannelID)
+	if err != nil {
+		return fmt.Errorf("getting library publisher: %w", err)
+	}
+
+	library, err := confimport.LoadLibrary(lib.Data)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Library '%s' version %d by %s", name, lib.Version, publisher.Name)
+
+	if description := strings.TrimRight(lib.Description, "."); description != "" {
+		builder.WriteString(": ")
+		builder.WriteString(description)
+	}
+
+	builder.WriteString(". Contains ")
+
+	for i, command := range library.Commands {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(s.Channel.Prefix)
+		builder.WriteString(command.Info.Name)
+	}
+
+	if n := len(library.Autoreplies); n != 0 {
+		if len(library.Commands) != 0 {
+			builder.WriteString(" and ")
+		}
+		fmt.Fprintf(&builder, "%d %s", n, pluralInt(n, "autoreply", "autoreplies"))
+	}
+
+	builder.WriteByte('.')
+
+	if len(lib.Parameters) != 0 {
+		builder.WriteString(" Parameters: ")
+		builder.WriteString(strings.Join(lib.Parameters, ", "))
+		builder.WriteByte('.')
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+func getLibrary(ctx context.Context, s *session, name string) (dbsql.CommandLibrary, bool, error) {
+	library, err := s.Queries.GetCommandLibraryByName(ctx, name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return library, false, nil
+	}
+	if err != nil {
+		return library, false, fmt.Errorf("getting library: %w", err)
+	}
+	return library, true, nil
+}
+
+// getOwnLibrary gets a library published by the current channel, replying
+// if there is none.
+func getOwnLibrary(ctx context.Context, s *session, name string) (dbsql.CommandLibrary, bool, error) {
+	library, found, err := getLibrary(ctx, s, name)
+	if err != nil {
+		return library, false, err
+	}
+
+	if !found {
+		return library, false, s.Replyf(ctx, "Library '%s' does not exist.", name)
+	}
+
+	if library.ChannelID != s.Channel.ID {
+		return library, false, s.Replyf(ctx, "Library '%s' is published by another channel.", name)
+	}
+
+	return library, true, nil
+}
+
+func getLibraryInstall(ctx context.Context, s *session, libraryID int64) (dbsql.CommandLibraryInstall, bool, error) {
+	install, err := s.Queries.GetCommandLibraryInstall(ctx, dbsql.GetCommandLibraryInstallParams{
+		ChannelID: s.Channel.ID,
+		LibraryID: libraryID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return install, false, nil
+	}
+	if err != nil {
+		return install, false, fmt.Errorf("getting library install: %w", err)
+	}
+	return install, true, nil
+}
+
+func installLibrary(ctx context.Context, s *session, library *confimport.Library, args map[string]string) (*confimport.Installed, error) {
+	installed, err := library.Install(ctx, s.Queries, s.Channel, args, s.User)
+	if err != nil {
+		return nil, fmt.Errorf("installing library: %w", err)
+	}
+
+	s.invalidateMatchers()
+
+	for _, command := range installed.Commands {
+		if value := command.Repeat; value != nil && value.Enabled {
+			if err := s.Deps.AddRepeat(ctx, value.ID, value.UpdatedAt.Time, time.Duration(value.Delay)*time.Second); err != nil {
+				return nil, err
+			}
+		}
+
+		if value := command.Schedule; value != nil && value.Enabled {
+			expr, err := repeat.ParseCronIn(value.CronExpression, s.Channel.Location())
+			if err != nil {
+				return nil, fmt.Errorf("parsing schedule: %w", err)
+			}
+			if err := s.Deps.AddScheduled(ctx, value.ID, expr); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return installed, nil
+}
+
+func uninstallLibrary(ctx context.Context, s *session, install *dbsql.CommandLibraryInstall) error {
+	repeats, schedules, err := confimport.Uninstall(ctx, s.Queries, install)
+	if err != nil {
+		return fmt.Errorf("uninstalling library: %w", err)
+	}
+
+	s.invalidateMatchers()
+
+	for _, id := range repeats {
+		if err := s.Deps.RemoveRepeat(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range schedules {
+		if err := s.Deps.RemoveScheduled(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseLibraryArguments parses space separated parameter=value pairs.
+func parseLibraryArguments(args string) (map[string]string, bool) {
+	libArgs := make(map[string]string)
+
+	for _, field := range strings.Fields(args) {
+		key, value, ok := strings.Cut(field, "=")
+		key = strings.ToLower(key)
+		if !ok || key == "" {
+			return nil, false
+		}
+		libArgs[key] = value
+	}
+
+	return libArgs, true
+}
+
+func libraryContents(commands, autoreplies int) string {
+	return fmt.Sprintf("%d %s and %d %s", commands, pluralInt(commands, "command", "commands"), autoreplies, pluralInt(autoreplies, "autoreply", "autoreplies"))
+}
+
+func libraryWarnings(warnings []string) string {
+	if len(warnings) == 0 {
+		return ""
+	}
+	return " " + strings.Join(warnings, " ")
+}
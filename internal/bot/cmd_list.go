@@ -154,6 +154,10 @@ func cmdListDelete(ctx context.Context, s *session, cmd string, args string) err
 		return s.Replyf(ctx, "List '%s' does not exist.", name)
 	}
 
+	if info.Name != name {
+		return s.replyIsAlias(ctx, name, info.Name)
+	}
+
 	if list == nil {
 		return s.Replyf(ctx, "'%s' is not a list.", name)
 	}
@@ -245,6 +249,10 @@ func cmdListRename(ctx context.Context, s *session, cmd string, args string) err
 		return s.Replyf(ctx, "List '%s' does not exist.", oldName)
 	}
 
+	if info.Name != oldName {
+		return s.replyIsAlias(ctx, oldName, info.Name)
+	}
+
 	if !info.CommandListID.Valid {
 		return s.Replyf(ctx, "'%s' is not a list.", oldName)
 	}
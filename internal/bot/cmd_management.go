@@ -192,7 +192,18 @@ func handleJoin(ctx context.Context, s *session, name string) error { //nolint:g
 	if err != nil {
 		return fmt.Errorf("getting scheduled commands: %w", err)
 	}
-	if err := updateScheduleds(ctx, s.Deps, scheduled, true); err != nil {
+	if err := updateScheduleds(ctx, s.Deps, scheduled, channel.Location(), true); err != nil {
+		return err
+	}
+
+	chatModeRules, err := q.ListChatModeRulesByTrigger(ctx, dbsql.ListChatModeRulesByTriggerParams{
+		ChannelID: channel.ID,
+		Trigger:   chatModeTriggerSchedule,
+	})
+	if err != nil {
+		return fmt.Errorf("getting scheduled chat mode rules: %w", err)
+	}
+	if err := updateChatModeSchedules(ctx, s.Deps, chatModeRules, channel.Location(), true); err != nil {
 		return err
 	}
 
@@ -259,7 +270,18 @@ func handleLeave(ctx context.Context, s *session, name string) error {
 	if err != nil {
 		return fmt.Errorf("getting scheduled commands: %w", err)
 	}
-	if err := updateScheduleds(ctx, s.Deps, scheduled, false); err != nil {
+	if err := updateScheduleds(ctx, s.Deps, scheduled, channel.Location(), false); err != nil {
+		return err
+	}
+
+	chatModeRules, err := q.ListChatModeRulesByTrigger(ctx, dbsql.ListChatModeRulesByTriggerParams{
+		ChannelID: channel.ID,
+		Trigger:   chatModeTriggerSchedule,
+	})
+	if err != nil {
+		return fmt.Errorf("getting scheduled chat mode rules: %w", err)
+	}
+	if err := updateChatModeSchedules(ctx, s.Deps, chatModeRules, channel.Location(), false); err != nil {
 		return err
 	}
 
@@ -303,7 +325,19 @@ func cmdLeave(ctx context.Context, s *session, cmd string, args string) error {
 		return fmt.Errorf("getting scheduled commands: %w", err)
 	}
 
-	if err := updateScheduleds(ctx, s.Deps, scheduleds, false); err != nil {
+	if err := updateScheduleds(ctx, s.Deps, scheduleds, s.Channel.Location(), false); err != nil {
+		return err
+	}
+
+	chatModeRules, err := q.ListChatModeRulesByTrigger(ctx, dbsql.ListChatModeRulesByTriggerParams{
+		ChannelID: s.Channel.ID,
+		Trigger:   chatModeTriggerSchedule,
+	})
+	if err != nil {
+		return fmt.Errorf("getting scheduled chat mode rules: %w", err)
+	}
+
+	if err := updateChatModeSchedules(ctx, s.Deps, chatModeRules, s.Channel.Location(), false); err != nil {
 		return err
 	}
 
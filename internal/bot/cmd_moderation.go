@@ -196,7 +196,7 @@ const permitDur = 5 * time.Minute
 var permitDurReadable = durafmt.Parse(permitDur).String()
 
 func cmdPermit(ctx context.Context, s *session, cmd string, args string) error {
-	if !s.Channel.EnableFilters || !s.Channel.FilterLinks {
+	if !s.Channel.EnableFilters || (!s.Channel.FilterLinks && !s.Channel.FilterLinksFirstMessage) {
 		return nil
 	}
 
@@ -0,0 +1,262 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hako/durafmt"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+const (
+	nukeLookbackDefault = time.Minute
+	nukeTimeoutMax      = 14 * 24 * time.Hour
+	nukeConfirmDur      = 30 * time.Second
+
+	// nukeMaxActions limits how many punishments and message deletions a
+	// single nuke performs, as each is a separate API call.
+	nukeMaxActions = 50
+)
+
+var nukeConfirmDurReadable = durafmt.Parse(nukeConfirmDur).String()
+
+func cmdNuke(ctx context.Context, s *session, cmd string, args string) error {
+	const usage = "<phrase|REGEX:pattern> [lookback [timeout|ban]]"
+
+	phrase, lookbackArg, timeoutArg := parseNukeArgs(args)
+	if phrase == "" {
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	lookback := nukeLookbackDefault
+	if lookbackArg != "" {
+		lookback, _ = time.ParseDuration(lookbackArg)
+		if lookback < time.Second || lookback > recentMessageAge {
+			return s.Replyf(ctx, "Lookback must be between 1 second and %s.", durafmt.Parse(recentMessageAge))
+		}
+	}
+
+	var re *regexp.Regexp
+	var err error
+
+	if pattern, ok := strings.CutPrefix(phrase, regexPrefix); ok {
+		if pattern == "" {
+			return s.replyBadPattern(ctx, errEmptyPattern)
+		}
+		re, err = s.Deps.ReCache.Compile(pattern)
+	} else {
+		re, err = s.Deps.ReCache.Compile("(?i)" + regexp.QuoteMeta(phrase))
+	}
+	if err != nil {
+		return s.replyBadPattern(ctx, err)
+	}
+
+	// A nuke is a retroactive banned phrase, so it uses that filter's settings.
+	settings, err := filterSettings(ctx, s, "banned_phrase")
+	if err != nil {
+		return err
+	}
+
+	punish := true
+	var duration int64 // Zero bans.
+
+	switch timeoutArg {
+	case "":
+		switch settings.Action {
+		case filterActionDelete:
+			punish = false
+		case filterActionBan:
+		default:
+			duration = filterTimeoutDuration(settings)
+		}
+	case "ban":
+	default:
+		d, _ := time.ParseDuration(timeoutArg)
+		if d < time.Second || d > nukeTimeoutMax {
+			return s.Replyf(ctx, "Timeout must be between 1 second and %s.", durafmt.Parse(nukeTimeoutMax))
+		}
+		duration = int64(d / time.Second)
+	}
+
+	exempt := newAccessLevel(settings.ExemptLevel)
+
+	var matched []recentMessage
+	var userIDs []int64
+	users := make(map[int64][]recentMessage)
+
+	for _, m := range s.Deps.RecentMessages.since(s.Channel.ID, time.Now().Add(-lookback)) {
+		if m.Level.CanAccess(AccessLevelModerator) || m.Level.CanAccess(exempt) {
+			continue
+		}
+
+		if !re.MatchString(m.Text) {
+			continue
+		}
+
+		matched = append(matched, m)
+
+		if _, ok := users[m.UserID]; !ok {
+			userIDs = append(userIDs, m.UserID)
+		}
+		users[m.UserID] = append(users[m.UserID], m)
+	}
+
+	if len(matched) == 0 {
+		return s.Replyf(ctx, "No messages from the last %s match.", durafmt.Parse(lookback))
+	}
+
+	var punishment string
+	switch {
+	case !punish:
+		punishment = "delete them"
+	case duration == 0:
+		punishment = "ban them"
+	default:
+		punishment = "time them out for " + durafmt.Parse(time.Duration(duration)*time.Second).String()
+	}
+
+	confirmed, err := s.Confirm(ctx, s.User, "nuke "+strings.TrimSpace(args), nukeConfirmDur)
+	if err != nil {
+		return err
+	}
+
+	if !confirmed {
+		return s.Replyf(ctx, "%d %s from %d %s match. Run the same command again in the next %s to %s.",
+			len(matched), pluralInt(len(matched), "message", "messages"),
+			len(users), pluralInt(len(users), "user", "users"),
+			nukeConfirmDurReadable, punishment,
+		)
+	}
+
+	var nuked []recentMessage
+	var failed, remaining int
+
+	if punish {
+		// Each user's matched messages are deleted once they have been
+		// punished; all of it counts towards the limit. A failed deletion is
+		// only logged, as the user has still been dealt with.
+		actions := 0
+
+		for i, userID := range userIDs {
+			if actions >= nukeMaxActions {
+				remaining = len(userIDs) - i
+				break
+			}
+
+			actions++
+			if err := s.BanByID(ctx, userID, duration, "nuked"); err != nil {
+				if ae, ok := apiclient.AsError(err); ok && ae.IsNotPermitted() {
+					return s.Reply(ctx, "Unable to punish users; is the bot modded?")
+				}
+				ctxlog.Warn(ctx, "error punishing nuked user", zap.Error(err), zap.Int64("user_id", userID))
+				failed++
+				continue
+			}
+
+			for _, m := range users[userID] {
+				actions++
+				if err := s.DeleteMessageByID(ctx, m.ID); err != nil {
+					ctxlog.Warn(ctx, "error deleting nuked message", zap.Error(err), zap.String("message_id", m.ID))
+				}
+				nuked = append(nuked, m)
+			}
+		}
+	} else {
+		if len(matched) > nukeMaxActions {
+			remaining = len(matched) - nukeMaxActions
+			matched = matched[:nukeMaxActions]
+		}
+
+		for _, m := range matched {
+			if err := s.DeleteMessageByID(ctx, m.ID); err != nil {
+				if ae, ok := apiclient.AsError(err); ok && ae.IsNotPermitted() {
+					return s.Reply(ctx, "Unable to delete messages; is the bot modded?")
+				}
+				ctxlog.Warn(ctx, "error deleting nuked message", zap.Error(err), zap.String("message_id", m.ID))
+				failed++
+				continue
+			}
+			nuked = append(nuked, m)
+		}
+	}
+
+	nukedIDs := make(map[string]bool, len(nuked))
+	nukedUsers := make(map[int64]bool)
+	for _, m := range nuked {
+		nukedIDs[m.ID] = true
+		nukedUsers[m.UserID] = true
+	}
+
+	// Don't nuke the same messages again if the nuke is repeated. Punished
+	// users are forgotten entirely.
+	s.Deps.RecentMessages.forget(s.Channel.ID, func(m recentMessage) bool {
+		if punish {
+			return nukedUsers[m.UserID]
+		}
+		return nukedIDs[m.ID]
+	})
+
+	singular, plural := "user", "users"
+	if !punish {
+		singular, plural = "message", "messages"
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Nuked %d %s from %d %s.",
+		len(nuked), pluralInt(len(nuked), "message", "messages"),
+		len(nukedUsers), pluralInt(len(nukedUsers), "user", "users"),
+	)
+
+	if failed != 0 {
+		fmt.Fprintf(&builder, " Failed to nuke %d %s.", failed, pluralInt(failed, singular, plural))
+	}
+
+	if remaining != 0 {
+		fmt.Fprintf(&builder, " Run the command again to nuke the remaining %d %s.", remaining, pluralInt(remaining, singular, plural))
+	}
+
+	return s.Reply(ctx, builder.String())
+}
+
+// parseNukeArgs splits the nuke command's arguments into the phrase and the
+// optional trailing lookback and timeout, which are only taken from the end
+// of the arguments if they are valid.
+func parseNukeArgs(args string) (phrase, lookback, timeout string) {
+	phrase = strings.TrimSpace(args)
+
+	rest, last := cutLastField(phrase)
+	if rest == "" || !isDuration(last) && last != "ban" {
+		return phrase, "", ""
+	}
+
+	if before, secondLast := cutLastField(rest); before != "" && isDuration(secondLast) {
+		return before, secondLast, last
+	}
+
+	if isDuration(last) {
+		return rest, last, ""
+	}
+
+	return phrase, "", ""
+}
+
+func cutLastField(s string) (rest, last string) {
+	i := strings.LastIndexFunc(s, unicode.IsSpace)
+	if i < 0 {
+		return "", s
+	}
+	_, size := utf8.DecodeRuneInString(s[i:])
+	return strings.TrimRightFunc(s[:i], unicode.IsSpace), s[i+size:]
+}
+
+func isDuration(s string) bool {
+	_, err := time.ParseDuration(s)
+	return err == nil
+}
@@ -0,0 +1,367 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
+	"golang.org/x/oauth2"
+)
+
+const (
+	pollMinChoices      = 2
+	pollMaxChoices      = 5
+	pollMinDuration     = 15 * time.Second
+	pollMaxDuration     = 30 * time.Minute
+	pollDefaultDuration = time.Minute
+
+	predictionMinOutcomes   = 2
+	predictionMaxOutcomes   = 10
+	predictionMinWindow     = 30 * time.Second
+	predictionMaxWindow     = 30 * time.Minute
+	predictionDefaultWindow = 2 * time.Minute
+)
+
+// Statuses returned by the Helix poll and prediction endpoints.
+const (
+	pollStatusActive       = "ACTIVE"
+	predictionStatusActive = "ACTIVE"
+	predictionStatusLocked = "LOCKED"
+)
+
+const pollStartUsage = `"<question>" <choice> | <choice> [| ...] [seconds]`
+
+func cmdPoll(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, _ := splitSpace(args)
+
+	switch strings.ToLower(subcommand) {
+	case "":
+		return cmdPollReport(ctx, s)
+	case "end":
+		return cmdPollEnd(ctx, s)
+	}
+
+	title, choices, duration, ok := parsePollArgs(args)
+	if !ok {
+		return s.ReplyUsage(ctx, pollStartUsage+"|end")
+	}
+
+	if len(choices) < pollMinChoices || len(choices) > pollMaxChoices {
+		return s.Replyf(ctx, "A poll must have between %d and %d choices.", pollMinChoices, pollMaxChoices)
+	}
+
+	if duration == 0 {
+		duration = pollDefaultDuration
+	}
+
+	if duration < pollMinDuration || duration > pollMaxDuration {
+		return s.Replyf(ctx, "A poll must run for between %d and %d seconds.", int(pollMinDuration.Seconds()), int(pollMaxDuration.Seconds()))
+	}
+
+	tok, err := s.ChannelTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, newToken, err := s.Deps.Twitch.CreatePoll(ctx, s.Channel.TwitchID, tok, title, choices, duration)
+	if err := saveChannelTwitchToken(ctx, s, newToken); err != nil {
+		return err
+	}
+
+	if err != nil {
+		return replyChannelTwitchError(ctx, s, err, "Could not start the poll.")
+	}
+
+	return s.Replyf(ctx, `Poll "%s" started for %s!`, title, durafmt.Parse(duration).String())
+}
+
+func cmdPollReport(ctx context.Context, s *session) error {
+	tok, err := s.ChannelTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	poll, newToken, err := s.Deps.Twitch.GetLatestPoll(ctx, s.Channel.TwitchID, tok)
+	if err := saveChannelTwitchToken(ctx, s, newToken); err != nil {
+		return err
+	}
+
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok && ae.IsNotFound() {
+			return s.Reply(ctx, "There have been no polls.")
+		}
+		return replyChannelTwitchError(ctx, s, err, "Could not get the latest poll.")
+	}
+
+	var builder strings.Builder
+	for i, c := range poll.Choices {
+		if i != 0 {
+			builder.WriteString(", ")
+		}
+		fmt.Fprintf(&builder, "%s: %d", c.Title, c.Votes)
+	}
+
+	return s.Replyf(ctx, `Poll "%s" (%s): %s.`, poll.Title, strings.ToLower(poll.Status), builder.String())
+}
+
+func cmdPollEnd(ctx context.Context, s *session) error {
+	tok, err := s.ChannelTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	poll, newToken, err := s.Deps.Twitch.GetLatestPoll(ctx, s.Channel.TwitchID, tok)
+	if err := saveChannelTwitchToken(ctx, s, newToken); err != nil {
+		return err
+	}
+
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok && ae.IsNotFound() {
+			return s.Reply(ctx, "There is no active poll.")
+		}
+		return replyChannelTwitchError(ctx, s, err, "Could not end the poll.")
+	}
+
+	if poll.Status != pollStatusActive {
+		return s.Reply(ctx, "There is no active poll.")
+	}
+
+	_, newToken, err = s.Deps.Twitch.EndPoll(ctx, s.Channel.TwitchID, tok, poll.ID)
+	if err := saveChannelTwitchToken(ctx, s, newToken); err != nil {
+		return err
+	}
+
+	if err != nil {
+		return replyChannelTwitchError(ctx, s, err, "Could not end the poll.")
+	}
+
+	return s.Replyf(ctx, `Poll "%s" ended.`, poll.Title)
+}
+
+func cmdPredict(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, rest := splitSpace(args)
+
+	switch strings.ToLower(subcommand) {
+	case "":
+		return cmdPredictReport(ctx, s)
+	case "lock":
+		return cmdPredictEnd(ctx, s, twitch.PredictionLocked, "")
+	case "resolve":
+		if rest == "" {
+			return s.ReplyUsage(ctx, "resolve <outcome>")
+		}
+		return cmdPredictEnd(ctx, s, twitch.PredictionResolved, rest)
+	case "cancel":
+		return cmdPredictEnd(ctx, s, twitch.PredictionCanceled, "")
+	}
+
+	title, outcomes, window, ok := parsePollArgs(args)
+	if !ok {
+		return s.ReplyUsage(ctx, pollStartUsage+"|lock|resolve <outcome>|cancel")
+	}
+
+	if len(outcomes) < predictionMinOutcomes || len(outcomes) > predictionMaxOutcomes {
+		return s.Replyf(ctx, "A prediction must have between %d and %d outcomes.", predictionMinOutcomes, predictionMaxOutcomes)
+	}
+
+	if window == 0 {
+		window = predictionDefaultWindow
+	}
+
+	if window < predictionMinWindow || window > predictionMaxWindow {
+		return s.Replyf(ctx, "A prediction must be open for between %d and %d seconds.", int(predictionMinWindow.Seconds()), int(predictionMaxWindow.Seconds()))
+	}
+
+	tok, err := s.ChannelTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, newToken, err := s.Deps.Twitch.CreatePrediction(ctx, s.Channel.TwitchID, tok, title, outcomes, window)
+	if err := saveChannelTwitchToken(ctx, s, newToken); err != nil {
+		return err
+	}
+
+	if err != nil {
+		return replyChannelTwitchError(ctx, s, err, "Could not start the prediction.")
+	}
+
+	return s.Replyf(ctx, `Prediction "%s" started! Predictions close in %s.`, title, durafmt.Parse(window).String())
+}
+
+func cmdPredictReport(ctx context.Context, s *session) error {
+	tok, err := s.ChannelTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	prediction, newToken, err := s.Deps.Twitch.GetLatestPrediction(ctx, s.Channel.TwitchID, tok)
+	if err := saveChannelTwitchToken(ctx, s, newToken); err != nil {
+		return err
+	}
+
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok && ae.IsNotFound() {
+			return s.Reply(ctx, "There have been no predictions.")
+		}
+		return replyChannelTwitchError(ctx, s, err, "Could not get the latest prediction.")
+	}
+
+	var builder strings.Builder
+	for i, o := range prediction.Outcomes {
+		if i != 0 {
+			builder.WriteString("; ")
+		}
+		fmt.Fprintf(&builder, "%s: %d %s, %d %s", o.Title, o.Users, pluralInt(o.Users, "user", "users"), o.ChannelPoints, pluralInt(o.ChannelPoints, "point", "points"))
+	}
+
+	return s.Replyf(ctx, `Prediction "%s" (%s): %s.`, prediction.Title, strings.ToLower(prediction.Status), builder.String())
+}
+
+func cmdPredictEnd(ctx context.Context, s *session, status string, outcome string) error {
+	tok, err := s.ChannelTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	prediction, newToken, err := s.Deps.Twitch.GetLatestPrediction(ctx, s.Channel.TwitchID, tok)
+	if err := saveChannelTwitchToken(ctx, s, newToken); err != nil {
+		return err
+	}
+
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok && ae.IsNotFound() {
+			return s.Reply(ctx, "There is no active prediction.")
+		}
+		return replyChannelTwitchError(ctx, s, err, "Could not update the prediction.")
+	}
+
+	switch prediction.Status {
+	case predictionStatusActive:
+	case predictionStatusLocked:
+		if status == twitch.PredictionLocked {
+			return s.Reply(ctx, "The prediction is already locked.")
+		}
+	default:
+		return s.Reply(ctx, "There is no active prediction.")
+	}
+
+	var winningOutcomeID string
+	if status == twitch.PredictionResolved {
+		winner := findPredictionOutcome(prediction.Outcomes, outcome)
+		if winner == nil {
+			return s.Replyf(ctx, `Unknown outcome "%s".`, outcome)
+		}
+		winningOutcomeID = winner.ID
+	}
+
+	_, newToken, err = s.Deps.Twitch.EndPrediction(ctx, s.Channel.TwitchID, tok, prediction.ID, status, winningOutcomeID)
+	if err := saveChannelTwitchToken(ctx, s, newToken); err != nil {
+		return err
+	}
+
+	if err != nil {
+		return replyChannelTwitchError(ctx, s, err, "Could not update the prediction.")
+	}
+
+	switch status {
+	case twitch.PredictionLocked:
+		return s.Replyf(ctx, `Prediction "%s" is now locked.`, prediction.Title)
+	case twitch.PredictionResolved:
+		return s.Replyf(ctx, `Prediction "%s" resolved.`, prediction.Title)
+	default:
+		return s.Replyf(ctx, `Prediction "%s" canceled.`, prediction.Title)
+	}
+}
+
+// findPredictionOutcome finds an outcome by its one-based position or title.
+func findPredictionOutcome(outcomes []*twitch.PredictionOutcome, v string) *twitch.PredictionOutcome {
+	if n, err := strconv.Atoi(v); err == nil {
+		if n < 1 || n > len(outcomes) {
+			return nil
+		}
+		return outcomes[n-1]
+	}
+
+	for _, o := range outcomes {
+		if strings.EqualFold(o.Title, v) {
+			return o
+		}
+	}
+
+	return nil
+}
+
+// parsePollArgs parses arguments of the form:
+//
+//	"Question" choice one | choice two | choice three 120
+//
+// A trailing number on the last choice is taken as a duration in seconds,
+// unless it is the entire choice.
+func parsePollArgs(args string) (title string, choices []string, duration time.Duration, ok bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(args), `"`)
+	if !ok {
+		return "", nil, 0, false
+	}
+
+	title, rest, ok = strings.Cut(rest, `"`)
+	title = strings.TrimSpace(title)
+	if !ok || title == "" {
+		return "", nil, 0, false
+	}
+
+	choices = strings.Split(rest, "|")
+	last := strings.TrimSpace(choices[len(choices)-1])
+
+	if i := strings.LastIndexByte(last, ' '); i != -1 {
+		if seconds, err := strconv.Atoi(last[i+1:]); err == nil {
+			if seconds <= 0 {
+				return "", nil, 0, false
+			}
+			duration = time.Duration(seconds) * time.Second
+			choices[len(choices)-1] = last[:i]
+		}
+	}
+
+	for i, c := range choices {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			return "", nil, 0, false
+		}
+		choices[i] = c
+	}
+
+	return title, choices, duration, true
+}
+
+func saveChannelTwitchToken(ctx context.Context, s *session, newToken *oauth2.Token) error {
+	if newToken == nil {
+		return nil
+	}
+	return s.SetChannelTwitchToken(ctx, newToken)
+}
+
+// replyChannelTwitchError replies to an error from a Twitch API call made with
+// the channel's token. Errors which did not come from the API are returned.
+func replyChannelTwitchError(ctx context.Context, s *session, err error, failure string) error {
+	ae, ok := apiclient.AsError(err)
+	if !ok {
+		return err
+	}
+
+	if ae.IsNotPermitted() || errors.Is(err, twitch.ErrDeadToken) {
+		return s.Reply(ctx, s.TwitchNotAuthMessage())
+	}
+
+	if ae.IsServerError() {
+		return s.Reply(ctx, twitchServerErrorReply)
+	}
+
+	return s.Reply(ctx, failure)
+}
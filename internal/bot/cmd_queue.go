@@ -0,0 +1,328 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	queueMaxNext     = 10
+	queueMaxGameName = 64
+)
+
+var queueCommands = newHandlerMap(map[string]handlerFunc{
+	"open":        {fn: cmdQueueOpenClose, minLevel: AccessLevelModerator},
+	"close":       {fn: cmdQueueOpenClose, minLevel: AccessLevelModerator},
+	"join":        {fn: cmdQueueJoin, minLevel: AccessLevelEveryone, skipCooldown: true},
+	"leave":       {fn: cmdQueueLeave, minLevel: AccessLevelEveryone, skipCooldown: true},
+	"position":    {fn: cmdQueuePosition, minLevel: AccessLevelEveryone, skipCooldown: true},
+	"pos":         {fn: cmdQueuePosition, minLevel: AccessLevelEveryone, skipCooldown: true},
+	"next":        {fn: cmdQueueNext, minLevel: AccessLevelModerator},
+	"remove":      {fn: cmdQueueRemove, minLevel: AccessLevelModerator},
+	"clear":       {fn: cmdQueueClear, minLevel: AccessLevelModerator},
+	"list":        {fn: cmdQueueList, minLevel: AccessLevelEveryone},
+	"subpriority": {fn: cmdQueueSubPriority, minLevel: AccessLevelModerator},
+	"vippriority": {fn: cmdQueueVIPPriority, minLevel: AccessLevelModerator},
+})
+
+func cmdQueue(ctx context.Context, s *session, cmd string, args string) error {
+	subcommand, args := splitSpace(args)
+	subcommand = strings.ToLower(subcommand)
+
+	if subcommand == "" {
+		return cmdQueueStatus(ctx, s)
+	}
+
+	ok, err := queueCommands.RunWithCooldown(ctx, s, subcommand, args)
+	if ok || err != nil {
+		return err
+	}
+
+	return s.ReplyUsage(ctx, "open|close|join|leave|position|next|remove|clear|list|subpriority|vippriority")
+}
+
+func cmdQueueStatus(ctx context.Context, s *session) error {
+	if !s.Channel.QueueOpen {
+		return s.Reply(ctx, "Queue is closed.")
+	}
+
+	count, err := s.Queries.CountQueueEntries(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("counting queue entries: %w", err)
+	}
+
+	return s.Replyf(ctx, "Queue is open with %d %s. Use %squeue join to join!", count, pluralInt(count, "entry", "entries"), s.Channel.Prefix)
+}
+
+func cmdQueueOpenClose(ctx context.Context, s *session, cmd string, args string) error {
+	open := false
+
+	switch cmd {
+	case "open":
+		open = true
+	case "close":
+	default:
+		panic("unreachable")
+	}
+
+	if s.Channel.QueueOpen == open {
+		if open {
+			return s.Replyf(ctx, "Queue is already open. Use %squeue join to join!", s.Channel.Prefix)
+		}
+		return s.Reply(ctx, "Queue is already closed.")
+	}
+
+	s.Channel.QueueOpen = open
+
+	if err := s.Queries.UpdateChannelQueueOpen(ctx, dbsql.UpdateChannelQueueOpenParams{
+		QueueOpen: s.Channel.QueueOpen,
+		ID:        s.Channel.ID,
+	}); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	if open {
+		return s.Replyf(ctx, "Queue opened. Use %squeue join to join!", s.Channel.Prefix)
+	}
+
+	return s.Reply(ctx, "Queue closed.")
+}
+
+func cmdQueueJoin(ctx context.Context, s *session, cmd string, args string) error {
+	if !s.Channel.QueueOpen {
+		return s.Reply(ctx, "Queue is closed.")
+	}
+
+	gameName := args
+	if utf8.RuneCountInString(gameName) > queueMaxGameName {
+		return s.Replyf(ctx, "In-game name must be at most %d characters.", queueMaxGameName)
+	}
+
+	inserted, err := s.Queries.InsertQueueEntry(ctx, dbsql.InsertQueueEntryParams{
+		ChannelID:   s.Channel.ID,
+		UserID:      s.UserID,
+		UserName:    s.User,
+		UserDisplay: s.UserDisplay,
+		GameName:    gameName,
+		Priority:    s.queuePriority(),
+	})
+	if err != nil {
+		return fmt.Errorf("inserting queue entry: %w", err)
+	}
+
+	if inserted == 0 && gameName != "" {
+		if err := s.Queries.UpdateQueueEntryGameName(ctx, dbsql.UpdateQueueEntryGameNameParams{
+			GameName:  gameName,
+			ChannelID: s.Channel.ID,
+			UserID:    s.UserID,
+		}); err != nil {
+			return fmt.Errorf("updating queue entry: %w", err)
+		}
+	}
+
+	position, _, err := s.queuePosition(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case inserted != 0:
+		return s.Replyf(ctx, "%s joined the queue at position %d.", s.UserDisplay, position)
+	case gameName != "":
+		return s.Replyf(ctx, "%s, your in-game name has been updated. You are at position %d.", s.UserDisplay, position)
+	default:
+		return s.Replyf(ctx, "%s, you are already in the queue at position %d.", s.UserDisplay, position)
+	}
+}
+
+func cmdQueueLeave(ctx context.Context, s *session, cmd string, args string) error {
+	deleted, err := s.Queries.DeleteQueueEntryByUserID(ctx, dbsql.DeleteQueueEntryByUserIDParams{
+		ChannelID: s.Channel.ID,
+		UserID:    s.UserID,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting queue entry: %w", err)
+	}
+
+	if deleted == 0 {
+		return s.Replyf(ctx, "%s, you are not in the queue.", s.UserDisplay)
+	}
+
+	return s.Replyf(ctx, "%s left the queue.", s.UserDisplay)
+}
+
+func cmdQueuePosition(ctx context.Context, s *session, cmd string, args string) error {
+	position, ok, err := s.queuePosition(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.Replyf(ctx, "%s, you are not in the queue.", s.UserDisplay)
+	}
+
+	count, err := s.Queries.CountQueueEntries(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("counting queue entries: %w", err)
+	}
+
+	return s.Replyf(ctx, "%s, you are at position %d of %d.", s.UserDisplay, position, count)
+}
+
+func cmdQueueNext(ctx context.Context, s *session, cmd string, args string) error {
+	n := int64(1)
+
+	if count, _ := splitSpace(args); count != "" {
+		var err error
+		n, err = strconv.ParseInt(count, 10, 64)
+		if err != nil || n < 1 || n > queueMaxNext {
+			return s.ReplyUsage(ctx, "<count>")
+		}
+	}
+
+	entries, err := s.QueueNext(ctx, n)
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return s.Reply(ctx, "Queue is empty.")
+	}
+
+	return s.Replyf(ctx, "Next up: %s", formatQueueEntries(entries))
+}
+
+func cmdQueueRemove(ctx context.Context, s *session, cmd string, args string) error {
+	user, _ := splitSpace(args)
+	user = cleanUsername(user)
+
+	if user == "" {
+		return s.ReplyUsage(ctx, "<user>")
+	}
+
+	deleted, err := s.Queries.DeleteQueueEntryByUserName(ctx, dbsql.DeleteQueueEntryByUserNameParams{
+		ChannelID: s.Channel.ID,
+		UserName:  user,
+	})
+	if err != nil {
+		return fmt.Errorf("deleting queue entry: %w", err)
+	}
+
+	if deleted == 0 {
+		return s.Replyf(ctx, "%s is not in the queue.", user)
+	}
+
+	return s.Replyf(ctx, "%s has been removed from the queue.", user)
+}
+
+func cmdQueueClear(ctx context.Context, s *session, cmd string, args string) error {
+	if _, err := s.Queries.ClearQueue(ctx, s.Channel.ID); err != nil {
+		return fmt.Errorf("clearing queue: %w", err)
+	}
+
+	return s.Reply(ctx, "Queue cleared.")
+}
+
+func cmdQueueList(ctx context.Context, s *session, cmd string, args string) error {
+	return s.Replyf(ctx, "You can find the queue at: %s/c/%s/queue", s.WebAddr(), s.ChannelName)
+}
+
+func cmdQueueSubPriority(ctx context.Context, s *session, _ string, args string) error {
+	return updateBoolean(
+		ctx, s, args, &s.Channel.QueueSubPriority,
+		"subPriority",
+		"Subs already have priority in the queue.",
+		"Subs already do not have priority in the queue.",
+		"Subs now have priority in the queue.",
+		"Subs no longer have priority in the queue.",
+	)
+}
+
+func cmdQueueVIPPriority(ctx context.Context, s *session, _ string, args string) error {
+	return updateBoolean(
+		ctx, s, args, &s.Channel.QueueVIPPriority,
+		"vipPriority",
+		"VIPs already have priority in the queue.",
+		"VIPs already do not have priority in the queue.",
+		"VIPs now have priority in the queue.",
+		"VIPs no longer have priority in the queue.",
+	)
+}
+
+// queuePriority returns the priority the current user's queue entry should
+// be given. Higher priorities are placed earlier in the queue.
+func (s *session) queuePriority() int32 {
+	switch {
+	case s.Channel.QueueVIPPriority && s.UserLevel.CanAccess(AccessLevelVIP):
+		return 2
+	case s.Channel.QueueSubPriority && s.UserLevel.CanAccess(AccessLevelSubscriber):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (s *session) queuePosition(ctx context.Context) (int64, bool, error) {
+	position, err := s.Queries.GetQueuePosition(ctx, dbsql.GetQueuePositionParams{
+		ChannelID: s.Channel.ID,
+		UserID:    s.UserID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("getting queue position: %w", err)
+	}
+	return position, true, nil
+}
+
+// QueueNext removes and returns up to n entries from the front of the queue.
+func (s *session) QueueNext(ctx context.Context, n int64) ([]dbsql.QueueEntry, error) {
+	entries, err := s.Queries.ListNextQueueEntriesForUpdate(ctx, dbsql.ListNextQueueEntriesForUpdateParams{
+		ChannelID: s.Channel.ID,
+		Count:     int32(n),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing queue entries: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return entries, nil
+	}
+
+	ids := make([]int64, len(entries))
+	for i, e := range entries {
+		ids[i] = e.ID
+	}
+
+	if err := s.Queries.DeleteQueueEntries(ctx, ids); err != nil {
+		return nil, fmt.Errorf("deleting queue entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+func formatQueueEntry(e dbsql.QueueEntry) string {
+	if e.GameName == "" {
+		return e.UserDisplay
+	}
+	return e.UserDisplay + " (" + e.GameName + ")"
+}
+
+func formatQueueEntries(entries []dbsql.QueueEntry) string {
+	var sb strings.Builder
+	for i, e := range entries {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(formatQueueEntry(e))
+	}
+	return sb.String()
+}
@@ -2,20 +2,55 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/hako/durafmt"
 	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	raffleMaxName     = 32
+	raffleMaxKeyword  = 64
+	raffleMaxWeight   = 10
+	raffleMaxWinners  = 20
+	raffleMinDuration = 10 * time.Second
+	raffleMaxDuration = 24 * time.Hour
+	raffleMaxClaim    = 600
+)
+
+const (
+	raffleWinnerWon       = "won"
+	raffleWinnerPending   = "pending"
+	raffleWinnerClaimed   = "claimed"
+	raffleWinnerForfeited = "forfeited"
 )
 
 var raffleCommands = newHandlerMap(map[string]handlerFunc{
-	"enable":  {fn: cmdRaffleEnableDisable, minLevel: AccessLevelModerator},
-	"disable": {fn: cmdRaffleEnableDisable, minLevel: AccessLevelModerator},
-	"count":   {fn: cmdRaffleCount, minLevel: AccessLevelModerator},
-	"winner":  {fn: cmdRaffleWinner, minLevel: AccessLevelModerator},
-	"reset":   {fn: cmdRaffleReset, minLevel: AccessLevelModerator},
+	"enable":         {fn: cmdRaffleEnableDisable, minLevel: AccessLevelModerator},
+	"disable":        {fn: cmdRaffleEnableDisable, minLevel: AccessLevelModerator},
+	"count":          {fn: cmdRaffleCount, minLevel: AccessLevelModerator},
+	"winner":         {fn: cmdRaffleWinner, minLevel: AccessLevelModerator},
+	"reset":          {fn: cmdRaffleReset, minLevel: AccessLevelModerator},
+	"start":          {fn: cmdRaffleStart, minLevel: AccessLevelModerator},
+	"close":          {fn: cmdRaffleClose, minLevel: AccessLevelModerator},
+	"end":            {fn: cmdRaffleEnd, minLevel: AccessLevelModerator},
+	"draw":           {fn: cmdRaffleDraw, minLevel: AccessLevelModerator},
+	"enter":          {fn: cmdRaffleEnter, minLevel: AccessLevelEveryone, skipCooldown: true},
+	"claim":          {fn: cmdRaffleClaim, minLevel: AccessLevelEveryone, skipCooldown: true},
+	"list":           {fn: cmdRaffleList, minLevel: AccessLevelModerator},
+	"history":        {fn: cmdRaffleHistory, minLevel: AccessLevelEveryone},
+	"subweight":      {fn: cmdRaffleWeight, minLevel: AccessLevelModerator},
+	"vipweight":      {fn: cmdRaffleWeight, minLevel: AccessLevelModerator},
+	"excludewinners": {fn: cmdRaffleExcludeWinners, minLevel: AccessLevelModerator},
+	"claimtime":      {fn: cmdRaffleClaimTime, minLevel: AccessLevelModerator},
 })
 
 func cmdRaffle(ctx context.Context, s *session, cmd string, args string) error {
@@ -36,7 +71,7 @@ func cmdRaffle(ctx context.Context, s *session, cmd string, args string) error {
 		return err
 	}
 
-	return s.ReplyUsage(ctx, "enable|disable|count|winner|reset")
+	return s.ReplyUsage(ctx, "enable|disable|count|winner|reset|start|close|end|draw|enter|claim|list|history|subweight|vipweight|excludewinners|claimtime")
 }
 
 func cmdRaffleEnableDisable(ctx context.Context, s *session, cmd string, args string) error {
@@ -80,6 +115,20 @@ func cmdRaffleEnableDisable(ctx context.Context, s *session, cmd string, args st
 }
 
 func cmdRaffleCount(ctx context.Context, s *session, cmd string, args string) error {
+	if name, _ := splitSpace(args); name != "" {
+		raffle, ok, err := s.getRaffle(ctx, name)
+		if !ok || err != nil {
+			return err
+		}
+
+		count, err := s.Queries.CountRaffleEntries(ctx, raffle.ID)
+		if err != nil {
+			return fmt.Errorf("counting raffle entries: %w", err)
+		}
+
+		return s.Replyf(ctx, "Raffle %s has %d %s.", raffle.Name, count, pluralInt(count, "entry", "entries"))
+	}
+
 	count, err := s.RaffleCount(ctx)
 	if err != nil {
 		return err
@@ -152,3 +201,537 @@ func cmdRaffleReset(ctx context.Context, s *session, cmd string, args string) er
 
 	return s.Reply(ctx, "Raffle reset.")
 }
+
+func cmdRaffleStart(ctx context.Context, s *session, cmd string, args string) error {
+	const usage = "<name> [duration] [keyword]"
+
+	name, args := splitSpace(args)
+	name = strings.ToLower(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, usage)
+	}
+
+	if utf8.RuneCountInString(name) > raffleMaxName {
+		return s.Replyf(ctx, "Raffle name must be at most %d characters.", raffleMaxName)
+	}
+
+	var closesAt pgtype.Timestamptz
+	var duration time.Duration
+
+	if first, rest := splitSpace(args); first != "" {
+		if d, err := time.ParseDuration(first); err == nil {
+			if d < raffleMinDuration || d > raffleMaxDuration {
+				return s.Replyf(ctx, "Raffle duration must be between %s and %s.", durafmt.Parse(raffleMinDuration), durafmt.Parse(raffleMaxDuration))
+			}
+			duration = d
+			closesAt = dbsql.TimestamptzFrom(time.Now().Add(d))
+			args = rest
+		}
+	}
+
+	keyword := strings.ToLower(strings.TrimSpace(args))
+	if keyword == "" {
+		keyword = name
+	}
+
+	if utf8.RuneCountInString(keyword) > raffleMaxKeyword {
+		return s.Replyf(ctx, "Raffle keyword must be at most %d characters.", raffleMaxKeyword)
+	}
+
+	existing, err := s.Queries.GetRaffleByName(ctx, dbsql.GetRaffleByNameParams{
+		ChannelID: s.Channel.ID,
+		Name:      name,
+	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("getting raffle: %w", err)
+	case existing.Open:
+		return s.Replyf(ctx, "Raffle %s is already running. Type %s to enter.", existing.Name, existing.Keyword)
+	default:
+		// Starting a closed raffle again starts it from scratch.
+		if err := s.Queries.DeleteRaffle(ctx, existing.ID); err != nil {
+			return fmt.Errorf("deleting raffle: %w", err)
+		}
+		if err := s.Deps.UnscheduleRaffle(ctx, existing.ID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := s.Queries.GetOpenRaffleByKeyword(ctx, dbsql.GetOpenRaffleByKeywordParams{
+		ChannelID: s.Channel.ID,
+		Keyword:   keyword,
+	}); err == nil {
+		return s.Replyf(ctx, "Another raffle is already using the keyword %s.", keyword)
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("getting raffle: %w", err)
+	}
+
+	raffle, err := s.Queries.InsertRaffle(ctx, dbsql.InsertRaffleParams{
+		ChannelID: s.Channel.ID,
+		Name:      name,
+		Keyword:   keyword,
+		ClosesAt:  closesAt,
+	})
+	if err != nil {
+		return fmt.Errorf("inserting raffle: %w", err)
+	}
+
+	s.invalidateMatchers()
+
+	if err := s.scheduleRaffle(ctx, &raffle); err != nil {
+		return err
+	}
+
+	if duration != 0 {
+		return s.Replyf(ctx, "Raffle %s started! Type %s to enter. Entries close in %s.", raffle.Name, raffle.Keyword, durafmt.Parse(duration))
+	}
+
+	return s.Replyf(ctx, "Raffle %s started! Type %s to enter.", raffle.Name, raffle.Keyword)
+}
+
+func cmdRaffleClose(ctx context.Context, s *session, cmd string, args string) error {
+	raffle, ok, err := s.getRaffle(ctx, args)
+	if !ok || err != nil {
+		return err
+	}
+
+	if !raffle.Open {
+		return s.Replyf(ctx, "Raffle %s is already closed.", raffle.Name)
+	}
+
+	raffle.Open = false
+	raffle.ClosesAt = pgtype.Timestamptz{}
+
+	if err := s.Queries.UpdateRaffleOpen(ctx, dbsql.UpdateRaffleOpenParams{
+		Open:     raffle.Open,
+		ClosesAt: raffle.ClosesAt,
+		ID:       raffle.ID,
+	}); err != nil {
+		return fmt.Errorf("closing raffle: %w", err)
+	}
+
+	s.invalidateMatchers()
+
+	if err := s.scheduleRaffle(ctx, raffle); err != nil {
+		return err
+	}
+
+	count, err := s.Queries.CountRaffleEntries(ctx, raffle.ID)
+	if err != nil {
+		return fmt.Errorf("counting raffle entries: %w", err)
+	}
+
+	return s.Replyf(ctx, "Raffle %s closed with %d %s.", raffle.Name, count, pluralInt(count, "entry", "entries"))
+}
+
+func cmdRaffleEnd(ctx context.Context, s *session, cmd string, args string) error {
+	raffle, ok, err := s.getRaffle(ctx, args)
+	if !ok || err != nil {
+		return err
+	}
+
+	if err := s.Queries.DeleteRaffle(ctx, raffle.ID); err != nil {
+		return fmt.Errorf("deleting raffle: %w", err)
+	}
+
+	if raffle.Open {
+		s.invalidateMatchers()
+	}
+
+	if err := s.Deps.UnscheduleRaffle(ctx, raffle.ID); err != nil {
+		return err
+	}
+
+	return s.Replyf(ctx, "Raffle %s ended.", raffle.Name)
+}
+
+func cmdRaffleDraw(ctx context.Context, s *session, cmd string, args string) error {
+	name, args := splitSpace(args)
+
+	n := int64(1)
+	if count, _ := splitSpace(args); count != "" {
+		var err error
+		n, err = strconv.ParseInt(count, 10, 64)
+		if err != nil || n < 1 || n > raffleMaxWinners {
+			return s.ReplyUsage(ctx, "<name> [count]")
+		}
+	}
+
+	raffle, ok, err := s.getRaffle(ctx, name)
+	if !ok || err != nil {
+		return err
+	}
+
+	winners, err := s.RaffleDraw(ctx, raffle, int(n))
+	if err != nil {
+		return err
+	}
+
+	if len(winners) == 0 {
+		return s.Replyf(ctx, "Raffle %s has no eligible entries.", raffle.Name)
+	}
+
+	return s.Reply(ctx, s.raffleWinnersMessage(raffle, winners))
+}
+
+func cmdRaffleEnter(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name>")
+	}
+
+	raffle, err := s.Queries.GetRaffleByName(ctx, dbsql.GetRaffleByNameParams{
+		ChannelID: s.Channel.ID,
+		Name:      strings.ToLower(name),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("getting raffle: %w", err)
+	}
+
+	return s.RaffleEnter(ctx, &raffle)
+}
+
+func cmdRaffleClaim(ctx context.Context, s *session, cmd string, args string) error {
+	winner, err := s.Queries.GetPendingRaffleWinnerByUser(ctx, dbsql.GetPendingRaffleWinnerByUserParams{
+		ChannelID: s.Channel.ID,
+		UserID:    s.UserID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return s.Replyf(ctx, "%s, you have no prize to claim.", s.UserDisplay)
+		}
+		return fmt.Errorf("getting raffle winner: %w", err)
+	}
+
+	if err := s.Queries.UpdateRaffleWinnerStatus(ctx, dbsql.UpdateRaffleWinnerStatusParams{
+		Status: raffleWinnerClaimed,
+		ID:     winner.ID,
+	}); err != nil {
+		return fmt.Errorf("updating raffle winner: %w", err)
+	}
+
+	return s.Replyf(ctx, "%s claimed the prize for raffle %s!", s.UserDisplay, winner.RaffleName)
+}
+
+func cmdRaffleList(ctx context.Context, s *session, cmd string, args string) error {
+	raffles, err := s.Queries.ListRaffles(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("listing raffles: %w", err)
+	}
+
+	if len(raffles) == 0 {
+		return s.Reply(ctx, "There are no raffles.")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Raffles: ")
+
+	for i, r := range raffles {
+		count, err := s.Queries.CountRaffleEntries(ctx, r.ID)
+		if err != nil {
+			return fmt.Errorf("counting raffle entries: %w", err)
+		}
+
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+
+		state := "closed"
+		if r.Open {
+			state = "open"
+		}
+
+		fmt.Fprintf(&sb, "%s (%s, %d %s)", r.Name, state, count, pluralInt(count, "entry", "entries"))
+	}
+
+	return s.Reply(ctx, sb.String())
+}
+
+func cmdRaffleHistory(ctx context.Context, s *session, cmd string, args string) error {
+	return s.Replyf(ctx, "You can find the raffle winners at: %s/c/%s/raffles", s.WebAddr(), s.ChannelName)
+}
+
+func cmdRaffleWeight(ctx context.Context, s *session, cmd string, args string) error {
+	var value *int32
+	var who string
+
+	switch cmd {
+	case "subweight":
+		value = &s.Channel.RaffleSubWeight
+		who = "Subscribers"
+	case "vipweight":
+		value = &s.Channel.RaffleVIPWeight
+		who = "VIPs"
+	default:
+		panic("unreachable")
+	}
+
+	arg, _ := splitSpace(args)
+	if arg == "" {
+		return s.Replyf(ctx, "%s get %d %s in raffles.", who, *value, pluralInt(*value, "entry", "entries"))
+	}
+
+	n, err := strconv.ParseInt(arg, 10, 32)
+	if err != nil || n < 1 || n > raffleMaxWeight {
+		return s.ReplyUsage(ctx, fmt.Sprintf("<1-%d>", raffleMaxWeight))
+	}
+
+	*value = int32(n)
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	return s.Replyf(ctx, "%s now get %d %s in raffles.", who, *value, pluralInt(*value, "entry", "entries"))
+}
+
+func cmdRaffleExcludeWinners(ctx context.Context, s *session, _ string, args string) error {
+	return updateBoolean(
+		ctx, s, args, &s.Channel.RaffleExcludeWinners,
+		"excludeWinners",
+		"Previous winners are already excluded from raffles.",
+		"Previous winners are already allowed to win raffles.",
+		"Previous winners are now excluded from raffles.",
+		"Previous winners are now allowed to win raffles.",
+	)
+}
+
+func cmdRaffleClaimTime(ctx context.Context, s *session, cmd string, args string) error {
+	arg, _ := splitSpace(args)
+	if arg == "" {
+		if s.Channel.RaffleClaimSeconds == 0 {
+			return s.Reply(ctx, "Raffle winners do not need to claim their prize.")
+		}
+		return s.Replyf(ctx, "Raffle winners have %d seconds to claim their prize.", s.Channel.RaffleClaimSeconds)
+	}
+
+	n, err := strconv.ParseInt(arg, 10, 32)
+	if err != nil || n < 0 || n > raffleMaxClaim {
+		return s.ReplyUsage(ctx, fmt.Sprintf("<0-%d seconds>", raffleMaxClaim))
+	}
+
+	s.Channel.RaffleClaimSeconds = int32(n)
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	if n == 0 {
+		return s.Reply(ctx, "Raffle winners no longer need to claim their prize.")
+	}
+
+	return s.Replyf(ctx, "Raffle winners now have %d seconds to claim their prize.", n)
+}
+
+func tryRaffleKeyword(ctx context.Context, s *session) (bool, error) {
+	keyword := strings.ToLower(strings.TrimSpace(s.Message))
+	if keyword == "" {
+		return false, nil
+	}
+
+	// Most channels have no open raffles, so check the cached keywords
+	// before querying for the raffle itself.
+	keywords, err := s.Deps.Matchers.RaffleKeywords(ctx, s.Queries, s.Channel.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if !keywords[keyword] {
+		return false, nil
+	}
+
+	raffle, err := s.Queries.GetOpenRaffleByKeyword(ctx, dbsql.GetOpenRaffleByKeywordParams{
+		ChannelID: s.Channel.ID,
+		Keyword:   keyword,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting raffle: %w", err)
+	}
+
+	return true, s.RaffleEnter(ctx, &raffle)
+}
+
+// getRaffle looks up a named raffle, replying to the user if it does not
+// exist.
+func (s *session) getRaffle(ctx context.Context, args string) (*dbsql.Raffle, bool, error) {
+	name, _ := splitSpace(args)
+	name = strings.ToLower(name)
+
+	if name == "" {
+		return nil, false, s.ReplyUsage(ctx, "<name>")
+	}
+
+	raffle, err := s.Queries.GetRaffleByName(ctx, dbsql.GetRaffleByNameParams{
+		ChannelID: s.Channel.ID,
+		Name:      name,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, false, s.Replyf(ctx, "Raffle %s does not exist.", name)
+		}
+		return nil, false, fmt.Errorf("getting raffle: %w", err)
+	}
+
+	return &raffle, true, nil
+}
+
+// RaffleEnter enters the current user into a named raffle. Entries into
+// closed raffles, repeat entries, and entries from excluded previous winners
+// are silently ignored.
+func (s *session) RaffleEnter(ctx context.Context, raffle *dbsql.Raffle) error {
+	if !raffle.Open {
+		return nil
+	}
+
+	if s.Channel.RaffleExcludeWinners {
+		won, err := s.Queries.HasWonRaffle(ctx, dbsql.HasWonRaffleParams{
+			ChannelID: s.Channel.ID,
+			UserID:    s.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("checking raffle winners: %w", err)
+		}
+		if won {
+			return nil
+		}
+	}
+
+	if _, err := s.Queries.InsertRaffleEntry(ctx, dbsql.InsertRaffleEntryParams{
+		RaffleID:    raffle.ID,
+		UserID:      s.UserID,
+		UserName:    s.User,
+		UserDisplay: s.UserDisplay,
+		Weight:      s.raffleWeight(),
+	}); err != nil {
+		return fmt.Errorf("inserting raffle entry: %w", err)
+	}
+
+	return nil
+}
+
+// raffleWeight returns the number of entries the current user gets in a
+// named raffle.
+func (s *session) raffleWeight() int32 {
+	weight := int32(1)
+	if s.UserLevel.CanAccess(AccessLevelSubscriber) {
+		weight = max(weight, s.Channel.RaffleSubWeight)
+	}
+	if s.UserLevel.CanAccess(AccessLevelVIP) {
+		weight = max(weight, s.Channel.RaffleVIPWeight)
+	}
+	return weight
+}
+
+// RaffleDraw removes up to n weighted random entries from a named raffle and
+// records them as winners. If the channel requires prizes to be claimed, the
+// winners are given until the claim deadline to do so.
+func (s *session) RaffleDraw(ctx context.Context, raffle *dbsql.Raffle, n int) ([]dbsql.RaffleWinner, error) {
+	entries, err := s.Queries.ListRaffleEntriesForUpdate(ctx, dbsql.ListRaffleEntriesForUpdateParams{
+		RaffleID:       raffle.ID,
+		ExcludeWinners: s.Channel.RaffleExcludeWinners,
+		ChannelID:      s.Channel.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing raffle entries: %w", err)
+	}
+
+	total := 0
+	for _, e := range entries {
+		total += int(e.Weight)
+	}
+
+	var picked []dbsql.RaffleEntry
+	for len(picked) < n && len(entries) > 0 {
+		r := s.Deps.Rand.Intn(total)
+
+		i := 0
+		for ; i < len(entries)-1; i++ {
+			r -= int(entries[i].Weight)
+			if r < 0 {
+				break
+			}
+		}
+
+		picked = append(picked, entries[i])
+		total -= int(entries[i].Weight)
+		entries = slices.Delete(entries, i, i+1)
+	}
+
+	if len(picked) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, len(picked))
+	for i, e := range picked {
+		ids[i] = e.ID
+	}
+
+	if err := s.Queries.DeleteRaffleEntries(ctx, ids); err != nil {
+		return nil, fmt.Errorf("deleting raffle entries: %w", err)
+	}
+
+	status := raffleWinnerWon
+	var deadline pgtype.Timestamptz
+
+	if s.Channel.RaffleClaimSeconds > 0 {
+		status = raffleWinnerPending
+		deadline = dbsql.TimestamptzFrom(time.Now().Add(time.Duration(s.Channel.RaffleClaimSeconds) * time.Second))
+	}
+
+	winners := make([]dbsql.RaffleWinner, 0, len(picked))
+
+	for _, e := range picked {
+		w, err := s.Queries.InsertRaffleWinner(ctx, dbsql.InsertRaffleWinnerParams{
+			ChannelID:     s.Channel.ID,
+			RaffleID:      pgtype.Int8{Int64: raffle.ID, Valid: true},
+			RaffleName:    raffle.Name,
+			UserID:        e.UserID,
+			UserName:      e.UserName,
+			UserDisplay:   e.UserDisplay,
+			Status:        status,
+			ClaimDeadline: deadline,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("inserting raffle winner: %w", err)
+		}
+		winners = append(winners, w)
+	}
+
+	if status == raffleWinnerPending {
+		if err := s.scheduleRaffle(ctx, raffle); err != nil {
+			return nil, err
+		}
+	}
+
+	return winners, nil
+}
+
+func (s *session) raffleWinnersMessage(raffle *dbsql.Raffle, winners []dbsql.RaffleWinner) string {
+	var sb strings.Builder
+
+	if len(winners) == 1 {
+		fmt.Fprintf(&sb, "Winner of raffle %s is %s!", raffle.Name, winners[0].UserDisplay)
+	} else {
+		fmt.Fprintf(&sb, "Winners of raffle %s: ", raffle.Name)
+		for i, w := range winners {
+			if i != 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(w.UserDisplay)
+		}
+	}
+
+	if winners[0].Status == raffleWinnerPending {
+		fmt.Fprintf(&sb, " Type %sraffle claim within %d seconds to claim your prize.", s.Channel.Prefix, s.Channel.RaffleClaimSeconds)
+	}
+
+	return sb.String()
+}
@@ -54,7 +54,7 @@ func cmdScheduleAdd(ctx context.Context, s *session, cmd string, args string) er
 
 	pattern = strings.ReplaceAll(pattern, "_", " ")
 
-	expr, err := repeat.ParseCron(pattern)
+	expr, err := repeat.ParseCronIn(pattern, s.Channel.Location())
 	if err != nil {
 		return s.Replyf(ctx, "Bad cron expression: %s", pattern)
 	}
@@ -217,7 +217,7 @@ func cmdScheduleOnOff(ctx context.Context, s *session, cmd string, args string)
 	}
 	scheduled.UpdatedAt = updated.UpdatedAt
 
-	expr := must.Must(repeat.ParseCron(scheduled.CronExpression))
+	expr := must.Must(repeat.ParseCronIn(scheduled.CronExpression, s.Channel.Location()))
 
 	if enable {
 		err = s.Deps.AddScheduled(ctx, scheduled.ID, expr)
@@ -3,8 +3,10 @@ package bot
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/hortbot/hortbot/internal/cbp"
@@ -31,6 +33,15 @@ var settingCommands = newHandlerMap(map[string]handlerFunc{
 	"steam":              {fn: cmdSettingsSteam, minLevel: AccessLevelModerator},
 	"urban":              {fn: cmdSettingUrban, minLevel: AccessLevelModerator},
 	"tweet":              {fn: cmdSettingTweet, minLevel: AccessLevelModerator},
+	"timezone":           {fn: cmdSettingTimezone, minLevel: AccessLevelModerator},
+	"apicache":           {fn: cmdSettingAPICache, minLevel: AccessLevelModerator},
+	"raidmessage":        {fn: cmdSettingRaidMessage, minLevel: AccessLevelModerator},
+	"raidthreshold":      {fn: cmdSettingRaidThreshold, minLevel: AccessLevelModerator},
+	"raidshoutout":       {fn: cmdSettingRaidShoutout, minLevel: AccessLevelModerator},
+	"threadedreplies":    {fn: cmdSettingThreadedReplies, minLevel: AccessLevelModerator},
+	"whisperbuiltins":    {fn: cmdSettingWhisperBuiltins, minLevel: AccessLevelModerator},
+	"firstchatcommand":   {fn: cmdSettingFirstChatCommand, minLevel: AccessLevelModerator},
+	"firstchatcooldown":  {fn: cmdSettingFirstChatCooldown, minLevel: AccessLevelModerator},
 })
 
 func cmdSettings(ctx context.Context, s *session, cmd string, args string) error {
@@ -253,6 +264,16 @@ func cmdSettingTimeoutDuration(ctx context.Context, s *session, cmd string, args
 		return fmt.Errorf("updating channel: %w", err)
 	}
 
+	err = s.Queries.UpdateChannelFiltersDuration(ctx, dbsql.UpdateChannelFiltersDurationParams{
+		Duration:  dur,
+		ChannelID: s.Channel.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("updating filter durations: %w", err)
+	}
+
+	s.invalidateMatchers()
+
 	if dur == 0 {
 		return s.Reply(ctx, "Timeout duration changed to Twitch default.")
 	}
@@ -291,7 +312,9 @@ func cmdSettingSubsRegsMinusLinks(ctx context.Context, s *session, cmd string, a
 }
 
 func cmdSettingSubsMayLink(ctx context.Context, s *session, _ string, args string) error {
-	return updateBoolean(
+	before := s.Channel.SubsMayLink
+
+	err := updateBoolean(
 		ctx, s, args, &s.Channel.SubsMayLink,
 		"subsMayLink",
 		"Subs already may post links.",
@@ -299,6 +322,12 @@ func cmdSettingSubsMayLink(ctx context.Context, s *session, _ string, args strin
 		"Subs may now post links.",
 		"Subs may no longer post links.",
 	)
+	if err != nil || s.Channel.SubsMayLink == before {
+		return err
+	}
+
+	links := defaultFilterSettings(s.Channel, "links")
+	return updateFiltersExemptLevel(ctx, s, links.ExemptLevel, "links")
 }
 
 func cmdSettingMode(ctx context.Context, s *session, cmd string, args string) error {
@@ -490,3 +519,268 @@ func cmdSettingTweet(ctx context.Context, s *session, cmd string, args string) e
 
 	return s.Replyf(ctx, `Tweet set to: "%s"%s`, args, warning)
 }
+
+func cmdSettingTimezone(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+
+	if name == "" {
+		if s.Channel.Timezone == "" {
+			return s.Reply(ctx, "Timezone is not set (UTC).")
+		}
+		return s.Replyf(ctx, "Timezone is set to %s.", s.Channel.Timezone)
+	}
+
+	reset := strings.EqualFold(name, "reset")
+
+	if reset {
+		name = ""
+	} else {
+		loc, err := time.LoadLocation(name)
+		if err != nil || name == "Local" {
+			return s.Replyf(ctx, "Unknown timezone '%s'. Use a name like America/Chicago or Europe/Berlin.", name)
+		}
+		name = loc.String()
+	}
+
+	s.Channel.Timezone = name
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	scheduleds, err := s.Queries.ListScheduledCommands(ctx, s.Channel.ID)
+	if err != nil {
+		return fmt.Errorf("getting scheduled commands: %w", err)
+	}
+
+	if err := updateScheduleds(ctx, s.Deps, scheduleds, s.Channel.Location(), true); err != nil {
+		return err
+	}
+
+	chatModeRules, err := s.Queries.ListChatModeRulesByTrigger(ctx, dbsql.ListChatModeRulesByTriggerParams{
+		ChannelID: s.Channel.ID,
+		Trigger:   chatModeTriggerSchedule,
+	})
+	if err != nil {
+		return fmt.Errorf("getting scheduled chat mode rules: %w", err)
+	}
+
+	if err := updateChatModeSchedules(ctx, s.Deps, chatModeRules, s.Channel.Location(), true); err != nil {
+		return err
+	}
+
+	if reset {
+		return s.Reply(ctx, "Timezone reset to UTC.")
+	}
+
+	return s.Replyf(ctx, "Timezone changed to %s.", name)
+}
+
+const maxAPICacheSeconds = 24 * 60 * 60
+
+func cmdSettingAPICache(ctx context.Context, s *session, cmd string, args string) error {
+	if args == "" {
+		if s.Channel.APICacheSeconds == 0 {
+			return s.Reply(ctx, "API responses are not cached.")
+		}
+		return s.Replyf(ctx, "API responses are cached for %d seconds.", s.Channel.APICacheSeconds)
+	}
+
+	secs, err := parseInt32(args)
+	if err != nil {
+		return s.ReplyUsage(ctx, "<seconds>")
+	}
+
+	if secs < 0 || secs > maxAPICacheSeconds {
+		return s.Replyf(ctx, "API cache duration must be between 0 and %d seconds.", maxAPICacheSeconds)
+	}
+
+	s.Channel.APICacheSeconds = secs
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	if secs == 0 {
+		return s.Reply(ctx, "API response caching disabled.")
+	}
+
+	return s.Replyf(ctx, "API responses will now be cached for %d seconds.", secs)
+}
+
+func cmdSettingRaidMessage(ctx context.Context, s *session, cmd string, args string) error {
+	args = strings.TrimSpace(args)
+	wasSet := s.Channel.RaidMessage != ""
+
+	switch {
+	case args == "":
+		if s.Channel.RaidMessage == "" {
+			return s.Reply(ctx, "Raid message is not set.")
+		}
+		return s.Replyf(ctx, "Raid message is set to: %s", s.Channel.RaidMessage)
+
+	case strings.EqualFold(args, "off"):
+		s.Channel.RaidMessage = ""
+
+	default:
+		s.Channel.RaidMessage = args
+	}
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	// Raid notifications are only subscribed to when they'd be used.
+	if (s.Channel.RaidMessage != "") != wasSet {
+		s.requestEventsubUpdate()
+	}
+
+	if s.Channel.RaidMessage == "" {
+		return s.Reply(ctx, "Raid message disabled.")
+	}
+
+	return s.Replyf(ctx, "Raid message set to: %s", s.Channel.RaidMessage)
+}
+
+func cmdSettingRaidThreshold(ctx context.Context, s *session, cmd string, args string) error {
+	if args == "" {
+		return s.Replyf(ctx, "Raids with at least %d %s get a raid message or shoutout.", s.Channel.RaidThreshold, pluralInt(s.Channel.RaidThreshold, "viewer", "viewers"))
+	}
+
+	viewers, err := parseInt32(args)
+	if err != nil || viewers < 0 {
+		return s.ReplyUsage(ctx, "<viewers>")
+	}
+
+	s.Channel.RaidThreshold = viewers
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	return s.Replyf(ctx, "Raids with at least %d %s will now get a raid message or shoutout.", viewers, pluralInt(viewers, "viewer", "viewers"))
+}
+
+func cmdSettingRaidShoutout(ctx context.Context, s *session, cmd string, args string) error {
+	prev := s.Channel.RaidShoutout
+
+	err := updateBoolean(
+		ctx, s, args, &s.Channel.RaidShoutout,
+		"raidShoutout",
+		"Raiders are already shouted out.",
+		"Raiders are already not shouted out.",
+		"Raiders will now be shouted out.",
+		"Raiders will no longer be shouted out.",
+	)
+
+	if s.Channel.RaidShoutout != prev {
+		s.requestEventsubUpdate()
+	}
+
+	return err
+}
+
+func cmdSettingThreadedReplies(ctx context.Context, s *session, cmd string, args string) error {
+	return updateBoolean(
+		ctx, s, args, &s.Channel.ThreadedReplies,
+		"threadedReplies",
+		"Command responses are already threaded replies.",
+		"Command responses are already not threaded replies.",
+		"Command responses will now be sent as threaded replies.",
+		"Command responses will no longer be sent as threaded replies.",
+	)
+}
+
+func cmdSettingWhisperBuiltins(ctx context.Context, s *session, cmd string, args string) error {
+	args = strings.TrimSpace(args)
+
+	switch {
+	case args == "":
+		if len(s.Channel.WhisperBuiltins) == 0 {
+			return s.Reply(ctx, "No builtin commands respond with whispers.")
+		}
+		return s.Replyf(ctx, "Builtin commands which respond with whispers: %s", strings.Join(s.Channel.WhisperBuiltins, ", "))
+
+	case strings.EqualFold(args, "off"):
+		s.Channel.WhisperBuiltins = []string{}
+
+	default:
+		names := strings.FieldsFunc(args, func(r rune) bool { return r == ' ' || r == ',' })
+		for i, name := range names {
+			name = cleanCommandName(name)
+			if !isBuiltinName(name) {
+				return s.Replyf(ctx, "'%s' is not a builtin command.", name)
+			}
+			names[i] = name
+		}
+
+		slices.Sort(names)
+		s.Channel.WhisperBuiltins = slices.Compact(names)
+	}
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	if len(s.Channel.WhisperBuiltins) == 0 {
+		return s.Reply(ctx, "Builtin commands will no longer respond with whispers.")
+	}
+	return s.Replyf(ctx, "Builtin commands which respond with whispers set to: %s", strings.Join(s.Channel.WhisperBuiltins, ", "))
+}
+
+func cmdSettingFirstChatCommand(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+
+	switch {
+	case name == "":
+		if s.Channel.FirstChatCommand == "" {
+			return s.Reply(ctx, "First chat command is not set.")
+		}
+		return s.Replyf(ctx, "First chat command is set to: %s", s.Channel.FirstChatCommand)
+
+	case strings.EqualFold(name, "off"):
+		s.Channel.FirstChatCommand = ""
+
+	default:
+		name = cleanCommandName(name)
+
+		info, commandMsg, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, false)
+		if err != nil {
+			return fmt.Errorf("finding command: %w", err)
+		}
+		if !found || !commandMsg.Valid {
+			return s.Replyf(ctx, "Command '%s' does not exist.", name)
+		}
+
+		s.Channel.FirstChatCommand = info.Name
+	}
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	if s.Channel.FirstChatCommand == "" {
+		return s.Reply(ctx, "First chat command disabled.")
+	}
+
+	return s.Replyf(ctx, "First chat command set to: %s", s.Channel.FirstChatCommand)
+}
+
+func cmdSettingFirstChatCooldown(ctx context.Context, s *session, cmd string, args string) error {
+	if args == "" {
+		return s.Replyf(ctx, "First chat cooldown is %d %s.", s.Channel.FirstChatCooldown, pluralInt(s.Channel.FirstChatCooldown, "second", "seconds"))
+	}
+
+	seconds, err := parseInt32(args)
+	if err != nil || seconds < 0 {
+		return s.ReplyUsage(ctx, "<seconds>")
+	}
+
+	s.Channel.FirstChatCooldown = seconds
+
+	if err := s.updateChannelSettings(ctx); err != nil {
+		return fmt.Errorf("updating channel: %w", err)
+	}
+
+	return s.Replyf(ctx, "First chat cooldown changed to %d %s.", seconds, pluralInt(seconds, "second", "seconds"))
+}
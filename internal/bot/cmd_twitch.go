@@ -296,6 +296,11 @@ func cmdUptime(ctx context.Context, s *session, cmd string, args string) error {
 	uptime := time.Since(stream.StartedAt).Truncate(time.Minute)
 	uStr := durafmt.Parse(uptime).String()
 
+	if s.Channel.Timezone != "" {
+		started := stream.StartedAt.In(s.Channel.Location()).Format("3:04 PM MST")
+		return s.Replyf(ctx, "Live for %s (since %s).", uStr, started)
+	}
+
 	return s.Replyf(ctx, "Live for %s.", uStr)
 }
 
@@ -399,10 +404,156 @@ func cmdIsLive(ctx context.Context, s *session, cmd string, args string) error {
 	return s.Replyf(ctx, "Yes, %s is live playing %s with %d %s.", name, gameName, viewers, v)
 }
 
-func cmdHost(ctx context.Context, s *session, cmd string, args string) error {
-	return s.Reply(ctx, "Twitch no longer supports host mode.")
+func cmdShoutout(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanUsername(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<user>")
+	}
+
+	u, ch, replied, err := twitchUserChannel(ctx, s, name)
+	if replied || err != nil {
+		return err
+	}
+
+	if err := s.SendShoutout(ctx, int64(u.ID)); err != nil {
+		// Twitch rejects shoutouts while offline, during cooldowns, or when the
+		// bot isn't a moderator; the chat message is still useful.
+		if _, ok := apiclient.AsError(err); !ok {
+			return err
+		}
+	}
+
+	return s.Replyf(ctx, "Go check out %s at twitch.tv/%s!%s", u.DispName(), u.Name, lastPlaying(ch))
+}
+
+func cmdRaid(ctx context.Context, s *session, cmd string, args string) error {
+	name, _ := splitSpace(args)
+	name = cleanUsername(name)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<user>")
+	}
+
+	if name == s.Channel.Name {
+		return s.Reply(ctx, "You can't raid yourself.")
+	}
+
+	u, ch, replied, err := twitchUserChannel(ctx, s, name)
+	if replied || err != nil {
+		return err
+	}
+
+	tok, err := s.ChannelTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	newToken, err := s.Deps.Twitch.StartRaid(ctx, s.Channel.TwitchID, int64(u.ID), tok)
+
+	// Check this, even if an error occurred.
+	if newToken != nil {
+		if err := s.SetChannelTwitchToken(ctx, newToken); err != nil {
+			return err
+		}
+	}
+
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok {
+			if ae.IsNotPermitted() || errors.Is(err, twitch.ErrDeadToken) {
+				return s.Reply(ctx, s.TwitchNotAuthMessage())
+			}
+			if ae.IsServerError() {
+				return s.Reply(ctx, twitchServerErrorReply)
+			}
+			return s.Replyf(ctx, "Could not raid %s.", u.DispName())
+		}
+		return fmt.Errorf("starting raid: %w", err)
+	}
+
+	return s.Replyf(ctx, "Raiding %s!%s", u.DispName(), lastPlaying(ch))
+}
+
+func cmdUnraid(ctx context.Context, s *session, cmd string, args string) error {
+	tok, err := s.ChannelTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	newToken, err := s.Deps.Twitch.CancelRaid(ctx, s.Channel.TwitchID, tok)
+
+	// Check this, even if an error occurred.
+	if newToken != nil {
+		if err := s.SetChannelTwitchToken(ctx, newToken); err != nil {
+			return err
+		}
+	}
+
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok {
+			if ae.IsNotFound() {
+				return s.Reply(ctx, "There is no raid to cancel.")
+			}
+			if ae.IsNotPermitted() || errors.Is(err, twitch.ErrDeadToken) {
+				return s.Reply(ctx, s.TwitchNotAuthMessage())
+			}
+			if ae.IsServerError() {
+				return s.Reply(ctx, twitchServerErrorReply)
+			}
+		}
+		return fmt.Errorf("canceling raid: %w", err)
+	}
+
+	return s.Reply(ctx, "Raid canceled.")
+}
+
+// twitchUser looks up a user by name, replying if the user does not exist or
+// Twitch returns a server error.
+func twitchUser(ctx context.Context, s *session, name string) (u *twitch.User, replied bool, err error) {
+	u, err = s.Deps.Twitch.GetUserByUsername(ctx, name)
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok {
+			if ae.IsNotFound() {
+				return nil, true, s.Replyf(ctx, "User %s does not exist.", name)
+			}
+			if ae.IsServerError() {
+				return nil, true, s.Reply(ctx, twitchServerErrorReply)
+			}
+		}
+		return nil, false, fmt.Errorf("getting user: %w", err)
+	}
+	return u, false, nil
+}
+
+// twitchUserChannel looks up a user and their channel information, replying
+// if the user does not exist. The returned channel may be nil.
+func twitchUserChannel(ctx context.Context, s *session, name string) (u *twitch.User, ch *twitch.Channel, replied bool, err error) {
+	u, replied, err = twitchUser(ctx, s, name)
+	if replied || err != nil {
+		return nil, nil, replied, err
+	}
+
+	ch, err = s.Deps.Twitch.GetChannelByID(ctx, int64(u.ID))
+	if err != nil {
+		if ae, ok := apiclient.AsError(err); ok && (ae.IsNotFound() || ae.IsServerError()) {
+			// The channel information is only decoration.
+			return u, nil, false, nil
+		}
+		return nil, nil, false, fmt.Errorf("getting channel: %w", err)
+	}
+
+	return u, ch, false, nil
 }
 
-func cmdUnhost(ctx context.Context, s *session, cmd string, args string) error {
-	return s.Reply(ctx, "Twitch no longer supports host mode.")
+func lastPlaying(ch *twitch.Channel) string {
+	if ch == nil || ch.Game == "" {
+		return ""
+	}
+
+	if ch.Title == "" {
+		return fmt.Sprintf(" They were last playing %s.", ch.Game)
+	}
+
+	return fmt.Sprintf(" They were last playing %s: %s", ch.Game, ch.Title)
 }
@@ -2,8 +2,14 @@ package bot
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
 )
 
 var varCommands = newHandlerMap(map[string]handlerFunc{
@@ -13,8 +19,20 @@ var varCommands = newHandlerMap(map[string]handlerFunc{
 	"remove":    {fn: cmdVarDelete, minLevel: AccessLevelModerator},
 	"increment": {fn: cmdVarIncrement, minLevel: AccessLevelModerator},
 	"decrement": {fn: cmdVarIncrement, minLevel: AccessLevelModerator},
+	"list":      {fn: cmdVarList, minLevel: AccessLevelModerator},
+	"type":      {fn: cmdVarType, minLevel: AccessLevelModerator},
+	"expire":    {fn: cmdVarExpire, minLevel: AccessLevelModerator},
+	"share":     {fn: cmdVarShare, minLevel: AccessLevelModerator},
+	"user":      {fn: cmdVarUser, minLevel: AccessLevelModerator},
 })
 
+var varKindDescriptions = map[string]string{
+	dbsql.VariableKindText:    "text",
+	dbsql.VariableKindNumber:  "a number",
+	dbsql.VariableKindBoolean: "true or false",
+	dbsql.VariableKindJSON:    "valid JSON",
+}
+
 func cmdVar(ctx context.Context, s *session, cmd string, args string) error {
 	subcommand, args := splitSpace(args)
 	subcommand = strings.ToLower(subcommand)
@@ -25,7 +43,7 @@ func cmdVar(ctx context.Context, s *session, cmd string, args string) error {
 	}
 
 	if !ok {
-		return s.ReplyUsage(ctx, "set|get|delete|increment|decrement")
+		return s.ReplyUsage(ctx, "set|get|delete|increment|decrement|list|type|expire|share|user")
 	}
 
 	return nil
@@ -38,10 +56,15 @@ func cmdVarSet(ctx context.Context, s *session, cmd string, args string) error {
 		return s.ReplyUsage(ctx, "<name> <value>")
 	}
 
-	if err := s.VarSet(ctx, name, value); err != nil {
+	value, kind, badValue, err := s.VarSet(ctx, 0, name, value)
+	if err != nil {
 		return err
 	}
 
+	if badValue {
+		return s.Replyf(ctx, "Variable %s must be set to %s.", name, varKindDescriptions[kind])
+	}
+
 	return s.Replyf(ctx, "Variable %s set to: %s", name, value)
 }
 
@@ -50,7 +73,7 @@ func cmdVarGet(ctx context.Context, s *session, cmd string, args string) error {
 		return s.ReplyUsage(ctx, "<name>")
 	}
 
-	value, ok, err := s.VarGet(ctx, args)
+	value, ok, err := s.VarGet(ctx, 0, args)
 	if err != nil {
 		return err
 	}
@@ -67,7 +90,7 @@ func cmdVarDelete(ctx context.Context, s *session, cmd string, args string) erro
 		return s.ReplyUsage(ctx, "<name>")
 	}
 
-	if err := s.VarDelete(ctx, args); err != nil {
+	if err := s.VarDelete(ctx, 0, args); err != nil {
 		return err
 	}
 
@@ -93,7 +116,7 @@ func cmdVarIncrement(ctx context.Context, s *session, cmd string, args string) e
 		inc = 0 - inc
 	}
 
-	x, badVar, err := s.VarIncrement(ctx, name, inc)
+	x, badVar, err := s.VarIncrement(ctx, 0, name, inc)
 
 	switch {
 	case err != nil:
@@ -101,6 +124,206 @@ func cmdVarIncrement(ctx context.Context, s *session, cmd string, args string) e
 	case badVar:
 		return s.Replyf(ctx, "Variable %s is not an integer.", name)
 	default:
-		return s.Replyf(ctx, "Variable %s has been %sed to %d.", name, cmd, x)
+		return s.Replyf(ctx, "Variable %s has been %sed to %s.", name, cmd, x)
+	}
+}
+
+func cmdVarList(ctx context.Context, s *session, cmd string, args string) error {
+	variables, err := s.Queries.ListChannelVariables(ctx, dbsql.ListChannelVariablesParams{
+		ChannelID: s.Channel.ID,
+		Now:       dbsql.TimestamptzFrom(time.Now()),
+	})
+	if err != nil {
+		return fmt.Errorf("listing variables: %w", err)
+	}
+
+	if len(variables) == 0 {
+		return s.Reply(ctx, "There are no variables.")
+	}
+
+	names := make([]string, len(variables))
+	for i, v := range variables {
+		names[i] = v.Name
+	}
+
+	return s.Replyf(ctx, "Variables: %s", strings.Join(names, ", "))
+}
+
+func cmdVarType(ctx context.Context, s *session, cmd string, args string) error {
+	name, kind := splitSpace(args)
+	kind = strings.ToLower(kind)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name> ["+strings.Join(dbsql.VariableKinds, "|")+"]")
+	}
+
+	v, err := s.varLookup(ctx, 0, name)
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return s.Replyf(ctx, "Variable %s does not exist.", name)
+	}
+
+	if kind == "" {
+		return s.Replyf(ctx, "Variable %s holds %s.", name, varKindDescriptions[v.Kind])
+	}
+
+	if !slices.Contains(dbsql.VariableKinds, kind) {
+		return s.ReplyUsage(ctx, "<name> ["+strings.Join(dbsql.VariableKinds, "|")+"]")
+	}
+
+	value, ok := dbsql.NormalizeVariableValue(kind, v.Value)
+	if !ok {
+		return s.Replyf(ctx, "Variable %s can't hold %s; its value is: %s", name, varKindDescriptions[kind], v.Value)
+	}
+
+	if err := s.Queries.UpdateVariableKind(ctx, dbsql.UpdateVariableKindParams{
+		Kind:  kind,
+		Value: value,
+		ID:    v.ID,
+	}); err != nil {
+		return fmt.Errorf("updating variable kind: %w", err)
+	}
+
+	return s.Replyf(ctx, "Variable %s now holds %s.", name, varKindDescriptions[kind])
+}
+
+func cmdVarExpire(ctx context.Context, s *session, cmd string, args string) error {
+	name, durStr := splitSpace(args)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name> [<duration>|never]")
+	}
+
+	v, err := s.varLookup(ctx, 0, name)
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return s.Replyf(ctx, "Variable %s does not exist.", name)
+	}
+
+	now := time.Now()
+
+	if durStr == "" {
+		if !v.ExpiresAt.Valid {
+			return s.Replyf(ctx, "Variable %s does not expire.", name)
+		}
+		return s.Replyf(ctx, "Variable %s expires in %s.", name, durafmt.Parse(v.ExpiresAt.Time.Sub(now).Truncate(time.Second)).LimitFirstN(2))
+	}
+
+	var expiresAt time.Time
+
+	if !strings.EqualFold(durStr, "never") {
+		dur, err := time.ParseDuration(durStr)
+		if err != nil || dur <= 0 {
+			return s.ReplyUsage(ctx, "<name> [<duration>|never]")
+		}
+		expiresAt = now.Add(dur)
 	}
+
+	params := dbsql.UpdateVariableExpiryParams{ID: v.ID}
+	if !expiresAt.IsZero() {
+		params.ExpiresAt = dbsql.TimestamptzFrom(expiresAt)
+	}
+
+	if err := s.Queries.UpdateVariableExpiry(ctx, params); err != nil {
+		return fmt.Errorf("updating variable expiry: %w", err)
+	}
+
+	if expiresAt.IsZero() {
+		return s.Replyf(ctx, "Variable %s will no longer expire.", name)
+	}
+
+	return s.Replyf(ctx, "Variable %s will expire in %s.", name, durafmt.Parse(expiresAt.Sub(now)).LimitFirstN(2))
+}
+
+func cmdVarShare(ctx context.Context, s *session, cmd string, args string) error {
+	name, rest := splitSpace(args)
+
+	if name == "" {
+		return s.ReplyUsage(ctx, "<name> [<channels>|all|none]")
+	}
+
+	v, err := s.varLookup(ctx, 0, name)
+	if err != nil {
+		return err
+	}
+
+	if v == nil {
+		return s.Replyf(ctx, "Variable %s does not exist.", name)
+	}
+
+	var sharedWith []string
+
+	switch {
+	case rest == "":
+		return replyVarSharing(ctx, s, name, v.SharedWith)
+
+	case strings.EqualFold(rest, "none"):
+		sharedWith = []string{}
+
+	case strings.EqualFold(rest, "all"):
+		sharedWith = []string{dbsql.VariableSharedWithAll}
+
+	default:
+		for _, ch := range strings.FieldsFunc(rest, func(r rune) bool { return r == ' ' || r == ',' }) {
+			sharedWith = append(sharedWith, cleanUsername(ch))
+		}
+		slices.Sort(sharedWith)
+		sharedWith = slices.Compact(sharedWith)
+	}
+
+	if err := s.Queries.UpdateVariableSharing(ctx, dbsql.UpdateVariableSharingParams{
+		SharedWith: sharedWith,
+		ID:         v.ID,
+	}); err != nil {
+		return fmt.Errorf("updating variable sharing: %w", err)
+	}
+
+	return replyVarSharing(ctx, s, name, sharedWith)
+}
+
+func replyVarSharing(ctx context.Context, s *session, name string, sharedWith []string) error {
+	switch {
+	case len(sharedWith) == 0:
+		return s.Replyf(ctx, "Variable %s is not shared with other channels.", name)
+	case slices.Contains(sharedWith, dbsql.VariableSharedWithAll):
+		return s.Replyf(ctx, "Variable %s is shared with all channels.", name)
+	default:
+		return s.Replyf(ctx, "Variable %s is shared with: %s", name, strings.Join(sharedWith, ", "))
+	}
+}
+
+func cmdVarUser(ctx context.Context, s *session, cmd string, args string) error {
+	user, name := splitSpace(args)
+	user = cleanUsername(user)
+	name, _ = splitSpace(name)
+
+	if user == "" || name == "" {
+		return s.ReplyUsage(ctx, "<user> <name>")
+	}
+
+	chatter, found, err := findChatter(ctx, s, user)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return s.Replyf(ctx, "%s has not been seen in this channel.", user)
+	}
+
+	value, ok, err := s.VarGet(ctx, chatter.UserID, name)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return s.Replyf(ctx, "Variable %s does not exist for %s.", name, user)
+	}
+
+	return s.Replyf(ctx, "Variable %s for %s is set to: %s", name, user, value)
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hortbot/hortbot/internal/confimport"
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
@@ -220,6 +221,135 @@ func (b *Bot) updateModeratedChannelsNow() {
 	}
 }
 
+// backupInterval is how often each active channel is backed up.
+const backupInterval = 24 * time.Hour
+
+func (b *Bot) backupChannels(ctx context.Context) error {
+	start := time.Now()
+
+	ids, err := b.queries.ListChannelsWithoutRecentBackup(ctx, dbsql.ListChannelsWithoutRecentBackupParams{
+		Kind:  dbsql.BackupKindAutomatic,
+		Since: dbsql.TimestamptzFrom(start.Add(-backupInterval)),
+	})
+	if err != nil {
+		return fmt.Errorf("getting channels to back up: %w", err)
+	}
+
+	backedUp := 0
+
+	for _, id := range ids {
+		err := dbx.Transact(ctx, b.db, func(ctx context.Context, tx pgx.Tx) error {
+			q := dbsql.New(tx)
+
+			channel, err := q.GetChannelByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("getting channel: %w", err)
+			}
+
+			_, err = confimport.SaveBackup(ctx, q, channel, channel.BotName, dbsql.BackupKindAutomatic)
+			return err
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			ctxlog.Error(ctx, "failed to back up channel", zap.Error(err), zap.Int64("channel_id", id))
+			continue
+		}
+		backedUp++
+	}
+
+	var deleted int64
+	if b.backupRetention > 0 {
+		// Only automatic backups expire; other kinds are limited to the
+		// most recent few per channel when they are stored.
+		deleted, err = b.queries.DeleteChannelBackupsBefore(ctx, dbsql.DeleteChannelBackupsBeforeParams{
+			Kind:   dbsql.BackupKindAutomatic,
+			Before: dbsql.TimestamptzFrom(start.Add(-b.backupRetention)),
+		})
+		if err != nil {
+			return fmt.Errorf("deleting old backups: %w", err)
+		}
+	}
+
+	ctxlog.Debug(ctx, "backed up channels",
+		zap.Duration("duration", time.Since(start)),
+		zap.Int("backed_up", backedUp),
+		zap.Int64("deleted", deleted),
+	)
+	return nil
+}
+
+func (b *Bot) runBackups(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tickerChan(b.backupsTicker):
+		}
+
+		if err := b.backupChannels(ctx); err != nil {
+			ctxlog.Error(ctx, "failed to back up channels", zap.Error(err))
+		}
+	}
+}
+
+// emoteRefreshInterval is how often each channel's third-party emotes are
+// refreshed.
+const emoteRefreshInterval = 6 * time.Hour
+
+func (b *Bot) refreshEmotes(ctx context.Context) error {
+	start := time.Now()
+
+	channels, err := b.queries.ListChannelsForEmoteRefresh(ctx, dbsql.TimestamptzFrom(start.Add(-emoteRefreshInterval)))
+	if err != nil {
+		return fmt.Errorf("getting channels to refresh emotes: %w", err)
+	}
+
+	if len(channels) == 0 {
+		return nil
+	}
+
+	globals := fetchGlobalEmotes(ctx, b.deps.Emotes)
+	if len(globals) == 0 {
+		return errors.New("no global emotes could be fetched")
+	}
+
+	refreshed := 0
+
+	for _, channel := range channels {
+		sets := fetchEmoteSets(ctx, b.deps.Emotes, channel.TwitchID, globals)
+		if err := saveEmoteSets(ctx, b.queries, channel.ID, sets); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			ctxlog.Error(ctx, "failed to refresh channel emotes", zap.Error(err), zap.Int64("channel_id", channel.ID))
+			continue
+		}
+		refreshed++
+	}
+
+	ctxlog.Debug(ctx, "refreshed channel emotes",
+		zap.Duration("duration", time.Since(start)),
+		zap.Int("refreshed", refreshed),
+	)
+	return nil
+}
+
+func (b *Bot) runRefreshEmotes(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tickerChan(b.refreshEmotesTicker):
+		}
+
+		if err := b.refreshEmotes(ctx); err != nil {
+			ctxlog.Error(ctx, "failed to refresh emotes", zap.Error(err))
+		}
+	}
+}
+
 func tickerChan(t *time.Ticker) <-chan time.Time {
 	if t == nil {
 		return nil
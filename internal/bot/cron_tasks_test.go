@@ -2,6 +2,7 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/twitchmocks"
 	"github.com/hortbot/hortbot/internal/pkg/testpostgres"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/oauth2"
@@ -62,3 +64,58 @@ func TestValidateTokensUsesTransactionConnection(t *testing.T) {
 	assert.NilError(t, err)
 	assert.DeepEqual(t, token.Scopes, []string{"new"})
 }
+
+func TestBackupRetentionKeepsManualBackups(t *testing.T) {
+	t.Parallel()
+
+	pdb, err := testpostgres.New()
+	assert.NilError(t, err)
+	t.Cleanup(pdb.Cleanup)
+	assert.NilError(t, migrations.Up(pdb.ConnStr(), nil))
+
+	db, err := pgxpool.New(t.Context(), pdb.ConnStr())
+	assert.NilError(t, err)
+	t.Cleanup(db.Close)
+
+	queries := dbsql.New(db)
+	channel, err := queries.InsertDefaultChannel(t.Context(), dbsql.InsertDefaultChannelParams{
+		TwitchID:    1,
+		Name:        "foobar",
+		DisplayName: "FooBar",
+		BotName:     "hortbot",
+	})
+	assert.NilError(t, err)
+
+	insertOld := func(kind string) int64 {
+		id, err := queries.InsertChannelBackup(t.Context(), dbsql.InsertChannelBackupParams{
+			ChannelID: channel.ID,
+			Creator:   "foobar",
+			Kind:      kind,
+			Version:   1,
+			Data:      []byte("{}"),
+		})
+		assert.NilError(t, err)
+
+		_, err = db.Exec(t.Context(), `UPDATE channel_backups SET created_at = now() - interval '30 days' WHERE id = $1`, id)
+		assert.NilError(t, err)
+		return id
+	}
+
+	automatic := insertOld(dbsql.BackupKindAutomatic)
+	manual := insertOld(dbsql.BackupKindManual)
+
+	b := &Bot{
+		db:              db,
+		queries:         queries,
+		deps:            &sharedDeps{},
+		backupRetention: 14 * 24 * time.Hour,
+	}
+
+	assert.NilError(t, b.backupChannels(t.Context()))
+
+	_, err = queries.GetChannelBackup(t.Context(), dbsql.GetChannelBackupParams{ChannelID: channel.ID, ID: automatic})
+	assert.Assert(t, errors.Is(err, pgx.ErrNoRows))
+
+	_, err = queries.GetChannelBackup(t.Context(), dbsql.GetChannelBackupParams{ChannelID: channel.ID, ID: manual})
+	assert.NilError(t, err)
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/hortbot/hortbot/internal/db/botstate"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/emotes"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/extralife"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/hltb"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/lastfm"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/redirects"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/simple"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/steam"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/tinyurl"
@@ -34,8 +37,21 @@ type sharedDeps struct {
 	Urban     urban.API
 	Simple    simple.API
 	HLTB      hltb.API
+	Emotes    emotes.API
+	Redirects redirects.API
 
-	ReCache *recache.RegexpCache
+	// RedirectCache holds the links shortened links were recently resolved
+	// to, keyed by the shortened link.
+	RedirectCache *expirable.LRU[string, string]
+
+	// APITimeout limits requests made by the TEXTAPI and JSONAPI actions,
+	// so a slow API cannot consume the entire handle budget.
+	APITimeout time.Duration
+
+	ReCache        *recache.RegexpCache
+	Matchers       *matcherCache
+	ChatRates      *chatRateTracker
+	RecentMessages *recentMessageBuffer
 
 	// TODO: split these into an interface.
 
@@ -46,6 +62,14 @@ type sharedDeps struct {
 	ReloadRepeats   func(ctx context.Context) error
 	CountRepeats    func(ctx context.Context) (repeats, schedules int, err error)
 
+	ScheduleRaffle   func(ctx context.Context, id int64, at time.Time) error
+	UnscheduleRaffle func(ctx context.Context, id int64) error
+
+	ScheduleChatModeRevert   func(ctx context.Context, id int64, at time.Time) error
+	UnscheduleChatModeRevert func(ctx context.Context, id int64) error
+	AddChatModeSchedule      func(ctx context.Context, id int64, expr *repeat.Cron) error
+	RemoveChatModeSchedule   func(ctx context.Context, id int64) error
+
 	TriggerValidateTokens   func()
 	UpdateModeratedChannels func()
 
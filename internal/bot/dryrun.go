@@ -0,0 +1,320 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+)
+
+// DryRunKind is the kind of thing tested by a dry run.
+type DryRunKind string
+
+// Kinds of dry run.
+const (
+	DryRunCommand   DryRunKind = "command"
+	DryRunAutoreply DryRunKind = "autoreply"
+	DryRunFilter    DryRunKind = "filter"
+)
+
+// dryRun records what a session would have done, in place of doing it.
+type dryRun struct {
+	replies []string
+	skipped []string
+
+	filter   string
+	reason   string
+	shadowed []string
+}
+
+func (d *dryRun) skip(format string, args ...any) {
+	d.skipped = append(d.skipped, fmt.Sprintf(format, args...))
+}
+
+// describe summarizes the replies and skipped actions, using subject to refer
+// to whatever was run.
+func (d *dryRun) describe(subject string) string {
+	var builder strings.Builder
+	builder.WriteString(subject)
+
+	if len(d.replies) == 0 {
+		builder.WriteString(" would not reply.")
+	} else {
+		builder.WriteString(" would reply: ")
+		builder.WriteString(strings.Join(d.replies, " / "))
+	}
+
+	if len(d.skipped) != 0 {
+		builder.WriteString(" (skipped: ")
+		builder.WriteString(strings.Join(d.skipped, ", "))
+		builder.WriteString(")")
+	}
+
+	return builder.String()
+}
+
+// dryRunSession runs fn with a copy of the session which handles message
+// without side effects. Database changes are made in a savepoint which is
+// rolled back afterward, cooldowns are ignored, and replies and moderation
+// actions are recorded instead of being sent to Twitch.
+func (s *session) dryRunSession(ctx context.Context, message string, fn func(ctx context.Context, d *session) error) (*dryRun, error) {
+	savepoint, err := s.tx.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("starting dry run: %w", err)
+	}
+	defer savepoint.Rollback(context.WithoutCancel(ctx)) //nolint:errcheck
+
+	channel := *s.Channel
+
+	d := *s
+	d.Queries = dbsql.New(savepoint)
+	d.tx = savepoint
+	d.Channel = &channel
+	d.Message = message
+	d.Me = false
+	d.Silent = false
+	d.replyMode = replyModeChat
+	d.usageContext = ""
+	d.autoreplyMatch = nil
+	d.builtinUsage = nil
+	d.actionUsage = nil
+	d.eventsubUpdateRequested = false
+	d.matchersChanged = 0
+	d.banListChannels = nil
+	d.cache.links = onced[[]*url.URL]{}
+	d.cache.resolvedLinks = onced[[]*url.URL]{}
+	d.SetCommandParams("")
+	d.dryRun = &dryRun{}
+
+	if err := fn(ctx, &d); err != nil {
+		return nil, err
+	}
+
+	return d.dryRun, nil
+}
+
+// dryRunCommand tests a command with the given arguments, as the current user.
+func dryRunCommand(ctx context.Context, s *session, args string) (string, error) {
+	name, params := splitSpace(args)
+	name = cleanCommandName(name)
+
+	if name == "" {
+		return "", errDryRunUsage
+	}
+
+	info, commandMsg, found, err := s.Queries.LookupCommand(ctx, s.Channel.ID, name, true)
+	if err != nil {
+		return "", fmt.Errorf("finding command: %w", err)
+	}
+
+	if !found {
+		return fmt.Sprintf("Command '%s' does not exist.", name), nil
+	}
+
+	message := strings.TrimSpace(s.Channel.Prefix + name + " " + params)
+
+	run, err := s.dryRunSession(ctx, message, func(ctx context.Context, d *session) error {
+		d.SetCommandParams(params)
+		d.replyMode = d.commandReplyMode(info.ReplyMode)
+
+		if commandMsg.Valid {
+			return runCommandAndCount(ctx, d, info, commandMsg.String, true)
+		}
+
+		_, err := handleList(ctx, d, info, true)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	result := run.describe(s.Channel.Prefix + name)
+	if !info.Enabled {
+		result += " It is currently disabled."
+	}
+	return result, nil
+}
+
+// dryRunAutoreply tests which autoreply would respond to a message sent by a
+// regular viewer.
+func dryRunAutoreply(ctx context.Context, s *session, message string) (string, error) {
+	if message == "" {
+		return "", errDryRunUsage
+	}
+
+	var matched *dbsql.ListAutoreplyMatchersRow
+
+	run, err := s.dryRunSession(ctx, message, func(ctx context.Context, d *session) error {
+		d.UserLevel = AccessLevelEveryone
+
+		m, err := d.Deps.Matchers.Autoreplies(ctx, d.Queries, d.Channel.ID)
+		if err != nil {
+			return err
+		}
+
+		m.set.Each(d.Message, func(i int, re *regexp.Regexp, groups []string) bool {
+			var handled bool
+			handled, err = runAutoreply(ctx, d, &m.autoreplies[i], re, groups)
+			if handled && err == nil {
+				matched = &m.autoreplies[i]
+			}
+			return !handled && err == nil
+		})
+
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if matched == nil {
+		return "No autoreply would respond to that message.", nil
+	}
+
+	return run.describe("Autoreply #" + strconv.Itoa(int(matched.Num))), nil
+}
+
+// dryRunFilters tests whether the filters would catch a message sent by a
+// regular viewer.
+func dryRunFilters(ctx context.Context, s *session, message string) (string, error) {
+	if message == "" {
+		return "", errDryRunUsage
+	}
+
+	if !s.Channel.ShouldModerate || !s.Channel.EnableFilters {
+		return "Filters are disabled.", nil
+	}
+
+	run, err := s.dryRunSession(ctx, message, func(ctx context.Context, d *session) error {
+		d.UserLevel = AccessLevelEveryone
+		_, err := tryFilter(ctx, d)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+
+	if run.filter == "" {
+		if len(run.replies) != 0 {
+			result = "That message would not be filtered; " + strings.Join(run.replies, " / ")
+		} else {
+			result = "That message would not be filtered."
+		}
+	} else {
+		result = fmt.Sprintf("That message would be caught by the %s filter: %s.", filterDisplayName(run.filter), run.reason)
+	}
+
+	if len(run.shadowed) != 0 {
+		noun := "filter"
+		if len(run.shadowed) > 1 {
+			noun = "filters"
+		}
+		result += fmt.Sprintf(" It would be logged by the %s %s in shadow mode.", filterShadowList(run.shadowed), noun)
+	}
+
+	return result, nil
+}
+
+var errDryRunUsage = errors.New("bot: dry run usage")
+
+// DryRun tests a command (given its name and arguments), the autoreplies, or
+// the filters against a message in a channel without side effects, returning
+// the result as it would be described in chat. Commands are run as the
+// channel's broadcaster, and messages are tested as if sent by a regular
+// viewer. The bot need not be initialized.
+func (b *Bot) DryRun(ctx context.Context, channelID int64, kind DryRunKind, input string) (string, error) {
+	input = strings.TrimSpace(input)
+
+	// Without the listener started by Init, cached matchers may be stale.
+	if !b.initialized {
+		b.deps.Matchers.Invalidate(channelID)
+	}
+
+	tx, err := b.db.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("beginning dry run transaction: %w", err)
+	}
+	defer tx.Rollback(context.WithoutCancel(ctx)) //nolint:errcheck
+
+	queries := dbsql.New(tx)
+
+	channel, err := queries.GetChannelByID(ctx, channelID)
+	if err != nil {
+		return "", fmt.Errorf("getting channel: %w", err)
+	}
+
+	s := &session{
+		Type:        sessionNormal,
+		BotLogin:    channel.BotName,
+		M:           dryRunMessage{channel: &channel, text: input},
+		Deps:        b.deps,
+		Queries:     queries,
+		tx:          tx,
+		Start:       time.Now(),
+		ID:          "dry-run",
+		RoomID:      channel.TwitchID,
+		RoomIDOrig:  channel.TwitchID,
+		ChannelName: channel.Name,
+		Message:     input,
+		User:        channel.Name,
+		UserDisplay: channel.DisplayName,
+		UserID:      channel.TwitchID,
+		UserLevel:   AccessLevelBroadcaster,
+		Channel:     &channel,
+	}
+
+	var result string
+
+	switch kind {
+	case DryRunCommand:
+		result, err = dryRunCommand(ctx, s, input)
+	case DryRunAutoreply:
+		result, err = dryRunAutoreply(ctx, s, input)
+	case DryRunFilter:
+		result, err = dryRunFilters(ctx, s, input)
+	default:
+		return "", fmt.Errorf("unknown dry run kind %q", kind)
+	}
+
+	if errors.Is(err, errDryRunUsage) {
+		return "Nothing to test.", nil
+	}
+	return result, err
+}
+
+// dryRunMessage is the message used for dry runs which do not come from chat.
+type dryRunMessage struct {
+	channel *dbsql.Channel
+	text    string
+}
+
+var _ Message = dryRunMessage{}
+
+func (m dryRunMessage) MarshalJSON() ([]byte, error)  { return json.Marshal(m.text) }
+func (m dryRunMessage) Bot() string                   { return m.channel.BotName }
+func (dryRunMessage) MessageID() string               { return "dry-run" }
+func (dryRunMessage) MessageTimestamp() time.Time     { return time.Time{} }
+func (m dryRunMessage) Broadcaster() ChatIdentity     { return m.identity() }
+func (m dryRunMessage) Chatter() ChatIdentity         { return m.identity() }
+func (m dryRunMessage) Text() string                  { return m.text }
+func (dryRunMessage) IsAction() bool                  { return false }
+func (dryRunMessage) CountEmotes() int                { return 0 }
+func (dryRunMessage) Emotes() []string                { return nil }
+func (dryRunMessage) ChatterAccessLevel() AccessLevel { return AccessLevelBroadcaster }
+
+func (m dryRunMessage) identity() ChatIdentity {
+	return ChatIdentity{
+		ID:          m.channel.TwitchID,
+		Login:       m.channel.Name,
+		DisplayName: m.channel.DisplayName,
+	}
+}
@@ -0,0 +1,119 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/emotes"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// fetchGlobalEmotes fetches each provider's global emotes. Providers which
+// fail are logged and omitted.
+func fetchGlobalEmotes(ctx context.Context, api emotes.API) map[emotes.Provider][]string {
+	globals := make(map[emotes.Provider][]string, len(emotes.Providers))
+	for _, provider := range emotes.Providers {
+		names, err := api.GlobalEmotes(ctx, provider)
+		if err != nil {
+			ctxlog.Warn(ctx, "error fetching global emotes", zap.Error(err), zap.String("provider", string(provider)))
+			continue
+		}
+		globals[provider] = names
+	}
+	return globals
+}
+
+// fetchEmoteSets fetches a channel's emotes from each provider, merged with
+// the provider's global emotes. Providers which fail, or whose global emotes
+// could not be fetched, are logged and omitted so their previous sets are
+// kept.
+func fetchEmoteSets(ctx context.Context, api emotes.API, twitchID int64, globals map[emotes.Provider][]string) map[emotes.Provider][]string {
+	sets := make(map[emotes.Provider][]string, len(globals))
+	for _, provider := range emotes.Providers {
+		global, ok := globals[provider]
+		if !ok {
+			continue
+		}
+
+		names, err := api.ChannelEmotes(ctx, provider, twitchID)
+		if err != nil {
+			ctxlog.Warn(ctx, "error fetching channel emotes", zap.Error(err), zap.String("provider", string(provider)))
+			continue
+		}
+
+		names = append(slices.Clone(names), global...)
+		slices.Sort(names)
+		sets[provider] = slices.Compact(names)
+	}
+	return sets
+}
+
+func saveEmoteSets(ctx context.Context, queries *dbsql.Queries, channelID int64, sets map[emotes.Provider][]string) error {
+	for _, provider := range emotes.Providers {
+		names, ok := sets[provider]
+		if !ok {
+			continue
+		}
+
+		if err := queries.UpsertChannelEmoteSet(ctx, dbsql.UpsertChannelEmoteSetParams{
+			ChannelID: channelID,
+			Provider:  string(provider),
+			Emotes:    names,
+		}); err != nil {
+			return fmt.Errorf("saving emote set: %w", err)
+		}
+	}
+	return nil
+}
+
+// ThirdPartyEmotes returns each word of the message which is one of the
+// channel's third-party emotes. Words already sent as Twitch emotes are
+// excluded, so they are not counted twice.
+func (s *session) ThirdPartyEmotes(ctx context.Context) ([]string, error) {
+	return s.cache.emotes.get(func() ([]string, error) {
+		set, err := s.Deps.Matchers.Emotes(ctx, s.Queries, s.Channel.ID)
+		if err != nil || len(set) == 0 {
+			return nil, err
+		}
+
+		twitchEmotes := s.M.Emotes()
+
+		var found []string
+		for _, word := range strings.Fields(s.Message) {
+			if set[word] && !slices.Contains(twitchEmotes, word) {
+				found = append(found, word)
+			}
+		}
+		return found, nil
+	})
+}
+
+// trackEmotes counts the emotes used in the message towards the channel's
+// emote leaderboard.
+func trackEmotes(ctx context.Context, s *session) error {
+	if !s.Channel.TrackEmotes {
+		return nil
+	}
+
+	thirdParty, err := s.ThirdPartyEmotes(ctx)
+	if err != nil {
+		return err
+	}
+
+	used := append(slices.Clone(s.M.Emotes()), thirdParty...)
+	if len(used) == 0 {
+		return nil
+	}
+
+	if err := s.Queries.AddChannelEmoteUsage(ctx, dbsql.AddChannelEmoteUsageParams{
+		ChannelID: s.Channel.ID,
+		Emotes:    used,
+	}); err != nil {
+		return fmt.Errorf("adding emote usage: %w", err)
+	}
+	return nil
+}
@@ -41,6 +41,107 @@ func ToMessage(botLoginMap map[int64]string, m *eventsub.WebsocketMessage) bot.M
 	}
 }
 
+// ToRaid converts a channel.raid notification into an incoming raid.
+func ToRaid(m *eventsub.WebsocketMessage) *bot.Raid {
+	if m == nil {
+		return nil
+	}
+
+	notification := m.Payload.(*eventsub.NotificationPayload)
+	event := notification.Event.(*eventsub.ChannelRaidEvent)
+
+	return &bot.Raid{
+		Broadcaster: bot.ChatIdentity{
+			ID:          int64(event.ToBroadcasterUserID),
+			Login:       event.ToBroadcasterUserLogin,
+			DisplayName: event.ToBroadcasterUserName,
+		},
+		Raider: bot.ChatIdentity{
+			ID:          int64(event.FromBroadcasterUserID),
+			Login:       event.FromBroadcasterUserLogin,
+			DisplayName: event.FromBroadcasterUserName,
+		},
+		Viewers: event.Viewers,
+	}
+}
+
+func ToPollResult(m *eventsub.WebsocketMessage) *bot.PollResult {
+	if m == nil {
+		return nil
+	}
+
+	notification := m.Payload.(*eventsub.NotificationPayload)
+	event := notification.Event.(*eventsub.ChannelPollEndEvent)
+
+	choices := make([]bot.PollChoice, len(event.Choices))
+	for i, c := range event.Choices {
+		choices[i] = bot.PollChoice{
+			Title: c.Title,
+			Votes: c.Votes,
+		}
+	}
+
+	return &bot.PollResult{
+		Broadcaster: bot.ChatIdentity{
+			ID:          int64(event.BroadcasterUserID),
+			Login:       event.BroadcasterUserLogin,
+			DisplayName: event.BroadcasterUserName,
+		},
+		Title:   event.Title,
+		Status:  event.Status,
+		Choices: choices,
+	}
+}
+
+func ToPredictionResult(m *eventsub.WebsocketMessage) *bot.PredictionResult {
+	if m == nil {
+		return nil
+	}
+
+	notification := m.Payload.(*eventsub.NotificationPayload)
+	event := notification.Event.(*eventsub.ChannelPredictionEndEvent)
+
+	outcomes := make([]bot.PredictionOutcome, len(event.Outcomes))
+	for i, o := range event.Outcomes {
+		outcomes[i] = bot.PredictionOutcome{
+			ID:            o.ID,
+			Title:         o.Title,
+			Users:         o.Users,
+			ChannelPoints: o.ChannelPoints,
+		}
+	}
+
+	return &bot.PredictionResult{
+		Broadcaster: bot.ChatIdentity{
+			ID:          int64(event.BroadcasterUserID),
+			Login:       event.BroadcasterUserLogin,
+			DisplayName: event.BroadcasterUserName,
+		},
+		Title:            event.Title,
+		Status:           event.Status,
+		WinningOutcomeID: event.WinningOutcomeID,
+		Outcomes:         outcomes,
+	}
+}
+
+// ToStreamOffline converts a stream.offline notification.
+func ToStreamOffline(m *eventsub.WebsocketMessage) *bot.StreamOffline {
+	if m == nil {
+		return nil
+	}
+
+	notification := m.Payload.(*eventsub.NotificationPayload)
+	event := notification.Event.(*eventsub.StreamOfflineEvent)
+
+	return &bot.StreamOffline{
+		Broadcaster: bot.ChatIdentity{
+			ID:          int64(event.BroadcasterUserID),
+			Login:       event.BroadcasterUserLogin,
+			DisplayName: event.BroadcasterUserName,
+		},
+	}
+}
+
 func (m *chatMessage) MarshalJSON() ([]byte, error) {
 	data, err := json.Marshal(struct {
 		BotLogin string                     `json:"bot_login"`
@@ -87,6 +188,16 @@ func (m *chatMessage) CountEmotes() int {
 	return count
 }
 
+func (m *chatMessage) Emotes() []string {
+	var emotes []string
+	for _, fragment := range m.event.Message.Fragments {
+		if fragment.Type == "emote" {
+			emotes = append(emotes, fragment.Text)
+		}
+	}
+	return emotes
+}
+
 func (m *chatMessage) ChatterAccessLevel() bot.AccessLevel {
 	return accessLevel(m.event)
 }
@@ -22,6 +22,132 @@ func TestMessage(t *testing.T) {
 	assert.Equal(t, msg.IsAction(), true)
 }
 
+func TestRaid(t *testing.T) {
+	t.Parallel()
+
+	raid := eventsubtobot.ToRaid(&eventsub.WebsocketMessage{
+		Metadata: &eventsub.WebsocketMessageMetadata{MessageTimestamp: time.Now()},
+		Payload: &eventsub.NotificationPayload{
+			Subscription: &eventsub.Subscription{
+				Type:      eventsub.ChannelRaidSubscriptionType,
+				Condition: &eventsub.ChannelRaidSubscriptionCondition{ToBroadcasterUserID: 1},
+			},
+			Event: &eventsub.ChannelRaidEvent{
+				FromBroadcasterUserID:    idstr.IDStr(2),
+				FromBroadcasterUserLogin: "raider",
+				FromBroadcasterUserName:  "Raider",
+				ToBroadcasterUserID:      idstr.IDStr(1),
+				ToBroadcasterUserLogin:   "channel",
+				ToBroadcasterUserName:    "Channel",
+				Viewers:                  42,
+			},
+		},
+	})
+
+	assert.DeepEqual(t, raid, &bot.Raid{
+		Broadcaster: bot.ChatIdentity{ID: 1, Login: "channel", DisplayName: "Channel"},
+		Raider:      bot.ChatIdentity{ID: 2, Login: "raider", DisplayName: "Raider"},
+		Viewers:     42,
+	})
+}
+
+func TestPollResult(t *testing.T) {
+	t.Parallel()
+
+	result := eventsubtobot.ToPollResult(&eventsub.WebsocketMessage{
+		Metadata: &eventsub.WebsocketMessageMetadata{MessageTimestamp: time.Now()},
+		Payload: &eventsub.NotificationPayload{
+			Subscription: &eventsub.Subscription{
+				Type:      eventsub.ChannelPollEndSubscriptionType,
+				Condition: &eventsub.BroadcasterSubscriptionCondition{BroadcasterUserID: 1},
+			},
+			Event: &eventsub.ChannelPollEndEvent{
+				ID:                   "poll",
+				BroadcasterUserID:    idstr.IDStr(1),
+				BroadcasterUserLogin: "channel",
+				BroadcasterUserName:  "Channel",
+				Title:                "Best fruit?",
+				Choices: []eventsub.ChannelPollEndEventChoice{
+					{ID: "1", Title: "Apple", Votes: 3},
+					{ID: "2", Title: "Banana", Votes: 5},
+				},
+				Status: "completed",
+			},
+		},
+	})
+
+	assert.DeepEqual(t, result, &bot.PollResult{
+		Broadcaster: bot.ChatIdentity{ID: 1, Login: "channel", DisplayName: "Channel"},
+		Title:       "Best fruit?",
+		Status:      "completed",
+		Choices: []bot.PollChoice{
+			{Title: "Apple", Votes: 3},
+			{Title: "Banana", Votes: 5},
+		},
+	})
+}
+
+func TestPredictionResult(t *testing.T) {
+	t.Parallel()
+
+	result := eventsubtobot.ToPredictionResult(&eventsub.WebsocketMessage{
+		Metadata: &eventsub.WebsocketMessageMetadata{MessageTimestamp: time.Now()},
+		Payload: &eventsub.NotificationPayload{
+			Subscription: &eventsub.Subscription{
+				Type:      eventsub.ChannelPredictionEndSubscriptionType,
+				Condition: &eventsub.BroadcasterSubscriptionCondition{BroadcasterUserID: 1},
+			},
+			Event: &eventsub.ChannelPredictionEndEvent{
+				ID:                   "prediction",
+				BroadcasterUserID:    idstr.IDStr(1),
+				BroadcasterUserLogin: "channel",
+				BroadcasterUserName:  "Channel",
+				Title:                "Will we win?",
+				WinningOutcomeID:     "1",
+				Outcomes: []eventsub.ChannelPredictionEndEventOutcome{
+					{ID: "1", Title: "Yes", Color: "blue", Users: 2, ChannelPoints: 500},
+					{ID: "2", Title: "No", Color: "pink", Users: 1, ChannelPoints: 100},
+				},
+				Status: "resolved",
+			},
+		},
+	})
+
+	assert.DeepEqual(t, result, &bot.PredictionResult{
+		Broadcaster:      bot.ChatIdentity{ID: 1, Login: "channel", DisplayName: "Channel"},
+		Title:            "Will we win?",
+		Status:           "resolved",
+		WinningOutcomeID: "1",
+		Outcomes: []bot.PredictionOutcome{
+			{ID: "1", Title: "Yes", Users: 2, ChannelPoints: 500},
+			{ID: "2", Title: "No", Users: 1, ChannelPoints: 100},
+		},
+	})
+}
+
+func TestStreamOffline(t *testing.T) {
+	t.Parallel()
+
+	offline := eventsubtobot.ToStreamOffline(&eventsub.WebsocketMessage{
+		Metadata: &eventsub.WebsocketMessageMetadata{MessageTimestamp: time.Now()},
+		Payload: &eventsub.NotificationPayload{
+			Subscription: &eventsub.Subscription{
+				Type:      eventsub.StreamOfflineSubscriptionType,
+				Condition: &eventsub.BroadcasterSubscriptionCondition{BroadcasterUserID: 1},
+			},
+			Event: &eventsub.StreamOfflineEvent{
+				BroadcasterUserID:    idstr.IDStr(1),
+				BroadcasterUserLogin: "channel",
+				BroadcasterUserName:  "Channel",
+			},
+		},
+	})
+
+	assert.DeepEqual(t, offline, &bot.StreamOffline{
+		Broadcaster: bot.ChatIdentity{ID: 1, Login: "channel", DisplayName: "Channel"},
+	})
+}
+
 func TestUserAccessLevel(t *testing.T) {
 	t.Parallel()
 
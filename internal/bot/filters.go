@@ -2,11 +2,14 @@ package bot
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/pkg/linkmatch"
 )
 
@@ -20,6 +23,25 @@ var filters = []func(context.Context, *session) (filtered bool, err error){
 	filterBannedPhrases,
 }
 
+// filterKeys maps the names used by the filter command to the keys used to
+// identify each filter when punishing or recording a message.
+var filterKeys = map[string]string{
+	"me":            "me",
+	"messagelength": "max_length",
+	"links":         "links",
+	"emotes":        "emotes",
+	"caps":          "caps",
+	"symbols":       "symbols",
+	"banphrase":     "banned_phrase",
+}
+
+const filterShadowPrefix = "shadow_"
+
+// filterDisplayName returns the human readable name of a filter key.
+func filterDisplayName(filter string) string {
+	return strings.ReplaceAll(filter, "_", " ")
+}
+
 func tryFilter(ctx context.Context, s *session) (filtered bool, err error) {
 	if !s.Channel.ShouldModerate || !s.Channel.EnableFilters {
 		return false, nil
@@ -40,37 +62,75 @@ func filterMe(ctx context.Context, s *session) (filtered bool, err error) {
 		return false, nil
 	}
 
-	if s.UserLevel.CanAccess(s.FilterExemptLevel()) {
-		return false, nil
+	if exempt, err := filterExempt(ctx, s, "me"); exempt || err != nil {
+		return false, err
 	}
 
-	return true, filterDoPunish(ctx, s, "me", "\"/me\" is not allowed in this channel")
+	return filterDoPunish(ctx, s, "me", "\"/me\" is not allowed in this channel")
 }
 
 func filterLinks(ctx context.Context, s *session) (filtered bool, err error) {
-	if !s.Channel.FilterLinks {
+	links := s.Links(ctx)
+
+	if len(links) == 0 {
 		return false, nil
 	}
 
-	minLevel := AccessLevelModerator
-	if s.Channel.SubsMayLink {
-		minLevel = AccessLevelSubscriber
+	// Moderators may always post links.
+	if s.UserLevel.CanAccess(AccessLevelModerator) {
+		return false, nil
+	}
+
+	resolved := s.ResolvedLinks(ctx)
+
+	// Blocked links are removed even when the link filter is off, and
+	// regardless of the filter's exempt level, custom regulars, and permits.
+	blocked, err := anyLinkBlocked(ctx, s, links, resolved)
+	if err != nil {
+		return false, err
+	}
+
+	if blocked {
+		return filterDoPunishWith(ctx, s, "links", "that link is not allowed", nil)
 	}
 
-	if s.UserLevel.CanAccess(minLevel) {
+	if s.FirstChat && s.Channel.FilterLinksFirstMessage {
+		return filterFirstMessageLinks(ctx, s, resolved)
+	}
+
+	if !s.Channel.FilterLinks {
 		return false, nil
 	}
 
+	if exempt, err := filterExempt(ctx, s, "links"); exempt || err != nil {
+		return false, err
+	}
+
 	if _, found := stringSliceIndex(s.Channel.CustomRegulars, s.User); found {
 		return false, nil
 	}
 
-	links := s.Links(ctx)
-
-	if len(links) == 0 {
+	if allLinksPermitted(s.Channel.PermittedLinks, resolved) {
 		return false, nil
 	}
 
+	permitted, err := s.HasLinkPermit(ctx, s.User)
+	if err != nil {
+		return false, err
+	}
+
+	if permitted {
+		return false, s.Replyf(ctx, "Link permitted. (%s)", s.UserDisplay)
+	}
+
+	return filterDoPunish(ctx, s, "links", "please ask a moderator before posting links")
+}
+
+// filterFirstMessageLinks holds back links in a user's first message in the
+// channel. Only permitted link patterns and permits let them through; the
+// exempt level and custom regulars do not apply. Held messages are deleted
+// rather than punished, as the user has not yet had a chance to be warned.
+func filterFirstMessageLinks(ctx context.Context, s *session, links []*url.URL) (filtered bool, err error) {
 	if allLinksPermitted(s.Channel.PermittedLinks, links) {
 		return false, nil
 	}
@@ -84,7 +144,50 @@ func filterLinks(ctx context.Context, s *session) (filtered bool, err error) {
 		return false, s.Replyf(ctx, "Link permitted. (%s)", s.UserDisplay)
 	}
 
-	return true, filterDoPunish(ctx, s, "links", "please ask a moderator before posting links")
+	return filterDoPunishWith(ctx, s, "links", "please chat for a bit before posting links", func(settings *dbsql.ChannelFilter) {
+		settings.Action = filterActionDelete
+	})
+}
+
+// anyLinkBlocked returns true if any of the links, either as posted or as
+// resolved, are on the global domain blocklist or match one of the channel's
+// blocked link patterns.
+func anyLinkBlocked(ctx context.Context, s *session, links, resolved []*url.URL) (bool, error) {
+	all := slices.Concat(links, resolved)
+
+	for _, pattern := range s.Channel.BlockedLinks {
+		for _, u := range all {
+			if linkmatch.HostAndPath(pattern, u) {
+				return true, nil
+			}
+		}
+	}
+
+	if len(all) == 0 {
+		return false, nil
+	}
+
+	domains, err := s.BlockedDomains(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, d := range domains {
+		for _, u := range all {
+			if domainMatches(d.Domain, u) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// domainMatches returns true if the URL's host is the domain or one of its
+// subdomains.
+func domainMatches(domain string, u *url.URL) bool {
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	return host == domain || strings.HasSuffix(host, "."+domain)
 }
 
 func allLinksPermitted(permitted []string, urls []*url.URL) bool {
@@ -137,8 +240,8 @@ func filterCaps(ctx context.Context, s *session) (filtered bool, err error) {
 		return false, nil
 	}
 
-	if s.UserLevel.CanAccess(s.FilterExemptLevel()) {
-		return false, nil
+	if exempt, err := filterExempt(ctx, s, "caps"); exempt || err != nil {
+		return false, err
 	}
 
 	message := s.Message
@@ -169,7 +272,7 @@ func filterCaps(ctx context.Context, s *session) (filtered bool, err error) {
 		return false, nil
 	}
 
-	return true, filterDoPunish(ctx, s, "caps", "please don't shout or talk in all caps")
+	return filterDoPunish(ctx, s, "caps", "please don't shout or talk in all caps")
 }
 
 var symbolFuncs = []func(rune) bool{
@@ -184,8 +287,8 @@ func filterSymbols(ctx context.Context, s *session) (filtered bool, err error) {
 		return false, nil
 	}
 
-	if s.UserLevel.CanAccess(s.FilterExemptLevel()) {
-		return false, nil
+	if exempt, err := filterExempt(ctx, s, "symbols"); exempt || err != nil {
+		return false, err
 	}
 
 	message := withoutSpaces(s.Message)
@@ -214,7 +317,7 @@ func filterSymbols(ctx context.Context, s *session) (filtered bool, err error) {
 		return false, nil
 	}
 
-	return true, filterDoPunish(ctx, s, "symbols", "please don't spam symbols")
+	return filterDoPunish(ctx, s, "symbols", "please don't spam symbols")
 }
 
 func filterLength(ctx context.Context, s *session) (filtered bool, err error) {
@@ -222,15 +325,15 @@ func filterLength(ctx context.Context, s *session) (filtered bool, err error) {
 		return false, nil
 	}
 
-	if s.UserLevel.CanAccess(s.FilterExemptLevel()) {
-		return false, nil
+	if exempt, err := filterExempt(ctx, s, "max_length"); exempt || err != nil {
+		return false, err
 	}
 
 	if utf8.RuneCountInString(s.Message) < int(s.Channel.FilterMaxLength) {
 		return false, nil
 	}
 
-	return true, filterDoPunish(ctx, s, "max_length", "please don't spam long messages")
+	return filterDoPunish(ctx, s, "max_length", "please don't spam long messages")
 }
 
 func filterEmotes(ctx context.Context, s *session) (filtered bool, err error) {
@@ -238,20 +341,24 @@ func filterEmotes(ctx context.Context, s *session) (filtered bool, err error) {
 		return false, nil
 	}
 
-	if s.UserLevel.CanAccess(s.FilterExemptLevel()) {
-		return false, nil
+	if exempt, err := filterExempt(ctx, s, "emotes"); exempt || err != nil {
+		return false, err
+	}
+
+	thirdParty, err := s.ThirdPartyEmotes(ctx)
+	if err != nil {
+		return false, err
 	}
 
-	// TODO: BTTV/FFZ emotes.
-	count := s.M.CountEmotes()
+	count := s.M.CountEmotes() + len(thirdParty)
 
 	if count > int(s.Channel.FilterEmotesMax) {
-		return true, filterDoPunish(ctx, s, "emotes", "please don't spam emotes")
+		return filterDoPunish(ctx, s, "emotes", "please don't spam emotes")
 	}
 
 	// If the count is 1 and the message has no whitespace, then it's a single emote message.
 	if count == 1 && s.Channel.FilterEmotesSingle && !containsSpace(s.Message) {
-		return true, filterDoPunish(ctx, s, "emotes", "single emote messages are not allowed")
+		return filterDoPunish(ctx, s, "emotes", "single emote messages are not allowed")
 	}
 
 	return false, nil
@@ -262,19 +369,12 @@ func filterBannedPhrases(ctx context.Context, s *session) (filtered bool, err er
 		return false, nil
 	}
 
-	if s.UserLevel.CanAccess(s.FilterExemptLevel()) {
-		return false, nil
+	if exempt, err := filterExempt(ctx, s, "banned_phrase"); exempt || err != nil {
+		return false, err
 	}
 
-	for _, pattern := range s.Channel.FilterBannedPhrasesPatterns {
-		re, err := s.Deps.ReCache.Compile(pattern)
-		if err != nil {
-			continue
-		}
-
-		if re.MatchString(s.Message) {
-			return true, filterDoPunish(ctx, s, "banned_phrase", "disallowed word or phrase")
-		}
+	if s.Deps.Matchers.BannedPhrases(s.Channel).MatchString(s.Message) {
+		return filterDoPunish(ctx, s, "banned_phrase", "disallowed word or phrase")
 	}
 
 	return false, nil
@@ -298,40 +398,193 @@ func containsSpace(s string) bool {
 	return false
 }
 
-func filterDoPunish(ctx context.Context, s *session, filter, message string) error {
-	if s.Channel.EnableWarnings {
+// Actions a filter may take against a message it catches.
+const (
+	filterActionDelete  = "delete"
+	filterActionTimeout = "timeout"
+	filterActionBan     = "ban"
+)
+
+// filterSettings returns a filter's settings for the channel. Filters which
+// have never been configured separately use the channel-wide settings.
+func filterSettings(ctx context.Context, s *session, filter string) (dbsql.ChannelFilter, error) {
+	settings, err := s.Deps.Matchers.Filters(ctx, s.Queries, s.Channel.ID)
+	if err != nil {
+		return dbsql.ChannelFilter{}, err
+	}
+
+	if f, ok := settings[filter]; ok {
+		return f, nil
+	}
+
+	return defaultFilterSettings(s.Channel, filter), nil
+}
+
+func defaultFilterSettings(channel *dbsql.Channel, filter string) dbsql.ChannelFilter {
+	exempt := channel.FilterExemptLevel
+
+	// For historical reasons, link filtering is controlled by subsMayLink.
+	if filter == "links" {
+		exempt = dbsql.AccessLevelModerator
+		if channel.SubsMayLink {
+			exempt = dbsql.AccessLevelSubscriber
+		}
+	}
+
+	return dbsql.ChannelFilter{
+		ChannelID:   channel.ID,
+		Filter:      filter,
+		ExemptLevel: exempt,
+		Action:      filterActionTimeout,
+		Duration:    channel.TimeoutDuration,
+	}
+}
+
+func filterExempt(ctx context.Context, s *session, filter string) (bool, error) {
+	settings, err := filterSettings(ctx, s, filter)
+	if err != nil {
+		return false, err
+	}
+	return s.UserLevel.CanAccess(newAccessLevel(settings.ExemptLevel)), nil
+}
+
+func filterDoPunish(ctx context.Context, s *session, filter, message string) (filtered bool, err error) {
+	settings, err := filterSettings(ctx, s, filter)
+	if err != nil {
+		return false, err
+	}
+
+	if settings.Message != "" {
+		message = settings.Message
+	}
+
+	return filterPunish(ctx, s, settings, message)
+}
+
+// filterDoPunishWith punishes the message like filterDoPunish, but always
+// uses the given message, and lets the caller adjust the filter's settings
+// for this message only.
+func filterDoPunishWith(ctx context.Context, s *session, filter, message string, adjust func(*dbsql.ChannelFilter)) (filtered bool, err error) {
+	settings, err := filterSettings(ctx, s, filter)
+	if err != nil {
+		return false, err
+	}
+
+	if adjust != nil {
+		adjust(&settings)
+	}
+
+	return filterPunish(ctx, s, settings, message)
+}
+
+func filterPunish(ctx context.Context, s *session, settings dbsql.ChannelFilter, message string) (filtered bool, err error) {
+	filter := settings.Filter
+
+	shadow := slices.Contains(s.Channel.FilterShadow, filter)
+
+	if s.dryRun != nil {
+		if shadow {
+			s.dryRun.shadowed = append(s.dryRun.shadowed, filter)
+			return false, nil
+		}
+		s.dryRun.filter = filter
+		s.dryRun.reason = message
+		return true, nil
+	}
+
+	if shadow {
+		return false, filterRecordShadowHit(ctx, s, settings)
+	}
+
+	warn := settings.Action == filterActionDelete
+
+	if !warn && s.Channel.EnableWarnings {
 		warned, err := s.FilterWarned(ctx, s.User, filter)
 		if err != nil {
-			return err
+			return true, err
 		}
+		warn = !warned
+	}
 
-		if !warned {
-			if err := s.DeleteMessage(ctx); err != nil {
-				return err
-			}
-
-			if s.Channel.DisplayWarnings {
-				return s.Replyf(ctx, "%s, %s - warning", s.UserDisplay, message)
-			}
+	if warn {
+		if err := s.DeleteMessage(ctx); err != nil {
+			return true, err
+		}
 
-			return nil
+		if s.Channel.DisplayWarnings {
+			return true, s.Replyf(ctx, "%s, %s - warning", s.UserDisplay, message)
 		}
+
+		return true, nil
 	}
 
-	var err error
+	punishment := "timeout"
+	duration := filterTimeoutDuration(settings)
 
-	if s.Channel.TimeoutDuration == 0 {
-		err = s.BanByID(ctx, s.UserID, 600, message)
-	} else {
-		err = s.BanByID(ctx, s.UserID, int64(s.Channel.TimeoutDuration), message)
+	if settings.Action == filterActionBan {
+		punishment = "ban"
+		duration = 0
 	}
 
-	if err != nil {
-		return err
+	if err := s.BanByID(ctx, s.UserID, duration, message); err != nil {
+		return true, err
 	}
 
 	if s.Channel.DisplayWarnings {
-		return s.Replyf(ctx, "%s, %s - timeout", s.UserDisplay, message)
+		return true, s.Replyf(ctx, "%s, %s - %s", s.UserDisplay, message, punishment)
+	}
+
+	return true, nil
+}
+
+func filterTimeoutDuration(settings dbsql.ChannelFilter) int64 {
+	if settings.Duration == 0 {
+		return 600
+	}
+	return int64(settings.Duration)
+}
+
+// describeFilterAction describes what a filter does to the messages it
+// catches, after any warning.
+func describeFilterAction(settings dbsql.ChannelFilter) string {
+	switch settings.Action {
+	case filterActionDelete:
+		return "delete"
+	case filterActionBan:
+		return "ban"
+	default:
+		return fmt.Sprintf("timeout for %d seconds", filterTimeoutDuration(settings))
+	}
+}
+
+// filterRecordShadowHit records what a filter in shadow mode would have done
+// to the message, without doing it. Warnings are tracked separately from
+// those given by enforced filters, so that switching a filter out of shadow
+// mode starts with a clean slate.
+func filterRecordShadowHit(ctx context.Context, s *session, settings dbsql.ChannelFilter) error {
+	punishment := describeFilterAction(settings)
+
+	if settings.Action != filterActionDelete && s.Channel.EnableWarnings {
+		warned, err := s.FilterWarned(ctx, s.User, filterShadowPrefix+settings.Filter)
+		if err != nil {
+			return err
+		}
+
+		if !warned {
+			punishment = "warning"
+		}
+	}
+
+	err := s.Queries.InsertFilterShadowHit(ctx, dbsql.InsertFilterShadowHitParams{
+		ChannelID:  s.Channel.ID,
+		Filter:     settings.Filter,
+		UserID:     s.UserID,
+		UserName:   s.User,
+		Message:    s.Message,
+		Punishment: punishment,
+	})
+	if err != nil {
+		return fmt.Errorf("recording shadow filter hit: %w", err)
 	}
 
 	return nil
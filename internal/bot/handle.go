@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -160,8 +161,10 @@ func (b *Bot) handleChatMessage(ctx context.Context, m Message, enqueuedAt time.
 			beforeHandle = time.Now()
 
 			s.Queries = dbsql.New(tx)
+			s.tx = tx
 			defer func() {
 				s.Queries = nil
+				s.tx = nil
 			}()
 
 			err := handleSession(ctx, s)
@@ -218,6 +221,21 @@ func (b *Bot) handleChatMessage(ctx context.Context, m Message, enqueuedAt time.
 }
 
 func (b *Bot) flushDeferred(ctx context.Context, s *session) {
+	if s.matchersChanged != 0 {
+		b.deps.Matchers.Invalidate(s.matchersChanged)
+	}
+
+	if len(s.banListChannels) != 0 {
+		// Banning in every subscribed channel may take a while, so it happens in
+		// the background rather than holding up this channel's messages. Bans
+		// left queued if the bot stops are applied on the next start.
+		channelIDs := s.banListChannels
+		b.g.Go(func(ctx context.Context) error {
+			b.applyBanListBans(ctx, channelIDs)
+			return nil
+		})
+	}
+
 	if s.eventsubUpdateRequested {
 		if err := b.deps.EventsubUpdateNotifier.NotifyEventsubUpdates(ctx, b.queries); err != nil {
 			ctxlog.Error(ctx, "error notifying EventSub updates", zap.Error(err))
@@ -393,6 +411,18 @@ func handleSession(ctx context.Context, s *session) error {
 		ignored = false
 	}
 
+	firstChat, err := trackChatter(ctx, s)
+	if err != nil {
+		return err
+	}
+	s.FirstChat = firstChat
+
+	if err := trackChatRate(ctx, s); err != nil {
+		return err
+	}
+
+	trackRecentMessage(s)
+
 	if filtered, err := tryFilter(ctx, s); filtered || err != nil {
 		return err
 	}
@@ -414,6 +444,16 @@ func handleSession(ctx context.Context, s *session) error {
 		return fmt.Errorf("updating channel: %w", err)
 	}
 
+	if err := trackEmotes(ctx, s); err != nil {
+		return err
+	}
+
+	if firstChat {
+		if err := tryFirstChatCommand(ctx, s); err != nil {
+			return err
+		}
+	}
+
 	if ok, err := tryCommand(ctx, s); ok || err != nil {
 		switch {
 		case err == nil:
@@ -426,6 +466,10 @@ func handleSession(ctx context.Context, s *session) error {
 		}
 	}
 
+	if ok, err := tryRaffleKeyword(ctx, s); ok || err != nil {
+		return err
+	}
+
 	if s.Channel.ParseYoutube && s.Deps.YouTube != nil {
 		for _, u := range s.Links(ctx) {
 			title := s.Deps.YouTube.VideoTitle(ctx, u)
@@ -510,7 +554,9 @@ func tryCommand(ctx context.Context, s *session) (bool, error) {
 		return true, fmt.Errorf("finding command: %w", err)
 	}
 
-	if !found {
+	// Disabled commands behave as though they don't exist, so a builtin
+	// with the same name becomes reachable again.
+	if !found || !info.Enabled {
 		return tryBuiltinCommand(ctx, s, name, params)
 	}
 
@@ -518,6 +564,8 @@ func tryCommand(ctx context.Context, s *session) (bool, error) {
 		return false, errNotAuthorized
 	}
 
+	s.replyMode = s.commandReplyMode(info.ReplyMode)
+
 	if commandMsg.Valid {
 		return handleCustomCommand(ctx, s, info, commandMsg.String, thisChannel)
 	}
@@ -531,5 +579,25 @@ func tryBuiltinCommand(ctx context.Context, s *session, cmd string, args string)
 		cmd = cleanCommandName(cmd)
 	}
 
+	if isBuiltinName(cmd) {
+		mode := ""
+		if slices.Contains(s.Channel.WhisperBuiltins, cmd) {
+			mode = replyModeWhisper
+		}
+		s.replyMode = s.commandReplyMode(mode)
+	}
+
 	return builtinCommands.RunWithCooldown(ctx, s, cmd, args)
 }
+
+// commandReplyMode returns the reply mode for a command, falling back to the
+// channel's default when the command does not set one.
+func (s *session) commandReplyMode(mode string) string {
+	if mode != "" {
+		return mode
+	}
+	if s.Channel.ThreadedReplies {
+		return replyModeReply
+	}
+	return replyModeChat
+}
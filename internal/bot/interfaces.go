@@ -17,6 +17,51 @@ type ChatIdentity struct {
 	DisplayName string
 }
 
+// Raid is an incoming raid on a channel.
+type Raid struct {
+	Broadcaster ChatIdentity
+	Raider      ChatIdentity
+	Viewers     int
+}
+
+// StreamOffline is a notification that a channel's stream has ended.
+type StreamOffline struct {
+	Broadcaster ChatIdentity
+}
+
+// PollResult is the final state of a poll which has ended.
+type PollResult struct {
+	Broadcaster ChatIdentity
+	Title       string
+	Status      string
+	Choices     []PollChoice
+}
+
+// PollChoice is one of a poll's choices and its vote count.
+type PollChoice struct {
+	Title string
+	Votes int
+}
+
+// PredictionResult is the final state of a prediction which has been
+// resolved or canceled.
+type PredictionResult struct {
+	Broadcaster      ChatIdentity
+	Title            string
+	Status           string
+	WinningOutcomeID string
+	Outcomes         []PredictionOutcome
+}
+
+// PredictionOutcome is one of a prediction's outcomes and the channel points
+// wagered on it.
+type PredictionOutcome struct {
+	ID            string
+	Title         string
+	Users         int
+	ChannelPoints int
+}
+
 type Message interface {
 	json.Marshaler
 	Bot() string
@@ -27,6 +72,7 @@ type Message interface {
 	Text() string
 	IsAction() bool
 	CountEmotes() int
+	Emotes() []string
 	ChatterAccessLevel() AccessLevel
 }
 
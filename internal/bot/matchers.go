@@ -0,0 +1,280 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/recache"
+	"github.com/hortbot/hortbot/internal/pkg/rematch"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// matcherNotificationChannel is notified with a channel's ID by database
+// triggers when its autoreplies, banned phrases, filter settings, third-party
+// emotes, or open raffles change.
+const matcherNotificationChannel = "hortbot_channel_matchers"
+
+// autoreplyMatcher is a channel's autoreplies, with their triggers compiled
+// into a single set.
+type autoreplyMatcher struct {
+	autoreplies []dbsql.ListAutoreplyMatchersRow
+	set         *rematch.Set
+}
+
+// matcherCache caches compiled per-channel matchers in memory, so that
+// messages are not matched against each pattern one by one. The per-filter
+// settings, third-party emotes, and open raffle keywords consulted for every
+// message are cached alongside them. Entries are invalidated when the bot
+// edits a channel's autoreplies, banned phrases, filter settings, emotes, or
+// raffles, and when notified of changes made elsewhere.
+type matcherCache struct {
+	reCache *recache.RegexpCache
+
+	mu          sync.Mutex
+	gen         uint64
+	autoreplies map[int64]*autoreplyMatcher
+	phrases     map[int64]*rematch.Set
+	filters     map[int64]map[string]dbsql.ChannelFilter
+	emotes      map[int64]map[string]bool
+	raffles     map[int64]map[string]bool
+}
+
+func newMatcherCache(reCache *recache.RegexpCache) *matcherCache {
+	return &matcherCache{
+		reCache:     reCache,
+		autoreplies: make(map[int64]*autoreplyMatcher),
+		phrases:     make(map[int64]*rematch.Set),
+		filters:     make(map[int64]map[string]dbsql.ChannelFilter),
+		emotes:      make(map[int64]map[string]bool),
+		raffles:     make(map[int64]map[string]bool),
+	}
+}
+
+// Autoreplies returns the channel's autoreply matcher, loading it if needed.
+func (c *matcherCache) Autoreplies(ctx context.Context, queries *dbsql.Queries, channelID int64) (*autoreplyMatcher, error) {
+	c.mu.Lock()
+	m := c.autoreplies[channelID]
+	gen := c.gen
+	c.mu.Unlock()
+
+	if m != nil {
+		return m, nil
+	}
+
+	autoreplies, err := queries.ListAutoreplyMatchers(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("querying for autoreplies: %w", err)
+	}
+
+	triggers := make([]string, len(autoreplies))
+	for i, autoreply := range autoreplies {
+		triggers[i] = autoreply.Trigger
+	}
+
+	m = &autoreplyMatcher{
+		autoreplies: autoreplies,
+		set:         rematch.New(triggers, c.reCache.Compile),
+	}
+
+	if len(triggers) != m.set.Len() {
+		ctxlog.Warn(ctx, "failed to compile autoreply triggers", zap.Int("failed", len(triggers)-m.set.Len()))
+	}
+
+	c.store(gen, func() { c.autoreplies[channelID] = m })
+	return m, nil
+}
+
+// BannedPhrases returns a matcher for the channel's banned phrases.
+func (c *matcherCache) BannedPhrases(channel *dbsql.Channel) *rematch.Set {
+	c.mu.Lock()
+	set := c.phrases[channel.ID]
+	gen := c.gen
+	c.mu.Unlock()
+
+	if set != nil {
+		return set
+	}
+
+	set = rematch.New(channel.FilterBannedPhrasesPatterns, c.reCache.Compile)
+	c.store(gen, func() { c.phrases[channel.ID] = set })
+	return set
+}
+
+// Filters returns the channel's per-filter settings, keyed by filter, loading
+// them if needed. Filters without settings are missing from the map.
+func (c *matcherCache) Filters(ctx context.Context, queries *dbsql.Queries, channelID int64) (map[string]dbsql.ChannelFilter, error) {
+	c.mu.Lock()
+	filters, ok := c.filters[channelID]
+	gen := c.gen
+	c.mu.Unlock()
+
+	if ok {
+		return filters, nil
+	}
+
+	rows, err := queries.ListChannelFilters(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("querying for filter settings: %w", err)
+	}
+
+	filters = make(map[string]dbsql.ChannelFilter, len(rows))
+	for _, row := range rows {
+		filters[row.Filter] = row
+	}
+
+	c.store(gen, func() { c.filters[channelID] = filters })
+	return filters, nil
+}
+
+// Emotes returns the set of the channel's third-party emote names, loading it
+// if needed.
+func (c *matcherCache) Emotes(ctx context.Context, queries *dbsql.Queries, channelID int64) (map[string]bool, error) {
+	c.mu.Lock()
+	emotes, ok := c.emotes[channelID]
+	gen := c.gen
+	c.mu.Unlock()
+
+	if ok {
+		return emotes, nil
+	}
+
+	sets, err := queries.ListChannelEmoteSets(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("querying for emote sets: %w", err)
+	}
+
+	emotes = make(map[string]bool)
+	for _, set := range sets {
+		for _, name := range set.Emotes {
+			emotes[name] = true
+		}
+	}
+
+	c.store(gen, func() { c.emotes[channelID] = emotes })
+	return emotes, nil
+}
+
+// RaffleKeywords returns the set of the channel's open raffle keywords,
+// loading it if needed.
+func (c *matcherCache) RaffleKeywords(ctx context.Context, queries *dbsql.Queries, channelID int64) (map[string]bool, error) {
+	c.mu.Lock()
+	keywords, ok := c.raffles[channelID]
+	gen := c.gen
+	c.mu.Unlock()
+
+	if ok {
+		return keywords, nil
+	}
+
+	rows, err := queries.ListOpenRaffleKeywords(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("querying for raffle keywords: %w", err)
+	}
+
+	keywords = make(map[string]bool, len(rows))
+	for _, keyword := range rows {
+		keywords[keyword] = true
+	}
+
+	c.store(gen, func() { c.raffles[channelID] = keywords })
+	return keywords, nil
+}
+
+// store runs fn to add an entry, unless the cache has been invalidated since
+// gen was read, as the entry may have been loaded before the change.
+func (c *matcherCache) store(gen uint64, fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.gen == gen {
+		fn()
+	}
+}
+
+// Invalidate removes a channel's matchers from the cache.
+func (c *matcherCache) Invalidate(channelID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	delete(c.autoreplies, channelID)
+	delete(c.phrases, channelID)
+	delete(c.filters, channelID)
+	delete(c.emotes, channelID)
+	delete(c.raffles, channelID)
+}
+
+// InvalidateAll empties the cache.
+func (c *matcherCache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.gen++
+	clear(c.autoreplies)
+	clear(c.phrases)
+	clear(c.filters)
+	clear(c.emotes)
+	clear(c.raffles)
+}
+
+// invalidateMatchers marks the channel's matchers as changed, so they are
+// reloaded once the session's transaction commits.
+func (s *session) invalidateMatchers() {
+	s.matchersChanged = s.Channel.ID
+}
+
+// listenMatchers invalidates cached matchers as the database notifies the
+// bot of changes, reconnecting on failure.
+func (b *Bot) listenMatchers(ctx context.Context) error {
+	for {
+		err := b.listenMatchersOnce(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		ctxlog.Warn(ctx, "matcher listener failed; retrying", zap.Error(err))
+
+		timer := time.NewTimer(time.Second)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+func (b *Bot) listenMatchersOnce(ctx context.Context) error {
+	pooled, err := b.db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquiring matcher listener connection: %w", err)
+	}
+
+	// The connection is left listening, so must not return to the pool.
+	conn := pooled.Hijack()
+	defer conn.Close(context.WithoutCancel(ctx)) //nolint:errcheck
+
+	if _, err := conn.Exec(ctx, `LISTEN `+matcherNotificationChannel); err != nil {
+		return fmt.Errorf("listening for matcher changes: %w", err)
+	}
+
+	// Changes may have been missed while not listening.
+	b.deps.Matchers.InvalidateAll()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("waiting for matcher changes: %w", err)
+		}
+
+		channelID, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			ctxlog.Warn(ctx, "bad matcher notification", zap.String("payload", notification.Payload))
+			continue
+		}
+
+		b.deps.Matchers.Invalidate(channelID)
+	}
+}
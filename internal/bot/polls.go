@@ -0,0 +1,111 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// HandlePollEnd announces the results of a poll which has ended.
+func (b *Bot) HandlePollEnd(ctx context.Context, p *PollResult) error {
+	if p == nil || p.Broadcaster.ID == 0 {
+		ctxlog.Error(ctx, "invalid poll result", zap.Any("poll", p))
+		return errInvalidMessage
+	}
+
+	// Archived polls have already been announced when they ended.
+	if strings.EqualFold(p.Status, "archived") {
+		return nil
+	}
+
+	ctx = ctxlog.With(ctx, zap.Int64("roomID", p.Broadcaster.ID))
+
+	return b.handleChannelEvent(ctx, "poll end", p.Broadcaster.ID, func(ctx context.Context, s *session) error {
+		return s.Reply(ctx, pollResultMessage(p.Title, p.Choices))
+	})
+}
+
+// HandlePredictionEnd announces the results of a prediction which has been
+// resolved or canceled.
+func (b *Bot) HandlePredictionEnd(ctx context.Context, p *PredictionResult) error {
+	if p == nil || p.Broadcaster.ID == 0 {
+		ctxlog.Error(ctx, "invalid prediction result", zap.Any("prediction", p))
+		return errInvalidMessage
+	}
+
+	ctx = ctxlog.With(ctx, zap.Int64("roomID", p.Broadcaster.ID))
+
+	return b.handleChannelEvent(ctx, "prediction end", p.Broadcaster.ID, func(ctx context.Context, s *session) error {
+		switch strings.ToLower(p.Status) {
+		case "resolved":
+			return s.Reply(ctx, predictionResolvedMessage(p))
+		case "canceled":
+			return s.Replyf(ctx, `Prediction "%s" was canceled; all channel points have been refunded.`, p.Title)
+		default:
+			return nil
+		}
+	})
+}
+
+func pollResultMessage(title string, choices []PollChoice) string {
+	total := 0
+	most := 0
+	for _, c := range choices {
+		total += c.Votes
+		most = max(most, c.Votes)
+	}
+
+	if total == 0 {
+		return fmt.Sprintf(`Poll "%s" has ended with no votes.`, title)
+	}
+
+	var winners []string
+	for _, c := range choices {
+		if c.Votes == most {
+			winners = append(winners, c.Title)
+		}
+	}
+
+	if len(winners) > 1 {
+		return fmt.Sprintf(`Poll "%s" has ended in a tie between %s with %d %s each.`, title, joinAnd(winners), most, pluralInt(most, "vote", "votes"))
+	}
+
+	percent := int(math.Round(float64(most) * 100 / float64(total)))
+	return fmt.Sprintf(`Poll "%s" has ended! Winner: %s with %d of %d %s (%d%%).`, title, winners[0], most, total, pluralInt(total, "vote", "votes"), percent)
+}
+
+func predictionResolvedMessage(p *PredictionResult) string {
+	points := 0
+	var winner *PredictionOutcome
+
+	for i, o := range p.Outcomes {
+		points += o.ChannelPoints
+		if o.ID == p.WinningOutcomeID {
+			winner = &p.Outcomes[i]
+		}
+	}
+
+	if winner == nil {
+		return fmt.Sprintf(`Prediction "%s" has been resolved.`, p.Title)
+	}
+
+	return fmt.Sprintf(`Prediction "%s" has been resolved: %s! %d %s won a share of %d channel %s.`,
+		p.Title, winner.Title, winner.Users, pluralInt(winner.Users, "user", "users"), points, pluralInt(points, "point", "points"))
+}
+
+func joinAnd(items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " and " + items[1]
+	default:
+		return strings.Join(items[:len(items)-1], ", ") + ", and " + items[len(items)-1]
+	}
+}
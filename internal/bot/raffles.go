@@ -0,0 +1,257 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/hako/durafmt"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/dbx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// raffleCountdowns are the times before a timed raffle closes at which a
+// reminder is sent to chat.
+var raffleCountdowns = []time.Duration{
+	time.Minute,
+	30 * time.Second,
+	10 * time.Second,
+}
+
+func (b *Bot) scheduleRaffle(ctx context.Context, id int64, at time.Time) error {
+	now := time.Now()
+	interval := max(at.Sub(now), time.Millisecond)
+	return b.raffleRep.Add(ctx, id, b.runRaffleTimer, now, interval)
+}
+
+func (b *Bot) unscheduleRaffle(ctx context.Context, id int64) error {
+	return b.raffleRep.Remove(ctx, id)
+}
+
+func (b *Bot) loadRaffleTimers(ctx context.Context) error {
+	if err := b.raffleRep.Reset(ctx); err != nil {
+		return err
+	}
+
+	ids, err := b.queries.ListRaffleTimerIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("getting raffle timers: %w", err)
+	}
+
+	// Let the timer work out what is due; anything that expired while the
+	// bot was down is handled immediately.
+	now := time.Now()
+	for _, id := range ids {
+		if err := b.scheduleRaffle(ctx, id, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Bot) runRaffleTimer(ctx context.Context, id int64) (readd bool) {
+	ctx = ctxlog.With(ctx, zap.Int64("raffle", id))
+
+	err := dbx.Transact(ctx, b.db,
+		dbx.SetLocalLockTimeout(5*time.Second),
+		func(ctx context.Context, tx pgx.Tx) error {
+			queries := dbsql.New(tx)
+
+			raffle, err := queries.GetRaffle(ctx, id)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil
+				}
+				return fmt.Errorf("getting raffle: %w", err)
+			}
+
+			channel, err := queries.GetChannelByID(ctx, raffle.ChannelID)
+			if err != nil {
+				return fmt.Errorf("getting channel: %w", err)
+			}
+
+			// Serialize with chat messages, which may modify the raffle.
+			if err := pgLock(ctx, queries, channel.TwitchID); err != nil {
+				return err
+			}
+
+			raffle, err = queries.GetRaffle(ctx, id)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil
+				}
+				return fmt.Errorf("getting raffle: %w", err)
+			}
+
+			s := &session{
+				Type:        sessionRepeat,
+				Deps:        b.deps,
+				Queries:     queries,
+				Start:       time.Now(),
+				UserLevel:   AccessLevelEveryone,
+				Channel:     &channel,
+				BotLogin:    channel.BotName,
+				ChannelName: channel.Name,
+				RoomID:      channel.TwitchID,
+				RoomIDOrig:  channel.TwitchID,
+				// Keep the raffle's state moving, but don't talk in channels
+				// the bot has left.
+				Silent: !channel.Active,
+			}
+
+			ctx = ctxlog.With(ctx, zap.Int64("roomID", s.RoomID), zap.String("channel", s.ChannelName))
+
+			return s.raffleTick(ctx, &raffle)
+		})
+	if err != nil {
+		ctxlog.Warn(ctx, "error running raffle timer", zap.Error(err))
+	}
+
+	return false
+}
+
+// raffleTick handles everything that has come due for a raffle: countdown
+// reminders, closing timed raffles, and re-rolling unclaimed prizes. The
+// raffle's timer is then rescheduled for its next event.
+func (s *session) raffleTick(ctx context.Context, raffle *dbsql.Raffle) error {
+	now := time.Now()
+
+	if raffle.Open && raffle.ClosesAt.Valid {
+		remaining := raffle.ClosesAt.Time.Sub(now).Round(time.Second)
+
+		if remaining <= 0 {
+			raffle.Open = false
+			raffle.ClosesAt = pgtype.Timestamptz{}
+
+			if err := s.Queries.UpdateRaffleOpen(ctx, dbsql.UpdateRaffleOpenParams{
+				Open:     raffle.Open,
+				ClosesAt: raffle.ClosesAt,
+				ID:       raffle.ID,
+			}); err != nil {
+				return fmt.Errorf("closing raffle: %w", err)
+			}
+
+			count, err := s.Queries.CountRaffleEntries(ctx, raffle.ID)
+			if err != nil {
+				return fmt.Errorf("counting raffle entries: %w", err)
+			}
+
+			if err := s.Replyf(ctx, "Raffle %s is now closed with %d %s.", raffle.Name, count, pluralInt(count, "entry", "entries")); err != nil {
+				return err
+			}
+		} else if err := s.raffleCountdown(ctx, raffle, remaining); err != nil {
+			return err
+		}
+	}
+
+	expired, err := s.Queries.ListExpiredRaffleWinnersForUpdate(ctx, dbsql.ListExpiredRaffleWinnersForUpdateParams{
+		RaffleID: raffle.ID,
+		Now:      dbsql.TimestamptzFrom(now),
+	})
+	if err != nil {
+		return fmt.Errorf("listing expired raffle winners: %w", err)
+	}
+
+	for _, w := range expired {
+		if err := s.Queries.UpdateRaffleWinnerStatus(ctx, dbsql.UpdateRaffleWinnerStatusParams{
+			Status: raffleWinnerForfeited,
+			ID:     w.ID,
+		}); err != nil {
+			return fmt.Errorf("updating raffle winner: %w", err)
+		}
+
+		winners, err := s.RaffleDraw(ctx, raffle, 1)
+		if err != nil {
+			return err
+		}
+
+		if len(winners) == 0 {
+			if err := s.Replyf(ctx, "%s did not claim the prize for raffle %s in time, and there are no eligible entries left.", w.UserDisplay, raffle.Name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.Replyf(ctx, "%s did not claim the prize for raffle %s in time. %s", w.UserDisplay, raffle.Name, s.raffleWinnersMessage(raffle, winners)); err != nil {
+			return err
+		}
+	}
+
+	return s.scheduleRaffle(ctx, raffle)
+}
+
+// raffleCountdown sends the reminder for the shortest countdown that has come
+// due, if it has not already been sent. A timer that fires late still sends
+// its reminder with the actual time remaining, and a later tick (for example,
+// after a restart) does not repeat it.
+func (s *session) raffleCountdown(ctx context.Context, raffle *dbsql.Raffle, remaining time.Duration) error {
+	i := slices.IndexFunc(raffleCountdowns, func(d time.Duration) bool { return remaining > d })
+	switch i {
+	case 0:
+		return nil
+	case -1:
+		i = len(raffleCountdowns)
+	}
+	due := raffleCountdowns[i-1]
+
+	// Keyed on the closing time, so reopening a raffle starts its countdowns
+	// over; the mark only needs to outlive the raffle's remaining time.
+	key := "raffle_" + strconv.FormatInt(raffle.ID, 10) + "_" + strconv.FormatInt(raffle.ClosesAt.Time.Unix(), 10) + "_countdown_" + strconv.Itoa(int(due/time.Second))
+	seconds := int((remaining + time.Minute) / time.Second)
+
+	if err := s.tryCooldown(ctx, key, seconds, false); err != nil {
+		if errors.Is(err, errInCooldown) {
+			return nil
+		}
+		return err
+	}
+
+	return s.Replyf(ctx, "Raffle %s closes in %s! Type %s to enter.", raffle.Name, durafmt.Parse(remaining).String(), raffle.Keyword)
+}
+
+// scheduleRaffle sets the raffle's timer to fire at its next event, if any.
+func (s *session) scheduleRaffle(ctx context.Context, raffle *dbsql.Raffle) error {
+	now := time.Now()
+
+	var next time.Time
+
+	consider := func(t time.Time) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+
+	if raffle.Open && raffle.ClosesAt.Valid {
+		closesAt := raffle.ClosesAt.Time
+		consider(closesAt)
+
+		for _, d := range raffleCountdowns {
+			if t := closesAt.Add(-d); t.After(now) {
+				consider(t)
+			}
+		}
+	}
+
+	deadline, err := s.Queries.NextRaffleClaimDeadline(ctx, raffle.ID)
+	if err != nil {
+		return fmt.Errorf("getting next claim deadline: %w", err)
+	}
+
+	if deadline.Valid {
+		consider(deadline.Time)
+	}
+
+	if next.IsZero() {
+		return s.Deps.UnscheduleRaffle(ctx, raffle.ID)
+	}
+
+	return s.Deps.ScheduleRaffle(ctx, raffle.ID, next)
+}
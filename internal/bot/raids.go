@@ -0,0 +1,72 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// HandleRaid handles an incoming raid, applying the channel's raid chat mode
+// rules and sending the channel's raid message and shoutout if the raid is
+// large enough.
+func (b *Bot) HandleRaid(ctx context.Context, r *Raid) error {
+	if r == nil || r.Broadcaster.ID == 0 || r.Raider.ID == 0 {
+		ctxlog.Error(ctx, "invalid raid", zap.Any("raid", r))
+		return errInvalidMessage
+	}
+
+	ctx = ctxlog.With(ctx,
+		zap.Int64("roomID", r.Broadcaster.ID),
+		zap.Int64("raiderID", r.Raider.ID),
+		zap.String("raiderLogin", r.Raider.Login),
+		zap.Int("viewers", r.Viewers),
+	)
+
+	return b.handleChannelEvent(ctx, "raid", r.Broadcaster.ID, func(ctx context.Context, s *session) error {
+		// Chat mode rules protect against raids of any size.
+		if err := s.applyChatModeRules(ctx, chatModeTriggerRaid, 0); err != nil {
+			return err
+		}
+
+		if r.Viewers < int(s.Channel.RaidThreshold) {
+			return nil
+		}
+
+		display := r.Raider.DisplayName
+		if display == "" {
+			display = r.Raider.Login
+		}
+
+		s.User = r.Raider.Login
+		s.UserDisplay = display
+		s.UserID = r.Raider.ID
+		s.RaidViewers = r.Viewers
+
+		return s.raidReceived(ctx)
+	})
+}
+
+func (s *session) raidReceived(ctx context.Context) error {
+	if s.Channel.RaidShoutout {
+		if err := s.SendShoutout(ctx, s.UserID); err != nil {
+			// Twitch rejects shoutouts while offline or during cooldowns; the
+			// raid message should still be sent.
+			if _, ok := apiclient.AsError(err); !ok {
+				return err
+			}
+		}
+	}
+
+	if s.Channel.RaidMessage == "" {
+		return nil
+	}
+
+	reply, err := processCommand(ctx, s, s.Channel.RaidMessage)
+	if err != nil {
+		return err
+	}
+
+	return s.Reply(ctx, reply)
+}
@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// recentMessageMax is the number of messages kept for each channel.
+	recentMessageMax = 500
+
+	// recentMessageAge is how long messages are kept for, and so the longest
+	// lookback the nuke command may use.
+	recentMessageAge = 10 * time.Minute
+)
+
+type recentMessage struct {
+	ID     string
+	UserID int64
+	User   string
+	Level  AccessLevel
+	Text   string
+	Time   time.Time
+}
+
+// recentMessageBuffer keeps each channel's most recent chat messages. It is
+// kept in memory, so each bot process only sees the messages it handles.
+type recentMessageBuffer struct {
+	mu       sync.Mutex
+	channels map[int64][]recentMessage
+}
+
+func newRecentMessageBuffer() *recentMessageBuffer {
+	return &recentMessageBuffer{
+		channels: make(map[int64][]recentMessage),
+	}
+}
+
+// add records a message sent to the channel, dropping messages which are too
+// old or beyond the channel's limit.
+func (b *recentMessageBuffer) add(channelID int64, m recentMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	msgs := append(b.channels[channelID], m)
+
+	cutoff := m.Time.Add(-recentMessageAge)
+	drop := max(len(msgs)-recentMessageMax, 0)
+	for drop < len(msgs) && msgs[drop].Time.Before(cutoff) {
+		drop++
+	}
+
+	if drop > 0 {
+		// Copy so the dropped messages' backing array can be freed.
+		msgs = append([]recentMessage(nil), msgs[drop:]...)
+	}
+
+	b.channels[channelID] = msgs
+}
+
+// since returns the channel's messages sent at or after the given time,
+// oldest first.
+func (b *recentMessageBuffer) since(channelID int64, t time.Time) []recentMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var out []recentMessage
+	for _, m := range b.channels[channelID] {
+		if !m.Time.Before(t) {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// forget removes the channel's messages for which fn returns true.
+func (b *recentMessageBuffer) forget(channelID int64, fn func(m recentMessage) bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	msgs := b.channels[channelID]
+	kept := msgs[:0]
+	for _, m := range msgs {
+		if !fn(m) {
+			kept = append(kept, m)
+		}
+	}
+	clear(msgs[len(kept):])
+	b.channels[channelID] = kept
+}
+
+// trackRecentMessage records the message for the nuke command.
+func trackRecentMessage(s *session) {
+	// Impersonated messages were sent to another channel.
+	if s.Imp || !s.Channel.ShouldModerate || s.ID == "" {
+		return
+	}
+
+	s.Deps.RecentMessages.add(s.Channel.ID, recentMessage{
+		ID:     s.ID,
+		UserID: s.UserID,
+		User:   s.User,
+		Level:  s.UserLevel,
+		Text:   s.Message,
+		Time:   time.Now(),
+	})
+}
@@ -211,6 +211,11 @@ func (runner *repeatedCommandRunner) allowed(ctx context.Context, queries *dbsql
 		return false, false, nil
 	}
 
+	// Disabled commands keep their repeat, but stay quiet until re-enabled.
+	if !runner.info().Enabled {
+		return true, false, nil
+	}
+
 	if channel.MessageCount < repeat.LastCount+repeat.MessageDiff {
 		return true, false, nil
 	}
@@ -318,6 +323,10 @@ func (runner *scheduledCommandRunner) allowed(ctx context.Context, queries *dbsq
 		return false, false, nil
 	}
 
+	if !runner.info().Enabled {
+		return true, false, nil
+	}
+
 	if channel.MessageCount < scheduled.LastCount+scheduled.MessageDiff {
 		return true, false, nil
 	}
@@ -416,7 +425,14 @@ func (b *Bot) loadRepeats(ctx context.Context) error {
 		return fmt.Errorf("getting active scheduled commands: %w", err)
 	}
 
-	return updateScheduleds(ctx, b.deps, scheduleds, true)
+	for _, row := range scheduleds {
+		channel := dbsql.Channel{Timezone: row.Timezone}
+		if err := updateScheduled(ctx, b.deps, &row.ScheduledCommand, channel.Location(), true); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func updateRepeating(ctx context.Context, deps *sharedDeps, repeats []dbsql.RepeatedCommand, enable bool) error {
@@ -443,21 +459,22 @@ func updateRepeating(ctx context.Context, deps *sharedDeps, repeats []dbsql.Repe
 	return nil
 }
 
-func updateScheduleds(ctx context.Context, deps *sharedDeps, scheduleds []dbsql.ScheduledCommand, enable bool) error {
-	for _, scheduled := range scheduleds {
-		if !enable || !scheduled.Enabled {
-			if err := deps.RemoveScheduled(ctx, scheduled.ID); err != nil {
-				return err
-			}
-			continue
-		}
-
-		expr := must.Must(repeat.ParseCron(scheduled.CronExpression))
-
-		if err := deps.AddScheduled(ctx, scheduled.ID, expr); err != nil {
+func updateScheduleds(ctx context.Context, deps *sharedDeps, scheduleds []dbsql.ScheduledCommand, loc *time.Location, enable bool) error {
+	for i := range scheduleds {
+		if err := updateScheduled(ctx, deps, &scheduleds[i], loc, enable); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+func updateScheduled(ctx context.Context, deps *sharedDeps, scheduled *dbsql.ScheduledCommand, loc *time.Location, enable bool) error {
+	if !enable || !scheduled.Enabled {
+		return deps.RemoveScheduled(ctx, scheduled.ID)
+	}
+
+	expr := must.Must(repeat.ParseCronIn(scheduled.CronExpression, loc))
+
+	return deps.AddScheduled(ctx, scheduled.ID, expr)
+}
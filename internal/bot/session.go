@@ -14,10 +14,12 @@ import (
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/lastfm"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/redirects"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/steam"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/idstr"
 	"github.com/hortbot/hortbot/internal/pkg/findlinks"
+	"github.com/hortbot/hortbot/internal/pkg/stringsx"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/zikaeroh/ctxlog"
@@ -27,6 +29,14 @@ import (
 
 const maxResponseLen = 500
 
+// Modes controlling how a command's responses are delivered. Commands without
+// a mode of their own follow the channel's threadedReplies setting.
+const (
+	replyModeChat    = "chat"
+	replyModeReply   = "reply"
+	replyModeWhisper = "whisper"
+)
+
 type sessionType int
 
 const (
@@ -44,6 +54,7 @@ type session struct {
 
 	Deps    *sharedDeps
 	Queries *dbsql.Queries
+	tx      pgx.Tx
 
 	Start  time.Time
 	SentAt time.Time
@@ -62,14 +73,22 @@ type session struct {
 
 	Channel *dbsql.Channel
 
+	RaidViewers int
+
+	// FirstChat is true if this is the user's first message in the channel.
+	FirstChat bool
+
 	CommandParams  string
 	parameters     *[]string
 	parameterIndex int
 
+	autoreplyMatch *autoreplyMatch
+
 	Silent bool
 	Imp    bool
 
 	usageContext string
+	replyMode    string
 
 	sendRoundtrip bool
 
@@ -77,17 +96,33 @@ type session struct {
 	actionUsage             map[string]int64
 	eventsubUpdateRequested bool
 
+	// dryRun is set when testing what the session would do, in which case
+	// replies and moderation actions are recorded rather than performed.
+	dryRun *dryRun
+
+	// matchersChanged is the ID of the channel whose autoreplies, banned
+	// phrases, filter settings, emotes, or open raffles were changed by this
+	// session, or zero.
+	matchersChanged int64
+
+	// banListChannels are the channels with ban list bans queued by this
+	// session, to be applied once its transaction commits.
+	banListChannels []int64
+
 	cache struct {
-		links         onced[[]*url.URL]
-		tracks        onced[[]lastfm.Track]
-		tok           onced[*oauth2.Token]
-		botTok        onced[tokenAndUserID]
-		isLive        onced[bool]
-		twitchChannel onced[*twitch.Channel]
-		twitchStream  onced[*twitch.Stream]
-		steamSummary  onced[*steam.Summary]
-		steamGames    onced[[]*steam.Game]
-		gameLinks     onced[[]twitch.GameLink]
+		links          onced[[]*url.URL]
+		resolvedLinks  onced[[]*url.URL]
+		blockedDomains onced[[]dbsql.BlockedDomain]
+		tracks         onced[[]lastfm.Track]
+		tok            onced[*oauth2.Token]
+		botTok         onced[tokenAndUserID]
+		isLive         onced[bool]
+		twitchChannel  onced[*twitch.Channel]
+		twitchStream   onced[*twitch.Stream]
+		steamSummary   onced[*steam.Summary]
+		steamGames     onced[[]*steam.Game]
+		gameLinks      onced[[]twitch.GameLink]
+		emotes         onced[[]string]
 	}
 }
 
@@ -129,7 +164,7 @@ func (s *session) formatResponse(response string) (message string, announce bool
 	response = builder.String()
 
 	if len(response) > maxResponseLen {
-		return response[:maxResponseLen], announce
+		return stringsx.Truncate(response, maxResponseLen), announce
 	}
 
 	return response, announce
@@ -164,13 +199,34 @@ func (s *session) Reply(ctx context.Context, response string) error {
 		return nil
 	}
 
+	if s.dryRun != nil {
+		s.dryRun.replies = append(s.dryRun.replies, response)
+		return nil
+	}
+
 	response, announce := s.formatResponse(response)
 
 	if announce {
 		return s.Announce(ctx, response)
 	}
 
-	return s.SendTwitchChatMessage(ctx, s.ChannelName, response)
+	switch s.replyMode {
+	case replyModeReply:
+		return s.SendTwitchChatMessage(ctx, s.ChannelName, response, s.ID)
+	case replyModeWhisper:
+		if s.UserID != 0 {
+			err := s.SendTwitchWhisper(ctx, s.UserID, response)
+			if err == nil {
+				return nil
+			}
+			// Whispers may be blocked by the user or restricted by Twitch;
+			// reply in chat instead so the response isn't lost.
+			ctxlog.Debug(ctx, "falling back from whisper", zap.Error(err))
+		}
+		return s.SendTwitchChatMessage(ctx, s.ChannelName, response, s.ID)
+	default:
+		return s.SendTwitchChatMessage(ctx, s.ChannelName, response, "")
+	}
 }
 
 func (s *session) Replyf(ctx context.Context, format string, args ...any) error {
@@ -263,12 +319,21 @@ func (s *session) parseUserLevel() AccessLevel {
 }
 
 func (s *session) DeleteMessage(ctx context.Context) error {
+	return s.DeleteMessageByID(ctx, s.ID)
+}
+
+func (s *session) DeleteMessageByID(ctx context.Context, id string) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("delete message")
+		return nil
+	}
+
 	botID, tok, err := s.BotTwitchToken(ctx)
 	if err != nil {
 		return err
 	}
 
-	newToken, err := s.Deps.Twitch.DeleteChatMessage(ctx, s.Channel.TwitchID, botID, tok, s.ID)
+	newToken, err := s.Deps.Twitch.DeleteChatMessage(ctx, s.Channel.TwitchID, botID, tok, id)
 	if err != nil {
 		if ae, ok := apiclient.AsError(err); ok && ae.IsNotFound() {
 			err = nil
@@ -298,6 +363,90 @@ func (s *session) Links(ctx context.Context) []*url.URL {
 	return links
 }
 
+const (
+	// maxResolvedLinks limits how many shortened links are resolved in a
+	// single message; any more are left as-is.
+	maxResolvedLinks = 3
+
+	// maxRedirectHops limits how many redirects are followed when resolving
+	// a single link, in case shorteners point to each other.
+	maxRedirectHops = 3
+
+	// redirectCacheSize and redirectCacheTTL bound the cache of resolved
+	// redirects shared between messages.
+	redirectCacheSize = 1000
+	redirectCacheTTL  = time.Hour
+)
+
+// ResolvedLinks returns the links in the message. If the channel resolves
+// links, links from known shorteners are replaced by where they redirect to;
+// links which cannot be resolved are left as-is.
+func (s *session) ResolvedLinks(ctx context.Context) []*url.URL {
+	links, _ := s.cache.resolvedLinks.get(func() ([]*url.URL, error) {
+		links := s.Links(ctx)
+		if !s.Channel.FilterLinksResolve || s.Deps.Redirects == nil {
+			return links, nil
+		}
+
+		// All resolution shares one deadline, so a message full of slow
+		// links cannot hold up the handler.
+		ctx, cancel := context.WithTimeout(ctx, s.Deps.APITimeout)
+		defer cancel()
+
+		resolved := make([]*url.URL, len(links))
+		n := 0
+		for i, u := range links {
+			if n == maxResolvedLinks || !redirects.IsShortener(u) {
+				resolved[i] = u
+				continue
+			}
+			n++
+			resolved[i] = s.resolveLink(ctx, u)
+		}
+		return resolved, nil
+	})
+	return links
+}
+
+func (s *session) resolveLink(ctx context.Context, u *url.URL) *url.URL {
+	for range maxRedirectHops {
+		if !redirects.IsShortener(u) {
+			break
+		}
+
+		link := u.String()
+
+		resolved, ok := s.Deps.RedirectCache.Get(link)
+		if !ok {
+			var err error
+			resolved, err = s.Deps.Redirects.Resolve(ctx, link)
+			if err != nil {
+				ctxlog.Debug(ctx, "error resolving link", zap.Error(err), zap.Stringer("link", u))
+				break
+			}
+			s.Deps.RedirectCache.Add(link, resolved)
+		}
+
+		next, err := url.Parse(resolved)
+		if err != nil {
+			break
+		}
+		u = next
+	}
+	return u
+}
+
+// BlockedDomains returns the global domain blocklist.
+func (s *session) BlockedDomains(ctx context.Context) ([]dbsql.BlockedDomain, error) {
+	return s.cache.blockedDomains.get(func() ([]dbsql.BlockedDomain, error) {
+		domains, err := s.Queries.ListBlockedDomains(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing blocked domains: %w", err)
+		}
+		return domains, nil
+	})
+}
+
 var errLastFMDisabled = errors.New("bot: LastFM disabled")
 
 func (s *session) Tracks(ctx context.Context) ([]lastfm.Track, error) {
@@ -383,6 +532,11 @@ func (s *session) GetUserID(ctx context.Context, username string) (int64, error)
 }
 
 func (s *session) BanByUsername(ctx context.Context, username string, duration int64, reason string) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("%s", describeBan(username, duration))
+		return nil
+	}
+
 	userID, err := s.GetUserID(ctx, username)
 	if err != nil {
 		return err
@@ -391,6 +545,11 @@ func (s *session) BanByUsername(ctx context.Context, username string, duration i
 }
 
 func (s *session) BanByID(ctx context.Context, userID int64, duration int64, reason string) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("%s", describeBan("user "+strconv.FormatInt(userID, 10), duration))
+		return nil
+	}
+
 	botID, tok, err := s.BotTwitchToken(ctx)
 	if err != nil {
 		return err
@@ -418,6 +577,11 @@ func (s *session) BanByID(ctx context.Context, userID int64, duration int64, rea
 }
 
 func (s *session) UnbanByUsername(ctx context.Context, username string) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("unban %s", username)
+		return nil
+	}
+
 	userID, err := s.GetUserID(ctx, username)
 	if err != nil {
 		return err
@@ -426,6 +590,11 @@ func (s *session) UnbanByUsername(ctx context.Context, username string) error {
 }
 
 func (s *session) UnbanByID(ctx context.Context, userID int64) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("unban user %d", userID)
+		return nil
+	}
+
 	botID, tok, err := s.BotTwitchToken(ctx)
 	if err != nil {
 		return err
@@ -447,6 +616,11 @@ func (s *session) UnbanByID(ctx context.Context, userID int64) error {
 }
 
 func (s *session) SetBotColor(ctx context.Context, color string) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("set color to %s", color)
+		return nil
+	}
+
 	botID, tok, err := s.BotTwitchToken(ctx)
 	if err != nil {
 		return err
@@ -468,6 +642,11 @@ func (s *session) SetBotColor(ctx context.Context, color string) error {
 }
 
 func (s *session) ClearChat(ctx context.Context) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("clear chat")
+		return nil
+	}
+
 	botID, tok, err := s.BotTwitchToken(ctx)
 	if err != nil {
 		return err
@@ -489,6 +668,11 @@ func (s *session) ClearChat(ctx context.Context) error {
 }
 
 func (s *session) UpdateChatSettings(ctx context.Context, patch *twitch.ChatSettingsPatch) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("change chat settings")
+		return nil
+	}
+
 	botID, tok, err := s.BotTwitchToken(ctx)
 	if err != nil {
 		return err
@@ -509,7 +693,35 @@ func (s *session) UpdateChatSettings(ctx context.Context, patch *twitch.ChatSett
 	return nil
 }
 
+// GetChatSettings gets the channel's current chat settings using the bot's
+// token.
+func (s *session) GetChatSettings(ctx context.Context) (*twitch.ChatSettings, error) {
+	botID, tok, err := s.BotTwitchToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, newToken, err := s.Deps.Twitch.GetChatSettings(ctx, s.Channel.TwitchID, botID, tok)
+	if newToken != nil {
+		if err := s.SetBotTwitchToken(ctx, botID, newToken); err != nil {
+			return nil, err
+		}
+	}
+
+	if err != nil {
+		logTwitchModerationError(ctx, err, "get chat settings")
+		return nil, fmt.Errorf("getting chat settings: %w", err)
+	}
+
+	return settings, nil
+}
+
 func (s *session) Announce(ctx context.Context, message string) error {
+	if s.dryRun != nil {
+		s.dryRun.replies = append(s.dryRun.replies, message)
+		return nil
+	}
+
 	botID, tok, err := s.BotTwitchToken(ctx)
 	if err != nil {
 		return err
@@ -530,7 +742,62 @@ func (s *session) Announce(ctx context.Context, message string) error {
 	return nil
 }
 
-func (s *session) SendTwitchChatMessage(ctx context.Context, target string, message string) error {
+func (s *session) SendShoutout(ctx context.Context, toID int64) error {
+	if s.dryRun != nil {
+		s.dryRun.skip("shout out user %d", toID)
+		return nil
+	}
+
+	botID, tok, err := s.BotTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	newToken, err := s.Deps.Twitch.SendShoutout(ctx, s.Channel.TwitchID, toID, botID, tok)
+	if newToken != nil {
+		if err := s.SetBotTwitchToken(ctx, botID, newToken); err != nil {
+			return err
+		}
+	}
+
+	if err != nil {
+		logTwitchModerationError(ctx, err, "shoutout")
+		return fmt.Errorf("sending shoutout: %w", err)
+	}
+
+	return nil
+}
+
+// GetChannelFollower looks up the user's follow of the channel using the
+// bot's token.
+func (s *session) GetChannelFollower(ctx context.Context, userID int64) (*twitch.ChannelFollower, error) {
+	botID, tok, err := s.BotTwitchToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	follower, newToken, err := s.Deps.Twitch.GetChannelFollower(ctx, s.Channel.TwitchID, userID, tok)
+	if newToken != nil {
+		if err := s.SetBotTwitchToken(ctx, botID, newToken); err != nil {
+			return nil, err
+		}
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("getting channel follower: %w", err)
+	}
+
+	return follower, nil
+}
+
+// SendTwitchChatMessage sends a message to the target's chat. If
+// replyParentID is set, the message is threaded as a reply to it.
+func (s *session) SendTwitchChatMessage(ctx context.Context, target string, message string, replyParentID string) error {
+	if s.dryRun != nil {
+		s.dryRun.replies = append(s.dryRun.replies, message)
+		return nil
+	}
+
 	botID, tok, err := s.BotTwitchToken(ctx)
 	if err != nil {
 		return err
@@ -545,7 +812,7 @@ func (s *session) SendTwitchChatMessage(ctx context.Context, target string, mess
 		return err
 	}
 
-	newToken, err := s.Deps.Twitch.SendChatMessage(ctx, targetID, botID, tok, message)
+	newToken, err := s.Deps.Twitch.SendChatMessage(ctx, targetID, botID, tok, message, replyParentID)
 	if newToken != nil {
 		if err := s.SetBotTwitchToken(ctx, botID, newToken); err != nil {
 			return err
@@ -562,6 +829,37 @@ func (s *session) SendTwitchChatMessage(ctx context.Context, target string, mess
 	return nil
 }
 
+func (s *session) SendTwitchWhisper(ctx context.Context, toID int64, message string) error {
+	if s.dryRun != nil {
+		s.dryRun.replies = append(s.dryRun.replies, "(whisper) "+message)
+		return nil
+	}
+
+	botID, tok, err := s.BotTwitchToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		return errors.New("bot: bot not authorized")
+	}
+
+	newToken, err := s.Deps.Twitch.SendWhisper(ctx, botID, toID, tok, message)
+	if newToken != nil {
+		if err := s.SetBotTwitchToken(ctx, botID, newToken); err != nil {
+			return err
+		}
+	}
+
+	if err != nil {
+		metricSentErrors.Inc()
+		return fmt.Errorf("sending whisper: %w", err)
+	}
+
+	metricSent.Inc()
+	return nil
+}
+
 func (s *session) IsLive(ctx context.Context) (bool, error) {
 	return s.cache.isLive.get(func() (bool, error) {
 		stream, err := s.TwitchStream(ctx)
@@ -670,12 +968,20 @@ func (s *session) SetCommandParams(params string) {
 	s.parameterIndex = 0
 }
 
-func (s *session) RoomIDStr() string {
-	return strconv.FormatInt(s.RoomID, 10)
+// describeBan describes a ban or timeout for a dry run.
+func describeBan(user string, duration int64) string {
+	switch duration {
+	case 0:
+		return "ban " + user
+	case 1:
+		return "purge " + user
+	default:
+		return "time out " + user + " for " + strconv.FormatInt(duration, 10) + "s"
+	}
 }
 
-func (s *session) FilterExemptLevel() AccessLevel {
-	return newAccessLevel(s.Channel.FilterExemptLevel)
+func (s *session) RoomIDStr() string {
+	return strconv.FormatInt(s.RoomID, 10)
 }
 
 // onced is not safe for concurrent use.
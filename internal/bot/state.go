@@ -31,6 +31,11 @@ func (s *session) Confirm(ctx context.Context, user string, key string, expiry t
 }
 
 func (s *session) AutoreplyAllowed(ctx context.Context, id int64, expiry time.Duration) (allowed bool, err error) {
+	// Dry runs ignore cooldowns; see tryCooldown.
+	if s.dryRun != nil {
+		return true, nil
+	}
+
 	allowed, err = s.Deps.State.AutoreplyAllowed(ctx, s.Queries, s.RoomIDStr(), id, expiry)
 	if err != nil {
 		return false, fmt.Errorf("autoreply allowed: %w", err)
@@ -46,6 +51,21 @@ func (s *session) FilterWarned(ctx context.Context, user string, filter string)
 	return warned, nil
 }
 
+func (s *session) GetAPICache(ctx context.Context, key string) (body string, ok bool, err error) {
+	body, ok, err = s.Deps.State.GetAPICache(ctx, s.Queries, s.RoomIDStr(), key)
+	if err != nil {
+		return "", false, fmt.Errorf("get api cache: %w", err)
+	}
+	return body, ok, nil
+}
+
+func (s *session) SetAPICache(ctx context.Context, key string, body string, expiry time.Duration) error {
+	if err := s.Deps.State.SetAPICache(ctx, s.Queries, s.RoomIDStr(), key, body, expiry); err != nil {
+		return fmt.Errorf("set api cache: %w", err)
+	}
+	return nil
+}
+
 func (s *session) RaffleAdd(ctx context.Context, user string) error {
 	if err := s.Deps.State.RaffleAdd(ctx, s.Queries, s.RoomIDStr(), user); err != nil {
 		return fmt.Errorf("raffle add: %w", err)
@@ -86,8 +106,10 @@ func (s *session) RaffleCount(ctx context.Context) (int64, error) {
 
 var errInCooldown = errors.New("bot: in cooldown")
 
+// tryCooldown checks and marks a cooldown. Dry runs ignore cooldowns, so
+// that their results do not depend on recent chat.
 func (s *session) tryCooldown(ctx context.Context, key string, seconds int, allowMods bool) error {
-	if seconds == 0 {
+	if seconds == 0 || s.dryRun != nil {
 		return nil
 	}
 
@@ -112,6 +134,20 @@ func (s *session) tryCooldown(ctx context.Context, key string, seconds int, allo
 	}
 }
 
+// cooldownActive reports whether a cooldown started by tryCooldown is still
+// running, without marking it.
+func (s *session) cooldownActive(ctx context.Context, key string) (bool, error) {
+	if s.dryRun != nil {
+		return false, nil
+	}
+
+	active, err := s.Deps.State.CooldownActive(ctx, s.Queries, s.RoomIDStr(), key)
+	if err != nil {
+		return false, fmt.Errorf("checking cooldown: %w", err)
+	}
+	return active, nil
+}
+
 func (s *session) TryCooldown(ctx context.Context) error {
 	cooldown := s.Deps.DefaultCooldown
 
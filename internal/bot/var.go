@@ -6,30 +6,65 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/jackc/pgx/v5"
 )
 
-func (s *session) VarGet(ctx context.Context, name string) (string, bool, error) {
+// varLookup finds a variable, returning nil if it does not exist. Expired
+// variables are deleted and treated as though they don't exist.
+func (s *session) varLookup(ctx context.Context, userID int64, name string) (*dbsql.Variable, error) {
 	v, err := s.Queries.GetVariable(ctx, dbsql.GetVariableParams{
 		ChannelID: s.Channel.ID,
+		UserID:    userID,
 		Name:      name,
 	})
 	if errors.Is(err, pgx.ErrNoRows) {
-		return "", false, nil
+		return nil, nil
 	}
 
 	if err != nil {
-		return "", false, fmt.Errorf("getting variable: %w", err)
+		return nil, fmt.Errorf("getting variable: %w", err)
+	}
+
+	if v.Expired(time.Now()) {
+		if err := s.VarDelete(ctx, userID, name); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	return &v, nil
+}
+
+// varLookupWithDefault finds a variable. Per-user variables which have not
+// been set fall back to the channel variable of the same name, which also
+// decides the kind of new per-user variables.
+func (s *session) varLookupWithDefault(ctx context.Context, userID int64, name string) (v *dbsql.Variable, isDefault bool, err error) {
+	v, err = s.varLookup(ctx, userID, name)
+	if v != nil || err != nil || userID == 0 {
+		return v, false, err
+	}
+
+	v, err = s.varLookup(ctx, 0, name)
+	return v, v != nil, err
+}
+
+func (s *session) VarGet(ctx context.Context, userID int64, name string) (string, bool, error) {
+	v, _, err := s.varLookupWithDefault(ctx, userID, name)
+	if v == nil || err != nil {
+		return "", false, err
 	}
 
 	return v.Value, true, nil
 }
 
 func (s *session) VarGetByChannel(ctx context.Context, ch, name string) (string, bool, error) {
+	ch = strings.ToLower(ch)
+
 	v, err := s.Queries.GetVariableByChannelName(ctx, dbsql.GetVariableByChannelNameParams{
-		ChannelName: strings.ToLower(ch),
+		ChannelName: ch,
 		Name:        name,
 	})
 
@@ -41,25 +76,52 @@ func (s *session) VarGetByChannel(ctx context.Context, ch, name string) (string,
 		return "", false, fmt.Errorf("getting variable: %w", err)
 	}
 
+	if v.Expired(time.Now()) {
+		return "", false, nil
+	}
+
+	if ch != s.Channel.Name && !v.SharedWithChannel(s.Channel.Name) {
+		return "", false, nil
+	}
+
 	return v.Value, true, nil
 }
 
-func (s *session) VarSet(ctx context.Context, name, value string) error {
-	err := s.Queries.UpsertVariable(ctx, dbsql.UpsertVariableParams{
+// VarSet sets a variable, returning its new value. If the value isn't valid
+// for the variable's kind, badValue is true and kind is the variable's kind.
+func (s *session) VarSet(ctx context.Context, userID int64, name, value string) (newValue string, kind string, badValue bool, err error) {
+	v, _, err := s.varLookupWithDefault(ctx, userID, name)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	kind = dbsql.VariableKindText
+	if v != nil {
+		kind = v.Kind
+	}
+
+	value, ok := dbsql.NormalizeVariableValue(kind, value)
+	if !ok {
+		return "", kind, true, nil
+	}
+
+	if err := s.Queries.UpsertVariable(ctx, dbsql.UpsertVariableParams{
 		ChannelID: s.Channel.ID,
+		UserID:    userID,
 		Name:      name,
+		Kind:      kind,
 		Value:     value,
-	})
-	if err != nil {
-		return fmt.Errorf("upserting variable: %w", err)
+	}); err != nil {
+		return "", "", false, fmt.Errorf("upserting variable: %w", err)
 	}
 
-	return nil
+	return value, kind, false, nil
 }
 
-func (s *session) VarDelete(ctx context.Context, name string) error {
+func (s *session) VarDelete(ctx context.Context, userID int64, name string) error {
 	if err := s.Queries.DeleteVariable(ctx, dbsql.DeleteVariableParams{
 		ChannelID: s.Channel.ID,
+		UserID:    userID,
 		Name:      name,
 	}); err != nil {
 		return fmt.Errorf("deleting variable: %w", err)
@@ -68,37 +130,65 @@ func (s *session) VarDelete(ctx context.Context, name string) error {
 	return nil
 }
 
-func (s *session) VarIncrement(ctx context.Context, name string, inc int64) (n int64, badVar bool, err error) {
+// VarIncrement adds inc to a variable. Text variables must hold integers, and
+// number variables may hold any number; other kinds cannot be incremented.
+func (s *session) VarIncrement(ctx context.Context, userID int64, name string, inc int64) (n string, badVar bool, err error) {
 	// TODO: Do this in a psql query, not in Go.
 
-	v, err := s.Queries.GetVariable(ctx, dbsql.GetVariableParams{
-		ChannelID: s.Channel.ID,
-		Name:      name,
-	})
-
-	if errors.Is(err, pgx.ErrNoRows) {
-		return inc, false, s.VarSet(ctx, name, strconv.FormatInt(inc, 10))
-	}
-
+	v, isDefault, err := s.varLookupWithDefault(ctx, userID, name)
 	if err != nil {
-		return 0, false, fmt.Errorf("getting variable: %w", err)
+		return "", false, err
 	}
 
-	vInt, err := strconv.ParseInt(v.Value, 10, 64)
-	if err != nil {
-		return 0, true, nil //nolint:nilerr
+	kind := dbsql.VariableKindText
+	value := "0"
+	if v != nil {
+		kind = v.Kind
+		value = v.Value
 	}
 
-	vInt += inc
+	switch kind {
+	case dbsql.VariableKindText:
+		vInt, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return "", true, nil //nolint:nilerr
+		}
+		value = strconv.FormatInt(vInt+inc, 10)
+
+	case dbsql.VariableKindNumber:
+		if vInt, err := strconv.ParseInt(value, 10, 64); err == nil {
+			value = strconv.FormatInt(vInt+inc, 10)
+		} else {
+			vFloat, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return "", true, nil //nolint:nilerr
+			}
+			value = strconv.FormatFloat(vFloat+float64(inc), 'f', -1, 64)
+		}
+
+	default:
+		return "", true, nil
+	}
 
-	v.Value = strconv.FormatInt(vInt, 10)
+	if v == nil || isDefault {
+		if err := s.Queries.UpsertVariable(ctx, dbsql.UpsertVariableParams{
+			ChannelID: s.Channel.ID,
+			UserID:    userID,
+			Name:      name,
+			Kind:      kind,
+			Value:     value,
+		}); err != nil {
+			return "", false, fmt.Errorf("upserting variable: %w", err)
+		}
+		return value, false, nil
+	}
 
 	if err := s.Queries.UpdateVariableValue(ctx, dbsql.UpdateVariableValueParams{
-		Value: v.Value,
+		Value: value,
 		ID:    v.ID,
 	}); err != nil {
-		return 0, false, fmt.Errorf("updating variable: %w", err)
+		return "", false, fmt.Errorf("updating variable: %w", err)
 	}
 
-	return vInt, false, nil
+	return value, false, nil
 }
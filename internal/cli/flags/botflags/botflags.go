@@ -5,12 +5,15 @@ import (
 	"context"
 	"net/http"
 	"runtime"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/bot"
 	"github.com/hortbot/hortbot/internal/db/botstate"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/emotes"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/extralife"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/hltb"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/lastfm"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/redirects"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/simple"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/steam"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/tinyurl"
@@ -41,20 +44,26 @@ type Bot struct {
 	SteamKey   string `long:"bot-steam-key" env:"HB_BOT_STEAM_KEY" description:"Steam API key"`
 	YouTubeKey string `long:"bot-youtube-key" env:"HB_BOT_YOUTUBE_KEY" description:"YouTube API key"`
 
+	APITimeout time.Duration `long:"bot-api-timeout" env:"HB_BOT_API_TIMEOUT" description:"Timeout for requests made by the TEXTAPI and JSONAPI actions"`
+
 	Workers int `long:"bot-workers" env:"HB_BOT_WORKERS" description:"number of concurrent workers for handling"`
 
 	PublicJoin         bool     `long:"bot-public-join" env:"HB_BOT_PUBLIC_JOIN" description:"Enable public join for all bots"`
 	PublicJoinDisabled []string `long:"bot-public-join-disabled" env:"HB_BOT_PUBLIC_JOIN_DISABLED" env-delim:"," description:"Bots to disable public join on regardless of global public join setting"`
 
 	GlobalIgnore []string `long:"bot-global-ignore" env:"HB_BOT_GLOBAL_IGNORE" env-delim:"," description:"List of users to ignore globally (e.g. known bots)"`
+
+	BackupDays int `long:"bot-backup-days" env:"HB_BOT_BACKUP_DAYS" description:"Days to keep automatic channel backups; 0 disables automatic backups"`
 }
 
 // Default contains the default flags. Make a copy of this, do not reuse.
 var Default = Bot{
 	DefaultCooldown: 5,
+	APITimeout:      5 * time.Second,
 	WebAddr:         "http://localhost:5000",
 	Workers:         runtime.GOMAXPROCS(0),
 	PublicJoin:      true,
+	BackupDays:      14,
 }
 
 // New creates a new Bot from the set flags and dependencies.
@@ -101,7 +110,10 @@ func (args *Bot) New(
 		TinyURL:                tinyurl.New(httpClient),
 		Urban:                  urban.New(httpClient),
 		Simple:                 simple.New(untrustedClient),
+		APITimeout:             args.APITimeout,
 		HLTB:                   hltb.New(untrustedClient),
+		Emotes:                 emotes.New(httpClient),
+		Redirects:              redirects.New(untrustedClient),
 		Admins:                 args.Admins,
 		SuperAdmins:            args.SuperAdmins,
 		WhitelistEnabled:       args.WhitelistEnabled,
@@ -116,6 +128,9 @@ func (args *Bot) New(
 		Cron: bot.CronConfig{
 			ValidateTokens:          true,
 			UpdateModeratedChannels: true,
+			Backups:                 args.BackupDays > 0,
+			BackupRetention:         time.Duration(args.BackupDays) * 24 * time.Hour,
+			RefreshEmotes:           true,
 		},
 	})
 
@@ -5,7 +5,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/db/migrations"
+	"github.com/hortbot/hortbot/internal/pkg/envelope"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/zikaeroh/ctxlog"
 	"go.uber.org/zap"
@@ -15,6 +17,8 @@ import (
 type SQL struct {
 	DB        string `long:"db" env:"HB_DB" description:"PostgresSQL connection string" required:"true"`
 	MigrateUp bool   `long:"db-migrate-up" env:"HB_DB_MIGRATE_UP" description:"Migrates the postgres database up"`
+
+	SecretKeys []string `long:"db-secret-key" env:"HB_DB_SECRET_KEYS" env-delim:"," description:"Key used to encrypt OAuth tokens, in the form id:base64key; the first key given encrypts new tokens"`
 }
 
 // Default contains the default flags. Make a copy of this, do not reuse.
@@ -22,6 +26,12 @@ var Default = SQL{}
 
 // Open opens a PostgreSQL connection pool.
 func (args *SQL) Open(ctx context.Context) *pgxpool.Pool {
+	keyring, err := envelope.ParseKeyring(args.SecretKeys)
+	if err != nil {
+		ctxlog.Fatal(ctx, "error parsing secret keys", zap.Error(err))
+	}
+	dbsql.SetSecretKeyring(keyring)
+
 	db, err := pgxpool.New(ctx, args.DB)
 	if err != nil {
 		ctxlog.Fatal(ctx, "error opening connection to database", zap.Error(err))
@@ -101,8 +101,7 @@ func runMessageWorker(
 		if err != nil {
 			return err
 		}
-		message := eventsubtobot.ToMessage(botLoginMap, &raw)
-		if err := b.HandleQueued(workCtx, message, lease.EnqueuedAt); err != nil {
+		if err := handleQueuedEvent(workCtx, b, botLoginMap, &raw, lease.EnqueuedAt); err != nil {
 			failErr := finishQueueOperation(workCtx, "fail chat message", func(ctx context.Context) error {
 				return queue.Fail(ctx, lease, err)
 			})
@@ -132,6 +131,34 @@ func runMessageWorker(
 	}
 }
 
+func handleQueuedEvent(
+	ctx context.Context,
+	b *corebot.Bot,
+	botLoginMap map[int64]string,
+	raw *eventsub.WebsocketMessage,
+	enqueuedAt time.Time,
+) error {
+	notification, ok := raw.Payload.(*eventsub.NotificationPayload)
+	if !ok {
+		return errors.New("queued message has invalid notification payload")
+	}
+
+	switch notification.Event.(type) {
+	case *eventsub.ChatMessageEvent:
+		return b.HandleQueued(ctx, eventsubtobot.ToMessage(botLoginMap, raw), enqueuedAt)
+	case *eventsub.ChannelRaidEvent:
+		return b.HandleRaid(ctx, eventsubtobot.ToRaid(raw))
+	case *eventsub.ChannelPollEndEvent:
+		return b.HandlePollEnd(ctx, eventsubtobot.ToPollResult(raw))
+	case *eventsub.ChannelPredictionEndEvent:
+		return b.HandlePredictionEnd(ctx, eventsubtobot.ToPredictionResult(raw))
+	case *eventsub.StreamOfflineEvent:
+		return b.HandleStreamOffline(ctx, eventsubtobot.ToStreamOffline(raw))
+	default:
+		return fmt.Errorf("queued message has unsupported event %T", notification.Event)
+	}
+}
+
 func completeHandledMessage(ctx context.Context, queue *chatqueue.Queue, lease *chatqueue.Lease) error {
 	return finishQueueOperation(ctx, "complete chat message", func(ctx context.Context) error {
 		return queue.Complete(ctx, lease)
@@ -0,0 +1,214 @@
+// Package channelbackup implements commands which export channels to files
+// and import them again, for moving channels between environments.
+package channelbackup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hortbot/hortbot/internal/cli"
+	"github.com/hortbot/hortbot/internal/cli/flags/sqlflags"
+	"github.com/hortbot/hortbot/internal/confimport"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/dbx"
+	"github.com/hortbot/hortbot/internal/pkg/jsonx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+type exportCmd struct {
+	cli.Common
+	SQL sqlflags.SQL
+
+	Dir        string `long:"dir" description:"Directory to write one JSON file per channel into" required:"true"`
+	ActiveOnly bool   `long:"active-only" description:"Only export active channels"`
+}
+
+// ExportCommand returns a fresh export-channels command. Channels may be
+// named as arguments; otherwise all channels are exported.
+func ExportCommand() cli.Command {
+	return &exportCmd{
+		Common: cli.Default,
+		SQL:    sqlflags.Default,
+	}
+}
+
+func (*exportCmd) Name() string {
+	return "export-channels"
+}
+
+func (c *exportCmd) Main(ctx context.Context, args []string) {
+	db := c.SQL.Open(ctx)
+	defer db.Close() //nolint:errcheck
+
+	queries := dbsql.New(db)
+
+	names := args
+	if len(names) == 0 {
+		var err error
+		names, err = queries.ListChannelNames(ctx, c.ActiveOnly)
+		if err != nil {
+			ctxlog.Fatal(ctx, "error listing channels", zap.Error(err))
+		}
+	}
+
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		ctxlog.Fatal(ctx, "error creating directory", zap.Error(err))
+	}
+
+	for _, name := range names {
+		name = strings.ToLower(name)
+
+		config, err := confimport.ExportByName(ctx, queries, name)
+		if err != nil {
+			ctxlog.Fatal(ctx, "error exporting channel", zap.String("channel", name), zap.Error(err))
+		}
+
+		data, err := json.MarshalIndent(config, "", "    ")
+		if err != nil {
+			ctxlog.Fatal(ctx, "error encoding channel", zap.String("channel", name), zap.Error(err))
+		}
+
+		path := filepath.Join(c.Dir, name+".json")
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			ctxlog.Fatal(ctx, "error writing channel", zap.String("channel", name), zap.Error(err))
+		}
+	}
+
+	ctxlog.Info(ctx, "exported channels", zap.Int("count", len(names)), zap.String("dir", c.Dir))
+}
+
+type importCmd struct {
+	cli.Common
+	SQL sqlflags.SQL
+
+	Replace bool `long:"replace" description:"Replace channels which already exist, rather than skipping them"`
+}
+
+// ImportCommand returns a fresh import-channels command. Its arguments are
+// files written by export-channels, or directories containing them.
+func ImportCommand() cli.Command {
+	return &importCmd{
+		Common: cli.Default,
+		SQL:    sqlflags.Default,
+	}
+}
+
+func (*importCmd) Name() string {
+	return "import-channels"
+}
+
+func (c *importCmd) Main(ctx context.Context, args []string) {
+	if len(args) == 0 {
+		ctxlog.Fatal(ctx, "no files to import")
+	}
+
+	paths, err := expandPaths(args)
+	if err != nil {
+		ctxlog.Fatal(ctx, "error listing files", zap.Error(err))
+	}
+
+	db := c.SQL.Open(ctx)
+	defer db.Close() //nolint:errcheck
+
+	imported := 0
+	skipped := 0
+
+	for _, path := range paths {
+		ctx := ctxlog.With(ctx, zap.String("path", path))
+
+		config, err := readConfig(path)
+		if err != nil {
+			ctxlog.Fatal(ctx, "error reading channel", zap.Error(err))
+		}
+		if config.Channel == nil {
+			ctxlog.Fatal(ctx, "file contains no channel")
+		}
+
+		ok, err := c.importConfig(ctx, db, config)
+		if err != nil {
+			ctxlog.Fatal(ctx, "error importing channel", zap.Error(err))
+		}
+
+		if ok {
+			imported++
+		} else {
+			skipped++
+			ctxlog.Warn(ctx, "channel already exists; skipping", zap.String("channel", config.Channel.Name))
+		}
+	}
+
+	ctxlog.Info(ctx, "imported channels", zap.Int("imported", imported), zap.Int("skipped", skipped))
+}
+
+func (c *importCmd) importConfig(ctx context.Context, db *pgxpool.Pool, config *confimport.Config) (ok bool, err error) {
+	err = dbx.Transact(ctx, db, func(ctx context.Context, tx pgx.Tx) error {
+		queries := dbsql.New(tx)
+
+		channel, err := queries.GetChannelByTwitchIDForUpdate(ctx, config.Channel.TwitchID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			ok = true
+			return config.Insert(ctx, queries)
+		}
+		if err != nil {
+			return fmt.Errorf("getting channel: %w", err)
+		}
+
+		if !c.Replace {
+			return nil
+		}
+
+		if _, err := confimport.SaveBackup(ctx, queries, channel, "import-channels", dbsql.BackupKindRestore); err != nil {
+			return err
+		}
+
+		warnings, err := config.Restore(ctx, queries, &channel, confimport.RestoreReplace)
+		for _, warning := range warnings {
+			ctxlog.Warn(ctx, warning)
+		}
+		ok = err == nil
+		return err
+	})
+	return ok, err
+}
+
+func expandPaths(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.json"))
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+func readConfig(path string) (*confimport.Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	defer f.Close()
+
+	config := &confimport.Config{}
+	if err := jsonx.DecodeSingle(f, config); err != nil {
+		return nil, fmt.Errorf("decoding config: %w", err)
+	}
+	return config, nil
+}
@@ -203,29 +203,57 @@ func queuedMessage(raw json.RawMessage, m *eventsub.WebsocketMessage) (chatqueue
 	if !ok {
 		return chatqueue.Message{}, errors.New("incoming message has invalid notification payload")
 	}
-	event, ok := notification.Event.(*eventsub.ChatMessageEvent)
-	if !ok {
-		return chatqueue.Message{}, errors.New("incoming message has invalid chat event")
-	}
 	if m.Metadata.MessageID == "" {
 		return chatqueue.Message{}, errors.New("incoming eventsub message has empty message ID")
 	}
-	if event.MessageID == "" {
-		return chatqueue.Message{}, errors.New("incoming chat event has empty message ID")
-	}
-	if event.BroadcasterUserLogin == "" {
-		return chatqueue.Message{}, fmt.Errorf("incoming chat event %q has empty broadcaster login", event.MessageID)
+
+	// Events are queued by the channel they are handled in, so that they are
+	// processed in order with that channel's chat messages.
+	var broadcasterLogin string
+
+	switch event := notification.Event.(type) {
+	case *eventsub.ChatMessageEvent:
+		if event.MessageID == "" {
+			return chatqueue.Message{}, errors.New("incoming chat event has empty message ID")
+		}
+		if event.BroadcasterUserLogin == "" {
+			return chatqueue.Message{}, fmt.Errorf("incoming chat event %q has empty broadcaster login", event.MessageID)
+		}
+		broadcasterLogin = event.BroadcasterUserLogin
+	case *eventsub.ChannelRaidEvent:
+		if event.ToBroadcasterUserLogin == "" {
+			return chatqueue.Message{}, fmt.Errorf("incoming raid event %q has empty broadcaster login", m.Metadata.MessageID)
+		}
+		broadcasterLogin = event.ToBroadcasterUserLogin
+	case *eventsub.ChannelPollEndEvent:
+		if event.BroadcasterUserLogin == "" {
+			return chatqueue.Message{}, fmt.Errorf("incoming poll event %q has empty broadcaster login", m.Metadata.MessageID)
+		}
+		broadcasterLogin = event.BroadcasterUserLogin
+	case *eventsub.ChannelPredictionEndEvent:
+		if event.BroadcasterUserLogin == "" {
+			return chatqueue.Message{}, fmt.Errorf("incoming prediction event %q has empty broadcaster login", m.Metadata.MessageID)
+		}
+		broadcasterLogin = event.BroadcasterUserLogin
+	case *eventsub.StreamOfflineEvent:
+		if event.BroadcasterUserLogin == "" {
+			return chatqueue.Message{}, fmt.Errorf("incoming stream offline event %q has empty broadcaster login", m.Metadata.MessageID)
+		}
+		broadcasterLogin = event.BroadcasterUserLogin
+	default:
+		return chatqueue.Message{}, errors.New("incoming message has invalid event")
 	}
+
 	if m.Metadata.MessageTimestamp.IsZero() {
-		return chatqueue.Message{}, fmt.Errorf("incoming chat event %q has zero timestamp", event.MessageID)
+		return chatqueue.Message{}, fmt.Errorf("incoming event %q has zero timestamp", m.Metadata.MessageID)
 	}
 	if !json.Valid(raw) {
-		return chatqueue.Message{}, fmt.Errorf("incoming chat event %q has invalid raw JSON", event.MessageID)
+		return chatqueue.Message{}, fmt.Errorf("incoming event %q has invalid raw JSON", m.Metadata.MessageID)
 	}
 
 	return chatqueue.Message{
 		ID:               m.Metadata.MessageID,
-		BroadcasterLogin: event.BroadcasterUserLogin,
+		BroadcasterLogin: broadcasterLogin,
 		MessageTimestamp: m.Metadata.MessageTimestamp,
 		EnqueuedAt:       time.Now(),
 		Payload:          raw,
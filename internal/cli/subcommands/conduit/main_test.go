@@ -36,6 +36,47 @@ func TestQueuedMessageRoundTrip(t *testing.T) {
 	assert.Equal(t, event.BroadcasterUserLogin, "channel")
 }
 
+func TestQueuedRaidMessage(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Now()
+	message := &eventsub.WebsocketMessage{
+		Metadata: &eventsub.WebsocketMessageMetadata{
+			MessageID:        "raid-notification",
+			MessageType:      "notification",
+			MessageTimestamp: timestamp,
+		},
+		Payload: &eventsub.NotificationPayload{
+			Subscription: &eventsub.Subscription{
+				Type: eventsub.ChannelRaidSubscriptionType,
+				Condition: &eventsub.ChannelRaidSubscriptionCondition{
+					ToBroadcasterUserID: idstr.IDStr(1),
+				},
+			},
+			Event: &eventsub.ChannelRaidEvent{
+				FromBroadcasterUserID:    idstr.IDStr(3),
+				FromBroadcasterUserLogin: "raider",
+				ToBroadcasterUserID:      idstr.IDStr(1),
+				ToBroadcasterUserLogin:   "channel",
+				Viewers:                  42,
+			},
+		},
+	}
+	raw, err := json.Marshal(message)
+	assert.NilError(t, err)
+
+	queued, err := queuedMessage(raw, message)
+	assert.NilError(t, err)
+	assert.Equal(t, queued.ID, "raid-notification")
+	assert.Equal(t, queued.BroadcasterLogin, "channel")
+
+	var roundTrip eventsub.WebsocketMessage
+	assert.NilError(t, json.Unmarshal(queued.Payload, &roundTrip))
+	event := roundTrip.Payload.(*eventsub.NotificationPayload).Event.(*eventsub.ChannelRaidEvent)
+	assert.Equal(t, event.FromBroadcasterUserLogin, "raider")
+	assert.Equal(t, event.Viewers, 42)
+}
+
 func TestNotificationHandlerFinishesEnqueueAfterCallerCancellation(t *testing.T) {
 	t.Parallel()
 
@@ -131,3 +172,75 @@ func testWebsocketMessage(timestamp time.Time) *eventsub.WebsocketMessage {
 		},
 	}
 }
+
+func TestQueuedPollEndMessage(t *testing.T) {
+	t.Parallel()
+
+	message := &eventsub.WebsocketMessage{
+		Metadata: &eventsub.WebsocketMessageMetadata{
+			MessageID:        "poll-notification",
+			MessageType:      "notification",
+			MessageTimestamp: time.Now(),
+		},
+		Payload: &eventsub.NotificationPayload{
+			Subscription: &eventsub.Subscription{
+				Type: eventsub.ChannelPollEndSubscriptionType,
+				Condition: &eventsub.BroadcasterSubscriptionCondition{
+					BroadcasterUserID: idstr.IDStr(1),
+				},
+			},
+			Event: &eventsub.ChannelPollEndEvent{
+				ID:                   "poll",
+				BroadcasterUserID:    idstr.IDStr(1),
+				BroadcasterUserLogin: "channel",
+				Status:               "completed",
+			},
+		},
+	}
+	raw, err := json.Marshal(message)
+	assert.NilError(t, err)
+
+	queued, err := queuedMessage(raw, message)
+	assert.NilError(t, err)
+	assert.Equal(t, queued.ID, "poll-notification")
+	assert.Equal(t, queued.BroadcasterLogin, "channel")
+
+	message.Payload.(*eventsub.NotificationPayload).Event.(*eventsub.ChannelPollEndEvent).BroadcasterUserLogin = ""
+	_, err = queuedMessage(raw, message)
+	assert.ErrorContains(t, err, "empty broadcaster login")
+}
+
+func TestQueuedStreamOfflineMessage(t *testing.T) {
+	t.Parallel()
+
+	message := &eventsub.WebsocketMessage{
+		Metadata: &eventsub.WebsocketMessageMetadata{
+			MessageID:        "offline-notification",
+			MessageType:      "notification",
+			MessageTimestamp: time.Now(),
+		},
+		Payload: &eventsub.NotificationPayload{
+			Subscription: &eventsub.Subscription{
+				Type: eventsub.StreamOfflineSubscriptionType,
+				Condition: &eventsub.BroadcasterSubscriptionCondition{
+					BroadcasterUserID: idstr.IDStr(1),
+				},
+			},
+			Event: &eventsub.StreamOfflineEvent{
+				BroadcasterUserID:    idstr.IDStr(1),
+				BroadcasterUserLogin: "channel",
+			},
+		},
+	}
+	raw, err := json.Marshal(message)
+	assert.NilError(t, err)
+
+	queued, err := queuedMessage(raw, message)
+	assert.NilError(t, err)
+	assert.Equal(t, queued.ID, "offline-notification")
+	assert.Equal(t, queued.BroadcasterLogin, "channel")
+
+	message.Payload.(*eventsub.NotificationPayload).Event.(*eventsub.StreamOfflineEvent).BroadcasterUserLogin = ""
+	_, err = queuedMessage(raw, message)
+	assert.ErrorContains(t, err, "empty broadcaster login")
+}
@@ -0,0 +1,54 @@
+// Package reencrypt implements a command which re-encrypts stored OAuth
+// tokens with the current primary secret key.
+package reencrypt
+
+import (
+	"context"
+
+	"github.com/hortbot/hortbot/internal/cli"
+	"github.com/hortbot/hortbot/internal/cli/flags/sqlflags"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/dbx"
+	"github.com/jackc/pgx/v5"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+type cmd struct {
+	cli.Common
+	SQL sqlflags.SQL
+}
+
+// Command returns a fresh reencrypt command.
+func Command() cli.Command {
+	return &cmd{
+		Common: cli.Default,
+		SQL:    sqlflags.Default,
+	}
+}
+
+func (*cmd) Name() string {
+	return "reencrypt-tokens"
+}
+
+func (c *cmd) Main(ctx context.Context, _ []string) {
+	db := c.SQL.Open(ctx)
+	defer db.Close() //nolint:errcheck
+
+	keyID := dbsql.SecretKeyID()
+	if keyID == "" {
+		ctxlog.Warn(ctx, "no secret keys configured; tokens will be stored in plaintext")
+	}
+
+	var n int
+	err := dbx.Transact(ctx, db, func(ctx context.Context, tx pgx.Tx) error {
+		var err error
+		n, err = dbsql.New(tx).ReencryptTwitchTokens(ctx)
+		return err
+	})
+	if err != nil {
+		ctxlog.Fatal(ctx, "error re-encrypting tokens", zap.Error(err))
+	}
+
+	ctxlog.Info(ctx, "re-encrypted tokens", zap.Int("updated", n), zap.String("key_id", keyID))
+}
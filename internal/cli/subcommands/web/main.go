@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/hortbot/hortbot/internal/cli"
+	"github.com/hortbot/hortbot/internal/cli/flags/botflags"
 	"github.com/hortbot/hortbot/internal/cli/flags/httpflags"
 	"github.com/hortbot/hortbot/internal/cli/flags/promflags"
 	"github.com/hortbot/hortbot/internal/cli/flags/sqlflags"
@@ -21,6 +22,7 @@ type cmd struct {
 	SQL        sqlflags.SQL
 	Twitch     twitchflags.Twitch
 	Web        webflags.Web
+	Bot        botflags.Bot
 	Prometheus promflags.Prometheus
 	HTTP       httpflags.HTTP
 }
@@ -32,6 +34,7 @@ func Command() cli.Command {
 		SQL:        sqlflags.Default,
 		Twitch:     twitchflags.Default,
 		Web:        webflags.Default,
+		Bot:        botflags.Default,
 		Prometheus: promflags.Default,
 		HTTP:       httpflags.Default,
 	}
@@ -47,11 +50,18 @@ func (c *cmd) Main(ctx context.Context, _ []string) {
 	db := c.SQL.Open(ctx)
 	defer db.Close() //nolint:errcheck
 
+	httpClient := c.HTTP.Client()
 	state := botstate.New()
-	tw := c.Twitch.Client(c.HTTP.Client())
+	tw := c.Twitch.Client(httpClient)
 	a := c.Web.New(c.Debug, state, db, tw)
 	a.EventsubUpdateNotifier = eventsubsync.Requests{}
 
+	// The bot is not initialized; it is only used to test commands, autoreplies,
+	// and filters, which never sends anything to chat.
+	b := c.Bot.New(ctx, db, state, a.EventsubUpdateNotifier, tw, httpClient, c.HTTP.UntrustedClient(ctx))
+	defer b.Stop()
+	a.DryRunner = b
+
 	if err := a.Run(ctx); err != nil {
 		ctxlog.Info(ctx, "exiting", zap.Error(err))
 	}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -46,7 +47,10 @@ type Service struct {
 	shardMu        sync.Mutex
 }
 
-type chatSubscription struct {
+// subscription identifies an EventSub subscription managed by the service.
+// BotID is only set for chat subscriptions.
+type subscription struct {
+	Type          string
 	BroadcasterID int64
 	BotID         int64
 }
@@ -314,15 +318,65 @@ func (s *Service) SynchronizeSubscriptions(ctx context.Context) error {
 		return fmt.Errorf("list active eventsub channels: %w", err)
 	}
 
-	wanted := make(map[chatSubscription]struct{})
+	raidChannels, err := s.queries.ListActiveRaidChannelIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("list active raid channels: %w", err)
+	}
+
+	pollChannels, err := s.queries.ListActiveChannelIDsWithScope(ctx, "channel:manage:polls")
+	if err != nil {
+		return fmt.Errorf("list active poll channels: %w", err)
+	}
+
+	predictionChannels, err := s.queries.ListActiveChannelIDsWithScope(ctx, "channel:manage:predictions")
+	if err != nil {
+		return fmt.Errorf("list active prediction channels: %w", err)
+	}
+
+	raidRuleChannels, err := s.queries.ListActiveChannelIDsWithChatModeTrigger(ctx, "raid")
+	if err != nil {
+		return fmt.Errorf("list active raid chat mode channels: %w", err)
+	}
+
+	offlineChannels, err := s.queries.ListActiveChannelIDsWithChatModeTrigger(ctx, "offline")
+	if err != nil {
+		return fmt.Errorf("list active offline chat mode channels: %w", err)
+	}
+
+	wanted := make(map[subscription]struct{})
 	for botID, broadcasterIDs := range channels {
 		for _, broadcasterID := range broadcasterIDs {
-			wanted[chatSubscription{
+			wanted[subscription{
+				Type:          eventsub.ChatMessageSubscriptionType,
 				BroadcasterID: broadcasterID,
 				BotID:         botID,
 			}] = struct{}{}
 		}
 	}
+	for _, broadcasterID := range slices.Concat(raidChannels, raidRuleChannels) {
+		wanted[subscription{
+			Type:          eventsub.ChannelRaidSubscriptionType,
+			BroadcasterID: broadcasterID,
+		}] = struct{}{}
+	}
+	for _, broadcasterID := range pollChannels {
+		wanted[subscription{
+			Type:          eventsub.ChannelPollEndSubscriptionType,
+			BroadcasterID: broadcasterID,
+		}] = struct{}{}
+	}
+	for _, broadcasterID := range predictionChannels {
+		wanted[subscription{
+			Type:          eventsub.ChannelPredictionEndSubscriptionType,
+			BroadcasterID: broadcasterID,
+		}] = struct{}{}
+	}
+	for _, broadcasterID := range offlineChannels {
+		wanted[subscription{
+			Type:          eventsub.StreamOfflineSubscriptionType,
+			BroadcasterID: broadcasterID,
+		}] = struct{}{}
+	}
 	metricWantedChatSubscriptions.Set(float64(len(wanted)))
 
 	allSubscriptions, err := s.twitch.GetSubscriptions(ctx)
@@ -338,7 +392,7 @@ func (s *Service) SynchronizeSubscriptions(ctx context.Context) error {
 
 	metricSubscriptions.Set(float64(len(allSubscriptions)))
 
-	actual, stale, statuses := classifyChatSubscriptions(ctx, s.conduitID, allSubscriptions)
+	actual, stale, statuses := classifySubscriptions(ctx, s.conduitID, allSubscriptions)
 	metricCurrentChatSubscriptions.Set(float64(len(actual)))
 
 	for _, status := range possibleStatuses {
@@ -381,12 +435,7 @@ func (s *Service) SynchronizeSubscriptions(ctx context.Context) error {
 	}
 
 	for sub := range toCreate {
-		if sub.BotID == 0 {
-			ctxlog.Error(ctx, "subscription has no bot ID", zap.Any("subscription", sub))
-			continue
-		}
-
-		if err := s.twitch.CreateChatSubscription(ctx, s.conduitID, sub.BroadcasterID, sub.BotID); err != nil {
+		if err := s.createSubscription(ctx, sub); err != nil {
 			ctxlog.Warn(ctx, "create subscription error", zap.Error(err), zap.Any("subscription", sub))
 			metricCreateSubscriptionErrors.Inc()
 		} else {
@@ -412,9 +461,25 @@ func (s *Service) SynchronizeSubscriptions(ctx context.Context) error {
 	return nil
 }
 
-func classifyChatSubscriptions(ctx context.Context, conduitID string, subscriptions []*eventsub.Subscription) (actual map[chatSubscription]string, stale map[string]chatSubscription, statuses map[string]int) {
-	actual = make(map[chatSubscription]string, len(subscriptions))
-	stale = make(map[string]chatSubscription)
+func (s *Service) createSubscription(ctx context.Context, sub subscription) error {
+	switch sub.Type {
+	case eventsub.ChatMessageSubscriptionType:
+		if sub.BotID == 0 {
+			return errors.New("subscription has no bot ID")
+		}
+		return s.twitch.CreateChatSubscription(ctx, s.conduitID, sub.BroadcasterID, sub.BotID)
+	case eventsub.ChannelRaidSubscriptionType:
+		return s.twitch.CreateRaidSubscription(ctx, s.conduitID, sub.BroadcasterID)
+	case eventsub.ChannelPollEndSubscriptionType, eventsub.ChannelPredictionEndSubscriptionType, eventsub.StreamOfflineSubscriptionType:
+		return s.twitch.CreateBroadcasterSubscription(ctx, s.conduitID, sub.Type, sub.BroadcasterID)
+	default:
+		return fmt.Errorf("unknown subscription type %q", sub.Type)
+	}
+}
+
+func classifySubscriptions(ctx context.Context, conduitID string, subscriptions []*eventsub.Subscription) (actual map[subscription]string, stale map[string]subscription, statuses map[string]int) {
+	actual = make(map[subscription]string, len(subscriptions))
+	stale = make(map[string]subscription)
 	statuses = make(map[string]int, len(subscriptions))
 
 	for _, sub := range subscriptions {
@@ -427,24 +492,42 @@ func classifyChatSubscriptions(ctx context.Context, conduitID string, subscripti
 			)
 			continue
 		}
-		if sub.Type != eventsub.ChatMessageSubscriptionType {
+
+		var key subscription
+		switch condition := sub.Condition.(type) {
+		case *eventsub.ChatMessageSubscriptionCondition:
+			key = subscription{
+				Type:          sub.Type,
+				BroadcasterID: int64(condition.BroadcasterUserID),
+				BotID:         int64(condition.UserID),
+			}
+		case *eventsub.ChannelRaidSubscriptionCondition:
+			// Only incoming raids are subscribed to.
+			if condition.ToBroadcasterUserID == 0 {
+				continue
+			}
+			key = subscription{
+				Type:          sub.Type,
+				BroadcasterID: int64(condition.ToBroadcasterUserID),
+			}
+		case *eventsub.BroadcasterSubscriptionCondition:
+			key = subscription{
+				Type:          sub.Type,
+				BroadcasterID: int64(condition.BroadcasterUserID),
+			}
+		default:
 			continue
 		}
 
-		condition := sub.Condition.(*eventsub.ChatMessageSubscriptionCondition)
-		chatSub := chatSubscription{
-			BroadcasterID: int64(condition.BroadcasterUserID),
-			BotID:         int64(condition.UserID),
-		}
 		if sub.Status != "enabled" {
-			stale[sub.ID] = chatSub
+			stale[sub.ID] = key
 			continue
 		}
-		if _, ok := actual[chatSub]; ok {
-			stale[sub.ID] = chatSub
+		if _, ok := actual[key]; ok {
+			stale[sub.ID] = key
 			continue
 		}
-		actual[chatSub] = sub.ID
+		actual[key] = sub.ID
 	}
 
 	return actual, stale, statuses
@@ -34,14 +34,111 @@ func TestDisabledSubscriptionIsNotCurrent(t *testing.T) {
 		},
 	}
 
-	chatSub := chatSubscription{BroadcasterID: 1, BotID: 2}
-	actual, stale, statuses := classifyChatSubscriptions(context.Background(), "conduit", []*eventsub.Subscription{sub})
+	chatSub := subscription{Type: eventsub.ChatMessageSubscriptionType, BroadcasterID: 1, BotID: 2}
+	actual, stale, statuses := classifySubscriptions(context.Background(), "conduit", []*eventsub.Subscription{sub})
 
-	assert.DeepEqual(t, actual, map[chatSubscription]string{})
-	assert.DeepEqual(t, stale, map[string]chatSubscription{"revoked": chatSub})
+	assert.DeepEqual(t, actual, map[subscription]string{})
+	assert.DeepEqual(t, stale, map[string]subscription{"revoked": chatSub})
 	assert.DeepEqual(t, statuses, map[string]int{"authorization_revoked": 1})
 }
 
+func TestClassifyRaidSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	transport := &eventsub.Transport{ConduitID: "conduit"}
+	subs := []*eventsub.Subscription{
+		{
+			ID:     "chat",
+			Status: "enabled",
+			Type:   eventsub.ChatMessageSubscriptionType,
+			Condition: &eventsub.ChatMessageSubscriptionCondition{
+				BroadcasterUserID: idstr.IDStr(1),
+				UserID:            idstr.IDStr(2),
+			},
+			Transport: transport,
+		},
+		{
+			ID:     "raid",
+			Status: "enabled",
+			Type:   eventsub.ChannelRaidSubscriptionType,
+			Condition: &eventsub.ChannelRaidSubscriptionCondition{
+				ToBroadcasterUserID: idstr.IDStr(1),
+			},
+			Transport: transport,
+		},
+		{
+			ID:     "duplicate",
+			Status: "enabled",
+			Type:   eventsub.ChannelRaidSubscriptionType,
+			Condition: &eventsub.ChannelRaidSubscriptionCondition{
+				ToBroadcasterUserID: idstr.IDStr(1),
+			},
+			Transport: transport,
+		},
+		{
+			ID:     "outgoing",
+			Status: "enabled",
+			Type:   eventsub.ChannelRaidSubscriptionType,
+			Condition: &eventsub.ChannelRaidSubscriptionCondition{
+				FromBroadcasterUserID: idstr.IDStr(1),
+			},
+			Transport: transport,
+		},
+	}
+
+	chatSub := subscription{Type: eventsub.ChatMessageSubscriptionType, BroadcasterID: 1, BotID: 2}
+	raidSub := subscription{Type: eventsub.ChannelRaidSubscriptionType, BroadcasterID: 1}
+	actual, stale, statuses := classifySubscriptions(context.Background(), "conduit", subs)
+
+	assert.DeepEqual(t, actual, map[subscription]string{chatSub: "chat", raidSub: "raid"})
+	assert.DeepEqual(t, stale, map[string]subscription{"duplicate": raidSub})
+	assert.DeepEqual(t, statuses, map[string]int{"enabled": 4})
+}
+
+func TestClassifyBroadcasterSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	transport := &eventsub.Transport{ConduitID: "conduit"}
+	subs := []*eventsub.Subscription{
+		{
+			ID:     "poll",
+			Status: "enabled",
+			Type:   eventsub.ChannelPollEndSubscriptionType,
+			Condition: &eventsub.BroadcasterSubscriptionCondition{
+				BroadcasterUserID: idstr.IDStr(1),
+			},
+			Transport: transport,
+		},
+		{
+			ID:     "prediction",
+			Status: "enabled",
+			Type:   eventsub.ChannelPredictionEndSubscriptionType,
+			Condition: &eventsub.BroadcasterSubscriptionCondition{
+				BroadcasterUserID: idstr.IDStr(1),
+			},
+			Transport: transport,
+		},
+		{
+			ID:     "revoked",
+			Status: "authorization_revoked",
+			Type:   eventsub.ChannelPollEndSubscriptionType,
+			Condition: &eventsub.BroadcasterSubscriptionCondition{
+				BroadcasterUserID: idstr.IDStr(2),
+			},
+			Transport: transport,
+		},
+	}
+
+	pollSub := subscription{Type: eventsub.ChannelPollEndSubscriptionType, BroadcasterID: 1}
+	predictionSub := subscription{Type: eventsub.ChannelPredictionEndSubscriptionType, BroadcasterID: 1}
+	revokedSub := subscription{Type: eventsub.ChannelPollEndSubscriptionType, BroadcasterID: 2}
+	actual, stale, statuses := classifySubscriptions(context.Background(), "conduit", subs)
+
+	assert.DeepEqual(t, actual, map[subscription]string{pollSub: "poll", predictionSub: "prediction"})
+	assert.DeepEqual(t, stale, map[string]subscription{"revoked": revokedSub})
+	assert.DeepEqual(t, statuses, map[string]int{"enabled": 2, "authorization_revoked": 1})
+}
+
 func TestWebsocketKeepaliveTimeout(t *testing.T) {
 	t.Parallel()
 
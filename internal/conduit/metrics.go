@@ -80,28 +80,28 @@ var (
 		Namespace: "hortbot",
 		Subsystem: "conduit",
 		Name:      "wanted_chat_subscriptions",
-		Help:      "Number of chat subscriptions wanted for active EventSub-eligible channels.",
+		Help:      "Number of managed EventSub subscriptions wanted for active EventSub-eligible channels.",
 	})
 
 	metricCurrentChatSubscriptions = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "hortbot",
 		Subsystem: "conduit",
 		Name:      "current_chat_subscriptions",
-		Help:      "Number of chat subscriptions currently using this conduit.",
+		Help:      "Number of managed EventSub subscriptions currently using this conduit.",
 	})
 
 	metricCreateChatSubscriptions = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "hortbot",
 		Subsystem: "conduit",
 		Name:      "create_chat_subscriptions",
-		Help:      "Number of chat subscriptions to create in the current sync.",
+		Help:      "Number of managed EventSub subscriptions to create in the current sync.",
 	})
 
 	metricDeleteChatSubscriptions = promauto.NewGauge(prometheus.GaugeOpts{
 		Namespace: "hortbot",
 		Subsystem: "conduit",
 		Name:      "delete_chat_subscriptions",
-		Help:      "Number of chat subscriptions to delete in the current sync.",
+		Help:      "Number of managed EventSub subscriptions to delete in the current sync.",
 	})
 
 	metricWebsockets = promauto.NewGauge(prometheus.GaugeOpts{
@@ -0,0 +1,68 @@
+package confimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+)
+
+// MaxKeptBackups is how many backups other than automatic ones are kept for
+// each channel. Automatic backups expire instead.
+const MaxKeptBackups = 20
+
+// SaveBackup exports the channel and stores the config as one of the
+// channel's backups, returning the backup's ID.
+func SaveBackup(ctx context.Context, queries *dbsql.Queries, channel dbsql.Channel, creator, kind string) (int64, error) {
+	config, err := Export(ctx, queries, channel)
+	if err != nil {
+		return 0, err
+	}
+	return StoreBackup(ctx, queries, channel.ID, config, creator, kind)
+}
+
+// StoreBackup stores a config as one of the channel's backups, returning the
+// backup's ID. Storing a backup other than an automatic one deletes the oldest
+// such backups beyond MaxKeptBackups.
+func StoreBackup(ctx context.Context, queries *dbsql.Queries, channelID int64, config *Config, creator, kind string) (int64, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return 0, fmt.Errorf("marshaling backup: %w", err)
+	}
+
+	id, err := queries.InsertChannelBackup(ctx, dbsql.InsertChannelBackupParams{
+		ChannelID: channelID,
+		Creator:   creator,
+		Kind:      kind,
+		Version:   int32(max(config.Version, 1)),
+		Data:      data,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("inserting backup: %w", err)
+	}
+
+	if kind != dbsql.BackupKindAutomatic {
+		_, err := queries.DeleteExcessChannelBackups(ctx, dbsql.DeleteExcessChannelBackupsParams{
+			ChannelID: channelID,
+			Keep:      MaxKeptBackups,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("deleting excess backups: %w", err)
+		}
+	}
+
+	return id, nil
+}
+
+// LoadBackup decodes a stored backup.
+func LoadBackup(backup *dbsql.ChannelBackup) (*Config, error) {
+	config := &Config{}
+	if err := json.Unmarshal(backup.Data, config); err != nil {
+		return nil, fmt.Errorf("unmarshaling backup: %w", err)
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
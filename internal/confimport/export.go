@@ -3,6 +3,8 @@ package confimport
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 )
@@ -13,6 +15,12 @@ func ExportByName(ctx context.Context, queries *dbsql.Queries, name string) (*Co
 	if err != nil {
 		return nil, fmt.Errorf("getting channel: %w", err)
 	}
+	return Export(ctx, queries, channelRow)
+}
+
+// Export exports a channel's full configuration, including its unexpired bot
+// state as of now.
+func Export(ctx context.Context, queries *dbsql.Queries, channelRow dbsql.Channel) (*Config, error) {
 	channel := Channel(channelRow)
 
 	quoteRows, err := queries.ListQuotes(ctx, channel.ID)
@@ -54,18 +62,70 @@ func ExportByName(ctx context.Context, queries *dbsql.Queries, name string) (*Co
 	if err != nil {
 		return nil, fmt.Errorf("getting scheduled commands: %w", err)
 	}
+	aliasRows, err := queries.ListCommandAliases(ctx, channel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting command aliases: %w", err)
+	}
 
 	commands, err := assembleCommands(
 		infoRows, customCommandRows, commandListRows,
-		repeatedCommandRows, scheduledCommandRows,
+		repeatedCommandRows, scheduledCommandRows, aliasRows,
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	highlightRows, err := queries.ListHighlights(ctx, channel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting highlights: %w", err)
+	}
+
+	filterRows, err := queries.ListChannelFilters(ctx, channel.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting filters: %w", err)
+	}
+
+	state, err := exportState(ctx, queries, strconv.FormatInt(channel.TwitchID, 10), time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
+		Version: Version,
 		Channel: &channel, Quotes: pointers(quoteRows), Commands: commands,
 		Autoreplies: autoreplies, Variables: pointers(variableRows),
+		Highlights: pointers(highlightRows), Filters: pointers(filterRows),
+		State: state,
+	}, nil
+}
+
+func exportState(ctx context.Context, queries *dbsql.Queries, channel string, now time.Time) (*State, error) {
+	commandCooldowns, err := queries.BotStateListChannelCommandCooldowns(ctx, dbsql.BotStateListChannelCommandCooldownsParams{
+		Channel: channel,
+		Now:     dbsql.TimestamptzFrom(now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting command cooldowns: %w", err)
+	}
+	linkPermits, err := queries.BotStateListChannelLinkPermits(ctx, dbsql.BotStateListChannelLinkPermitsParams{
+		Channel: channel,
+		Now:     dbsql.TimestamptzFrom(now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting link permits: %w", err)
+	}
+	filterWarnings, err := queries.BotStateListChannelFilterWarnings(ctx, dbsql.BotStateListChannelFilterWarningsParams{
+		Channel: channel,
+		Now:     dbsql.TimestamptzFrom(now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting filter warnings: %w", err)
+	}
+
+	return &State{
+		CommandCooldowns: pointers(commandCooldowns),
+		LinkPermits:      pointers(linkPermits),
+		FilterWarnings:   pointers(filterWarnings),
 	}, nil
 }
 
@@ -75,6 +135,7 @@ func assembleCommands(
 	commandListRows []dbsql.CommandList,
 	repeatedCommandRows []dbsql.RepeatedCommand,
 	scheduledCommandRows []dbsql.ScheduledCommand,
+	aliasRows []dbsql.CommandAlias,
 ) ([]*Command, error) {
 	customCommands := make(map[int64]*CustomCommand, len(customCommandRows))
 	for i := range customCommandRows {
@@ -92,6 +153,11 @@ func assembleCommands(
 	for i := range scheduledCommandRows {
 		scheduledCommands[scheduledCommandRows[i].CommandInfoID] = &scheduledCommandRows[i]
 	}
+	aliases := make(map[int64][]*CommandAlias)
+	for i := range aliasRows {
+		infoID := aliasRows[i].CommandInfoID
+		aliases[infoID] = append(aliases[infoID], &aliasRows[i])
+	}
 
 	commands := make([]*Command, len(infoRows))
 	for i := range infoRows {
@@ -100,6 +166,7 @@ func assembleCommands(
 			Info:     info,
 			Repeat:   repeatedCommands[info.ID],
 			Schedule: scheduledCommands[info.ID],
+			Aliases:  aliases[info.ID],
 		}
 		switch {
 		case info.CustomCommandID.Valid:
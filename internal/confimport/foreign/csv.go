@@ -0,0 +1,95 @@
+package foreign
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvColumns lists the header names used for each command field in CSV
+// exports, lowercased and without spaces.
+var csvColumns = map[string][]string{
+	"name":       {"command", "name", "trigger"},
+	"response":   {"response", "message", "reply", "output"},
+	"permission": {"permission", "access", "userlevel", "role"},
+	"cooldown":   {"cooldown", "globalcooldown"},
+	"enabled":    {"enabled"},
+	"count":      {"count", "uses"},
+	"aliases":    {"aliases", "alias"},
+}
+
+// convertCSVCommands converts a CSV export of commands with a header row.
+// Aliases are separated by spaces or commas.
+func convertCSVCommands(c *converter, data []byte) error {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.Join(strings.Fields(name), ""))
+		for field, names := range csvColumns {
+			for _, n := range names {
+				if n == name {
+					if _, ok := columns[field]; !ok {
+						columns[field] = i
+					}
+				}
+			}
+		}
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return errors.New("CSV has no command column")
+	}
+	if _, ok := columns["response"]; !ok {
+		return errors.New("CSV has no response column")
+	}
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading CSV: %w", err)
+		}
+
+		get := func(field string) string {
+			i, ok := columns[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		name := get("name")
+		cooldown, _ := strconv.Atoi(get("cooldown"))
+		count, _ := strconv.ParseInt(get("count"), 10, 64)
+
+		enabled := true
+		if v := get("enabled"); v != "" {
+			enabled, _ = strconv.ParseBool(strings.ToLower(v))
+		}
+
+		c.addCommand(command{
+			name:        name,
+			responses:   []string{get("response")},
+			accessLevel: c.accessLevel(name, get("permission")),
+			aliases: strings.FieldsFunc(get("aliases"), func(r rune) bool {
+				return r == ' ' || r == ','
+			}),
+			enabled:  enabled,
+			count:    count,
+			cooldown: cooldown != 0,
+		})
+	}
+}
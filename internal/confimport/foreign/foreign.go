@@ -0,0 +1,398 @@
+// Package foreign converts channel configurations exported from other chat
+// bots into configs which may be inserted with confimport.
+package foreign
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hortbot/hortbot/internal/confimport"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/repeat"
+)
+
+// Format is the name of a supported export format.
+type Format string
+
+// Supported export formats.
+const (
+	FormatNightbot       Format = "nightbot"
+	FormatStreamElements Format = "streamelements"
+	FormatStreamlabs     Format = "streamlabs"
+	FormatFossabot       Format = "fossabot"
+	FormatMoobot         Format = "moobot"
+)
+
+// Formats lists the supported export formats.
+var Formats = []Format{
+	FormatNightbot,
+	FormatStreamElements,
+	FormatStreamlabs,
+	FormatFossabot,
+	FormatMoobot,
+}
+
+// ErrUnknownFormat is returned when converting an unsupported format.
+var ErrUnknownFormat = errors.New("foreign: unknown format")
+
+// Options describes the channel the converted config will create.
+type Options struct {
+	TwitchID    int64
+	Name        string
+	DisplayName string
+	BotName     string
+}
+
+// Result is a converted config, along with a description of everything which
+// could not be converted exactly.
+type Result struct {
+	Config   *confimport.Config `json:"config"`
+	Warnings []string           `json:"warnings"`
+}
+
+// Convert converts an export in the given format into a config.
+func Convert(format Format, data []byte, opts Options) (*Result, error) {
+	if opts.TwitchID == 0 || opts.Name == "" || opts.BotName == "" {
+		return nil, errors.New("foreign: channel ID, name, and bot name are required")
+	}
+
+	var fn func(*converter, []byte) error
+
+	switch format {
+	case FormatNightbot:
+		fn = convertNightbot
+	case FormatStreamElements:
+		fn = convertStreamElements
+	case FormatStreamlabs:
+		fn = convertStreamlabs
+	case FormatFossabot:
+		fn = convertFossabot
+	case FormatMoobot:
+		fn = convertMoobot
+	default:
+		return nil, ErrUnknownFormat
+	}
+
+	c := newConverter(format, opts)
+	if err := fn(c, data); err != nil {
+		return nil, fmt.Errorf("foreign: converting %s export: %w", format, err)
+	}
+
+	if c.cooldowns != 0 {
+		c.warnf("Cooldowns of %d %s were not imported; the channel cooldown applies instead.", c.cooldowns, plural(c.cooldowns, "command", "commands"))
+	}
+
+	return &Result{
+		Config:   c.config,
+		Warnings: c.warnings,
+	}, nil
+}
+
+type converter struct {
+	format    Format
+	syntax    *syntax
+	config    *confimport.Config
+	names     map[string]bool
+	warnings  []string
+	cooldowns int
+}
+
+func newConverter(format Format, opts Options) *converter {
+	return &converter{
+		format: format,
+		syntax: syntaxes[format],
+		config: &confimport.Config{
+			Channel: newChannel(opts),
+		},
+		names: make(map[string]bool),
+	}
+}
+
+// newChannel creates a channel with the same defaults as the
+// InsertDefaultChannel query.
+func newChannel(opts Options) *confimport.Channel {
+	displayName := opts.DisplayName
+	if displayName == "" {
+		displayName = opts.Name
+	}
+
+	return &confimport.Channel{
+		TwitchID:                opts.TwitchID,
+		Name:                    strings.ToLower(opts.Name),
+		DisplayName:             displayName,
+		BotName:                 strings.ToLower(opts.BotName),
+		Active:                  true,
+		Prefix:                  "!",
+		Mode:                    dbsql.AccessLevelEveryone,
+		ShouldModerate:          true,
+		EnableWarnings:          true,
+		SubsMayLink:             true,
+		TimeoutDuration:         600,
+		RollLevel:               dbsql.AccessLevelSubscriber,
+		RollCooldown:            10,
+		RollDefault:             20,
+		FilterCapsPercentage:    50,
+		FilterCapsMinCaps:       6,
+		FilterSymbolsPercentage: 50,
+		FilterSymbolsMinSymbols: 5,
+		FilterMaxLength:         500,
+		FilterEmotesMax:         4,
+		Tweet:                   "Check out (_CHANNEL_URL_) playing (_GAME_) on @Twitch!",
+		FilterExemptLevel:       dbsql.AccessLevelSubscriber,
+	}
+}
+
+func (c *converter) warnf(format string, args ...any) {
+	c.warnings = append(c.warnings, fmt.Sprintf(format, args...))
+}
+
+func (c *converter) creator() string {
+	return string(c.format) + " import"
+}
+
+// command is a command in a foreign export, with its response still in the
+// foreign bot's syntax.
+type command struct {
+	name        string
+	responses   []string
+	accessLevel dbsql.AccessLevel
+	aliases     []string
+	enabled     bool
+	count       int64
+	cooldown    bool
+}
+
+// timer is a periodic message in a foreign export. Exactly one of interval and
+// cron should be set.
+type timer struct {
+	name      string
+	responses []string
+	interval  time.Duration
+	cron      string
+	lines     int64
+	enabled   bool
+}
+
+func (c *converter) addCommand(cmd command) {
+	name := cleanCommandName(cmd.name)
+	if name == "" {
+		c.warnf("Command %q has no usable name and was skipped.", cmd.name)
+		return
+	}
+
+	if c.names[name] {
+		c.warnf("Command %s is a duplicate and was skipped.", name)
+		return
+	}
+
+	responses := c.convertResponses("command "+name, name, cmd.responses)
+	if len(responses) == 0 {
+		c.warnf("Command %s has no response and was skipped.", name)
+		return
+	}
+
+	if cmd.cooldown {
+		c.cooldowns++
+	}
+
+	c.names[name] = true
+	imported := c.newCommand(name, responses, cmd.accessLevel, cmd.enabled)
+	imported.Info.Count = cmd.count
+
+	for _, alias := range cmd.aliases {
+		alias = cleanCommandName(alias)
+		if alias == "" || alias == name {
+			continue
+		}
+		if c.names[alias] {
+			c.warnf("Alias %s of command %s is already in use and was skipped.", alias, name)
+			continue
+		}
+		c.names[alias] = true
+		imported.Aliases = append(imported.Aliases, &confimport.CommandAlias{
+			Name:    alias,
+			Creator: c.creator(),
+		})
+	}
+
+	c.config.Commands = append(c.config.Commands, imported)
+}
+
+func (c *converter) addTimer(t timer) {
+	name := cleanCommandName(t.name)
+	if name == "" || c.names[name] {
+		for i := 1; ; i++ {
+			name = "timer" + strconv.Itoa(i)
+			if !c.names[name] {
+				break
+			}
+		}
+	}
+
+	what := "timer " + name
+	if t.name != "" && cleanCommandName(t.name) != name {
+		what = fmt.Sprintf("timer %q (imported as %s)", t.name, name)
+	}
+
+	if t.cron != "" {
+		if _, err := repeat.ParseCron(t.cron); err != nil {
+			c.warnf("The %s has an unsupported schedule %q and was skipped.", what, t.cron)
+			return
+		}
+	}
+
+	responses := c.convertResponses(what, "", t.responses)
+	if len(responses) == 0 {
+		c.warnf("The %s has no message and was skipped.", what)
+		return
+	}
+
+	lines := max(t.lines, 1)
+	imported := c.newCommand(name, responses, dbsql.AccessLevelModerator, true)
+
+	if t.cron != "" {
+		imported.Schedule = &confimport.ScheduledCommand{
+			Enabled:        t.enabled,
+			CronExpression: t.cron,
+			MessageDiff:    lines,
+			Creator:        c.creator(),
+			Editor:         c.creator(),
+		}
+	} else {
+		delay := t.interval
+		if delay < minTimerInterval {
+			c.warnf("The %s ran more often than allowed and now runs every %v.", what, minTimerInterval)
+			delay = minTimerInterval
+		}
+		imported.Repeat = &confimport.RepeatedCommand{
+			Enabled:     t.enabled,
+			Delay:       int32(delay / time.Second),
+			MessageDiff: lines,
+			Creator:     c.creator(),
+			Editor:      c.creator(),
+		}
+	}
+
+	c.names[name] = true
+	c.config.Commands = append(c.config.Commands, imported)
+}
+
+// minTimerInterval matches the shortest delay allowed by !repeat.
+const minTimerInterval = 30 * time.Second
+
+func (c *converter) newCommand(name string, responses []string, level dbsql.AccessLevel, enabled bool) *confimport.Command {
+	imported := &confimport.Command{
+		Info: &confimport.CommandInfo{
+			Name:        name,
+			AccessLevel: level,
+			Creator:     c.creator(),
+			Editor:      c.creator(),
+			Enabled:     enabled,
+		},
+	}
+
+	if len(responses) == 1 {
+		imported.CustomCommand = &confimport.CustomCommand{Message: responses[0]}
+	} else {
+		imported.CommandList = &confimport.CommandList{Items: responses}
+	}
+
+	return imported
+}
+
+func (c *converter) convertResponses(what, commandName string, responses []string) []string {
+	converted := make([]string, 0, len(responses))
+	for _, response := range responses {
+		response = strings.TrimSpace(response)
+		if response == "" {
+			continue
+		}
+		converted = append(converted, c.translate(what, commandName, response))
+	}
+	return converted
+}
+
+func (c *converter) addQuote(text, creator string, createdAt time.Time) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	if creator == "" {
+		creator = c.creator()
+	}
+
+	quote := &confimport.Quote{
+		Num:     int32(len(c.config.Quotes) + 1),
+		Quote:   text,
+		Creator: creator,
+		Editor:  creator,
+	}
+	if !createdAt.IsZero() {
+		quote.CreatedAt = dbsql.TimestamptzFrom(createdAt)
+		quote.UpdatedAt = quote.CreatedAt
+	}
+
+	c.config.Quotes = append(c.config.Quotes, quote)
+}
+
+// cleanCommandName matches the bot's normalization of command names, after
+// removing any prefix the other bot kept as part of the name.
+func cleanCommandName(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimLeft(s, "!")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, s)
+	return strings.ToLower(s)
+}
+
+// accessLevel maps the role names used by most bots to access levels.
+// Regulars do not exist in HortBot, so are treated as subscribers.
+func (c *converter) accessLevel(commandName, role string) dbsql.AccessLevel {
+	switch strings.ToLower(strings.TrimSpace(role)) {
+	case "", "everyone", "all", "viewer", "viewers", "follower", "followers":
+		return dbsql.AccessLevelEveryone
+	case "regular", "regulars", "subscriber", "subscribers", "sub", "subs":
+		return dbsql.AccessLevelSubscriber
+	case "vip", "vips", "twitch_vip":
+		return dbsql.AccessLevelVip
+	case "moderator", "moderators", "mod", "mods", "editor", "editors", "admin", "supermod", "super moderator":
+		return dbsql.AccessLevelModerator
+	case "owner", "broadcaster", "streamer", "caster":
+		return dbsql.AccessLevelBroadcaster
+	default:
+		c.warnf("The command %s had unknown permission %q and is now moderator only.", cleanCommandName(commandName), role)
+		return dbsql.AccessLevelModerator
+	}
+}
+
+// isEnabled treats items without an enabled flag as enabled.
+func isEnabled(enabled *bool) bool {
+	return enabled == nil || *enabled
+}
+
+func plural(n int, singular, plural string) string {
+	if n == 1 {
+		return singular
+	}
+	return plural
+}
+
+// sortedKeys is used to produce stable warnings from maps.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
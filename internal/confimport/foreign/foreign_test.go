@@ -0,0 +1,222 @@
+package foreign_test
+
+import (
+	"testing"
+
+	"github.com/hortbot/hortbot/internal/confimport/foreign"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+var testOptions = foreign.Options{
+	TwitchID:    1234,
+	Name:        "SomeChannel",
+	DisplayName: "SomeChannel",
+	BotName:     "hortbot",
+}
+
+func TestNightbot(t *testing.T) {
+	t.Parallel()
+
+	const data = `{
+		"commands": [
+			{"name": "!hug", "message": "$(user) hugs $(touser)!", "coolDown": 5, "count": 12, "userLevel": "everyone"},
+			{"name": "!weather", "message": "$(urlfetch https://example.com/weather?q=$(query))", "coolDown": 30, "userLevel": "regular"},
+			{"name": "!roll", "message": "You rolled $(randomnum 1-6). $(eval 1+1)", "coolDown": 5, "userLevel": "moderator"},
+			{"name": "!hugs", "alias": "!hug", "coolDown": 5, "userLevel": "everyone"}
+		],
+		"timers": [
+			{"name": "Discord", "message": "Join the Discord!", "interval": "*/15 * * * *", "lines": 2, "enabled": true},
+			{"name": "Broken", "message": "Nope", "interval": "every so often", "lines": 2, "enabled": true}
+		]
+	}`
+
+	result, err := foreign.Convert(foreign.FormatNightbot, []byte(data), testOptions)
+	assert.NilError(t, err)
+
+	config := result.Config
+	assert.Equal(t, config.Channel.Name, "somechannel")
+	assert.Equal(t, config.Channel.TwitchID, int64(1234))
+	assert.Equal(t, config.Channel.Prefix, "!")
+
+	assert.Assert(t, is.Len(config.Commands, 4))
+
+	hug := config.Commands[0]
+	assert.Equal(t, hug.Info.Name, "hug")
+	assert.Equal(t, hug.Info.AccessLevel, dbsql.AccessLevelEveryone)
+	assert.Equal(t, hug.Info.Count, int64(12))
+	assert.Equal(t, hug.CustomCommand.Message, "(_USER_DISPLAY_) hugs (_P_OR_(_USER_DISPLAY_)_)!")
+
+	weather := config.Commands[1]
+	assert.Equal(t, weather.Info.AccessLevel, dbsql.AccessLevelSubscriber)
+	assert.Equal(t, weather.CustomCommand.Message, "(_TEXTAPI_https://example.com/weather?q=(_PARAMETER_)_)")
+
+	roll := config.Commands[2]
+	assert.Equal(t, roll.Info.AccessLevel, dbsql.AccessLevelModerator)
+	assert.Equal(t, roll.CustomCommand.Message, "You rolled (_RANDOM_INT_1_7_). $(eval 1+1)")
+
+	discord := config.Commands[3]
+	assert.Equal(t, discord.Info.Name, "discord")
+	assert.Equal(t, discord.CustomCommand.Message, "Join the Discord!")
+	assert.Assert(t, discord.Repeat == nil)
+	assert.Equal(t, discord.Schedule.CronExpression, "*/15 * * * *")
+	assert.Equal(t, discord.Schedule.MessageDiff, int64(2))
+
+	assert.DeepEqual(t, result.Warnings, []string{
+		"The command roll uses unsupported variables, which were left as-is: $(eval 1+1)",
+		"Command !hugs runs !hug, which is not supported; add it as an alias instead.",
+		`The timer broken has an unsupported schedule "every so often" and was skipped.`,
+		"Cooldowns of 1 command were not imported; the channel cooldown applies instead.",
+	})
+}
+
+func TestStreamElements(t *testing.T) {
+	t.Parallel()
+
+	const data = `{
+		"commands": [
+			{"command": "lurk", "reply": "${sender} is lurking. ${channel} thanks you! (${count})", "accessLevel": 100, "aliases": ["Lurking", "lurk"], "enabled": true, "cooldown": {"user": 0, "global": 0}},
+			{"command": "so", "reply": "Go follow ${1}!", "accessLevel": 500, "enabled": false, "cooldown": {"user": 10, "global": 5}},
+			{"command": "vip", "reply": "${random.1-100}", "accessLevel": 400, "enabled": true}
+		],
+		"timers": [
+			{"name": "Socials", "messages": ["Follow me!", "Join the Discord!"], "enabled": true, "interval": {"online": 10, "offline": 0}, "chatLines": 5},
+			{"name": "lurk", "messages": ["Fast"], "interval": {"online": 0, "offline": 0}}
+		],
+		"quotes": [
+			{"message": "Something funny.", "username": "somemod", "createdAt": "2020-01-02T03:04:05Z"}
+		]
+	}`
+
+	result, err := foreign.Convert(foreign.FormatStreamElements, []byte(data), testOptions)
+	assert.NilError(t, err)
+
+	config := result.Config
+	assert.Assert(t, is.Len(config.Commands, 5))
+
+	lurk := config.Commands[0]
+	assert.Equal(t, lurk.CustomCommand.Message, "(_USER_DISPLAY_) is lurking. SomeChannel thanks you! ((_LURK_COUNT_))")
+	assert.Assert(t, is.Len(lurk.Aliases, 1))
+	assert.Equal(t, lurk.Aliases[0].Name, "lurking")
+
+	so := config.Commands[1]
+	assert.Equal(t, so.Info.AccessLevel, dbsql.AccessLevelModerator)
+	assert.Equal(t, so.Info.Enabled, false)
+	assert.Equal(t, so.CustomCommand.Message, "Go follow (_P_1_)!")
+
+	vip := config.Commands[2]
+	assert.Equal(t, vip.Info.AccessLevel, dbsql.AccessLevelVip)
+	assert.Equal(t, vip.Info.Enabled, true)
+
+	socials := config.Commands[3]
+	assert.Equal(t, socials.Info.Name, "socials")
+	assert.Assert(t, socials.CustomCommand == nil)
+	assert.DeepEqual(t, socials.CommandList.Items, []string{"Follow me!", "Join the Discord!"})
+	assert.Equal(t, socials.Repeat.Delay, int32(600))
+	assert.Equal(t, socials.Repeat.MessageDiff, int64(5))
+
+	timer := config.Commands[4]
+	assert.Equal(t, timer.Info.Name, "timer1")
+	assert.Equal(t, timer.Repeat.Delay, int32(30))
+	assert.Equal(t, timer.Repeat.Enabled, true)
+
+	assert.Assert(t, is.Len(config.Quotes, 1))
+	assert.Equal(t, config.Quotes[0].Num, int32(1))
+	assert.Equal(t, config.Quotes[0].Quote, "Something funny.")
+	assert.Equal(t, config.Quotes[0].Creator, "somemod")
+	assert.Equal(t, config.Quotes[0].CreatedAt.Time.Year(), 2020)
+
+	assert.DeepEqual(t, result.Warnings, []string{
+		"The command so uses numbered arguments, which are separated by semicolons instead of spaces.",
+		`The timer "lurk" (imported as timer1) ran more often than allowed and now runs every 30s.`,
+		"Cooldowns of 1 command were not imported; the channel cooldown applies instead.",
+	})
+}
+
+func TestStreamlabsCSV(t *testing.T) {
+	t.Parallel()
+
+	const data = "Command,Permission,Response,Cooldown,Enabled\n" +
+		"!discord,Everyone,\"Join us, {user}!\",0,true\n" +
+		"!secret,Streamer,{mystery},0,false\n" +
+		"!bad,Nobody,Hi,0,true\n" +
+		",Everyone,Nameless,0,true\n"
+
+	result, err := foreign.Convert(foreign.FormatStreamlabs, []byte(data), testOptions)
+	assert.NilError(t, err)
+
+	config := result.Config
+	assert.Assert(t, is.Len(config.Commands, 3))
+	assert.Equal(t, config.Commands[0].CustomCommand.Message, "Join us, (_USER_DISPLAY_)!")
+	assert.Equal(t, config.Commands[1].Info.AccessLevel, dbsql.AccessLevelBroadcaster)
+	assert.Equal(t, config.Commands[1].Info.Enabled, false)
+	assert.Equal(t, config.Commands[2].Info.AccessLevel, dbsql.AccessLevelModerator)
+
+	assert.DeepEqual(t, result.Warnings, []string{
+		"The command secret uses unsupported variables, which were left as-is: {mystery}",
+		`The command bad had unknown permission "Nobody" and is now moderator only.`,
+		`Command "" has no usable name and was skipped.`,
+	})
+}
+
+func TestFossabot(t *testing.T) {
+	t.Parallel()
+
+	const data = `{
+		"commands": [
+			{"name": "uptime", "response": "$(channel) is playing $(game).", "minimum_role": "everyone", "aliases": ["up"]},
+			{"name": "up", "response": "Duplicate alias.", "minimum_role": "everyone"}
+		],
+		"timers": [
+			{"name": "uptime", "response": "Hello!", "interval_minutes": 20, "chat_lines": 0, "enabled": false}
+		]
+	}`
+
+	result, err := foreign.Convert(foreign.FormatFossabot, []byte(data), testOptions)
+	assert.NilError(t, err)
+
+	config := result.Config
+	assert.Assert(t, is.Len(config.Commands, 2))
+	assert.Equal(t, config.Commands[0].Info.Enabled, true)
+	assert.Equal(t, config.Commands[0].CustomCommand.Message, "SomeChannel is playing (_GAME_).")
+	assert.Equal(t, config.Commands[1].Info.Name, "timer1")
+	assert.Equal(t, config.Commands[1].Repeat.Delay, int32(1200))
+	assert.Equal(t, config.Commands[1].Repeat.MessageDiff, int64(1))
+	assert.Equal(t, config.Commands[1].Repeat.Enabled, false)
+
+	assert.DeepEqual(t, result.Warnings, []string{
+		"Command up is a duplicate and was skipped.",
+	})
+}
+
+func TestMoobotCSV(t *testing.T) {
+	t.Parallel()
+
+	const data = "Name,Response,Access,Cooldown\n" +
+		"socials,Follow {username} on Twitter!,Moderators,15\n"
+
+	result, err := foreign.Convert(foreign.FormatMoobot, []byte(data), testOptions)
+	assert.NilError(t, err)
+
+	config := result.Config
+	assert.Assert(t, is.Len(config.Commands, 1))
+	assert.Equal(t, config.Commands[0].Info.AccessLevel, dbsql.AccessLevelModerator)
+	assert.Equal(t, config.Commands[0].CustomCommand.Message, "Follow (_USER_DISPLAY_) on Twitter!")
+}
+
+func TestConvertErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := foreign.Convert("unknown", []byte("{}"), testOptions)
+	assert.ErrorIs(t, err, foreign.ErrUnknownFormat)
+
+	_, err = foreign.Convert(foreign.FormatNightbot, []byte("{}"), foreign.Options{})
+	assert.ErrorContains(t, err, "required")
+
+	_, err = foreign.Convert(foreign.FormatNightbot, []byte("not json"), testOptions)
+	assert.ErrorContains(t, err, "decoding export")
+
+	_, err = foreign.Convert(foreign.FormatMoobot, []byte("Response\nHello\n"), testOptions)
+	assert.ErrorContains(t, err, "no command column")
+}
@@ -0,0 +1,56 @@
+package foreign
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type fossabotExport struct {
+	Commands []struct {
+		Name           string   `json:"name"`
+		Response       string   `json:"response"`
+		Enabled        *bool    `json:"enabled"`
+		MinimumRole    string   `json:"minimum_role"`
+		GlobalCooldown int      `json:"global_cooldown"`
+		UserCooldown   int      `json:"user_cooldown"`
+		Aliases        []string `json:"aliases"`
+	} `json:"commands"`
+	Timers []struct {
+		Name            string `json:"name"`
+		Response        string `json:"response"`
+		IntervalMinutes int    `json:"interval_minutes"`
+		ChatLines       int64  `json:"chat_lines"`
+		Enabled         *bool  `json:"enabled"`
+	} `json:"timers"`
+}
+
+func convertFossabot(c *converter, data []byte) error {
+	var export fossabotExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("decoding export: %w", err)
+	}
+
+	for _, cmd := range export.Commands {
+		c.addCommand(command{
+			name:        cmd.Name,
+			responses:   []string{cmd.Response},
+			accessLevel: c.accessLevel(cmd.Name, cmd.MinimumRole),
+			aliases:     cmd.Aliases,
+			enabled:     isEnabled(cmd.Enabled),
+			cooldown:    cmd.GlobalCooldown != 0 || cmd.UserCooldown != 0,
+		})
+	}
+
+	for _, t := range export.Timers {
+		c.addTimer(timer{
+			name:      t.Name,
+			responses: []string{t.Response},
+			interval:  time.Duration(t.IntervalMinutes) * time.Minute,
+			lines:     t.ChatLines,
+			enabled:   isEnabled(t.Enabled),
+		})
+	}
+
+	return nil
+}
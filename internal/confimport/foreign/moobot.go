@@ -0,0 +1,68 @@
+package foreign
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// moobotExport is a Moobot export. Moobot's dashboard can also export only
+// commands, as CSV.
+type moobotExport struct {
+	Commands []struct {
+		Name       string `json:"name"`
+		Response   string `json:"response"`
+		Permission string `json:"permission"`
+		Cooldown   int    `json:"cooldown"`
+		Enabled    *bool  `json:"enabled"`
+	} `json:"commands"`
+	Timers []struct {
+		Name     string `json:"name"`
+		Response string `json:"response"`
+		Interval int    `json:"interval"`
+		Lines    int64  `json:"lines"`
+		Enabled  *bool  `json:"enabled"`
+	} `json:"timers"`
+	Quotes []struct {
+		Quote     string    `json:"quote"`
+		AddedBy   string    `json:"added_by"`
+		CreatedAt time.Time `json:"created_at"`
+	} `json:"quotes"`
+}
+
+func convertMoobot(c *converter, data []byte) error {
+	if !isJSONObject(data) {
+		return convertCSVCommands(c, data)
+	}
+
+	var export moobotExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("decoding export: %w", err)
+	}
+
+	for _, cmd := range export.Commands {
+		c.addCommand(command{
+			name:        cmd.Name,
+			responses:   []string{cmd.Response},
+			accessLevel: c.accessLevel(cmd.Name, cmd.Permission),
+			enabled:     isEnabled(cmd.Enabled),
+			cooldown:    cmd.Cooldown != 0,
+		})
+	}
+
+	for _, t := range export.Timers {
+		c.addTimer(timer{
+			name:      t.Name,
+			responses: []string{t.Response},
+			interval:  time.Duration(t.Interval) * time.Minute,
+			lines:     t.Lines,
+			enabled:   isEnabled(t.Enabled),
+		})
+	}
+
+	for _, q := range export.Quotes {
+		c.addQuote(q.Quote, q.AddedBy, q.CreatedAt)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package foreign
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// nightbotExport combines the responses of Nightbot's commands and timers
+// APIs, which is how Nightbot configurations are exported.
+type nightbotExport struct {
+	Commands []struct {
+		Name      string `json:"name"`
+		Message   string `json:"message"`
+		CoolDown  int    `json:"coolDown"`
+		Count     int64  `json:"count"`
+		UserLevel string `json:"userLevel"`
+		Alias     string `json:"alias"`
+	} `json:"commands"`
+	Timers []struct {
+		Name     string `json:"name"`
+		Message  string `json:"message"`
+		Interval string `json:"interval"`
+		Lines    int64  `json:"lines"`
+		Enabled  *bool  `json:"enabled"`
+	} `json:"timers"`
+}
+
+func convertNightbot(c *converter, data []byte) error {
+	var export nightbotExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("decoding export: %w", err)
+	}
+
+	for _, cmd := range export.Commands {
+		if cmd.Alias != "" {
+			c.warnf("Command %s runs %s, which is not supported; add it as an alias instead.", cmd.Name, cmd.Alias)
+			continue
+		}
+
+		c.addCommand(command{
+			name:        cmd.Name,
+			responses:   []string{cmd.Message},
+			accessLevel: c.accessLevel(cmd.Name, cmd.UserLevel),
+			enabled:     true,
+			count:       cmd.Count,
+			cooldown:    cmd.CoolDown > defaultNightbotCooldown,
+		})
+	}
+
+	for _, t := range export.Timers {
+		c.addTimer(timer{
+			name:      t.Name,
+			responses: []string{t.Message},
+			cron:      t.Interval,
+			lines:     t.Lines,
+			enabled:   isEnabled(t.Enabled),
+		})
+	}
+
+	return nil
+}
+
+// defaultNightbotCooldown is the cooldown Nightbot gives new commands, which
+// is not worth reporting.
+const defaultNightbotCooldown = 5
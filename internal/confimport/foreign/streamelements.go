@@ -0,0 +1,96 @@
+package foreign
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+)
+
+// streamElementsExport combines the responses of StreamElements' commands,
+// timers, and quotes APIs.
+type streamElementsExport struct {
+	Commands []struct {
+		Command     string   `json:"command"`
+		Reply       string   `json:"reply"`
+		AccessLevel int      `json:"accessLevel"`
+		Aliases     []string `json:"aliases"`
+		Enabled     *bool    `json:"enabled"`
+		Cooldown    struct {
+			User   int `json:"user"`
+			Global int `json:"global"`
+		} `json:"cooldown"`
+	} `json:"commands"`
+	Timers []struct {
+		Name     string   `json:"name"`
+		Messages []string `json:"messages"`
+		Enabled  *bool    `json:"enabled"`
+		Interval struct {
+			Online  int `json:"online"`
+			Offline int `json:"offline"`
+		} `json:"interval"`
+		ChatLines int64 `json:"chatLines"`
+	} `json:"timers"`
+	Quotes []struct {
+		Message   string    `json:"message"`
+		Username  string    `json:"username"`
+		CreatedAt time.Time `json:"createdAt"`
+	} `json:"quotes"`
+}
+
+func convertStreamElements(c *converter, data []byte) error {
+	var export streamElementsExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("decoding export: %w", err)
+	}
+
+	for _, cmd := range export.Commands {
+		c.addCommand(command{
+			name:        cmd.Command,
+			responses:   []string{cmd.Reply},
+			accessLevel: streamElementsAccessLevel(cmd.AccessLevel),
+			aliases:     cmd.Aliases,
+			enabled:     isEnabled(cmd.Enabled),
+			cooldown:    cmd.Cooldown.User != 0 || cmd.Cooldown.Global != 0,
+		})
+	}
+
+	for _, t := range export.Timers {
+		interval := t.Interval.Online
+		if interval == 0 {
+			interval = t.Interval.Offline
+		}
+
+		c.addTimer(timer{
+			name:      t.Name,
+			responses: t.Messages,
+			interval:  time.Duration(interval) * time.Minute,
+			lines:     t.ChatLines,
+			enabled:   isEnabled(t.Enabled),
+		})
+	}
+
+	for _, q := range export.Quotes {
+		c.addQuote(q.Message, q.Username, q.CreatedAt)
+	}
+
+	return nil
+}
+
+// streamElementsAccessLevel converts StreamElements' numeric access levels.
+// Regulars (300) are treated as subscribers.
+func streamElementsAccessLevel(level int) dbsql.AccessLevel {
+	switch {
+	case level >= 1500:
+		return dbsql.AccessLevelBroadcaster
+	case level >= 500:
+		return dbsql.AccessLevelModerator
+	case level >= 400:
+		return dbsql.AccessLevelVip
+	case level >= 250:
+		return dbsql.AccessLevelSubscriber
+	default:
+		return dbsql.AccessLevelEveryone
+	}
+}
@@ -0,0 +1,78 @@
+package foreign
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// streamlabsExport is a Streamlabs Cloudbot export. Commands may also be
+// imported from Cloudbot's CSV export.
+type streamlabsExport struct {
+	Commands []struct {
+		Command      string   `json:"command"`
+		Response     string   `json:"response"`
+		Permission   string   `json:"permission"`
+		Cooldown     int      `json:"cooldown"`
+		UserCooldown int      `json:"user_cooldown"`
+		Enabled      *bool    `json:"enabled"`
+		Aliases      []string `json:"aliases"`
+	} `json:"commands"`
+	Timers []struct {
+		Name        string `json:"name"`
+		Response    string `json:"response"`
+		Interval    int    `json:"interval"`
+		LineMinimum int64  `json:"line_minimum"`
+		Enabled     *bool  `json:"enabled"`
+	} `json:"timers"`
+	Quotes []struct {
+		Quote string `json:"quote"`
+		Date  string `json:"date"`
+	} `json:"quotes"`
+}
+
+func convertStreamlabs(c *converter, data []byte) error {
+	if !isJSONObject(data) {
+		return convertCSVCommands(c, data)
+	}
+
+	var export streamlabsExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return fmt.Errorf("decoding export: %w", err)
+	}
+
+	for _, cmd := range export.Commands {
+		c.addCommand(command{
+			name:        cmd.Command,
+			responses:   []string{cmd.Response},
+			accessLevel: c.accessLevel(cmd.Command, cmd.Permission),
+			aliases:     cmd.Aliases,
+			enabled:     isEnabled(cmd.Enabled),
+			cooldown:    cmd.Cooldown != 0 || cmd.UserCooldown != 0,
+		})
+	}
+
+	for _, t := range export.Timers {
+		c.addTimer(timer{
+			name:      t.Name,
+			responses: []string{t.Response},
+			interval:  time.Duration(t.Interval) * time.Minute,
+			lines:     t.LineMinimum,
+			enabled:   isEnabled(t.Enabled),
+		})
+	}
+
+	for _, q := range export.Quotes {
+		// Quote dates are informational; ones in other formats are dropped.
+		createdAt, _ := time.Parse(time.RFC3339, q.Date)
+		c.addQuote(q.Quote, "", createdAt)
+	}
+
+	return nil
+}
+
+func isJSONObject(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) != 0 && data[0] == '{'
+}
@@ -0,0 +1,239 @@
+package foreign
+
+import (
+	"strconv"
+	"strings"
+)
+
+// syntax describes how a bot writes variables in its responses, for example
+// $(user) or ${user}.
+type syntax struct {
+	open  string
+	close byte
+	// sep separates a variable's name from its argument, as in
+	// $(urlfetch https://example.com) or ${random.1-10}.
+	sep byte
+}
+
+var (
+	parenSyntax  = &syntax{open: "$(", close: ')', sep: ' '}
+	dollarSyntax = &syntax{open: "${", close: '}', sep: '.'}
+	braceSyntax  = &syntax{open: "{", close: '}', sep: ' '}
+)
+
+var syntaxes = map[Format]*syntax{
+	FormatNightbot:       parenSyntax,
+	FormatStreamElements: dollarSyntax,
+	FormatStreamlabs:     braceSyntax,
+	FormatFossabot:       parenSyntax,
+	FormatMoobot:         braceSyntax,
+}
+
+func (s *syntax) opener() byte {
+	return s.open[len(s.open)-1]
+}
+
+// variableContext is the information available when translating a variable.
+type variableContext struct {
+	c           *converter
+	commandName string
+	arg         string
+}
+
+type variableFunc func(v variableContext) (string, bool)
+
+func constVariable(action string) variableFunc {
+	return func(variableContext) (string, bool) {
+		return action, true
+	}
+}
+
+// variables maps the variable names of all supported bots to actions. Names
+// are matched case insensitively, first by the full variable, then by the part
+// before the syntax's separator with the rest passed as an argument.
+var variables = map[string]variableFunc{
+	"user":             constVariable("(_USER_DISPLAY_)"),
+	"user.name":        constVariable("(_USER_DISPLAY_)"),
+	"username":         constVariable("(_USER_DISPLAY_)"),
+	"sender":           constVariable("(_USER_DISPLAY_)"),
+	"source":           constVariable("(_USER_DISPLAY_)"),
+	"touser":           constVariable("(_P_OR_(_USER_DISPLAY_)_)"),
+	"touser.name":      constVariable("(_P_OR_(_USER_DISPLAY_)_)"),
+	"target":           constVariable("(_P_OR_(_USER_DISPLAY_)_)"),
+	"query":            constVariable("(_PARAMETER_)"),
+	"args":             constVariable("(_PARAMETER_)"),
+	"1:":               constVariable("(_PARAMETER_)"),
+	"channel":          channelVariable,
+	"channel.name":     channelVariable,
+	"channel.display":  channelVariable,
+	"channel.username": channelVariable,
+	"game":             constVariable("(_GAME_)"),
+	"channel.game":     constVariable("(_GAME_)"),
+	"title":            constVariable("(_STATUS_)"),
+	"status":           constVariable("(_STATUS_)"),
+	"channel.title":    constVariable("(_STATUS_)"),
+	"viewers":          constVariable("(_VIEWERS_)"),
+	"channel.viewers":  constVariable("(_VIEWERS_)"),
+	"followage":        constVariable("(_FOLLOW_AGE_)"),
+	"user.followage":   constVariable("(_FOLLOW_AGE_)"),
+	"accountage":       constVariable("(_ACCOUNT_AGE_)"),
+	"user.accountage":  constVariable("(_ACCOUNT_AGE_)"),
+	"time":             constVariable("(_TIME_)"),
+	"count":            countVariable,
+	"urlfetch":         apiVariable,
+	"customapi":        apiVariable,
+	"readapi":          apiVariable,
+	"random":           randomVariable,
+	"randomnum":        randomVariable,
+	"randnum":          randomVariable,
+	"random.number":    randomVariable,
+}
+
+func channelVariable(v variableContext) (string, bool) {
+	return v.c.config.Channel.DisplayName, true
+}
+
+func countVariable(v variableContext) (string, bool) {
+	if v.commandName == "" {
+		return "", false
+	}
+	return "(_" + strings.ToUpper(v.commandName) + "_COUNT_)", true
+}
+
+func apiVariable(v variableContext) (string, bool) {
+	u := strings.TrimSpace(v.arg)
+	if u == "" {
+		return "", false
+	}
+	return "(_TEXTAPI_" + u + "_)", true
+}
+
+func randomVariable(v variableContext) (string, bool) {
+	minStr, maxStr, ok := strings.Cut(strings.TrimSpace(v.arg), "-")
+	if !ok {
+		minStr, maxStr, ok = strings.Cut(v.arg, ",")
+	}
+	if !ok {
+		return "", false
+	}
+
+	minValue, err := strconv.Atoi(strings.TrimSpace(minStr))
+	if err != nil {
+		return "", false
+	}
+
+	maxValue, err := strconv.Atoi(strings.TrimSpace(maxStr))
+	if err != nil || maxValue < minValue {
+		return "", false
+	}
+
+	// Other bots include the maximum, while RANDOM_INT excludes it.
+	return "(_RANDOM_INT_" + strconv.Itoa(minValue) + "_" + strconv.Itoa(maxValue+1) + "_)", true
+}
+
+// translate converts the variables in a response to actions. Variables which
+// cannot be converted are left as-is and reported.
+func (c *converter) translate(what, commandName, response string) string {
+	t := translation{
+		c:           c,
+		commandName: commandName,
+		unsupported: make(map[string]bool),
+	}
+	response = t.translate(response)
+
+	if t.positional {
+		c.warnf("The %s uses numbered arguments, which are separated by semicolons instead of spaces.", what)
+	}
+
+	if len(t.unsupported) != 0 {
+		c.warnf("The %s uses unsupported variables, which were left as-is: %s", what, strings.Join(sortedKeys(t.unsupported), ", "))
+	}
+
+	return response
+}
+
+type translation struct {
+	c           *converter
+	commandName string
+	unsupported map[string]bool
+	positional  bool
+}
+
+func (t *translation) translate(s string) string {
+	syntax := t.c.syntax
+	var sb strings.Builder
+
+	for {
+		i := strings.Index(s, syntax.open)
+		if i < 0 {
+			sb.WriteString(s)
+			return sb.String()
+		}
+
+		sb.WriteString(s[:i])
+		s = s[i+len(syntax.open):]
+
+		end := syntax.closing(s)
+		if end < 0 {
+			sb.WriteString(syntax.open)
+			continue
+		}
+
+		content := s[:end]
+		s = s[end+1:]
+
+		if n, err := strconv.Atoi(content); err == nil && n > 0 {
+			// Other bots split arguments on spaces, but the bot splits
+			// them on semicolons.
+			t.positional = true
+			sb.WriteString("(_P_" + content + "_)")
+			continue
+		}
+
+		sb.WriteString(t.variable(content))
+	}
+}
+
+func (t *translation) variable(content string) string {
+	syntax := t.c.syntax
+
+	name, arg := strings.ToLower(content), ""
+	fn := variables[name]
+	if fn == nil {
+		if before, after, ok := strings.Cut(content, string(syntax.sep)); ok {
+			name, arg = strings.ToLower(before), after
+			fn = variables[name]
+		}
+	}
+
+	if fn != nil {
+		// Arguments may contain variables of their own, such as a query
+		// passed to an API.
+		arg = t.translate(arg)
+		if action, ok := fn(variableContext{c: t.c, commandName: t.commandName, arg: arg}); ok {
+			return action
+		}
+	}
+
+	original := syntax.open + content + string(syntax.close)
+	t.unsupported[original] = true
+	return original
+}
+
+// closing finds the index of the byte which closes a variable whose opening
+// has already been consumed, accounting for nested variables.
+func (s *syntax) closing(str string) int {
+	depth := 0
+	for i := range len(str) {
+		switch str[i] {
+		case s.opener():
+			depth++
+		case s.close:
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
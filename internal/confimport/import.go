@@ -6,28 +6,48 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// Version is the current version of the config format. Configs without a
+// version were exported before highlights and bot state were included, and
+// are version 1. Version 3 added per-filter settings; configs without them use
+// the channel-wide filter settings.
+const Version = 3
+
 // Config is a channel's full configuration, serialized.
 type Config struct {
-	Channel     *Channel     `json:"channel"`
-	Quotes      []*Quote     `json:"quotes"`
-	Commands    []*Command   `json:"commands"`
-	Autoreplies []*Autoreply `json:"autoreplies"`
-	Variables   []*Variable  `json:"variables"`
+	Version     int              `json:"version"`
+	Channel     *Channel         `json:"channel"`
+	Quotes      []*Quote         `json:"quotes"`
+	Commands    []*Command       `json:"commands"`
+	Autoreplies []*Autoreply     `json:"autoreplies"`
+	Variables   []*Variable      `json:"variables"`
+	Highlights  []*Highlight     `json:"highlights"`
+	Filters     []*ChannelFilter `json:"filters"`
+	State       *State           `json:"state"`
+}
+
+// State is the channel's unexpired bot state, such as command cooldowns and
+// link permits, which would otherwise be lost when moving a channel.
+type State struct {
+	CommandCooldowns []*CommandCooldown `json:"command_cooldowns"`
+	LinkPermits      []*LinkPermit      `json:"link_permits"`
+	FilterWarnings   []*FilterWarning   `json:"filter_warnings"`
 }
 
-// Command is a single command, including all variants and repeats/schedules.
+// Command is a single command, including all variants, aliases, and repeats/schedules.
 type Command struct {
 	Info          *CommandInfo      `json:"info"`
 	CustomCommand *CustomCommand    `json:"custom_command"`
 	CommandList   *CommandList      `json:"command_list"`
 	Repeat        *RepeatedCommand  `json:"repeat"`
 	Schedule      *ScheduledCommand `json:"schedule"`
+	Aliases       []*CommandAlias   `json:"aliases"`
 }
 
 type importInserter func(context.Context, []byte) (int64, error)
@@ -46,6 +66,15 @@ func (c *Config) Insert(ctx context.Context, queries *dbsql.Queries) error {
 	}
 	c.Channel.ID = id
 
+	return c.insertData(ctx, queries)
+}
+
+// insertData inserts everything in the config other than the channel itself
+// into the channel c.Channel.ID.
+func (c *Config) insertData(ctx context.Context, queries *dbsql.Queries) error {
+	id := c.Channel.ID
+
+	var err error
 	for _, quote := range c.Quotes {
 		quote.ChannelID = id
 		quote.ID, err = insertImported(ctx, quote, queries.InsertImportedQuote, "quote")
@@ -78,6 +107,14 @@ func (c *Config) Insert(ctx context.Context, queries *dbsql.Queries) error {
 		if err != nil {
 			return err
 		}
+		for _, value := range command.Aliases {
+			value.ChannelID = id
+			value.CommandInfoID = command.Info.ID
+			value.ID, err = insertImported(ctx, value, queries.InsertImportedCommandAlias, "command alias")
+			if err != nil {
+				return err
+			}
+		}
 		if value := command.Repeat; value != nil {
 			value.ChannelID = id
 			value.CommandInfoID = command.Info.ID
@@ -110,10 +147,67 @@ func (c *Config) Insert(ctx context.Context, queries *dbsql.Queries) error {
 			return err
 		}
 	}
+	for _, highlight := range c.Highlights {
+		highlight.ChannelID = id
+		highlight.ID, err = insertImported(ctx, highlight, queries.InsertImportedHighlight, "highlight")
+		if err != nil {
+			return err
+		}
+	}
+	for _, filter := range c.Filters {
+		filter.ChannelID = id
+		filter.ID, err = insertImported(ctx, filter, queries.InsertImportedChannelFilter, "filter")
+		if err != nil {
+			return err
+		}
+	}
+	return c.State.insert(ctx, queries, strconv.FormatInt(c.Channel.TwitchID, 10))
+}
+
+// insert restores the state, keyed on the channel's Twitch ID like the bot's
+// own state.
+func (s *State) insert(ctx context.Context, queries *dbsql.Queries, channel string) error {
+	if s == nil {
+		return nil
+	}
+	for _, value := range s.CommandCooldowns {
+		err := queries.BotStateMarkCommandCooldown(ctx, dbsql.BotStateMarkCommandCooldownParams{
+			Channel:    channel,
+			CommandKey: value.CommandKey,
+			ExpiresAt:  value.ExpiresAt,
+		})
+		if err != nil {
+			return fmt.Errorf("inserting command cooldown: %w", err)
+		}
+	}
+	for _, value := range s.LinkPermits {
+		err := queries.BotStateGrantLinkPermit(ctx, dbsql.BotStateGrantLinkPermitParams{
+			Channel:   channel,
+			UserID:    value.UserID,
+			ExpiresAt: value.ExpiresAt,
+		})
+		if err != nil {
+			return fmt.Errorf("inserting link permit: %w", err)
+		}
+	}
+	for _, value := range s.FilterWarnings {
+		err := queries.BotStateUpsertFilterWarning(ctx, dbsql.BotStateUpsertFilterWarningParams{
+			Channel:    channel,
+			UserID:     value.UserID,
+			FilterName: value.FilterName,
+			ExpiresAt:  value.ExpiresAt,
+		})
+		if err != nil {
+			return fmt.Errorf("inserting filter warning: %w", err)
+		}
+	}
 	return nil
 }
 
 func (c *Config) validate() error {
+	if c.Version > Version {
+		return fmt.Errorf("config version %d is newer than the supported version %d", c.Version, Version)
+	}
 	if c.Channel == nil {
 		return errors.New("config has no channel")
 	}
@@ -132,6 +226,11 @@ func (c *Config) validate() error {
 		if (command.CustomCommand == nil) == (command.CommandList == nil) {
 			return fmt.Errorf("command %d must have exactly one implementation", i)
 		}
+		for j, alias := range command.Aliases {
+			if alias == nil {
+				return fmt.Errorf("command %d alias %d is null", i, j)
+			}
+		}
 	}
 	for i, autoreply := range c.Autoreplies {
 		if autoreply == nil {
@@ -143,6 +242,33 @@ func (c *Config) validate() error {
 			return fmt.Errorf("variable %d is null", i)
 		}
 	}
+	for i, highlight := range c.Highlights {
+		if highlight == nil {
+			return fmt.Errorf("highlight %d is null", i)
+		}
+	}
+	for i, filter := range c.Filters {
+		if filter == nil {
+			return fmt.Errorf("filter %d is null", i)
+		}
+	}
+	if s := c.State; s != nil {
+		for i, value := range s.CommandCooldowns {
+			if value == nil {
+				return fmt.Errorf("command cooldown %d is null", i)
+			}
+		}
+		for i, value := range s.LinkPermits {
+			if value == nil {
+				return fmt.Errorf("link permit %d is null", i)
+			}
+		}
+		for i, value := range s.FilterWarnings {
+			if value == nil {
+				return fmt.Errorf("filter warning %d is null", i)
+			}
+		}
+	}
 	return nil
 }
 
@@ -155,6 +281,9 @@ func (c *Config) applyImportDefaults(now time.Time) {
 	defaultStrings(&c.Channel.CustomRegulars)
 	defaultStrings(&c.Channel.PermittedLinks)
 	defaultStrings(&c.Channel.FilterBannedPhrasesPatterns)
+	defaultStrings(&c.Channel.WhisperBuiltins)
+	defaultStrings(&c.Channel.FilterShadow)
+	defaultStrings(&c.Channel.BlockedLinks)
 	if c.Channel.FilterExemptLevel == "" {
 		c.Channel.FilterExemptLevel = dbsql.AccessLevelSubscriber
 	}
@@ -171,6 +300,9 @@ func (c *Config) applyImportDefaults(now time.Time) {
 			defaultTimestamps(&value.CreatedAt, &value.UpdatedAt, now)
 			defaultStrings(&value.Items)
 		}
+		for _, value := range command.Aliases {
+			defaultTimestamp(&value.CreatedAt, now)
+		}
 		if value := command.Repeat; value != nil {
 			defaultTimestamps(&value.CreatedAt, &value.UpdatedAt, now)
 			if value.MessageDiff == 0 {
@@ -186,9 +318,29 @@ func (c *Config) applyImportDefaults(now time.Time) {
 	}
 	for _, autoreply := range c.Autoreplies {
 		defaultTimestamps(&autoreply.CreatedAt, &autoreply.UpdatedAt, now)
+		defaultStrings(&autoreply.Games)
+		if autoreply.AccessLevel == "" {
+			autoreply.AccessLevel = dbsql.AccessLevelEveryone
+		}
+		if autoreply.MaxAccessLevel == "" {
+			autoreply.MaxAccessLevel = dbsql.AccessLevelAdmin
+		}
 	}
 	for _, variable := range c.Variables {
 		defaultTimestamps(&variable.CreatedAt, &variable.UpdatedAt, now)
+		if variable.Kind == "" {
+			variable.Kind = dbsql.VariableKindText
+		}
+		if variable.SharedWith == nil {
+			// Variables exported before sharing existed were readable from every channel.
+			variable.SharedWith = []string{dbsql.VariableSharedWithAll}
+		}
+	}
+	for _, highlight := range c.Highlights {
+		defaultTimestamp(&highlight.CreatedAt, now)
+	}
+	for _, filter := range c.Filters {
+		defaultTimestamps(&filter.CreatedAt, &filter.UpdatedAt, now)
 	}
 }
 
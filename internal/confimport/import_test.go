@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/db/migrations"
@@ -77,6 +78,10 @@ func TestExportImport(t *testing.T) {
 			Editor:      "test",
 		},
 		CommandList: &CommandList{},
+		Aliases: []*CommandAlias{{
+			Name:    "legacy-alias",
+			Creator: "test",
+		}},
 		Repeat: &RepeatedCommand{
 			Enabled: true,
 			Delay:   60,
@@ -87,6 +92,7 @@ func TestExportImport(t *testing.T) {
 		Info: &CommandInfo{
 			Name:        "legacy-command",
 			AccessLevel: dbsql.AccessLevelModerator,
+			Enabled:     true,
 			Creator:     "test",
 			Editor:      "test",
 		},
@@ -150,6 +156,9 @@ func TestExportImport(t *testing.T) {
 	exportedLegacy, err := ExportByName(ctx, queries, "legacy")
 	assert.NilError(t, err)
 	assert.Equal(t, len(exportedLegacy.Commands), 2)
+	for _, command := range exportedLegacy.Commands {
+		assert.Equal(t, command.Info.Enabled, command.Info.Name == "legacy-command")
+	}
 	assert.Equal(t, len(exportedLegacy.Quotes), 1)
 	assert.Equal(t, len(exportedLegacy.Autoreplies), 1)
 	assert.Equal(t, len(exportedLegacy.Variables), 1)
@@ -171,6 +180,11 @@ func TestExportImport(t *testing.T) {
 		if value := command["command_list"]; value != nil {
 			removeTableDefaults(t, ctx, db, "command_lists", value.(map[string]any))
 		}
+		if value := command["aliases"]; value != nil {
+			for _, alias := range value.([]any) {
+				removeTableDefaults(t, ctx, db, "command_aliases", alias.(map[string]any))
+			}
+		}
 		if value := command["repeat"]; value != nil {
 			removeTableDefaults(t, ctx, db, "repeated_commands", value.(map[string]any))
 		}
@@ -197,12 +211,180 @@ func TestExportImport(t *testing.T) {
 	roundtrip, err := ExportByName(ctx, queries, "roundtrip")
 	assert.NilError(t, err)
 	assert.Equal(t, len(roundtrip.Commands), 2)
+	for _, command := range roundtrip.Commands {
+		// The enabled flag was stripped along with the other defaults.
+		assert.Assert(t, command.Info.Enabled)
+		if command.Info.Name == "legacy-list" {
+			assert.Equal(t, len(command.Aliases), 1)
+			assert.Equal(t, command.Aliases[0].Name, "legacy-alias")
+			assert.Equal(t, command.Aliases[0].CommandInfoID, command.Info.ID)
+		} else {
+			assert.Equal(t, len(command.Aliases), 0)
+		}
+	}
 	assert.Equal(t, len(roundtrip.Quotes), 1)
 	assert.Equal(t, roundtrip.Quotes[0].Quote, "legacy quote")
 	assert.Equal(t, len(roundtrip.Autoreplies), 1)
 	assert.Equal(t, roundtrip.Autoreplies[0].Response, "reply")
+	// The cooldown was stripped along with the other defaults.
+	assert.Equal(t, roundtrip.Autoreplies[0].Cooldown, int32(30))
+	assert.Equal(t, roundtrip.Autoreplies[0].AccessLevel, dbsql.AccessLevelEveryone)
 	assert.Equal(t, len(roundtrip.Variables), 1)
 	assert.Equal(t, roundtrip.Variables[0].Value, "value")
+	assert.Equal(t, roundtrip.Variables[0].Kind, dbsql.VariableKindText)
+	assert.DeepEqual(t, roundtrip.Variables[0].SharedWith, []string{dbsql.VariableSharedWithAll})
+}
+
+func TestRestore(t *testing.T) {
+	t.Parallel()
+	pdb, err := testpostgres.New()
+	assert.NilError(t, err)
+	defer pdb.Cleanup()
+	assert.NilError(t, migrations.Up(pdb.ConnStr(), nil))
+
+	db, err := pdb.Open(t.Context())
+	assert.NilError(t, err)
+	defer db.Close()
+
+	ctx := t.Context()
+	queries := dbsql.New(db)
+
+	for i, name := range []string{"source", "target"} {
+		_, err := queries.InsertDefaultChannel(ctx, dbsql.InsertDefaultChannelParams{
+			TwitchID: int64(i + 1), Name: name, DisplayName: name, BotName: "hortbot",
+		})
+		assert.NilError(t, err)
+	}
+	source, err := queries.GetChannelByName(ctx, "source")
+	assert.NilError(t, err)
+	target, err := queries.GetChannelByName(ctx, "target")
+	assert.NilError(t, err)
+
+	addCommand := func(channel dbsql.Channel, name, message string) {
+		t.Helper()
+		config := &Config{Channel: (*Channel)(&channel), Commands: []*Command{{
+			Info:          &CommandInfo{Name: name, AccessLevel: dbsql.AccessLevelEveryone, Enabled: true, Creator: "test", Editor: "test"},
+			CustomCommand: &CustomCommand{Message: message},
+		}}}
+		config.applyImportDefaults(time.Now())
+		assert.NilError(t, config.insertData(ctx, queries))
+	}
+	addCommand(source, "shared", "from source")
+	addCommand(source, "only-source", "hello")
+	addCommand(target, "shared", "from target")
+
+	assert.NilError(t, queries.InsertHighlight(ctx, dbsql.InsertHighlightParams{
+		ChannelID:     source.ID,
+		HighlightedAt: dbsql.TimestamptzFrom(time.Now()),
+		Status:        "status",
+		Game:          "game",
+	}))
+	assert.NilError(t, queries.UpsertChannelFilter(ctx, dbsql.UpsertChannelFilterParams{
+		ChannelID:   source.ID,
+		Filter:      "caps",
+		ExemptLevel: dbsql.AccessLevelModerator,
+		Action:      "ban",
+		Message:     "no shouting",
+	}))
+	assert.NilError(t, queries.BotStateMarkCommandCooldown(ctx, dbsql.BotStateMarkCommandCooldownParams{
+		Channel:    "1",
+		CommandKey: "shared",
+		ExpiresAt:  dbsql.TimestamptzFrom(time.Now().Add(time.Hour)),
+	}))
+
+	exportSource := func() *Config {
+		t.Helper()
+		config, err := Export(ctx, queries, source)
+		assert.NilError(t, err)
+		assert.Equal(t, config.Version, Version)
+		assert.Equal(t, len(config.Highlights), 1)
+		assert.Equal(t, len(config.Filters), 1)
+		assert.Equal(t, len(config.State.CommandCooldowns), 1)
+		return config
+	}
+
+	config := exportSource()
+	config.Channel.Prefix = "?"
+	warnings, err := config.Restore(ctx, queries, &target, RestoreMerge)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, warnings, []string{"Command shared already exists and was skipped."})
+
+	merged, err := Export(ctx, queries, target)
+	assert.NilError(t, err)
+	assert.Equal(t, merged.Channel.Name, "target")
+	assert.Equal(t, merged.Channel.Prefix, "!")
+	assert.Equal(t, len(merged.Commands), 2)
+	assert.Equal(t, len(merged.Highlights), 1)
+	assert.Equal(t, len(merged.Filters), 0)
+	assert.Equal(t, len(merged.State.CommandCooldowns), 1)
+
+	config = exportSource()
+	config.Channel.Prefix = "?"
+	warnings, err = config.Restore(ctx, queries, &target, RestoreReplace)
+	assert.NilError(t, err)
+	assert.Equal(t, len(warnings), 0)
+
+	replaced, err := Export(ctx, queries, target)
+	assert.NilError(t, err)
+	assert.Equal(t, replaced.Channel.Name, "target")
+	assert.Equal(t, replaced.Channel.TwitchID, int64(2))
+	assert.Equal(t, replaced.Channel.Prefix, "?")
+	assert.Equal(t, len(replaced.Commands), 2)
+	for _, command := range replaced.Commands {
+		if command.Info.Name == "shared" {
+			assert.Equal(t, command.CustomCommand.Message, "from source")
+		}
+	}
+	assert.Equal(t, len(replaced.Highlights), 1)
+	assert.Equal(t, len(replaced.Filters), 1)
+	assert.Equal(t, replaced.Filters[0].Message, "no shouting")
+
+	_, err = config.Restore(ctx, queries, &target, "unknown")
+	assert.ErrorContains(t, err, "unknown restore mode")
+}
+
+func TestStoreBackupKeepsRecent(t *testing.T) {
+	t.Parallel()
+	pdb, err := testpostgres.New()
+	assert.NilError(t, err)
+	defer pdb.Cleanup()
+	assert.NilError(t, migrations.Up(pdb.ConnStr(), nil))
+
+	db, err := pdb.Open(t.Context())
+	assert.NilError(t, err)
+	defer db.Close()
+
+	ctx := t.Context()
+	queries := dbsql.New(db)
+
+	channel, err := queries.InsertDefaultChannel(ctx, dbsql.InsertDefaultChannelParams{
+		TwitchID: 1, Name: "foobar", DisplayName: "foobar", BotName: "hortbot",
+	})
+	assert.NilError(t, err)
+
+	config := &Config{Version: Version, Channel: (*Channel)(&channel)}
+
+	automatic, err := StoreBackup(ctx, queries, channel.ID, config, "hortbot", dbsql.BackupKindAutomatic)
+	assert.NilError(t, err)
+
+	var ids []int64
+	for range MaxKeptBackups + 2 {
+		id, err := StoreBackup(ctx, queries, channel.ID, config, "foobar", dbsql.BackupKindUpload)
+		assert.NilError(t, err)
+		ids = append(ids, id)
+	}
+
+	backups, err := queries.ListChannelBackups(ctx, channel.ID)
+	assert.NilError(t, err)
+	assert.Equal(t, len(backups), MaxKeptBackups+1)
+
+	kept := make(map[int64]bool, len(backups))
+	for _, b := range backups {
+		kept[b.ID] = true
+	}
+	assert.Assert(t, kept[automatic])
+	assert.Assert(t, !kept[ids[0]] && !kept[ids[1]])
+	assert.Assert(t, kept[ids[2]])
 }
 
 func TestValidate(t *testing.T) {
@@ -239,6 +421,10 @@ func TestValidate(t *testing.T) {
 		},
 		{name: "autoreply", config: Config{Channel: &Channel{}, Autoreplies: []*Autoreply{nil}}, err: "autoreply 0 is null"},
 		{name: "variable", config: Config{Channel: &Channel{}, Variables: []*Variable{nil}}, err: "variable 0 is null"},
+		{name: "highlight", config: Config{Channel: &Channel{}, Highlights: []*Highlight{nil}}, err: "highlight 0 is null"},
+		{name: "filter", config: Config{Channel: &Channel{}, Filters: []*ChannelFilter{nil}}, err: "filter 0 is null"},
+		{name: "link permit", config: Config{Channel: &Channel{}, State: &State{LinkPermits: []*LinkPermit{nil}}}, err: "link permit 0 is null"},
+		{name: "version", config: Config{Version: Version + 1, Channel: &Channel{}}, err: "newer than the supported version"},
 	}
 
 	for _, test := range tests {
@@ -269,3 +455,26 @@ func removeTableDefaults(t *testing.T, ctx context.Context, db *pgxpool.Pool, ta
 	}
 	assert.NilError(t, rows.Err())
 }
+
+func TestLibraryParameters(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{
+		"commands": [
+			{"info": {"name": "discord"}, "custom_command": {"message": "Join at {{invite}}, {{ Name }}!"}},
+			{"info": {"name": "tips"}, "command_list": {"items": ["Hi {{name}}", "No placeholders"]}}
+		],
+		"autoreplies": [
+			{"trigger": "^discord$", "response": "See {{invite}} or {{bad-name}}"}
+		]
+	}`)
+
+	library, err := LoadLibrary(data)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, library.Parameters(), []string{"invite", "name"})
+	assert.DeepEqual(t, library.MissingArguments(map[string]string{"name": "x"}), []string{"invite"})
+	assert.Assert(t, library.MissingArguments(map[string]string{"name": "x", "invite": ""}) == nil)
+
+	_, err = LoadLibrary([]byte(`{"commands": [{"info": {"name": "broken"}}]}`))
+	assert.ErrorContains(t, err, "exactly one implementation")
+}
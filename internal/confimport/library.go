@@ -0,0 +1,310 @@
+package confimport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/jackc/pgx/v5"
+)
+
+// Library is a bundle of commands, lists, repeats, and autoreplies which one
+// channel publishes for other channels to install. Placeholders of the form
+// {{name}} in command responses, list items, and autoreply responses are
+// filled in with arguments when the library is installed.
+type Library struct {
+	Commands    []*Command   `json:"commands"`
+	Autoreplies []*Autoreply `json:"autoreplies"`
+}
+
+// Installed describes the result of installing a library.
+type Installed struct {
+	Commands     []*Command
+	AutoreplyIDs []int64
+	Warnings     []string
+}
+
+// CommandInfoIDs returns the IDs of the installed commands.
+func (i *Installed) CommandInfoIDs() []int64 {
+	ids := make([]int64, len(i.Commands))
+	for j, command := range i.Commands {
+		ids[j] = command.Info.ID
+	}
+	return ids
+}
+
+var placeholderRegex = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+
+// NewLibrary builds a library from some of a channel's commands and
+// autoreplies. The commands and autoreplies which do not exist are returned
+// as missing.
+func NewLibrary(ctx context.Context, queries *dbsql.Queries, channel dbsql.Channel, names []string, autoreplyNums []int32) (library *Library, missing []string, err error) {
+	config, err := Export(ctx, queries, channel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	library = &Library{}
+
+	for _, name := range names {
+		i := slices.IndexFunc(config.Commands, func(c *Command) bool { return c.Info.Name == name })
+		if i < 0 {
+			missing = append(missing, name)
+			continue
+		}
+		library.Commands = append(library.Commands, libraryCommand(config.Commands[i]))
+	}
+
+	for _, num := range autoreplyNums {
+		i := slices.IndexFunc(config.Autoreplies, func(a *Autoreply) bool { return a.Num == num })
+		if i < 0 {
+			missing = append(missing, fmt.Sprintf("autoreply #%d", num))
+			continue
+		}
+		a := config.Autoreplies[i]
+		library.Autoreplies = append(library.Autoreplies, &Autoreply{
+			Trigger:        a.Trigger,
+			OrigPattern:    a.OrigPattern,
+			Response:       a.Response,
+			AccessLevel:    a.AccessLevel,
+			MaxAccessLevel: a.MaxAccessLevel,
+			Cooldown:       a.Cooldown,
+			UserCooldown:   a.UserCooldown,
+			LiveOnly:       a.LiveOnly,
+			Games:          slices.Clone(a.Games),
+		})
+	}
+
+	return library, missing, nil
+}
+
+// libraryCommand copies the parts of a command which make sense in another
+// channel, dropping IDs, usage counts, and authorship.
+func libraryCommand(c *Command) *Command {
+	command := &Command{
+		Info: &CommandInfo{
+			Name:        c.Info.Name,
+			AccessLevel: c.Info.AccessLevel,
+			Enabled:     c.Info.Enabled,
+			ReplyMode:   c.Info.ReplyMode,
+			Description: c.Info.Description,
+			Usage:       c.Info.Usage,
+			Category:    c.Info.Category,
+			Hidden:      c.Info.Hidden,
+		},
+	}
+	if c.CustomCommand != nil {
+		command.CustomCommand = &CustomCommand{Message: c.CustomCommand.Message}
+	}
+	if c.CommandList != nil {
+		command.CommandList = &CommandList{Items: slices.Clone(c.CommandList.Items)}
+	}
+	if c.Repeat != nil {
+		command.Repeat = &RepeatedCommand{
+			Enabled:     c.Repeat.Enabled,
+			Delay:       c.Repeat.Delay,
+			MessageDiff: c.Repeat.MessageDiff,
+		}
+	}
+	if c.Schedule != nil {
+		command.Schedule = &ScheduledCommand{
+			Enabled:        c.Schedule.Enabled,
+			CronExpression: c.Schedule.CronExpression,
+			MessageDiff:    c.Schedule.MessageDiff,
+		}
+	}
+	for _, alias := range c.Aliases {
+		command.Aliases = append(command.Aliases, &CommandAlias{Name: alias.Name})
+	}
+	return command
+}
+
+// LoadLibrary decodes a published library.
+func LoadLibrary(data []byte) (*Library, error) {
+	library := &Library{}
+	if err := json.Unmarshal(data, library); err != nil {
+		return nil, fmt.Errorf("unmarshaling library: %w", err)
+	}
+	// Libraries have no channel of their own; validate them as a config for an
+	// empty one.
+	config := Config{Channel: &Channel{}, Commands: library.Commands, Autoreplies: library.Autoreplies}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return library, nil
+}
+
+// Parameters returns the sorted names of the library's placeholders.
+func (l *Library) Parameters() []string {
+	var params []string
+	l.eachText(func(s *string) {
+		for _, m := range placeholderRegex.FindAllStringSubmatch(*s, -1) {
+			params = append(params, strings.ToLower(m[1]))
+		}
+	})
+	slices.Sort(params)
+	return slices.Compact(params)
+}
+
+func (l *Library) eachText(fn func(*string)) {
+	for _, command := range l.Commands {
+		if command.CustomCommand != nil {
+			fn(&command.CustomCommand.Message)
+		}
+		if command.CommandList != nil {
+			for i := range command.CommandList.Items {
+				fn(&command.CommandList.Items[i])
+			}
+		}
+	}
+	for _, autoreply := range l.Autoreplies {
+		fn(&autoreply.Response)
+	}
+}
+
+// Install inserts the library into a channel, filling in its placeholders with
+// the given arguments, keyed on lowercase parameter name. Commands and aliases
+// whose names are already in use are skipped, and described in the warnings.
+// The library is modified, so should be freshly loaded for each install.
+func (l *Library) Install(ctx context.Context, queries *dbsql.Queries, channel *dbsql.Channel, args map[string]string, user string) (*Installed, error) {
+	if missing := l.MissingArguments(args); len(missing) != 0 {
+		return nil, fmt.Errorf("missing arguments: %s", strings.Join(missing, ", "))
+	}
+
+	l.eachText(func(s *string) {
+		*s = placeholderRegex.ReplaceAllStringFunc(*s, func(m string) string {
+			name := placeholderRegex.FindStringSubmatch(m)[1]
+			return args[strings.ToLower(name)]
+		})
+	})
+
+	for _, command := range l.Commands {
+		command.Info.Creator = user
+		command.Info.Editor = user
+		if value := command.Repeat; value != nil {
+			value.Creator = user
+			value.Editor = user
+			value.LastCount = channel.MessageCount
+		}
+		if value := command.Schedule; value != nil {
+			value.Creator = user
+			value.Editor = user
+			value.LastCount = channel.MessageCount
+		}
+		for _, alias := range command.Aliases {
+			alias.Creator = user
+		}
+	}
+	for _, autoreply := range l.Autoreplies {
+		autoreply.Creator = user
+		autoreply.Editor = user
+	}
+
+	ch := Channel(*channel)
+	config := &Config{
+		Version:     Version,
+		Channel:     &ch,
+		Commands:    l.Commands,
+		Autoreplies: l.Autoreplies,
+	}
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	config.applyImportDefaults(time.Now())
+
+	warnings, err := config.removeExisting(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	if err := config.insertData(ctx, queries); err != nil {
+		return nil, err
+	}
+
+	installed := &Installed{
+		Commands:     config.Commands,
+		AutoreplyIDs: make([]int64, len(config.Autoreplies)),
+		Warnings:     warnings,
+	}
+	for i, autoreply := range config.Autoreplies {
+		installed.AutoreplyIDs[i] = autoreply.ID
+	}
+	return installed, nil
+}
+
+// MissingArguments returns the parameters which have no argument.
+func (l *Library) MissingArguments(args map[string]string) []string {
+	var missing []string
+	for _, param := range l.Parameters() {
+		if _, ok := args[param]; !ok {
+			missing = append(missing, param)
+		}
+	}
+	return missing
+}
+
+// Uninstall deletes the commands and autoreplies previously installed from a
+// library. Commands which have since been deleted are ignored, even if another
+// command has taken their name. The deleted repeats and schedules are returned
+// so they may be unscheduled.
+func Uninstall(ctx context.Context, queries *dbsql.Queries, install *dbsql.CommandLibraryInstall) (repeats, schedules []int64, err error) {
+	for _, id := range install.CommandInfoIds {
+		info, err := queries.GetCommandInfoByIDForUpdate(ctx, id)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, nil, fmt.Errorf("getting command: %w", err)
+		}
+		if info.ChannelID != install.ChannelID {
+			continue
+		}
+
+		repeated, scheduled, err := queries.DeleteCommandInfoCascade(ctx, &info)
+		if err != nil {
+			return nil, nil, fmt.Errorf("deleting command %s: %w", info.Name, err)
+		}
+		if repeated != nil {
+			repeats = append(repeats, repeated.ID)
+		}
+		if scheduled != nil {
+			schedules = append(schedules, scheduled.ID)
+		}
+	}
+
+	_, err = queries.DeleteAutorepliesByIDs(ctx, dbsql.DeleteAutorepliesByIDsParams{
+		ChannelID: install.ChannelID,
+		Ids:       install.AutoreplyIds,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("deleting autoreplies: %w", err)
+	}
+
+	return repeats, schedules, nil
+}
+
+// MarshalArguments encodes install arguments for storage.
+func MarshalArguments(args map[string]string) ([]byte, error) {
+	if args == nil {
+		args = map[string]string{}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling arguments: %w", err)
+	}
+	return data, nil
+}
+
+// UnmarshalArguments decodes stored install arguments.
+func UnmarshalArguments(data []byte) (map[string]string, error) {
+	args := map[string]string{}
+	if err := json.Unmarshal(data, &args); err != nil {
+		return nil, fmt.Errorf("unmarshaling arguments: %w", err)
+	}
+	return args, nil
+}
@@ -12,6 +12,7 @@ import (
 type (
 	Channel          dbsql.Channel
 	CommandInfo      dbsql.CommandInfo
+	CommandAlias     = dbsql.CommandAlias
 	RepeatedCommand  dbsql.RepeatedCommand
 	Autoreply        dbsql.Autoreply
 	Quote            = dbsql.Quote
@@ -19,6 +20,11 @@ type (
 	CommandList      = dbsql.CommandList
 	ScheduledCommand = dbsql.ScheduledCommand
 	Variable         = dbsql.Variable
+	Highlight        = dbsql.Highlight
+	ChannelFilter    = dbsql.ChannelFilter
+	CommandCooldown  = dbsql.BotStateListChannelCommandCooldownsRow
+	LinkPermit       = dbsql.BotStateListChannelLinkPermitsRow
+	FilterWarning    = dbsql.BotStateListChannelFilterWarningsRow
 )
 
 func (c Channel) MarshalJSON() ([]byte, error) {
@@ -71,6 +77,7 @@ func (c *CommandInfo) UnmarshalJSON(data []byte) error {
 		LastUsed        *time.Time `json:"last_used"`
 		CustomCommandID *int64     `json:"custom_command_id"`
 		CommandListID   *int64     `json:"command_list_id"`
+		Enabled         *bool      `json:"enabled"`
 	}{alias: (*alias)(c)}
 	if err := unmarshalJSON("command info", data, &value); err != nil {
 		return err
@@ -78,6 +85,8 @@ func (c *CommandInfo) UnmarshalJSON(data []byte) error {
 	c.LastUsed = nullTime(value.LastUsed)
 	c.CustomCommandID = nullInt64(value.CustomCommandID)
 	c.CommandListID = nullInt64(value.CommandListID)
+	// Configs exported before commands could be disabled have no flag.
+	c.Enabled = value.Enabled == nil || *value.Enabled
 	return nil
 }
 
@@ -121,11 +130,19 @@ func (a *Autoreply) UnmarshalJSON(data []byte) error {
 	value := struct {
 		*alias
 		OrigPattern *string `json:"orig_pattern"`
+		Cooldown    *int32  `json:"cooldown"`
 	}{alias: (*alias)(a)}
 	if err := unmarshalJSON("autoreply", data, &value); err != nil {
 		return err
 	}
 	a.OrigPattern = nullString(value.OrigPattern)
+	// Configs exported before autoreply cooldowns were configurable used a
+	// fixed 30 second cooldown.
+	if value.Cooldown == nil {
+		a.Cooldown = 30
+	} else {
+		a.Cooldown = *value.Cooldown
+	}
 	return nil
 }
 
@@ -0,0 +1,199 @@
+package confimport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+)
+
+// RestoreMode controls how a config is restored into an existing channel.
+type RestoreMode string
+
+const (
+	// RestoreMerge adds the config's data to the channel, skipping anything
+	// which already exists. The channel's settings are left unchanged.
+	RestoreMerge RestoreMode = "merge"
+
+	// RestoreReplace replaces the channel's settings and data with the config's.
+	RestoreReplace RestoreMode = "replace"
+)
+
+// Restore restores a config into an existing channel. The channel keeps its
+// identity (IDs, name, and bot), even if the config was exported from another
+// channel. Anything which was not restored is described in the returned
+// warnings.
+func (c *Config) Restore(ctx context.Context, queries *dbsql.Queries, channel *dbsql.Channel, mode RestoreMode) ([]string, error) {
+	if mode != RestoreMerge && mode != RestoreReplace {
+		return nil, fmt.Errorf("unknown restore mode %q", mode)
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	c.applyImportDefaults(time.Now())
+
+	c.Channel.ID = channel.ID
+	c.Channel.TwitchID = channel.TwitchID
+
+	var warnings []string
+
+	if mode == RestoreReplace {
+		if err := queries.DeleteChannelConfig(ctx, channel.ID); err != nil {
+			return nil, err
+		}
+		// The replaced commands no longer belong to any installed library.
+		if err := queries.DeleteCommandLibraryInstallsByChannel(ctx, channel.ID); err != nil {
+			return nil, fmt.Errorf("deleting library installs: %w", err)
+		}
+		if err := c.restoreSettings(ctx, queries); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		warnings, err = c.removeExisting(ctx, queries)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.insertData(ctx, queries); err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+func (c *Config) restoreSettings(ctx context.Context, queries *dbsql.Queries) error {
+	channel := dbsql.Channel(*c.Channel)
+	if err := queries.SaveChannelSettings(ctx, &channel); err != nil {
+		return fmt.Errorf("restoring settings: %w", err)
+	}
+	err := queries.UpdateChannelUserLists(ctx, dbsql.UpdateChannelUserListsParams{
+		CustomOwners:   channel.CustomOwners,
+		CustomMods:     channel.CustomMods,
+		CustomRegulars: channel.CustomRegulars,
+		Ignored:        channel.Ignored,
+		ID:             channel.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("restoring user lists: %w", err)
+	}
+	return nil
+}
+
+// removeExisting removes everything from the config which conflicts with the
+// channel's existing data, and renumbers quotes and autoreplies to follow the
+// existing ones.
+func (c *Config) removeExisting(ctx context.Context, queries *dbsql.Queries) ([]string, error) {
+	id := c.Channel.ID
+	var warnings []string
+
+	infoRows, err := queries.ListCommandInfos(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting command infos: %w", err)
+	}
+	aliasRows, err := queries.ListCommandAliases(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting command aliases: %w", err)
+	}
+	names := make(map[string]bool, len(infoRows)+len(aliasRows))
+	for _, info := range infoRows {
+		names[info.Name] = true
+	}
+	for _, alias := range aliasRows {
+		names[alias.Name] = true
+	}
+
+	commands := c.Commands[:0]
+	for _, command := range c.Commands {
+		if names[command.Info.Name] {
+			warnings = append(warnings, fmt.Sprintf("Command %s already exists and was skipped.", command.Info.Name))
+			continue
+		}
+		names[command.Info.Name] = true
+
+		aliases := command.Aliases[:0]
+		for _, alias := range command.Aliases {
+			if names[alias.Name] {
+				warnings = append(warnings, fmt.Sprintf("Alias %s of command %s is already in use and was skipped.", alias.Name, command.Info.Name))
+				continue
+			}
+			names[alias.Name] = true
+			aliases = append(aliases, alias)
+		}
+		command.Aliases = aliases
+		commands = append(commands, command)
+	}
+	c.Commands = commands
+
+	quoteRows, err := queries.ListQuotes(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting quotes: %w", err)
+	}
+	var lastQuote int32
+	if len(quoteRows) != 0 {
+		lastQuote = quoteRows[len(quoteRows)-1].Num
+	}
+	for i, quote := range c.Quotes {
+		quote.Num = lastQuote + int32(i) + 1
+	}
+
+	autoreplyRows, err := queries.ListAutoreplies(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting autoreplies: %w", err)
+	}
+	var lastAutoreply int32
+	if len(autoreplyRows) != 0 {
+		lastAutoreply = autoreplyRows[len(autoreplyRows)-1].Num
+	}
+	for i, autoreply := range c.Autoreplies {
+		autoreply.Num = lastAutoreply + int32(i) + 1
+	}
+
+	variableRows, err := queries.ListVariables(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting variables: %w", err)
+	}
+	type variableKey struct {
+		name   string
+		userID int64
+	}
+	existingVariables := make(map[variableKey]bool, len(variableRows))
+	for _, variable := range variableRows {
+		existingVariables[variableKey{variable.Name, variable.UserID}] = true
+	}
+	variables := c.Variables[:0]
+	for _, variable := range c.Variables {
+		key := variableKey{variable.Name, variable.UserID}
+		if existingVariables[key] {
+			if variable.UserID == 0 {
+				warnings = append(warnings, fmt.Sprintf("Variable %s already exists and was skipped.", variable.Name))
+			}
+			continue
+		}
+		existingVariables[key] = true
+		variables = append(variables, variable)
+	}
+	c.Variables = variables
+
+	highlightRows, err := queries.ListHighlights(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting highlights: %w", err)
+	}
+	existingHighlights := make(map[time.Time]bool, len(highlightRows))
+	for _, highlight := range highlightRows {
+		existingHighlights[highlight.HighlightedAt.Time.UTC()] = true
+	}
+	highlights := c.Highlights[:0]
+	for _, highlight := range c.Highlights {
+		if !existingHighlights[highlight.HighlightedAt.Time.UTC()] {
+			highlights = append(highlights, highlight)
+		}
+	}
+	c.Highlights = highlights
+
+	// Filter settings are settings, which a merge leaves unchanged.
+	c.Filters = nil
+
+	return warnings, nil
+}
@@ -0,0 +1,51 @@
+package botstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/jackc/pgx/v5"
+)
+
+// GetAPICache gets a cached API response body, reporting whether an
+// unexpired entry was found.
+func (s *Store) GetAPICache(ctx context.Context, queries *dbsql.Queries, channel, key string) (string, bool, error) {
+	now, err := s.currentTime(ctx, queries)
+	if err != nil {
+		return "", false, err
+	}
+
+	body, err := queries.BotStateGetAPICache(ctx, dbsql.BotStateGetAPICacheParams{
+		Channel:  channel,
+		CacheKey: key,
+		Now:      dbsql.TimestamptzFrom(now),
+	})
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return "", false, nil
+	case err != nil:
+		return "", false, fmt.Errorf("get api cache: %w", err)
+	}
+	return body, true, nil
+}
+
+// SetAPICache caches an API response body for the given duration,
+// replacing any existing entry.
+func (s *Store) SetAPICache(ctx context.Context, queries *dbsql.Queries, channel, key, body string, expiry time.Duration) error {
+	now, err := s.currentTime(ctx, queries)
+	if err != nil {
+		return err
+	}
+	if err := queries.BotStateSetAPICache(ctx, dbsql.BotStateSetAPICacheParams{
+		Channel:   channel,
+		CacheKey:  key,
+		Body:      body,
+		ExpiresAt: dbsql.TimestamptzFrom(now.Add(expiry)),
+	}); err != nil {
+		return fmt.Errorf("set api cache: %w", err)
+	}
+	return nil
+}
@@ -17,7 +17,9 @@ func (*Store) Cleanup(ctx context.Context, q *dbsql.Queries) error {
 		q.BotStateCleanupLinkPermits,
 		q.BotStateCleanupConfirmations,
 		q.BotStateCleanupFilterWarnings,
+		q.BotStateCleanupFilterShadowHits,
 		q.BotStateCleanupAuthStates,
+		q.BotStateCleanupAPICache,
 	} {
 		if err := cleanup(ctx); err != nil {
 			return fmt.Errorf("delete expired rows: %w", err)
@@ -44,6 +44,24 @@ func (s *Store) CheckAndMarkCooldown(ctx context.Context, queries *dbsql.Queries
 	return scanCooldown(marked, err, "check and mark command cooldown")
 }
 
+// CooldownActive reports whether a command is currently on cooldown, without
+// starting the cooldown.
+func (s *Store) CooldownActive(ctx context.Context, queries *dbsql.Queries, channel, command string) (bool, error) {
+	now, err := s.currentTime(ctx, queries)
+	if err != nil {
+		return false, err
+	}
+	active, err := queries.BotStateCommandCooldownActive(ctx, dbsql.BotStateCommandCooldownActiveParams{
+		Channel:    channel,
+		CommandKey: command,
+		Now:        dbsql.TimestamptzFrom(now),
+	})
+	if err != nil {
+		return false, fmt.Errorf("command cooldown active: %w", err)
+	}
+	return active, nil
+}
+
 // RepeatAllowed reports whether a repeated command may run, blocking
 // further repeats with the same id until the expiry elapses.
 func (s *Store) RepeatAllowed(ctx context.Context, queries *dbsql.Queries, channel string, id int64, expiry time.Duration) (bool, error) {
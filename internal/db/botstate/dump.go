@@ -77,6 +77,14 @@ func (*Store) Dump(ctx context.Context, q *dbsql.Queries) (string, error) {
 		return row.Key, row.Value, row.ExpiresAt.Time
 	})
 
+	apiCache, err := q.BotStateDumpAPICache(ctx)
+	if err != nil {
+		return "", fmt.Errorf("dump bot_api_cache: %w", err)
+	}
+	appendDumpRows(&sb, "bot_api_cache", apiCache, func(row dbsql.BotStateDumpAPICacheRow) (string, string, time.Time) {
+		return row.Key, row.Value, row.ExpiresAt.Time
+	})
+
 	return sb.String(), nil
 }
 
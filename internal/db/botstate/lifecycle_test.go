@@ -172,3 +172,39 @@ func TestCooldownKindsAreIndependent(t *testing.T) {
 	assert.NilError(t, err)
 	assert.Assert(t, !allowed)
 }
+
+func TestAPICacheLifecycle(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	db, clk := freshStore(t)
+
+	_, ok, err := db.GetAPICache(ctx, "ch", "key")
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+
+	assert.NilError(t, db.SetAPICache(ctx, "ch", "key", "first", 10*time.Second))
+
+	body, ok, err := db.GetAPICache(ctx, "ch", "key")
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, body, "first")
+
+	_, ok, err = db.GetAPICache(ctx, "other", "key")
+	assert.NilError(t, err)
+	assert.Assert(t, !ok, "cache entries are per channel")
+
+	assert.NilError(t, db.SetAPICache(ctx, "ch", "key", "second", 10*time.Second))
+
+	body, ok, err = db.GetAPICache(ctx, "ch", "key")
+	assert.NilError(t, err)
+	assert.Assert(t, ok)
+	assert.Equal(t, body, "second")
+
+	clk.Advance(11 * time.Second)
+
+	_, ok, err = db.GetAPICache(ctx, "ch", "key")
+	assert.NilError(t, err)
+	assert.Assert(t, !ok)
+}
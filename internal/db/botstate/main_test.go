@@ -152,6 +152,14 @@ func (db *testStore) RaffleWinners(ctx context.Context, channel string, n int64)
 	})
 }
 
+func (db *testStore) GetAPICache(ctx context.Context, channel, key string) (string, bool, error) {
+	return db.Store.GetAPICache(ctx, db.queries, channel, key)
+}
+
+func (db *testStore) SetAPICache(ctx context.Context, channel, key, body string, expiry time.Duration) error {
+	return db.Store.SetAPICache(ctx, db.queries, channel, key, body, expiry)
+}
+
 func (db *testStore) IncrementBuiltinUsageStat(ctx context.Context, name string) error {
 	return db.Store.IncrementBuiltinUsageStat(ctx, db.queries, name)
 }
@@ -69,6 +69,105 @@ func (q *Queries) ListActiveChannelAssignments(ctx context.Context) ([]ListActiv
 	return items, nil
 }
 
+const listActiveChannelIDsWithChatModeTrigger = `-- name: ListActiveChannelIDsWithChatModeTrigger :many
+SELECT c.twitch_id
+FROM channels c
+LEFT JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
+LEFT JOIN moderated_channels m
+    ON m.broadcaster_id = c.twitch_id
+   AND m.bot_name = c.bot_name
+WHERE c.active
+  AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL)
+  AND EXISTS (
+      SELECT 1 FROM chat_mode_rules r
+      WHERE r.channel_id = c.id AND r.trigger = $1
+  )
+`
+
+func (q *Queries) ListActiveChannelIDsWithChatModeTrigger(ctx context.Context, trigger string) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listActiveChannelIDsWithChatModeTrigger, trigger)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var twitch_id int64
+		if err := rows.Scan(&twitch_id); err != nil {
+			return nil, err
+		}
+		items = append(items, twitch_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveChannelIDsWithScope = `-- name: ListActiveChannelIDsWithScope :many
+SELECT c.twitch_id
+FROM channels c
+JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
+LEFT JOIN moderated_channels m
+    ON m.broadcaster_id = c.twitch_id
+   AND m.bot_name = c.bot_name
+WHERE c.active
+  AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL)
+  AND $1::text = ANY(tt.scopes)
+`
+
+func (q *Queries) ListActiveChannelIDsWithScope(ctx context.Context, scope string) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listActiveChannelIDsWithScope, scope)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var twitch_id int64
+		if err := rows.Scan(&twitch_id); err != nil {
+			return nil, err
+		}
+		items = append(items, twitch_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listActiveRaidChannelIDs = `-- name: ListActiveRaidChannelIDs :many
+SELECT c.twitch_id
+FROM channels c
+LEFT JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
+LEFT JOIN moderated_channels m
+    ON m.broadcaster_id = c.twitch_id
+   AND m.bot_name = c.bot_name
+WHERE c.active
+  AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL)
+  AND (c.raid_message <> '' OR c.raid_shoutout)
+`
+
+func (q *Queries) ListActiveRaidChannelIDs(ctx context.Context) ([]int64, error) {
+	rows, err := q.db.Query(ctx, listActiveRaidChannelIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int64{}
+	for rows.Next() {
+		var twitch_id int64
+		if err := rows.Scan(&twitch_id); err != nil {
+			return nil, err
+		}
+		items = append(items, twitch_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPublicActiveChannels = `-- name: ListPublicActiveChannels :many
 SELECT c.name, c.display_name
 FROM channels c
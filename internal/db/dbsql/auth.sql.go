@@ -11,6 +11,30 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const deleteAPIHost = `-- name: DeleteAPIHost :execrows
+DELETE FROM api_hosts WHERE host = $1
+`
+
+func (q *Queries) DeleteAPIHost(ctx context.Context, host string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAPIHost, host)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteBlockedDomain = `-- name: DeleteBlockedDomain :execrows
+DELETE FROM blocked_domains WHERE domain = $1
+`
+
+func (q *Queries) DeleteBlockedDomain(ctx context.Context, domain string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteBlockedDomain, domain)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteBlockedUser = `-- name: DeleteBlockedUser :exec
 DELETE FROM blocked_users WHERE twitch_id = $1
 `
@@ -46,7 +70,7 @@ func (q *Queries) DeleteTwitchTokenByID(ctx context.Context, twitchID int64) err
 }
 
 const getTwitchTokenByBotName = `-- name: GetTwitchTokenByBotName :one
-SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes FROM twitch_tokens WHERE bot_name = $1::text
+SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes, token_key_id FROM twitch_tokens WHERE bot_name = $1::text
 `
 
 func (q *Queries) GetTwitchTokenByBotName(ctx context.Context, botName string) (TwitchToken, error) {
@@ -63,12 +87,13 @@ func (q *Queries) GetTwitchTokenByBotName(ctx context.Context, botName string) (
 		&i.RefreshToken,
 		&i.Expiry,
 		&i.Scopes,
+		&i.TokenKeyID,
 	)
 	return i, err
 }
 
 const getTwitchTokenByID = `-- name: GetTwitchTokenByID :one
-SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes FROM twitch_tokens WHERE twitch_id = $1
+SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes, token_key_id FROM twitch_tokens WHERE twitch_id = $1
 `
 
 func (q *Queries) GetTwitchTokenByID(ctx context.Context, twitchID int64) (TwitchToken, error) {
@@ -85,6 +110,7 @@ func (q *Queries) GetTwitchTokenByID(ctx context.Context, twitchID int64) (Twitc
 		&i.RefreshToken,
 		&i.Expiry,
 		&i.Scopes,
+		&i.TokenKeyID,
 	)
 	return i, err
 }
@@ -123,8 +149,61 @@ func (q *Queries) IsModeratedChannel(ctx context.Context, arg IsModeratedChannel
 	return exists, err
 }
 
+const listAPIHosts = `-- name: ListAPIHosts :many
+SELECT host FROM api_hosts ORDER BY host
+`
+
+func (q *Queries) ListAPIHosts(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, listAPIHosts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var host string
+		if err := rows.Scan(&host); err != nil {
+			return nil, err
+		}
+		items = append(items, host)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlockedDomains = `-- name: ListBlockedDomains :many
+SELECT id, created_at, domain, reason FROM blocked_domains ORDER BY domain
+`
+
+func (q *Queries) ListBlockedDomains(ctx context.Context) ([]BlockedDomain, error) {
+	rows, err := q.db.Query(ctx, listBlockedDomains)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BlockedDomain{}
+	for rows.Next() {
+		var i BlockedDomain
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Domain,
+			&i.Reason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listBotTwitchTokens = `-- name: ListBotTwitchTokens :many
-SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes FROM twitch_tokens WHERE bot_name IS NOT NULL ORDER BY bot_name
+SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes, token_key_id FROM twitch_tokens WHERE bot_name IS NOT NULL ORDER BY bot_name
 `
 
 func (q *Queries) ListBotTwitchTokens(ctx context.Context) ([]TwitchToken, error) {
@@ -147,6 +226,7 @@ func (q *Queries) ListBotTwitchTokens(ctx context.Context) ([]TwitchToken, error
 			&i.RefreshToken,
 			&i.Expiry,
 			&i.Scopes,
+			&i.TokenKeyID,
 		); err != nil {
 			return nil, err
 		}
@@ -159,7 +239,7 @@ func (q *Queries) ListBotTwitchTokens(ctx context.Context) ([]TwitchToken, error
 }
 
 const listModerationBotTwitchTokens = `-- name: ListModerationBotTwitchTokens :many
-SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes
+SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes, token_key_id
 FROM twitch_tokens
 WHERE bot_name IS NOT NULL
   AND scopes @> ARRAY['user:read:moderated_channels']
@@ -186,6 +266,7 @@ func (q *Queries) ListModerationBotTwitchTokens(ctx context.Context) ([]TwitchTo
 			&i.RefreshToken,
 			&i.Expiry,
 			&i.Scopes,
+			&i.TokenKeyID,
 		); err != nil {
 			return nil, err
 		}
@@ -198,7 +279,7 @@ func (q *Queries) ListModerationBotTwitchTokens(ctx context.Context) ([]TwitchTo
 }
 
 const listTwitchTokens = `-- name: ListTwitchTokens :many
-SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes FROM twitch_tokens ORDER BY twitch_id
+SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes, token_key_id FROM twitch_tokens ORDER BY twitch_id
 `
 
 func (q *Queries) ListTwitchTokens(ctx context.Context) ([]TwitchToken, error) {
@@ -221,6 +302,43 @@ func (q *Queries) ListTwitchTokens(ctx context.Context) ([]TwitchToken, error) {
 			&i.RefreshToken,
 			&i.Expiry,
 			&i.Scopes,
+			&i.TokenKeyID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTwitchTokensNotOnKeyForUpdate = `-- name: ListTwitchTokensNotOnKeyForUpdate :many
+SELECT id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes, token_key_id FROM twitch_tokens WHERE token_key_id != $1 ORDER BY twitch_id FOR UPDATE
+`
+
+func (q *Queries) ListTwitchTokensNotOnKeyForUpdate(ctx context.Context, tokenKeyID string) ([]TwitchToken, error) {
+	rows, err := q.db.Query(ctx, listTwitchTokensNotOnKeyForUpdate, tokenKeyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TwitchToken{}
+	for rows.Next() {
+		var i TwitchToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.TwitchID,
+			&i.BotName,
+			&i.AccessToken,
+			&i.TokenType,
+			&i.RefreshToken,
+			&i.Expiry,
+			&i.Scopes,
+			&i.TokenKeyID,
 		); err != nil {
 			return nil, err
 		}
@@ -241,6 +359,58 @@ func (q *Queries) LockModeratedChannels(ctx context.Context) error {
 	return err
 }
 
+const updateTwitchTokenSecrets = `-- name: UpdateTwitchTokenSecrets :exec
+UPDATE twitch_tokens
+SET access_token = $1,
+    refresh_token = $2,
+    token_key_id = $3
+WHERE id = $4
+`
+
+type UpdateTwitchTokenSecretsParams struct {
+	AccessToken  Secret `json:"access_token"`
+	RefreshToken Secret `json:"refresh_token"`
+	TokenKeyID   string `json:"token_key_id"`
+	ID           int64  `json:"id"`
+}
+
+func (q *Queries) UpdateTwitchTokenSecrets(ctx context.Context, arg UpdateTwitchTokenSecretsParams) error {
+	_, err := q.db.Exec(ctx, updateTwitchTokenSecrets,
+		arg.AccessToken,
+		arg.RefreshToken,
+		arg.TokenKeyID,
+		arg.ID,
+	)
+	return err
+}
+
+const upsertAPIHost = `-- name: UpsertAPIHost :exec
+INSERT INTO api_hosts (host)
+VALUES ($1)
+ON CONFLICT (host) DO NOTHING
+`
+
+func (q *Queries) UpsertAPIHost(ctx context.Context, host string) error {
+	_, err := q.db.Exec(ctx, upsertAPIHost, host)
+	return err
+}
+
+const upsertBlockedDomain = `-- name: UpsertBlockedDomain :exec
+INSERT INTO blocked_domains (domain, reason)
+VALUES ($1, $2)
+ON CONFLICT (domain) DO UPDATE SET reason = EXCLUDED.reason
+`
+
+type UpsertBlockedDomainParams struct {
+	Domain string `json:"domain"`
+	Reason string `json:"reason"`
+}
+
+func (q *Queries) UpsertBlockedDomain(ctx context.Context, arg UpsertBlockedDomainParams) error {
+	_, err := q.db.Exec(ctx, upsertBlockedDomain, arg.Domain, arg.Reason)
+	return err
+}
+
 const upsertBlockedUser = `-- name: UpsertBlockedUser :exec
 INSERT INTO blocked_users (twitch_id)
 VALUES ($1)
@@ -300,7 +470,8 @@ INSERT INTO twitch_tokens (
     token_type,
     refresh_token,
     expiry,
-    scopes
+    scopes,
+    token_key_id
 )
 VALUES (
     $1,
@@ -309,7 +480,8 @@ VALUES (
     $4,
     $5,
     $6,
-    $7::text[]
+    $7::text[],
+    $8
 )
 ON CONFLICT (twitch_id) DO UPDATE
 SET bot_name = excluded.bot_name,
@@ -318,18 +490,20 @@ SET bot_name = excluded.bot_name,
     refresh_token = excluded.refresh_token,
     expiry = excluded.expiry,
     scopes = excluded.scopes,
+    token_key_id = excluded.token_key_id,
     updated_at = statement_timestamp()
-RETURNING id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes
+RETURNING id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes, token_key_id
 `
 
 type UpsertTwitchTokenParams struct {
 	TwitchID     int64              `json:"twitch_id"`
 	BotName      pgtype.Text        `json:"bot_name"`
-	AccessToken  string             `json:"access_token"`
+	AccessToken  Secret             `json:"access_token"`
 	TokenType    string             `json:"token_type"`
-	RefreshToken string             `json:"refresh_token"`
+	RefreshToken Secret             `json:"refresh_token"`
 	Expiry       pgtype.Timestamptz `json:"expiry"`
 	Scopes       []string           `json:"scopes"`
+	TokenKeyID   string             `json:"token_key_id"`
 }
 
 func (q *Queries) UpsertTwitchToken(ctx context.Context, arg UpsertTwitchTokenParams) (TwitchToken, error) {
@@ -341,6 +515,7 @@ func (q *Queries) UpsertTwitchToken(ctx context.Context, arg UpsertTwitchTokenPa
 		arg.RefreshToken,
 		arg.Expiry,
 		arg.Scopes,
+		arg.TokenKeyID,
 	)
 	var i TwitchToken
 	err := row.Scan(
@@ -354,6 +529,7 @@ func (q *Queries) UpsertTwitchToken(ctx context.Context, arg UpsertTwitchTokenPa
 		&i.RefreshToken,
 		&i.Expiry,
 		&i.Scopes,
+		&i.TokenKeyID,
 	)
 	return i, err
 }
@@ -364,30 +540,34 @@ INSERT INTO twitch_tokens (
     access_token,
     token_type,
     refresh_token,
-    expiry
+    expiry,
+    token_key_id
 )
 VALUES (
     $1,
     $2,
     $3,
     $4,
-    $5
+    $5,
+    $6
 )
 ON CONFLICT (twitch_id) DO UPDATE
 SET access_token = excluded.access_token,
     token_type = excluded.token_type,
     refresh_token = excluded.refresh_token,
     expiry = excluded.expiry,
+    token_key_id = excluded.token_key_id,
     updated_at = statement_timestamp()
-RETURNING id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes
+RETURNING id, created_at, updated_at, twitch_id, bot_name, access_token, token_type, refresh_token, expiry, scopes, token_key_id
 `
 
 type UpsertTwitchTokenPreservingMetadataParams struct {
 	TwitchID     int64              `json:"twitch_id"`
-	AccessToken  string             `json:"access_token"`
+	AccessToken  Secret             `json:"access_token"`
 	TokenType    string             `json:"token_type"`
-	RefreshToken string             `json:"refresh_token"`
+	RefreshToken Secret             `json:"refresh_token"`
 	Expiry       pgtype.Timestamptz `json:"expiry"`
+	TokenKeyID   string             `json:"token_key_id"`
 }
 
 func (q *Queries) UpsertTwitchTokenPreservingMetadata(ctx context.Context, arg UpsertTwitchTokenPreservingMetadataParams) (TwitchToken, error) {
@@ -397,6 +577,7 @@ func (q *Queries) UpsertTwitchTokenPreservingMetadata(ctx context.Context, arg U
 		arg.TokenType,
 		arg.RefreshToken,
 		arg.Expiry,
+		arg.TokenKeyID,
 	)
 	var i TwitchToken
 	err := row.Scan(
@@ -410,6 +591,7 @@ func (q *Queries) UpsertTwitchTokenPreservingMetadata(ctx context.Context, arg U
 		&i.RefreshToken,
 		&i.Expiry,
 		&i.Scopes,
+		&i.TokenKeyID,
 	)
 	return i, err
 }
@@ -1,6 +1,8 @@
 package dbsql_test
 
 import (
+	"bytes"
+	"encoding/base64"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/db/migrations"
+	"github.com/hortbot/hortbot/internal/pkg/envelope"
 	"github.com/hortbot/hortbot/internal/pkg/testpostgres"
 	"gotest.tools/v3/assert"
 )
@@ -102,31 +105,173 @@ func TestDeleteStaleModeratedChannels(t *testing.T) {
 	assert.Assert(t, variable.UpdatedAt.Time.After(transactionStart))
 }
 
+//nolint:paralleltest // Changes the global secret keyring.
+func TestTwitchTokenEncryption(t *testing.T) {
+	pdb, err := testpostgres.New()
+	assert.NilError(t, err)
+	t.Cleanup(pdb.Cleanup)
+	assert.NilError(t, migrations.Up(pdb.ConnStr(), nil))
+
+	db, err := pdb.Open(t.Context())
+	assert.NilError(t, err)
+	t.Cleanup(db.Close)
+
+	t.Cleanup(func() { dbsql.SetSecretKeyring(nil) })
+
+	ctx := t.Context()
+	queries := dbsql.New(db)
+
+	rawToken := func() (string, string) {
+		var accessToken, keyID string
+		assert.NilError(t, db.QueryRow(ctx, "SELECT access_token, token_key_id FROM twitch_tokens WHERE twitch_id = 1").Scan(&accessToken, &keyID))
+		return accessToken, keyID
+	}
+
+	token := &dbsql.TwitchToken{
+		TwitchID:     1,
+		AccessToken:  "some-access-token",
+		TokenType:    "bearer",
+		RefreshToken: "some-refresh-token",
+		Expiry:       dbsql.TimestamptzFrom(time.Now()),
+	}
+	assert.NilError(t, queries.SaveTwitchToken(ctx, token))
+
+	raw, keyID := rawToken()
+	assert.Equal(t, raw, "some-access-token")
+	assert.Equal(t, keyID, "")
+
+	key1 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	key2 := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+
+	keyring, err := envelope.ParseKeyring([]string{"one:" + key1})
+	assert.NilError(t, err)
+	dbsql.SetSecretKeyring(keyring)
+
+	// Plaintext tokens remain readable.
+	got, err := queries.GetTwitchTokenByID(ctx, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, got.AccessToken, dbsql.Secret("some-access-token"))
+
+	n, err := queries.ReencryptTwitchTokens(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 1)
+
+	raw, keyID = rawToken()
+	assert.Assert(t, !strings.Contains(raw, "some-access-token"))
+	assert.Equal(t, keyID, "one")
+
+	keyring, err = envelope.ParseKeyring([]string{"two:" + key2, "one:" + key1})
+	assert.NilError(t, err)
+	dbsql.SetSecretKeyring(keyring)
+
+	n, err = queries.ReencryptTwitchTokens(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 1)
+
+	n, err = queries.ReencryptTwitchTokens(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 0)
+
+	_, keyID = rawToken()
+	assert.Equal(t, keyID, "two")
+
+	// A refresh during re-encryption waits for it, rather than being lost.
+	keyring, err = envelope.ParseKeyring([]string{"one:" + key1, "two:" + key2})
+	assert.NilError(t, err)
+	dbsql.SetSecretKeyring(keyring)
+
+	tx, err := db.Begin(ctx)
+	assert.NilError(t, err)
+
+	n, err = dbsql.New(tx).ReencryptTwitchTokens(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 1)
+
+	refreshed := make(chan error, 1)
+	go func() {
+		refresh := *token
+		refresh.AccessToken = "refreshed-access-token"
+		refreshed <- queries.SaveTwitchToken(ctx, &refresh)
+	}()
+
+	// Only commit once the refresh is waiting on the re-encryption's lock, so
+	// that it cannot simply run after the commit.
+	for {
+		select {
+		case err := <-refreshed:
+			t.Fatalf("refresh finished during re-encryption: %v", err)
+		default:
+		}
+
+		var waiting bool
+		assert.NilError(t, db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_stat_activity WHERE datname = current_database() AND wait_event_type = 'Lock' AND query LIKE '%twitch_tokens%')").Scan(&waiting))
+		if waiting {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.NilError(t, tx.Commit(ctx))
+	assert.NilError(t, <-refreshed)
+
+	got, err = queries.GetTwitchTokenByID(ctx, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, got.AccessToken, dbsql.Secret("refreshed-access-token"))
+
+	keyring, err = envelope.ParseKeyring([]string{"two:" + key2, "one:" + key1})
+	assert.NilError(t, err)
+	dbsql.SetSecretKeyring(keyring)
+	assert.NilError(t, queries.SaveTwitchToken(ctx, token))
+
+	got, err = queries.GetTwitchTokenByID(ctx, 1)
+	assert.NilError(t, err)
+	assert.Equal(t, got.AccessToken, dbsql.Secret("some-access-token"))
+	assert.Equal(t, got.RefreshToken, dbsql.Secret("some-refresh-token"))
+	assert.Equal(t, got.TokenKeyID, "two")
+
+	dbsql.SetSecretKeyring(nil)
+	_, err = queries.GetTwitchTokenByID(ctx, 1)
+	assert.ErrorIs(t, err, envelope.ErrUnknownKey)
+}
+
 func TestQueryUpdatedAtPolicy(t *testing.T) {
 	t.Parallel()
 
 	updatedTables := map[string]bool{
-		"autoreplies":        true,
-		"channels":           true,
-		"command_infos":      true,
-		"command_lists":      true,
-		"custom_commands":    true,
-		"moderated_channels": true,
-		"quotes":             true,
-		"repeated_commands":  true,
-		"scheduled_commands": true,
-		"twitch_tokens":      true,
-		"variables":          true,
+		"autoreplies":            true,
+		"ban_list_bans":          true,
+		"ban_list_entries":       true,
+		"ban_list_subscriptions": true,
+		"ban_lists":              true,
+		"channel_emote_sets":     true,
+		"channel_emote_usage":    true,
+		"channel_filters":        true,
+		"channels":               true,
+		"chat_mode_rules":        true,
+		"command_infos":          true,
+		"command_lists":          true,
+		"custom_commands":        true,
+		"moderated_channels":     true,
+		"queue_entries":          true,
+		"quotes":                 true,
+		"raffle_winners":         true,
+		"raffles":                true,
+		"repeated_commands":      true,
+		"scheduled_commands":     true,
+		"twitch_tokens":          true,
+		"variables":              true,
 	}
 	doNotTouch := map[string]bool{
 		"CompactAutoreplies":              true,
 		"CompactQuotes":                   true,
+		"InsertQueueEntry":                true,
 		"UpdateAutoreplyCount":            true,
 		"UpdateChannelActivity":           true,
 		"UpdateCommandInfoCount":          true,
 		"UpdateCommandInfoUsage":          true,
 		"UpdateRepeatedCommandLastCount":  true,
 		"UpdateScheduledCommandLastCount": true,
+		"UpdateTwitchTokenSecrets":        true,
 	}
 
 	_, filename, _, ok := runtime.Caller(0)
@@ -39,6 +39,25 @@ func (q *Queries) CompactAutoreplies(ctx context.Context, arg CompactAutoreplies
 	return result.RowsAffected(), nil
 }
 
+const deleteAutorepliesByIDs = `-- name: DeleteAutorepliesByIDs :execrows
+DELETE FROM autoreplies
+WHERE channel_id = $1
+  AND id = ANY($2::bigint[])
+`
+
+type DeleteAutorepliesByIDsParams struct {
+	ChannelID int64   `json:"channel_id"`
+	Ids       []int64 `json:"ids"`
+}
+
+func (q *Queries) DeleteAutorepliesByIDs(ctx context.Context, arg DeleteAutorepliesByIDsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAutorepliesByIDs, arg.ChannelID, arg.Ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteAutoreply = `-- name: DeleteAutoreply :exec
 DELETE FROM autoreplies WHERE id = $1
 `
@@ -49,7 +68,7 @@ func (q *Queries) DeleteAutoreply(ctx context.Context, id int64) error {
 }
 
 const getAutoreplyForUpdate = `-- name: GetAutoreplyForUpdate :one
-SELECT id, created_at, updated_at, channel_id, num, trigger, orig_pattern, response, count, creator, editor
+SELECT id, created_at, updated_at, channel_id, num, trigger, orig_pattern, response, count, creator, editor, access_level, max_access_level, cooldown, user_cooldown, live_only, games
 FROM autoreplies
 WHERE channel_id = $1
   AND num = $2
@@ -76,6 +95,12 @@ func (q *Queries) GetAutoreplyForUpdate(ctx context.Context, arg GetAutoreplyFor
 		&i.Count,
 		&i.Creator,
 		&i.Editor,
+		&i.AccessLevel,
+		&i.MaxAccessLevel,
+		&i.Cooldown,
+		&i.UserCooldown,
+		&i.LiveOnly,
+		&i.Games,
 	)
 	return i, err
 }
@@ -140,7 +165,7 @@ func (q *Queries) InsertAutoreply(ctx context.Context, arg InsertAutoreplyParams
 }
 
 const listAutoreplies = `-- name: ListAutoreplies :many
-SELECT id, created_at, updated_at, channel_id, num, trigger, orig_pattern, response, count, creator, editor
+SELECT id, created_at, updated_at, channel_id, num, trigger, orig_pattern, response, count, creator, editor, access_level, max_access_level, cooldown, user_cooldown, live_only, games
 FROM autoreplies
 WHERE channel_id = $1
 ORDER BY num
@@ -167,6 +192,12 @@ func (q *Queries) ListAutoreplies(ctx context.Context, channelID int64) ([]Autor
 			&i.Count,
 			&i.Creator,
 			&i.Editor,
+			&i.AccessLevel,
+			&i.MaxAccessLevel,
+			&i.Cooldown,
+			&i.UserCooldown,
+			&i.LiveOnly,
+			&i.Games,
 		); err != nil {
 			return nil, err
 		}
@@ -179,17 +210,24 @@ func (q *Queries) ListAutoreplies(ctx context.Context, channelID int64) ([]Autor
 }
 
 const listAutoreplyMatchers = `-- name: ListAutoreplyMatchers :many
-SELECT id, trigger, response, count
+SELECT id, num, trigger, response, count, access_level, max_access_level, cooldown, user_cooldown, live_only, games
 FROM autoreplies
 WHERE channel_id = $1
 ORDER BY num
 `
 
 type ListAutoreplyMatchersRow struct {
-	ID       int64  `json:"id"`
-	Trigger  string `json:"trigger"`
-	Response string `json:"response"`
-	Count    int32  `json:"count"`
+	ID             int64       `json:"id"`
+	Num            int32       `json:"num"`
+	Trigger        string      `json:"trigger"`
+	Response       string      `json:"response"`
+	Count          int32       `json:"count"`
+	AccessLevel    AccessLevel `json:"access_level"`
+	MaxAccessLevel AccessLevel `json:"max_access_level"`
+	Cooldown       int32       `json:"cooldown"`
+	UserCooldown   int32       `json:"user_cooldown"`
+	LiveOnly       bool        `json:"live_only"`
+	Games          []string    `json:"games"`
 }
 
 func (q *Queries) ListAutoreplyMatchers(ctx context.Context, channelID int64) ([]ListAutoreplyMatchersRow, error) {
@@ -203,9 +241,16 @@ func (q *Queries) ListAutoreplyMatchers(ctx context.Context, channelID int64) ([
 		var i ListAutoreplyMatchersRow
 		if err := rows.Scan(
 			&i.ID,
+			&i.Num,
 			&i.Trigger,
 			&i.Response,
 			&i.Count,
+			&i.AccessLevel,
+			&i.MaxAccessLevel,
+			&i.Cooldown,
+			&i.UserCooldown,
+			&i.LiveOnly,
+			&i.Games,
 		); err != nil {
 			return nil, err
 		}
@@ -218,16 +263,11 @@ func (q *Queries) ListAutoreplyMatchers(ctx context.Context, channelID int64) ([
 }
 
 const updateAutoreplyCount = `-- name: UpdateAutoreplyCount :exec
-UPDATE autoreplies SET count = $1 WHERE id = $2
+UPDATE autoreplies SET count = count + 1 WHERE id = $1
 `
 
-type UpdateAutoreplyCountParams struct {
-	Count int32 `json:"count"`
-	ID    int64 `json:"id"`
-}
-
-func (q *Queries) UpdateAutoreplyCount(ctx context.Context, arg UpdateAutoreplyCountParams) error {
-	_, err := q.db.Exec(ctx, updateAutoreplyCount, arg.Count, arg.ID)
+func (q *Queries) UpdateAutoreplyCount(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, updateAutoreplyCount, id)
 	return err
 }
 
@@ -275,3 +315,41 @@ func (q *Queries) UpdateAutoreplyResponse(ctx context.Context, arg UpdateAutorep
 	_, err := q.db.Exec(ctx, updateAutoreplyResponse, arg.Response, arg.Editor, arg.ID)
 	return err
 }
+
+const updateAutoreplyRestrictions = `-- name: UpdateAutoreplyRestrictions :exec
+UPDATE autoreplies
+SET access_level = $1,
+    max_access_level = $2,
+    cooldown = $3,
+    user_cooldown = $4,
+    live_only = $5,
+    games = $6,
+    editor = $7,
+    updated_at = statement_timestamp()
+WHERE id = $8
+`
+
+type UpdateAutoreplyRestrictionsParams struct {
+	AccessLevel    AccessLevel `json:"access_level"`
+	MaxAccessLevel AccessLevel `json:"max_access_level"`
+	Cooldown       int32       `json:"cooldown"`
+	UserCooldown   int32       `json:"user_cooldown"`
+	LiveOnly       bool        `json:"live_only"`
+	Games          []string    `json:"games"`
+	Editor         string      `json:"editor"`
+	ID             int64       `json:"id"`
+}
+
+func (q *Queries) UpdateAutoreplyRestrictions(ctx context.Context, arg UpdateAutoreplyRestrictionsParams) error {
+	_, err := q.db.Exec(ctx, updateAutoreplyRestrictions,
+		arg.AccessLevel,
+		arg.MaxAccessLevel,
+		arg.Cooldown,
+		arg.UserCooldown,
+		arg.LiveOnly,
+		arg.Games,
+		arg.Editor,
+		arg.ID,
+	)
+	return err
+}
@@ -171,6 +171,15 @@ func (q *Queries) BotStateCheckAndMarkScheduledCooldown(ctx context.Context, arg
 	return column_1, err
 }
 
+const botStateCleanupAPICache = `-- name: BotStateCleanupAPICache :exec
+DELETE FROM bot_api_cache WHERE expires_at < now()
+`
+
+func (q *Queries) BotStateCleanupAPICache(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, botStateCleanupAPICache)
+	return err
+}
+
 const botStateCleanupAuthStates = `-- name: BotStateCleanupAuthStates :exec
 DELETE FROM web_auth_states WHERE expires_at < now()
 `
@@ -207,6 +216,15 @@ func (q *Queries) BotStateCleanupConfirmations(ctx context.Context) error {
 	return err
 }
 
+const botStateCleanupFilterShadowHits = `-- name: BotStateCleanupFilterShadowHits :exec
+DELETE FROM filter_shadow_hits WHERE created_at < now() - interval '7 days'
+`
+
+func (q *Queries) BotStateCleanupFilterShadowHits(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, botStateCleanupFilterShadowHits)
+	return err
+}
+
 const botStateCleanupFilterWarnings = `-- name: BotStateCleanupFilterWarnings :exec
 DELETE FROM bot_filter_warnings WHERE expires_at < now()
 `
@@ -243,6 +261,28 @@ func (q *Queries) BotStateCleanupScheduledCooldowns(ctx context.Context) error {
 	return err
 }
 
+const botStateCommandCooldownActive = `-- name: BotStateCommandCooldownActive :one
+SELECT EXISTS(
+  SELECT 1 FROM bot_command_cooldowns
+  WHERE channel = $1
+    AND command_key = $2
+    AND expires_at > $3
+)
+`
+
+type BotStateCommandCooldownActiveParams struct {
+	Channel    string             `json:"channel"`
+	CommandKey string             `json:"command_key"`
+	Now        pgtype.Timestamptz `json:"now"`
+}
+
+func (q *Queries) BotStateCommandCooldownActive(ctx context.Context, arg BotStateCommandCooldownActiveParams) (bool, error) {
+	row := q.db.QueryRow(ctx, botStateCommandCooldownActive, arg.Channel, arg.CommandKey, arg.Now)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
 const botStateConsumeLinkPermit = `-- name: BotStateConsumeLinkPermit :execrows
 DELETE FROM bot_link_permits
 WHERE channel = $1
@@ -309,6 +349,38 @@ func (q *Queries) BotStateDeleteRaffleEntries(ctx context.Context, arg BotStateD
 	return err
 }
 
+const botStateDumpAPICache = `-- name: BotStateDumpAPICache :many
+SELECT (channel || '/' || cache_key)::text AS key, body AS value, expires_at
+FROM bot_api_cache
+ORDER BY channel, cache_key
+`
+
+type BotStateDumpAPICacheRow struct {
+	Key       string             `json:"key"`
+	Value     string             `json:"value"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) BotStateDumpAPICache(ctx context.Context) ([]BotStateDumpAPICacheRow, error) {
+	rows, err := q.db.Query(ctx, botStateDumpAPICache)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BotStateDumpAPICacheRow{}
+	for rows.Next() {
+		var i BotStateDumpAPICacheRow
+		if err := rows.Scan(&i.Key, &i.Value, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const botStateDumpAuthStates = `-- name: BotStateDumpAuthStates :many
 SELECT key, encode(value, 'escape') AS value, expires_at
 FROM web_auth_states
@@ -569,6 +641,27 @@ func (q *Queries) BotStateDumpScheduledCooldowns(ctx context.Context) ([]BotStat
 	return items, nil
 }
 
+const botStateGetAPICache = `-- name: BotStateGetAPICache :one
+SELECT body
+FROM bot_api_cache
+WHERE channel = $1
+  AND cache_key = $2
+  AND expires_at > $3
+`
+
+type BotStateGetAPICacheParams struct {
+	Channel  string             `json:"channel"`
+	CacheKey string             `json:"cache_key"`
+	Now      pgtype.Timestamptz `json:"now"`
+}
+
+func (q *Queries) BotStateGetAPICache(ctx context.Context, arg BotStateGetAPICacheParams) (string, error) {
+	row := q.db.QueryRow(ctx, botStateGetAPICache, arg.Channel, arg.CacheKey, arg.Now)
+	var body string
+	err := row.Scan(&body)
+	return body, err
+}
+
 const botStateGetConfirmationExpiry = `-- name: BotStateGetConfirmationExpiry :one
 SELECT expires_at
 FROM bot_confirmations
@@ -677,6 +770,118 @@ func (q *Queries) BotStateListBuiltinUsageStats(ctx context.Context) ([]BotBuilt
 	return items, nil
 }
 
+const botStateListChannelCommandCooldowns = `-- name: BotStateListChannelCommandCooldowns :many
+SELECT command_key, expires_at
+FROM bot_command_cooldowns
+WHERE channel = $1 AND expires_at > $2
+ORDER BY command_key
+`
+
+type BotStateListChannelCommandCooldownsParams struct {
+	Channel string             `json:"channel"`
+	Now     pgtype.Timestamptz `json:"now"`
+}
+
+type BotStateListChannelCommandCooldownsRow struct {
+	CommandKey string             `json:"command_key"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) BotStateListChannelCommandCooldowns(ctx context.Context, arg BotStateListChannelCommandCooldownsParams) ([]BotStateListChannelCommandCooldownsRow, error) {
+	rows, err := q.db.Query(ctx, botStateListChannelCommandCooldowns, arg.Channel, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BotStateListChannelCommandCooldownsRow{}
+	for rows.Next() {
+		var i BotStateListChannelCommandCooldownsRow
+		if err := rows.Scan(&i.CommandKey, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const botStateListChannelFilterWarnings = `-- name: BotStateListChannelFilterWarnings :many
+SELECT user_id, filter_name, expires_at
+FROM bot_filter_warnings
+WHERE channel = $1 AND expires_at > $2
+ORDER BY user_id, filter_name
+`
+
+type BotStateListChannelFilterWarningsParams struct {
+	Channel string             `json:"channel"`
+	Now     pgtype.Timestamptz `json:"now"`
+}
+
+type BotStateListChannelFilterWarningsRow struct {
+	UserID     string             `json:"user_id"`
+	FilterName string             `json:"filter_name"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) BotStateListChannelFilterWarnings(ctx context.Context, arg BotStateListChannelFilterWarningsParams) ([]BotStateListChannelFilterWarningsRow, error) {
+	rows, err := q.db.Query(ctx, botStateListChannelFilterWarnings, arg.Channel, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BotStateListChannelFilterWarningsRow{}
+	for rows.Next() {
+		var i BotStateListChannelFilterWarningsRow
+		if err := rows.Scan(&i.UserID, &i.FilterName, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const botStateListChannelLinkPermits = `-- name: BotStateListChannelLinkPermits :many
+SELECT user_id, expires_at
+FROM bot_link_permits
+WHERE channel = $1 AND expires_at > $2
+ORDER BY user_id
+`
+
+type BotStateListChannelLinkPermitsParams struct {
+	Channel string             `json:"channel"`
+	Now     pgtype.Timestamptz `json:"now"`
+}
+
+type BotStateListChannelLinkPermitsRow struct {
+	UserID    string             `json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) BotStateListChannelLinkPermits(ctx context.Context, arg BotStateListChannelLinkPermitsParams) ([]BotStateListChannelLinkPermitsRow, error) {
+	rows, err := q.db.Query(ctx, botStateListChannelLinkPermits, arg.Channel, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BotStateListChannelLinkPermitsRow{}
+	for rows.Next() {
+		var i BotStateListChannelLinkPermitsRow
+		if err := rows.Scan(&i.UserID, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const botStateListRaffleEntriesForUpdate = `-- name: BotStateListRaffleEntriesForUpdate :many
 SELECT user_id
 FROM bot_raffle_entries
@@ -793,6 +998,31 @@ func (q *Queries) BotStateRaffleReset(ctx context.Context, channel string) error
 	return err
 }
 
+const botStateSetAPICache = `-- name: BotStateSetAPICache :exec
+INSERT INTO bot_api_cache (channel, cache_key, body, expires_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (channel, cache_key) DO UPDATE
+SET body = excluded.body,
+    expires_at = excluded.expires_at
+`
+
+type BotStateSetAPICacheParams struct {
+	Channel   string             `json:"channel"`
+	CacheKey  string             `json:"cache_key"`
+	Body      string             `json:"body"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) BotStateSetAPICache(ctx context.Context, arg BotStateSetAPICacheParams) error {
+	_, err := q.db.Exec(ctx, botStateSetAPICache,
+		arg.Channel,
+		arg.CacheKey,
+		arg.Body,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
 const botStateSetAuthState = `-- name: BotStateSetAuthState :exec
 INSERT INTO web_auth_states (key, value, expires_at)
 VALUES ($1, $2, $3)
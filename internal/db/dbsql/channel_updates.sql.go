@@ -11,6 +11,23 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const updateChannelQueueOpen = `-- name: UpdateChannelQueueOpen :exec
+UPDATE channels
+SET queue_open = $1,
+    updated_at = statement_timestamp()
+WHERE id = $2
+`
+
+type UpdateChannelQueueOpenParams struct {
+	QueueOpen bool  `json:"queue_open"`
+	ID        int64 `json:"id"`
+}
+
+func (q *Queries) UpdateChannelQueueOpen(ctx context.Context, arg UpdateChannelQueueOpenParams) error {
+	_, err := q.db.Exec(ctx, updateChannelQueueOpen, arg.QueueOpen, arg.ID)
+	return err
+}
+
 const updateChannelRaffleEnabled = `-- name: UpdateChannelRaffleEnabled :exec
 UPDATE channels
 SET raffle_enabled = $1,
@@ -66,8 +83,28 @@ SET prefix = $1,
     filter_banned_phrases = $34,
     filter_banned_phrases_patterns = $35::text[],
     filter_exempt_level = $36,
+    timezone = $37,
+    queue_sub_priority = $38,
+    queue_vip_priority = $39,
+    raffle_sub_weight = $40,
+    raffle_vip_weight = $41,
+    raffle_exclude_winners = $42,
+    raffle_claim_seconds = $43,
+    api_cache_seconds = $44,
+    raid_message = $45,
+    raid_threshold = $46,
+    raid_shoutout = $47,
+    threaded_replies = $48,
+    whisper_builtins = $49,
+    first_chat_command = $50,
+    first_chat_cooldown = $51,
+    filter_shadow = $52,
+    track_emotes = $53,
+    blocked_links = $54,
+    filter_links_resolve = $55,
+    filter_links_first_message = $56,
     updated_at = statement_timestamp()
-WHERE id = $37
+WHERE id = $57
 `
 
 type UpdateChannelSettingsParams struct {
@@ -107,6 +144,26 @@ type UpdateChannelSettingsParams struct {
 	FilterBannedPhrases         bool        `json:"filter_banned_phrases"`
 	FilterBannedPhrasesPatterns []string    `json:"filter_banned_phrases_patterns"`
 	FilterExemptLevel           AccessLevel `json:"filter_exempt_level"`
+	Timezone                    string      `json:"timezone"`
+	QueueSubPriority            bool        `json:"queue_sub_priority"`
+	QueueVIPPriority            bool        `json:"queue_vip_priority"`
+	RaffleSubWeight             int32       `json:"raffle_sub_weight"`
+	RaffleVIPWeight             int32       `json:"raffle_vip_weight"`
+	RaffleExcludeWinners        bool        `json:"raffle_exclude_winners"`
+	RaffleClaimSeconds          int32       `json:"raffle_claim_seconds"`
+	APICacheSeconds             int32       `json:"api_cache_seconds"`
+	RaidMessage                 string      `json:"raid_message"`
+	RaidThreshold               int32       `json:"raid_threshold"`
+	RaidShoutout                bool        `json:"raid_shoutout"`
+	ThreadedReplies             bool        `json:"threaded_replies"`
+	WhisperBuiltins             []string    `json:"whisper_builtins"`
+	FirstChatCommand            string      `json:"first_chat_command"`
+	FirstChatCooldown           int32       `json:"first_chat_cooldown"`
+	FilterShadow                []string    `json:"filter_shadow"`
+	TrackEmotes                 bool        `json:"track_emotes"`
+	BlockedLinks                []string    `json:"blocked_links"`
+	FilterLinksResolve          bool        `json:"filter_links_resolve"`
+	FilterLinksFirstMessage     bool        `json:"filter_links_first_message"`
 	ID                          int64       `json:"id"`
 }
 
@@ -148,6 +205,26 @@ func (q *Queries) UpdateChannelSettings(ctx context.Context, arg UpdateChannelSe
 		arg.FilterBannedPhrases,
 		arg.FilterBannedPhrasesPatterns,
 		arg.FilterExemptLevel,
+		arg.Timezone,
+		arg.QueueSubPriority,
+		arg.QueueVIPPriority,
+		arg.RaffleSubWeight,
+		arg.RaffleVIPWeight,
+		arg.RaffleExcludeWinners,
+		arg.RaffleClaimSeconds,
+		arg.APICacheSeconds,
+		arg.RaidMessage,
+		arg.RaidThreshold,
+		arg.RaidShoutout,
+		arg.ThreadedReplies,
+		arg.WhisperBuiltins,
+		arg.FirstChatCommand,
+		arg.FirstChatCooldown,
+		arg.FilterShadow,
+		arg.TrackEmotes,
+		arg.BlockedLinks,
+		arg.FilterLinksResolve,
+		arg.FilterLinksFirstMessage,
 		arg.ID,
 	)
 	return err
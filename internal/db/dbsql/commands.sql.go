@@ -16,6 +16,10 @@ SELECT EXISTS(
   SELECT 1 FROM command_infos
   WHERE channel_id = $1
     AND name = $2
+) OR EXISTS(
+  SELECT 1 FROM command_aliases
+  WHERE channel_id = $1
+    AND name = $2
 )
 `
 
@@ -26,9 +30,28 @@ type CommandInfoExistsParams struct {
 
 func (q *Queries) CommandInfoExists(ctx context.Context, arg CommandInfoExistsParams) (bool, error) {
 	row := q.db.QueryRow(ctx, commandInfoExists, arg.ChannelID, arg.Name)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const deleteCommandAlias = `-- name: DeleteCommandAlias :execrows
+DELETE FROM command_aliases
+WHERE channel_id = $1
+  AND name = $2
+`
+
+type DeleteCommandAliasParams struct {
+	ChannelID int64  `json:"channel_id"`
+	Name      string `json:"name"`
+}
+
+func (q *Queries) DeleteCommandAlias(ctx context.Context, arg DeleteCommandAliasParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCommandAlias, arg.ChannelID, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const deleteCommandInfo = `-- name: DeleteCommandInfo :exec
@@ -77,11 +100,17 @@ func (q *Queries) DeleteScheduledCommandByInfo(ctx context.Context, commandInfoI
 }
 
 const findCommand = `-- name: FindCommand :one
-SELECT ci.id, ci.created_at, ci.updated_at, ci.channel_id, ci.name, ci.access_level, ci.count, ci.creator, ci.editor, ci.last_used, ci.custom_command_id, ci.command_list_id, cc.message
+SELECT ci.id, ci.created_at, ci.updated_at, ci.channel_id, ci.name, ci.access_level, ci.count, ci.creator, ci.editor, ci.last_used, ci.custom_command_id, ci.command_list_id, ci.enabled, ci.reply_mode, ci.description, ci.usage, ci.category, ci.hidden, cc.message
 FROM command_infos ci
 LEFT JOIN custom_commands cc ON cc.id = ci.custom_command_id
 WHERE ci.channel_id = $1
-  AND ci.name = $2
+  AND (
+    ci.name = $2
+    OR ci.id = (
+      SELECT ca.command_info_id FROM command_aliases ca
+      WHERE ca.channel_id = $1 AND ca.name = $2
+    )
+  )
 `
 
 type FindCommandParams struct {
@@ -102,6 +131,12 @@ type FindCommandRow struct {
 	LastUsed        pgtype.Timestamptz `json:"last_used"`
 	CustomCommandID pgtype.Int8        `json:"custom_command_id"`
 	CommandListID   pgtype.Int8        `json:"command_list_id"`
+	Enabled         bool               `json:"enabled"`
+	ReplyMode       string             `json:"reply_mode"`
+	Description     string             `json:"description"`
+	Usage           string             `json:"usage"`
+	Category        string             `json:"category"`
+	Hidden          bool               `json:"hidden"`
 	Message         pgtype.Text        `json:"message"`
 }
 
@@ -121,17 +156,29 @@ func (q *Queries) FindCommand(ctx context.Context, arg FindCommandParams) (FindC
 		&i.LastUsed,
 		&i.CustomCommandID,
 		&i.CommandListID,
+		&i.Enabled,
+		&i.ReplyMode,
+		&i.Description,
+		&i.Usage,
+		&i.Category,
+		&i.Hidden,
 		&i.Message,
 	)
 	return i, err
 }
 
 const findCommandForUpdate = `-- name: FindCommandForUpdate :one
-SELECT ci.id, ci.created_at, ci.updated_at, ci.channel_id, ci.name, ci.access_level, ci.count, ci.creator, ci.editor, ci.last_used, ci.custom_command_id, ci.command_list_id, cc.message
+SELECT ci.id, ci.created_at, ci.updated_at, ci.channel_id, ci.name, ci.access_level, ci.count, ci.creator, ci.editor, ci.last_used, ci.custom_command_id, ci.command_list_id, ci.enabled, ci.reply_mode, ci.description, ci.usage, ci.category, ci.hidden, cc.message
 FROM command_infos ci
 LEFT JOIN custom_commands cc ON cc.id = ci.custom_command_id
 WHERE ci.channel_id = $1
-  AND ci.name = $2
+  AND (
+    ci.name = $2
+    OR ci.id = (
+      SELECT ca.command_info_id FROM command_aliases ca
+      WHERE ca.channel_id = $1 AND ca.name = $2
+    )
+  )
 FOR UPDATE OF ci
 `
 
@@ -153,6 +200,12 @@ type FindCommandForUpdateRow struct {
 	LastUsed        pgtype.Timestamptz `json:"last_used"`
 	CustomCommandID pgtype.Int8        `json:"custom_command_id"`
 	CommandListID   pgtype.Int8        `json:"command_list_id"`
+	Enabled         bool               `json:"enabled"`
+	ReplyMode       string             `json:"reply_mode"`
+	Description     string             `json:"description"`
+	Usage           string             `json:"usage"`
+	Category        string             `json:"category"`
+	Hidden          bool               `json:"hidden"`
 	Message         pgtype.Text        `json:"message"`
 }
 
@@ -172,13 +225,19 @@ func (q *Queries) FindCommandForUpdate(ctx context.Context, arg FindCommandForUp
 		&i.LastUsed,
 		&i.CustomCommandID,
 		&i.CommandListID,
+		&i.Enabled,
+		&i.ReplyMode,
+		&i.Description,
+		&i.Usage,
+		&i.Category,
+		&i.Hidden,
 		&i.Message,
 	)
 	return i, err
 }
 
 const getCommandInfo = `-- name: GetCommandInfo :one
-SELECT id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id
+SELECT id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id, enabled, reply_mode, description, usage, category, hidden
 FROM command_infos
 WHERE channel_id = $1
   AND name = $2
@@ -205,12 +264,18 @@ func (q *Queries) GetCommandInfo(ctx context.Context, arg GetCommandInfoParams)
 		&i.LastUsed,
 		&i.CustomCommandID,
 		&i.CommandListID,
+		&i.Enabled,
+		&i.ReplyMode,
+		&i.Description,
+		&i.Usage,
+		&i.Category,
+		&i.Hidden,
 	)
 	return i, err
 }
 
 const getCommandInfoForUpdate = `-- name: GetCommandInfoForUpdate :one
-SELECT id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id
+SELECT id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id, enabled, reply_mode, description, usage, category, hidden
 FROM command_infos
 WHERE channel_id = $1
   AND name = $2
@@ -238,6 +303,12 @@ func (q *Queries) GetCommandInfoForUpdate(ctx context.Context, arg GetCommandInf
 		&i.LastUsed,
 		&i.CustomCommandID,
 		&i.CommandListID,
+		&i.Enabled,
+		&i.ReplyMode,
+		&i.Description,
+		&i.Usage,
+		&i.Category,
+		&i.Hidden,
 	)
 	return i, err
 }
@@ -332,6 +403,38 @@ func (q *Queries) GetScheduledCommandIDByInfo(ctx context.Context, commandInfoID
 	return id, err
 }
 
+const insertCommandAlias = `-- name: InsertCommandAlias :one
+INSERT INTO command_aliases (channel_id, command_info_id, name, creator)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, channel_id, command_info_id, name, creator
+`
+
+type InsertCommandAliasParams struct {
+	ChannelID     int64  `json:"channel_id"`
+	CommandInfoID int64  `json:"command_info_id"`
+	Name          string `json:"name"`
+	Creator       string `json:"creator"`
+}
+
+func (q *Queries) InsertCommandAlias(ctx context.Context, arg InsertCommandAliasParams) (CommandAlias, error) {
+	row := q.db.QueryRow(ctx, insertCommandAlias,
+		arg.ChannelID,
+		arg.CommandInfoID,
+		arg.Name,
+		arg.Creator,
+	)
+	var i CommandAlias
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.ChannelID,
+		&i.CommandInfoID,
+		&i.Name,
+		&i.Creator,
+	)
+	return i, err
+}
+
 const insertCommandInfo = `-- name: InsertCommandInfo :one
 INSERT INTO command_infos (
   channel_id, name, access_level, count, creator, editor,
@@ -342,7 +445,7 @@ VALUES (
   $4, $5,
   $6::bigint, $7::bigint
 )
-RETURNING id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id
+RETURNING id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id, enabled, reply_mode, description, usage, category, hidden
 `
 
 type InsertCommandInfoParams struct {
@@ -379,6 +482,12 @@ func (q *Queries) InsertCommandInfo(ctx context.Context, arg InsertCommandInfoPa
 		&i.LastUsed,
 		&i.CustomCommandID,
 		&i.CommandListID,
+		&i.Enabled,
+		&i.ReplyMode,
+		&i.Description,
+		&i.Usage,
+		&i.Category,
+		&i.Hidden,
 	)
 	return i, err
 }
@@ -426,8 +535,67 @@ func (q *Queries) InsertCustomCommand(ctx context.Context, arg InsertCustomComma
 	return i, err
 }
 
+const listCommandAliasNames = `-- name: ListCommandAliasNames :many
+SELECT name FROM command_aliases
+WHERE command_info_id = $1
+ORDER BY name
+`
+
+func (q *Queries) ListCommandAliasNames(ctx context.Context, commandInfoID int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, listCommandAliasNames, commandInfoID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCommandAliases = `-- name: ListCommandAliases :many
+SELECT id, created_at, channel_id, command_info_id, name, creator FROM command_aliases
+WHERE channel_id = $1
+ORDER BY name
+`
+
+func (q *Queries) ListCommandAliases(ctx context.Context, channelID int64) ([]CommandAlias, error) {
+	rows, err := q.db.Query(ctx, listCommandAliases, channelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CommandAlias{}
+	for rows.Next() {
+		var i CommandAlias
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.ChannelID,
+			&i.CommandInfoID,
+			&i.Name,
+			&i.Creator,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCommandInfos = `-- name: ListCommandInfos :many
-SELECT id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id FROM command_infos
+SELECT id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id, enabled, reply_mode, description, usage, category, hidden FROM command_infos
 WHERE channel_id = $1
 ORDER BY name
 `
@@ -454,6 +622,12 @@ func (q *Queries) ListCommandInfos(ctx context.Context, channelID int64) ([]Comm
 			&i.LastUsed,
 			&i.CustomCommandID,
 			&i.CommandListID,
+			&i.Enabled,
+			&i.ReplyMode,
+			&i.Description,
+			&i.Usage,
+			&i.Category,
+			&i.Hidden,
 		); err != nil {
 			return nil, err
 		}
@@ -496,7 +670,9 @@ func (q *Queries) ListCommandLists(ctx context.Context, channelID int64) ([]Comm
 }
 
 const listCommandListsForWeb = `-- name: ListCommandListsForWeb :many
-SELECT cl.items, cl.updated_at, ci.name, ci.access_level, ci.count, ci.editor
+SELECT cl.items, cl.updated_at, ci.name, ci.access_level, ci.count, ci.editor, ci.enabled,
+  ci.description, ci.usage, ci.category, ci.hidden,
+  ARRAY(SELECT ca.name FROM command_aliases ca WHERE ca.command_info_id = ci.id ORDER BY ca.name)::text[] AS aliases
 FROM command_lists cl
 JOIN command_infos ci ON ci.command_list_id = cl.id
 WHERE cl.channel_id = $1
@@ -510,6 +686,12 @@ type ListCommandListsForWebRow struct {
 	AccessLevel AccessLevel        `json:"access_level"`
 	Count       int64              `json:"count"`
 	Editor      string             `json:"editor"`
+	Enabled     bool               `json:"enabled"`
+	Description string             `json:"description"`
+	Usage       string             `json:"usage"`
+	Category    string             `json:"category"`
+	Hidden      bool               `json:"hidden"`
+	Aliases     []string           `json:"aliases"`
 }
 
 func (q *Queries) ListCommandListsForWeb(ctx context.Context, channelID int64) ([]ListCommandListsForWebRow, error) {
@@ -528,6 +710,12 @@ func (q *Queries) ListCommandListsForWeb(ctx context.Context, channelID int64) (
 			&i.AccessLevel,
 			&i.Count,
 			&i.Editor,
+			&i.Enabled,
+			&i.Description,
+			&i.Usage,
+			&i.Category,
+			&i.Hidden,
+			&i.Aliases,
 		); err != nil {
 			return nil, err
 		}
@@ -570,7 +758,9 @@ func (q *Queries) ListCustomCommands(ctx context.Context, channelID int64) ([]Cu
 }
 
 const listCustomCommandsForWeb = `-- name: ListCustomCommandsForWeb :many
-SELECT cc.message, cc.updated_at, ci.name, ci.access_level, ci.count, ci.editor
+SELECT cc.message, cc.updated_at, ci.name, ci.access_level, ci.count, ci.editor, ci.enabled,
+  ci.description, ci.usage, ci.category, ci.hidden,
+  ARRAY(SELECT ca.name FROM command_aliases ca WHERE ca.command_info_id = ci.id ORDER BY ca.name)::text[] AS aliases
 FROM custom_commands cc
 JOIN command_infos ci ON ci.custom_command_id = cc.id
 WHERE cc.channel_id = $1
@@ -584,6 +774,12 @@ type ListCustomCommandsForWebRow struct {
 	AccessLevel AccessLevel        `json:"access_level"`
 	Count       int64              `json:"count"`
 	Editor      string             `json:"editor"`
+	Enabled     bool               `json:"enabled"`
+	Description string             `json:"description"`
+	Usage       string             `json:"usage"`
+	Category    string             `json:"category"`
+	Hidden      bool               `json:"hidden"`
+	Aliases     []string           `json:"aliases"`
 }
 
 func (q *Queries) ListCustomCommandsForWeb(ctx context.Context, channelID int64) ([]ListCustomCommandsForWebRow, error) {
@@ -602,6 +798,12 @@ func (q *Queries) ListCustomCommandsForWeb(ctx context.Context, channelID int64)
 			&i.AccessLevel,
 			&i.Count,
 			&i.Editor,
+			&i.Enabled,
+			&i.Description,
+			&i.Usage,
+			&i.Category,
+			&i.Hidden,
+			&i.Aliases,
 		); err != nil {
 			return nil, err
 		}
@@ -682,6 +884,76 @@ func (q *Queries) UpdateCommandInfoEditor(ctx context.Context, arg UpdateCommand
 	return err
 }
 
+const updateCommandInfoEnabled = `-- name: UpdateCommandInfoEnabled :exec
+UPDATE command_infos
+SET enabled = $1,
+    editor = $2,
+    updated_at = statement_timestamp()
+WHERE id = $3
+`
+
+type UpdateCommandInfoEnabledParams struct {
+	Enabled bool   `json:"enabled"`
+	Editor  string `json:"editor"`
+	ID      int64  `json:"id"`
+}
+
+func (q *Queries) UpdateCommandInfoEnabled(ctx context.Context, arg UpdateCommandInfoEnabledParams) error {
+	_, err := q.db.Exec(ctx, updateCommandInfoEnabled, arg.Enabled, arg.Editor, arg.ID)
+	return err
+}
+
+const updateCommandInfoMetadata = `-- name: UpdateCommandInfoMetadata :exec
+UPDATE command_infos
+SET description = $1,
+    usage = $2,
+    category = $3,
+    hidden = $4,
+    editor = $5,
+    updated_at = statement_timestamp()
+WHERE id = $6
+`
+
+type UpdateCommandInfoMetadataParams struct {
+	Description string `json:"description"`
+	Usage       string `json:"usage"`
+	Category    string `json:"category"`
+	Hidden      bool   `json:"hidden"`
+	Editor      string `json:"editor"`
+	ID          int64  `json:"id"`
+}
+
+func (q *Queries) UpdateCommandInfoMetadata(ctx context.Context, arg UpdateCommandInfoMetadataParams) error {
+	_, err := q.db.Exec(ctx, updateCommandInfoMetadata,
+		arg.Description,
+		arg.Usage,
+		arg.Category,
+		arg.Hidden,
+		arg.Editor,
+		arg.ID,
+	)
+	return err
+}
+
+const updateCommandInfoReplyMode = `-- name: UpdateCommandInfoReplyMode :exec
+UPDATE command_infos
+SET reply_mode = $1,
+    editor = $2,
+    updated_at = statement_timestamp()
+WHERE id = $3
+`
+
+type UpdateCommandInfoReplyModeParams struct {
+	ReplyMode string `json:"reply_mode"`
+	Editor    string `json:"editor"`
+	ID        int64  `json:"id"`
+}
+
+func (q *Queries) UpdateCommandInfoReplyMode(ctx context.Context, arg UpdateCommandInfoReplyModeParams) error {
+	_, err := q.db.Exec(ctx, updateCommandInfoReplyMode, arg.ReplyMode, arg.Editor, arg.ID)
+	return err
+}
+
 const updateCommandInfoUsage = `-- name: UpdateCommandInfoUsage :exec
 UPDATE command_infos
 SET count = $1,
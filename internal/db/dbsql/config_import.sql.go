@@ -100,6 +100,32 @@ func (q *Queries) InsertImportedChannel(ctx context.Context, data []byte) (int64
 	return id, err
 }
 
+const insertImportedChannelFilter = `-- name: InsertImportedChannelFilter :one
+INSERT INTO channel_filters OVERRIDING USER VALUE
+SELECT (jsonb_populate_record(NULL::channel_filters, $1::jsonb)).*
+RETURNING id
+`
+
+func (q *Queries) InsertImportedChannelFilter(ctx context.Context, data []byte) (int64, error) {
+	row := q.db.QueryRow(ctx, insertImportedChannelFilter, data)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const insertImportedCommandAlias = `-- name: InsertImportedCommandAlias :one
+INSERT INTO command_aliases OVERRIDING USER VALUE
+SELECT (jsonb_populate_record(NULL::command_aliases, $1::jsonb)).*
+RETURNING id
+`
+
+func (q *Queries) InsertImportedCommandAlias(ctx context.Context, data []byte) (int64, error) {
+	row := q.db.QueryRow(ctx, insertImportedCommandAlias, data)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const insertImportedCommandInfo = `-- name: InsertImportedCommandInfo :one
 INSERT INTO command_infos OVERRIDING USER VALUE
 SELECT (jsonb_populate_record(NULL::command_infos, $1::jsonb)).*
@@ -139,6 +165,19 @@ func (q *Queries) InsertImportedCustomCommand(ctx context.Context, data []byte)
 	return id, err
 }
 
+const insertImportedHighlight = `-- name: InsertImportedHighlight :one
+INSERT INTO highlights OVERRIDING USER VALUE
+SELECT (jsonb_populate_record(NULL::highlights, $1::jsonb)).*
+RETURNING id
+`
+
+func (q *Queries) InsertImportedHighlight(ctx context.Context, data []byte) (int64, error) {
+	row := q.db.QueryRow(ctx, insertImportedHighlight, data)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const insertImportedQuote = `-- name: InsertImportedQuote :one
 INSERT INTO quotes OVERRIDING USER VALUE
 SELECT (jsonb_populate_record(NULL::quotes, $1::jsonb)).*
@@ -49,6 +49,15 @@ func (q *Queries) DeleteChannel(ctx context.Context, id int64) error {
 	return err
 }
 
+const deleteCommandAliasesByChannel = `-- name: DeleteCommandAliasesByChannel :exec
+DELETE FROM command_aliases WHERE channel_id = $1
+`
+
+func (q *Queries) DeleteCommandAliasesByChannel(ctx context.Context, channelID int64) error {
+	_, err := q.db.Exec(ctx, deleteCommandAliasesByChannel, channelID)
+	return err
+}
+
 const deleteCommandInfosByChannel = `-- name: DeleteCommandInfosByChannel :exec
 DELETE FROM command_infos WHERE channel_id = $1
 `
@@ -113,7 +122,7 @@ func (q *Queries) DeleteVariablesByChannel(ctx context.Context, channelID int64)
 }
 
 const getActiveChannelByName = `-- name: GetActiveChannelByName :one
-SELECT c.id, c.created_at, c.updated_at, c.twitch_id, c.name, c.display_name, c.bot_name, c.active, c.prefix, c.bullet, c.message_count, c.mode, c.ignored, c.custom_owners, c.custom_mods, c.custom_regulars, c.cooldown, c.last_fm, c.parse_youtube, c.extra_life_id, c.raffle_enabled, c.steam_id, c.urban_enabled, c.tweet, c.roll_level, c.roll_cooldown, c.roll_default, c.should_moderate, c.display_warnings, c.enable_warnings, c.timeout_duration, c.enable_filters, c.filter_links, c.permitted_links, c.subs_may_link, c.filter_caps, c.filter_caps_min_chars, c.filter_caps_percentage, c.filter_caps_min_caps, c.filter_emotes, c.filter_emotes_max, c.filter_emotes_single, c.filter_symbols, c.filter_symbols_percentage, c.filter_symbols_min_symbols, c.filter_me, c.filter_max_length, c.filter_banned_phrases, c.filter_banned_phrases_patterns, c.sub_message, c.sub_message_enabled, c.resub_message, c.resub_message_enabled, c.last_seen, c.filter_exempt_level
+SELECT c.id, c.created_at, c.updated_at, c.twitch_id, c.name, c.display_name, c.bot_name, c.active, c.prefix, c.bullet, c.message_count, c.mode, c.ignored, c.custom_owners, c.custom_mods, c.custom_regulars, c.cooldown, c.last_fm, c.parse_youtube, c.extra_life_id, c.raffle_enabled, c.steam_id, c.urban_enabled, c.tweet, c.roll_level, c.roll_cooldown, c.roll_default, c.should_moderate, c.display_warnings, c.enable_warnings, c.timeout_duration, c.enable_filters, c.filter_links, c.permitted_links, c.subs_may_link, c.filter_caps, c.filter_caps_min_chars, c.filter_caps_percentage, c.filter_caps_min_caps, c.filter_emotes, c.filter_emotes_max, c.filter_emotes_single, c.filter_symbols, c.filter_symbols_percentage, c.filter_symbols_min_symbols, c.filter_me, c.filter_max_length, c.filter_banned_phrases, c.filter_banned_phrases_patterns, c.sub_message, c.sub_message_enabled, c.resub_message, c.resub_message_enabled, c.last_seen, c.filter_exempt_level, c.timezone, c.queue_open, c.queue_sub_priority, c.queue_vip_priority, c.raffle_sub_weight, c.raffle_vip_weight, c.raffle_exclude_winners, c.raffle_claim_seconds, c.api_cache_seconds, c.raid_message, c.raid_threshold, c.raid_shoutout, c.threaded_replies, c.whisper_builtins, c.first_chat_command, c.first_chat_cooldown, c.filter_shadow, c.track_emotes, c.blocked_links, c.filter_links_resolve, c.filter_links_first_message
 FROM channels c
 LEFT JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
 LEFT JOIN moderated_channels m ON m.broadcaster_id = c.twitch_id AND m.bot_name = c.bot_name
@@ -181,6 +190,27 @@ func (q *Queries) GetActiveChannelByName(ctx context.Context, name string) (Chan
 		&i.ResubMessageEnabled,
 		&i.LastSeen,
 		&i.FilterExemptLevel,
+		&i.Timezone,
+		&i.QueueOpen,
+		&i.QueueSubPriority,
+		&i.QueueVIPPriority,
+		&i.RaffleSubWeight,
+		&i.RaffleVIPWeight,
+		&i.RaffleExcludeWinners,
+		&i.RaffleClaimSeconds,
+		&i.APICacheSeconds,
+		&i.RaidMessage,
+		&i.RaidThreshold,
+		&i.RaidShoutout,
+		&i.ThreadedReplies,
+		&i.WhisperBuiltins,
+		&i.FirstChatCommand,
+		&i.FirstChatCooldown,
+		&i.FilterShadow,
+		&i.TrackEmotes,
+		&i.BlockedLinks,
+		&i.FilterLinksResolve,
+		&i.FilterLinksFirstMessage,
 	)
 	return i, err
 }
@@ -204,7 +234,7 @@ func (q *Queries) GetChannelBotByName(ctx context.Context, name string) (GetChan
 }
 
 const getChannelByID = `-- name: GetChannelByID :one
-SELECT id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level FROM channels WHERE id = $1
+SELECT id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level, timezone, queue_open, queue_sub_priority, queue_vip_priority, raffle_sub_weight, raffle_vip_weight, raffle_exclude_winners, raffle_claim_seconds, api_cache_seconds, raid_message, raid_threshold, raid_shoutout, threaded_replies, whisper_builtins, first_chat_command, first_chat_cooldown, filter_shadow, track_emotes, blocked_links, filter_links_resolve, filter_links_first_message FROM channels WHERE id = $1
 `
 
 func (q *Queries) GetChannelByID(ctx context.Context, id int64) (Channel, error) {
@@ -266,12 +296,33 @@ func (q *Queries) GetChannelByID(ctx context.Context, id int64) (Channel, error)
 		&i.ResubMessageEnabled,
 		&i.LastSeen,
 		&i.FilterExemptLevel,
+		&i.Timezone,
+		&i.QueueOpen,
+		&i.QueueSubPriority,
+		&i.QueueVIPPriority,
+		&i.RaffleSubWeight,
+		&i.RaffleVIPWeight,
+		&i.RaffleExcludeWinners,
+		&i.RaffleClaimSeconds,
+		&i.APICacheSeconds,
+		&i.RaidMessage,
+		&i.RaidThreshold,
+		&i.RaidShoutout,
+		&i.ThreadedReplies,
+		&i.WhisperBuiltins,
+		&i.FirstChatCommand,
+		&i.FirstChatCooldown,
+		&i.FilterShadow,
+		&i.TrackEmotes,
+		&i.BlockedLinks,
+		&i.FilterLinksResolve,
+		&i.FilterLinksFirstMessage,
 	)
 	return i, err
 }
 
 const getChannelByName = `-- name: GetChannelByName :one
-SELECT id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level FROM channels WHERE name = $1
+SELECT id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level, timezone, queue_open, queue_sub_priority, queue_vip_priority, raffle_sub_weight, raffle_vip_weight, raffle_exclude_winners, raffle_claim_seconds, api_cache_seconds, raid_message, raid_threshold, raid_shoutout, threaded_replies, whisper_builtins, first_chat_command, first_chat_cooldown, filter_shadow, track_emotes, blocked_links, filter_links_resolve, filter_links_first_message FROM channels WHERE name = $1
 `
 
 func (q *Queries) GetChannelByName(ctx context.Context, name string) (Channel, error) {
@@ -333,12 +384,33 @@ func (q *Queries) GetChannelByName(ctx context.Context, name string) (Channel, e
 		&i.ResubMessageEnabled,
 		&i.LastSeen,
 		&i.FilterExemptLevel,
+		&i.Timezone,
+		&i.QueueOpen,
+		&i.QueueSubPriority,
+		&i.QueueVIPPriority,
+		&i.RaffleSubWeight,
+		&i.RaffleVIPWeight,
+		&i.RaffleExcludeWinners,
+		&i.RaffleClaimSeconds,
+		&i.APICacheSeconds,
+		&i.RaidMessage,
+		&i.RaidThreshold,
+		&i.RaidShoutout,
+		&i.ThreadedReplies,
+		&i.WhisperBuiltins,
+		&i.FirstChatCommand,
+		&i.FirstChatCooldown,
+		&i.FilterShadow,
+		&i.TrackEmotes,
+		&i.BlockedLinks,
+		&i.FilterLinksResolve,
+		&i.FilterLinksFirstMessage,
 	)
 	return i, err
 }
 
 const getChannelByNameForUpdate = `-- name: GetChannelByNameForUpdate :one
-SELECT id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level FROM channels WHERE name = $1 FOR UPDATE
+SELECT id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level, timezone, queue_open, queue_sub_priority, queue_vip_priority, raffle_sub_weight, raffle_vip_weight, raffle_exclude_winners, raffle_claim_seconds, api_cache_seconds, raid_message, raid_threshold, raid_shoutout, threaded_replies, whisper_builtins, first_chat_command, first_chat_cooldown, filter_shadow, track_emotes, blocked_links, filter_links_resolve, filter_links_first_message FROM channels WHERE name = $1 FOR UPDATE
 `
 
 func (q *Queries) GetChannelByNameForUpdate(ctx context.Context, name string) (Channel, error) {
@@ -400,12 +472,33 @@ func (q *Queries) GetChannelByNameForUpdate(ctx context.Context, name string) (C
 		&i.ResubMessageEnabled,
 		&i.LastSeen,
 		&i.FilterExemptLevel,
+		&i.Timezone,
+		&i.QueueOpen,
+		&i.QueueSubPriority,
+		&i.QueueVIPPriority,
+		&i.RaffleSubWeight,
+		&i.RaffleVIPWeight,
+		&i.RaffleExcludeWinners,
+		&i.RaffleClaimSeconds,
+		&i.APICacheSeconds,
+		&i.RaidMessage,
+		&i.RaidThreshold,
+		&i.RaidShoutout,
+		&i.ThreadedReplies,
+		&i.WhisperBuiltins,
+		&i.FirstChatCommand,
+		&i.FirstChatCooldown,
+		&i.FilterShadow,
+		&i.TrackEmotes,
+		&i.BlockedLinks,
+		&i.FilterLinksResolve,
+		&i.FilterLinksFirstMessage,
 	)
 	return i, err
 }
 
 const getChannelByTwitchIDForUpdate = `-- name: GetChannelByTwitchIDForUpdate :one
-SELECT id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level FROM channels WHERE twitch_id = $1 FOR UPDATE
+SELECT id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level, timezone, queue_open, queue_sub_priority, queue_vip_priority, raffle_sub_weight, raffle_vip_weight, raffle_exclude_winners, raffle_claim_seconds, api_cache_seconds, raid_message, raid_threshold, raid_shoutout, threaded_replies, whisper_builtins, first_chat_command, first_chat_cooldown, filter_shadow, track_emotes, blocked_links, filter_links_resolve, filter_links_first_message FROM channels WHERE twitch_id = $1 FOR UPDATE
 `
 
 func (q *Queries) GetChannelByTwitchIDForUpdate(ctx context.Context, twitchID int64) (Channel, error) {
@@ -467,6 +560,27 @@ func (q *Queries) GetChannelByTwitchIDForUpdate(ctx context.Context, twitchID in
 		&i.ResubMessageEnabled,
 		&i.LastSeen,
 		&i.FilterExemptLevel,
+		&i.Timezone,
+		&i.QueueOpen,
+		&i.QueueSubPriority,
+		&i.QueueVIPPriority,
+		&i.RaffleSubWeight,
+		&i.RaffleVIPWeight,
+		&i.RaffleExcludeWinners,
+		&i.RaffleClaimSeconds,
+		&i.APICacheSeconds,
+		&i.RaidMessage,
+		&i.RaidThreshold,
+		&i.RaidShoutout,
+		&i.ThreadedReplies,
+		&i.WhisperBuiltins,
+		&i.FirstChatCommand,
+		&i.FirstChatCooldown,
+		&i.FilterShadow,
+		&i.TrackEmotes,
+		&i.BlockedLinks,
+		&i.FilterLinksResolve,
+		&i.FilterLinksFirstMessage,
 	)
 	return i, err
 }
@@ -506,7 +620,7 @@ VALUES (
   50, 6, 50, 5, 500, 4,
   'Check out (_CHANNEL_URL_) playing (_GAME_) on @Twitch!', 'subscriber'
 )
-RETURNING id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level
+RETURNING id, created_at, updated_at, twitch_id, name, display_name, bot_name, active, prefix, bullet, message_count, mode, ignored, custom_owners, custom_mods, custom_regulars, cooldown, last_fm, parse_youtube, extra_life_id, raffle_enabled, steam_id, urban_enabled, tweet, roll_level, roll_cooldown, roll_default, should_moderate, display_warnings, enable_warnings, timeout_duration, enable_filters, filter_links, permitted_links, subs_may_link, filter_caps, filter_caps_min_chars, filter_caps_percentage, filter_caps_min_caps, filter_emotes, filter_emotes_max, filter_emotes_single, filter_symbols, filter_symbols_percentage, filter_symbols_min_symbols, filter_me, filter_max_length, filter_banned_phrases, filter_banned_phrases_patterns, sub_message, sub_message_enabled, resub_message, resub_message_enabled, last_seen, filter_exempt_level, timezone, queue_open, queue_sub_priority, queue_vip_priority, raffle_sub_weight, raffle_vip_weight, raffle_exclude_winners, raffle_claim_seconds, api_cache_seconds, raid_message, raid_threshold, raid_shoutout, threaded_replies, whisper_builtins, first_chat_command, first_chat_cooldown, filter_shadow, track_emotes, blocked_links, filter_links_resolve, filter_links_first_message
 `
 
 type InsertDefaultChannelParams struct {
@@ -580,6 +694,27 @@ func (q *Queries) InsertDefaultChannel(ctx context.Context, arg InsertDefaultCha
 		&i.ResubMessageEnabled,
 		&i.LastSeen,
 		&i.FilterExemptLevel,
+		&i.Timezone,
+		&i.QueueOpen,
+		&i.QueueSubPriority,
+		&i.QueueVIPPriority,
+		&i.RaffleSubWeight,
+		&i.RaffleVIPWeight,
+		&i.RaffleExcludeWinners,
+		&i.RaffleClaimSeconds,
+		&i.APICacheSeconds,
+		&i.RaidMessage,
+		&i.RaidThreshold,
+		&i.RaidShoutout,
+		&i.ThreadedReplies,
+		&i.WhisperBuiltins,
+		&i.FirstChatCommand,
+		&i.FirstChatCooldown,
+		&i.FilterShadow,
+		&i.TrackEmotes,
+		&i.BlockedLinks,
+		&i.FilterLinksResolve,
+		&i.FilterLinksFirstMessage,
 	)
 	return i, err
 }
@@ -1,9 +1,15 @@
 package dbsql
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -75,17 +81,121 @@ func (q *Queries) SaveChannelSettings(ctx context.Context, channel *Channel) err
 		FilterBannedPhrases:         channel.FilterBannedPhrases,
 		FilterBannedPhrasesPatterns: channel.FilterBannedPhrasesPatterns,
 		FilterExemptLevel:           channel.FilterExemptLevel,
+		Timezone:                    channel.Timezone,
+		QueueSubPriority:            channel.QueueSubPriority,
+		QueueVIPPriority:            channel.QueueVIPPriority,
+		RaffleSubWeight:             channel.RaffleSubWeight,
+		RaffleVIPWeight:             channel.RaffleVIPWeight,
+		RaffleExcludeWinners:        channel.RaffleExcludeWinners,
+		RaffleClaimSeconds:          channel.RaffleClaimSeconds,
+		APICacheSeconds:             channel.APICacheSeconds,
+		RaidMessage:                 channel.RaidMessage,
+		RaidThreshold:               channel.RaidThreshold,
+		RaidShoutout:                channel.RaidShoutout,
+		ThreadedReplies:             channel.ThreadedReplies,
+		WhisperBuiltins:             channel.WhisperBuiltins,
+		FirstChatCommand:            channel.FirstChatCommand,
+		FirstChatCooldown:           channel.FirstChatCooldown,
+		FilterShadow:                channel.FilterShadow,
+		TrackEmotes:                 channel.TrackEmotes,
+		BlockedLinks:                channel.BlockedLinks,
+		FilterLinksResolve:          channel.FilterLinksResolve,
+		FilterLinksFirstMessage:     channel.FilterLinksFirstMessage,
 		ID:                          channel.ID,
 	})
 }
 
+// Location returns the channel's configured time zone, or UTC if the channel
+// has none set or it can no longer be loaded.
+func (c *Channel) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Variable kinds.
+const (
+	VariableKindText    = "text"
+	VariableKindNumber  = "number"
+	VariableKindBoolean = "boolean"
+	VariableKindJSON    = "json"
+)
+
+// VariableKinds lists the valid variable kinds.
+var VariableKinds = []string{VariableKindText, VariableKindNumber, VariableKindBoolean, VariableKindJSON}
+
+// Channel backup kinds.
+const (
+	BackupKindAutomatic = "automatic"
+	BackupKindManual    = "manual"
+	BackupKindUpload    = "upload"
+	BackupKindRestore   = "restore"
+)
+
+// VariableSharedWithAll in a variable's SharedWith allows every channel to read it.
+const VariableSharedWithAll = "*"
+
+// NormalizeVariableValue checks that value is valid for the kind of
+// variable, returning it in canonical form.
+func NormalizeVariableValue(kind, value string) (string, bool) {
+	switch kind {
+	case VariableKindText:
+		return value, true
+
+	case VariableKindNumber:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return strconv.FormatInt(i, 10), true
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", false
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), true
+
+	case VariableKindBoolean:
+		switch strings.ToLower(value) {
+		case "true", "on", "yes", "1":
+			return "true", true
+		case "false", "off", "no", "0":
+			return "false", true
+		}
+		return "", false
+
+	case VariableKindJSON:
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(value)); err != nil {
+			return "", false
+		}
+		return buf.String(), true
+
+	default:
+		return "", false
+	}
+}
+
+// Expired returns true if the variable has expired as of now.
+func (v *Variable) Expired(now time.Time) bool {
+	return v.ExpiresAt.Valid && !v.ExpiresAt.Time.After(now)
+}
+
+// SharedWithChannel returns true if the channel may read the variable.
+func (v *Variable) SharedWithChannel(name string) bool {
+	return slices.Contains(v.SharedWith, VariableSharedWithAll) || slices.Contains(v.SharedWith, name)
+}
+
 func NewTwitchToken(token *oauth2.Token, twitchID int64, botName pgtype.Text, scopes []string) *TwitchToken {
 	return &TwitchToken{
 		TwitchID:     twitchID,
 		BotName:      botName,
-		AccessToken:  token.AccessToken,
+		AccessToken:  Secret(token.AccessToken),
 		TokenType:    token.TokenType,
-		RefreshToken: token.RefreshToken,
+		RefreshToken: Secret(token.RefreshToken),
 		Expiry:       TimestamptzFrom(token.Expiry),
 		Scopes:       scopes,
 	}
@@ -93,9 +203,9 @@ func NewTwitchToken(token *oauth2.Token, twitchID int64, botName pgtype.Text, sc
 
 func (t TwitchToken) OAuth2Token() *oauth2.Token {
 	return &oauth2.Token{
-		AccessToken:  t.AccessToken,
+		AccessToken:  string(t.AccessToken),
 		TokenType:    t.TokenType,
-		RefreshToken: t.RefreshToken,
+		RefreshToken: string(t.RefreshToken),
 		Expiry:       t.Expiry.Time,
 	}
 }
@@ -109,6 +219,7 @@ func (q *Queries) SaveTwitchToken(ctx context.Context, token *TwitchToken) error
 		RefreshToken: token.RefreshToken,
 		Expiry:       token.Expiry,
 		Scopes:       token.Scopes,
+		TokenKeyID:   SecretKeyID(),
 	})
 	if err != nil {
 		return fmt.Errorf("upserting Twitch token: %w", err)
@@ -124,6 +235,7 @@ func (q *Queries) SaveTwitchTokenPreservingMetadata(ctx context.Context, token *
 		TokenType:    token.TokenType,
 		RefreshToken: token.RefreshToken,
 		Expiry:       token.Expiry,
+		TokenKeyID:   SecretKeyID(),
 	})
 	if err != nil {
 		return fmt.Errorf("upserting Twitch token: %w", err)
@@ -132,6 +244,29 @@ func (q *Queries) SaveTwitchTokenPreservingMetadata(ctx context.Context, token *
 	return nil
 }
 
+// ReencryptTwitchTokens re-encrypts all Twitch tokens which were not
+// encrypted with the current primary key, returning the number updated. It
+// must be run in a transaction, so that the tokens stay locked and a refresh
+// cannot be overwritten by the old token.
+func (q *Queries) ReencryptTwitchTokens(ctx context.Context) (int, error) {
+	keyID := SecretKeyID()
+	tokens, err := q.ListTwitchTokensNotOnKeyForUpdate(ctx, keyID)
+	if err != nil {
+		return 0, fmt.Errorf("listing Twitch tokens: %w", err)
+	}
+	for i, token := range tokens {
+		if err := q.UpdateTwitchTokenSecrets(ctx, UpdateTwitchTokenSecretsParams{
+			AccessToken:  token.AccessToken,
+			RefreshToken: token.RefreshToken,
+			TokenKeyID:   keyID,
+			ID:           token.ID,
+		}); err != nil {
+			return i, fmt.Errorf("updating Twitch token %d: %w", token.TwitchID, err)
+		}
+	}
+	return len(tokens), nil
+}
+
 func (q *Queries) DeleteCommandInfoCascade(ctx context.Context, info *CommandInfo) (repeated *RepeatedCommand, scheduled *ScheduledCommand, err error) {
 	if id, queryErr := q.GetRepeatedCommandIDByInfo(ctx, info.ID); queryErr == nil {
 		repeated = &RepeatedCommand{ID: id}
@@ -190,7 +325,9 @@ func (q *Queries) LookupCommand(ctx context.Context, channelID int64, name strin
 		ChannelID: row.ChannelID, Name: row.Name, AccessLevel: row.AccessLevel,
 		Count: row.Count, Creator: row.Creator, Editor: row.Editor,
 		LastUsed: row.LastUsed, CustomCommandID: row.CustomCommandID,
-		CommandListID: row.CommandListID,
+		CommandListID: row.CommandListID, Enabled: row.Enabled,
+		ReplyMode: row.ReplyMode, Description: row.Description,
+		Usage: row.Usage, Category: row.Category, Hidden: row.Hidden,
 	}, row.Message, true, nil
 }
 
@@ -229,9 +366,36 @@ func (q *Queries) ActiveChannelsByBot(ctx context.Context) (map[int64][]int64, e
 }
 
 func (q *Queries) DeleteChannelCascade(ctx context.Context, id int64) error {
+	if err := q.DeleteChannelConfig(ctx, id); err != nil {
+		return err
+	}
+	deletes := []func(context.Context, int64) error{
+		q.DeleteQueueEntriesByChannel,
+		q.DeleteRaffleWinnersByChannel,
+		q.DeleteRafflesByChannel,
+		q.DeleteChattersByChannel,
+		q.DeleteChannelBackupsByChannel,
+		q.DeleteFilterShadowHitsByChannel,
+		q.DeleteChannelEmoteSetsByChannel,
+		q.DeleteChannelEmoteUsageByChannel,
+		q.DeleteBanListBansByChannel,
+		q.DeleteBanListSubscriptionsByChannel,
+		q.ClearBanListMaintainerByChannel,
+		q.DeleteChatModeRulesByChannel,
+		q.DeleteCommandLibraryInstallsByChannel,
+		q.DeleteCommandLibrariesByChannel,
+		q.DeleteChannel,
+	}
+	return deleteChannelRows(ctx, id, deletes)
+}
+
+// DeleteChannelConfig deletes everything included in a channel's exported
+// configuration, leaving the channel itself in place.
+func (q *Queries) DeleteChannelConfig(ctx context.Context, id int64) error {
 	deletes := []func(context.Context, int64) error{
 		q.DeleteScheduledCommandsByChannel,
 		q.DeleteRepeatedCommandsByChannel,
+		q.DeleteCommandAliasesByChannel,
 		q.DeleteCommandInfosByChannel,
 		q.DeleteCommandListsByChannel,
 		q.DeleteVariablesByChannel,
@@ -239,8 +403,12 @@ func (q *Queries) DeleteChannelCascade(ctx context.Context, id int64) error {
 		q.DeleteQuotesByChannel,
 		q.DeleteCustomCommandsByChannel,
 		q.DeleteHighlightsByChannel,
-		q.DeleteChannel,
+		q.DeleteChannelFiltersByChannel,
 	}
+	return deleteChannelRows(ctx, id, deletes)
+}
+
+func deleteChannelRows(ctx context.Context, id int64, deletes []func(context.Context, int64) error) error {
 	for _, deleteRows := range deletes {
 		if err := deleteRows(ctx, id); err != nil {
 			return fmt.Errorf("deleting channel data: %w", err)
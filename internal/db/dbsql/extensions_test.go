@@ -22,6 +22,7 @@ func TestSaveQueryModelCoverage(t *testing.T) {
 		reflect.TypeFor[dbsql.UpdateChannelMembershipParams](),
 		reflect.TypeFor[dbsql.UpdateChannelUserListsParams](),
 		reflect.TypeFor[dbsql.UpdateChannelRaffleEnabledParams](),
+		reflect.TypeFor[dbsql.UpdateChannelQueueOpenParams](),
 		reflect.TypeFor[dbsql.UpdateChannelSettingsParams](),
 		reflect.TypeFor[dbsql.UpdateChannelActivityParams](),
 	)
@@ -115,3 +116,35 @@ func removeFields(fields []string, removed ...string) []string {
 	}
 	return fields
 }
+
+func TestNormalizeVariableValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		kind  string
+		value string
+		want  string
+		ok    bool
+	}{
+		{kind: dbsql.VariableKindText, value: " anything ", want: " anything ", ok: true},
+		{kind: dbsql.VariableKindNumber, value: "007", want: "7", ok: true},
+		{kind: dbsql.VariableKindNumber, value: "-1.50", want: "-1.5", ok: true},
+		{kind: dbsql.VariableKindNumber, value: "NaN", ok: false},
+		{kind: dbsql.VariableKindNumber, value: "Inf", ok: false},
+		{kind: dbsql.VariableKindNumber, value: "ten", ok: false},
+		{kind: dbsql.VariableKindBoolean, value: "Yes", want: "true", ok: true},
+		{kind: dbsql.VariableKindBoolean, value: "off", want: "false", ok: true},
+		{kind: dbsql.VariableKindBoolean, value: "maybe", ok: false},
+		{kind: dbsql.VariableKindJSON, value: `{ "a": [1, 2] }`, want: `{"a":[1,2]}`, ok: true},
+		{kind: dbsql.VariableKindJSON, value: `{"a":`, ok: false},
+		{kind: "unknown", value: "x", ok: false},
+	}
+
+	for _, test := range tests {
+		got, ok := dbsql.NormalizeVariableValue(test.kind, test.value)
+		assert.Equal(t, ok, test.ok, "%s %q", test.kind, test.value)
+		if ok {
+			assert.Equal(t, got, test.want, "%s %q", test.kind, test.value)
+		}
+	}
+}
@@ -58,17 +58,38 @@ func (ns NullAccessLevel) Value() (driver.Value, error) {
 }
 
 type Autoreply struct {
-	ID          int64              `json:"id"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	ChannelID   int64              `json:"channel_id"`
-	Num         int32              `json:"num"`
-	Trigger     string             `json:"trigger"`
-	OrigPattern pgtype.Text        `json:"orig_pattern"`
-	Response    string             `json:"response"`
-	Count       int32              `json:"count"`
-	Creator     string             `json:"creator"`
-	Editor      string             `json:"editor"`
+	ID             int64              `json:"id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	ChannelID      int64              `json:"channel_id"`
+	Num            int32              `json:"num"`
+	Trigger        string             `json:"trigger"`
+	OrigPattern    pgtype.Text        `json:"orig_pattern"`
+	Response       string             `json:"response"`
+	Count          int32              `json:"count"`
+	Creator        string             `json:"creator"`
+	Editor         string             `json:"editor"`
+	AccessLevel    AccessLevel        `json:"access_level"`
+	MaxAccessLevel AccessLevel        `json:"max_access_level"`
+	Cooldown       int32              `json:"cooldown"`
+	UserCooldown   int32              `json:"user_cooldown"`
+	LiveOnly       bool               `json:"live_only"`
+	Games          []string           `json:"games"`
+}
+
+type BanList struct {
+	ID                  int64              `json:"id"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	Name                string             `json:"name"`
+	MaintainerChannelID pgtype.Int8        `json:"maintainer_channel_id"`
+}
+
+type BlockedDomain struct {
+	ID        int64              `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	Domain    string             `json:"domain"`
+	Reason    string             `json:"reason"`
 }
 
 type BotActionUsageStat struct {
@@ -137,6 +158,94 @@ type Channel struct {
 	ResubMessageEnabled         bool               `json:"resub_message_enabled"`
 	LastSeen                    pgtype.Timestamptz `json:"last_seen"`
 	FilterExemptLevel           AccessLevel        `json:"filter_exempt_level"`
+	Timezone                    string             `json:"timezone"`
+	QueueOpen                   bool               `json:"queue_open"`
+	QueueSubPriority            bool               `json:"queue_sub_priority"`
+	QueueVIPPriority            bool               `json:"queue_vip_priority"`
+	RaffleSubWeight             int32              `json:"raffle_sub_weight"`
+	RaffleVIPWeight             int32              `json:"raffle_vip_weight"`
+	RaffleExcludeWinners        bool               `json:"raffle_exclude_winners"`
+	RaffleClaimSeconds          int32              `json:"raffle_claim_seconds"`
+	APICacheSeconds             int32              `json:"api_cache_seconds"`
+	RaidMessage                 string             `json:"raid_message"`
+	RaidThreshold               int32              `json:"raid_threshold"`
+	RaidShoutout                bool               `json:"raid_shoutout"`
+	ThreadedReplies             bool               `json:"threaded_replies"`
+	WhisperBuiltins             []string           `json:"whisper_builtins"`
+	FirstChatCommand            string             `json:"first_chat_command"`
+	FirstChatCooldown           int32              `json:"first_chat_cooldown"`
+	FilterShadow                []string           `json:"filter_shadow"`
+	TrackEmotes                 bool               `json:"track_emotes"`
+	BlockedLinks                []string           `json:"blocked_links"`
+	FilterLinksResolve          bool               `json:"filter_links_resolve"`
+	FilterLinksFirstMessage     bool               `json:"filter_links_first_message"`
+}
+
+type ChannelBackup struct {
+	ID        int64              `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ChannelID int64              `json:"channel_id"`
+	Creator   string             `json:"creator"`
+	Kind      string             `json:"kind"`
+	Version   int32              `json:"version"`
+	Data      []byte             `json:"data"`
+}
+
+type ChannelEmoteSet struct {
+	ID        int64              `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ChannelID int64              `json:"channel_id"`
+	Provider  string             `json:"provider"`
+	Emotes    []string           `json:"emotes"`
+}
+
+type ChannelFilter struct {
+	ID          int64              `json:"id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	ChannelID   int64              `json:"channel_id"`
+	Filter      string             `json:"filter"`
+	ExemptLevel AccessLevel        `json:"exempt_level"`
+	Action      string             `json:"action"`
+	Duration    int32              `json:"duration"`
+	Message     string             `json:"message"`
+}
+
+type ChatModeRule struct {
+	ID            int64              `json:"id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	ChannelID     int64              `json:"channel_id"`
+	Trigger       string             `json:"trigger"`
+	Threshold     int64              `json:"threshold"`
+	Schedule      string             `json:"schedule"`
+	Mode          string             `json:"mode"`
+	ModeSeconds   int64              `json:"mode_seconds"`
+	RevertSeconds int64              `json:"revert_seconds"`
+	RevertAt      pgtype.Timestamptz `json:"revert_at"`
+	PriorEnabled  bool               `json:"prior_enabled"`
+	PriorSeconds  int64              `json:"prior_seconds"`
+}
+
+type Chatter struct {
+	ID           int64              `json:"id"`
+	ChannelID    int64              `json:"channel_id"`
+	UserID       int64              `json:"user_id"`
+	UserName     string             `json:"user_name"`
+	UserDisplay  string             `json:"user_display"`
+	FirstSeen    pgtype.Timestamptz `json:"first_seen"`
+	LastSeen     pgtype.Timestamptz `json:"last_seen"`
+	MessageCount int64              `json:"message_count"`
+}
+
+type CommandAlias struct {
+	ID            int64              `json:"id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	ChannelID     int64              `json:"channel_id"`
+	CommandInfoID int64              `json:"command_info_id"`
+	Name          string             `json:"name"`
+	Creator       string             `json:"creator"`
 }
 
 type CommandInfo struct {
@@ -152,6 +261,40 @@ type CommandInfo struct {
 	LastUsed        pgtype.Timestamptz `json:"last_used"`
 	CustomCommandID pgtype.Int8        `json:"custom_command_id"`
 	CommandListID   pgtype.Int8        `json:"command_list_id"`
+	Enabled         bool               `json:"enabled"`
+	ReplyMode       string             `json:"reply_mode"`
+	Description     string             `json:"description"`
+	Usage           string             `json:"usage"`
+	Category        string             `json:"category"`
+	Hidden          bool               `json:"hidden"`
+}
+
+type CommandLibrary struct {
+	ID          int64              `json:"id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	ChannelID   int64              `json:"channel_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Version     int32              `json:"version"`
+	Parameters  []string           `json:"parameters"`
+	Creator     string             `json:"creator"`
+	Editor      string             `json:"editor"`
+	Data        []byte             `json:"data"`
+}
+
+type CommandLibraryInstall struct {
+	ID             int64              `json:"id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+	ChannelID      int64              `json:"channel_id"`
+	LibraryID      int64              `json:"library_id"`
+	Version        int32              `json:"version"`
+	Arguments      []byte             `json:"arguments"`
+	CommandInfoIds []int64            `json:"command_info_ids"`
+	AutoreplyIds   []int64            `json:"autoreply_ids"`
+	Creator        string             `json:"creator"`
+	Editor         string             `json:"editor"`
 }
 
 type CommandList struct {
@@ -170,6 +313,17 @@ type CustomCommand struct {
 	Message   string             `json:"message"`
 }
 
+type FilterShadowHit struct {
+	ID         int64              `json:"id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ChannelID  int64              `json:"channel_id"`
+	Filter     string             `json:"filter"`
+	UserID     int64              `json:"user_id"`
+	UserName   string             `json:"user_name"`
+	Message    string             `json:"message"`
+	Punishment string             `json:"punishment"`
+}
+
 type Highlight struct {
 	ID            int64              `json:"id"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
@@ -180,6 +334,18 @@ type Highlight struct {
 	Game          string             `json:"game"`
 }
 
+type QueueEntry struct {
+	ID          int64              `json:"id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	ChannelID   int64              `json:"channel_id"`
+	UserID      int64              `json:"user_id"`
+	UserName    string             `json:"user_name"`
+	UserDisplay string             `json:"user_display"`
+	GameName    string             `json:"game_name"`
+	Priority    int32              `json:"priority"`
+}
+
 type Quote struct {
 	ID        int64              `json:"id"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
@@ -191,6 +357,41 @@ type Quote struct {
 	Editor    string             `json:"editor"`
 }
 
+type Raffle struct {
+	ID        int64              `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ChannelID int64              `json:"channel_id"`
+	Name      string             `json:"name"`
+	Keyword   string             `json:"keyword"`
+	Open      bool               `json:"open"`
+	ClosesAt  pgtype.Timestamptz `json:"closes_at"`
+}
+
+type RaffleEntry struct {
+	ID          int64              `json:"id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	RaffleID    int64              `json:"raffle_id"`
+	UserID      int64              `json:"user_id"`
+	UserName    string             `json:"user_name"`
+	UserDisplay string             `json:"user_display"`
+	Weight      int32              `json:"weight"`
+}
+
+type RaffleWinner struct {
+	ID            int64              `json:"id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	ChannelID     int64              `json:"channel_id"`
+	RaffleID      pgtype.Int8        `json:"raffle_id"`
+	RaffleName    string             `json:"raffle_name"`
+	UserID        int64              `json:"user_id"`
+	UserName      string             `json:"user_name"`
+	UserDisplay   string             `json:"user_display"`
+	Status        string             `json:"status"`
+	ClaimDeadline pgtype.Timestamptz `json:"claim_deadline"`
+}
+
 type RepeatedCommand struct {
 	ID            int64              `json:"id"`
 	CreatedAt     pgtype.Timestamptz `json:"created_at"`
@@ -226,18 +427,23 @@ type TwitchToken struct {
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
 	TwitchID     int64              `json:"twitch_id"`
 	BotName      pgtype.Text        `json:"bot_name"`
-	AccessToken  string             `json:"access_token"`
+	AccessToken  Secret             `json:"access_token"`
 	TokenType    string             `json:"token_type"`
-	RefreshToken string             `json:"refresh_token"`
+	RefreshToken Secret             `json:"refresh_token"`
 	Expiry       pgtype.Timestamptz `json:"expiry"`
 	Scopes       []string           `json:"scopes"`
+	TokenKeyID   string             `json:"token_key_id"`
 }
 
 type Variable struct {
-	ID        int64              `json:"id"`
-	CreatedAt pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	ChannelID int64              `json:"channel_id"`
-	Name      string             `json:"name"`
-	Value     string             `json:"value"`
+	ID         int64              `json:"id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	ChannelID  int64              `json:"channel_id"`
+	Name       string             `json:"name"`
+	Value      string             `json:"value"`
+	UserID     int64              `json:"user_id"`
+	Kind       string             `json:"kind"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	SharedWith []string           `json:"shared_with"`
 }
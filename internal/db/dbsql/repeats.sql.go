@@ -30,7 +30,7 @@ func (q *Queries) DeleteScheduledCommand(ctx context.Context, id int64) error {
 }
 
 const getCommandInfoByIDForUpdate = `-- name: GetCommandInfoByIDForUpdate :one
-SELECT id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id FROM command_infos WHERE id = $1 FOR UPDATE
+SELECT id, created_at, updated_at, channel_id, name, access_level, count, creator, editor, last_used, custom_command_id, command_list_id, enabled, reply_mode, description, usage, category, hidden FROM command_infos WHERE id = $1 FOR UPDATE
 `
 
 func (q *Queries) GetCommandInfoByIDForUpdate(ctx context.Context, id int64) (CommandInfo, error) {
@@ -49,6 +49,12 @@ func (q *Queries) GetCommandInfoByIDForUpdate(ctx context.Context, id int64) (Co
 		&i.LastUsed,
 		&i.CustomCommandID,
 		&i.CommandListID,
+		&i.Enabled,
+		&i.ReplyMode,
+		&i.Description,
+		&i.Usage,
+		&i.Category,
+		&i.Hidden,
 	)
 	return i, err
 }
@@ -343,7 +349,7 @@ func (q *Queries) ListActiveRepeatedCommands(ctx context.Context) ([]RepeatedCom
 }
 
 const listActiveScheduledCommands = `-- name: ListActiveScheduledCommands :many
-SELECT s.id, s.created_at, s.updated_at, s.channel_id, s.command_info_id, s.enabled, s.cron_expression, s.message_diff, s.last_count, s.creator, s.editor
+SELECT s.id, s.created_at, s.updated_at, s.channel_id, s.command_info_id, s.enabled, s.cron_expression, s.message_diff, s.last_count, s.creator, s.editor, c.timezone
 FROM scheduled_commands s
 JOIN channels c ON s.channel_id = c.id
 LEFT JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
@@ -351,27 +357,33 @@ LEFT JOIN moderated_channels m ON m.broadcaster_id = c.twitch_id AND m.bot_name
 WHERE s.enabled AND c.active AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL)
 `
 
-func (q *Queries) ListActiveScheduledCommands(ctx context.Context) ([]ScheduledCommand, error) {
+type ListActiveScheduledCommandsRow struct {
+	ScheduledCommand ScheduledCommand `json:"scheduled_command"`
+	Timezone         string           `json:"timezone"`
+}
+
+func (q *Queries) ListActiveScheduledCommands(ctx context.Context) ([]ListActiveScheduledCommandsRow, error) {
 	rows, err := q.db.Query(ctx, listActiveScheduledCommands)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ScheduledCommand{}
+	items := []ListActiveScheduledCommandsRow{}
 	for rows.Next() {
-		var i ScheduledCommand
+		var i ListActiveScheduledCommandsRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.ChannelID,
-			&i.CommandInfoID,
-			&i.Enabled,
-			&i.CronExpression,
-			&i.MessageDiff,
-			&i.LastCount,
-			&i.Creator,
-			&i.Editor,
+			&i.ScheduledCommand.ID,
+			&i.ScheduledCommand.CreatedAt,
+			&i.ScheduledCommand.UpdatedAt,
+			&i.ScheduledCommand.ChannelID,
+			&i.ScheduledCommand.CommandInfoID,
+			&i.ScheduledCommand.Enabled,
+			&i.ScheduledCommand.CronExpression,
+			&i.ScheduledCommand.MessageDiff,
+			&i.ScheduledCommand.LastCount,
+			&i.ScheduledCommand.Creator,
+			&i.ScheduledCommand.Editor,
+			&i.Timezone,
 		); err != nil {
 			return nil, err
 		}
@@ -0,0 +1,55 @@
+package dbsql
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/hortbot/hortbot/internal/pkg/envelope"
+)
+
+var secretKeyring atomic.Pointer[envelope.Keyring]
+
+// SetSecretKeyring sets the keyring used to encrypt and decrypt Secret
+// columns. A nil keyring stores new secrets in plaintext.
+func SetSecretKeyring(k *envelope.Keyring) {
+	secretKeyring.Store(k)
+}
+
+// SecretKeyID returns the ID of the key new secrets are encrypted with, or
+// an empty string if secrets are stored in plaintext.
+func SecretKeyID() string {
+	return secretKeyring.Load().PrimaryID()
+}
+
+// Secret is a string which is encrypted when written to the database and
+// decrypted when read back.
+type Secret string
+
+// Scan implements sql.Scanner.
+func (s *Secret) Scan(src any) error {
+	var value string
+	switch src := src.(type) {
+	case nil:
+		*s = ""
+		return nil
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into Secret", src)
+	}
+
+	plaintext, err := secretKeyring.Load().Open(value)
+	if err != nil {
+		return err
+	}
+	*s = Secret(plaintext)
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (s Secret) Value() (driver.Value, error) {
+	return secretKeyring.Load().Seal(string(s))
+}
@@ -7,38 +7,44 @@ package dbsql
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const deleteVariable = `-- name: DeleteVariable :exec
 DELETE FROM variables
 WHERE channel_id = $1
-  AND name = $2
+  AND user_id = $2
+  AND name = $3
 `
 
 type DeleteVariableParams struct {
 	ChannelID int64  `json:"channel_id"`
+	UserID    int64  `json:"user_id"`
 	Name      string `json:"name"`
 }
 
 func (q *Queries) DeleteVariable(ctx context.Context, arg DeleteVariableParams) error {
-	_, err := q.db.Exec(ctx, deleteVariable, arg.ChannelID, arg.Name)
+	_, err := q.db.Exec(ctx, deleteVariable, arg.ChannelID, arg.UserID, arg.Name)
 	return err
 }
 
 const getVariable = `-- name: GetVariable :one
-SELECT id, created_at, updated_at, channel_id, name, value
+SELECT id, created_at, updated_at, channel_id, name, value, user_id, kind, expires_at, shared_with
 FROM variables
 WHERE channel_id = $1
-  AND name = $2
+  AND user_id = $2
+  AND name = $3
 `
 
 type GetVariableParams struct {
 	ChannelID int64  `json:"channel_id"`
+	UserID    int64  `json:"user_id"`
 	Name      string `json:"name"`
 }
 
 func (q *Queries) GetVariable(ctx context.Context, arg GetVariableParams) (Variable, error) {
-	row := q.db.QueryRow(ctx, getVariable, arg.ChannelID, arg.Name)
+	row := q.db.QueryRow(ctx, getVariable, arg.ChannelID, arg.UserID, arg.Name)
 	var i Variable
 	err := row.Scan(
 		&i.ID,
@@ -47,15 +53,20 @@ func (q *Queries) GetVariable(ctx context.Context, arg GetVariableParams) (Varia
 		&i.ChannelID,
 		&i.Name,
 		&i.Value,
+		&i.UserID,
+		&i.Kind,
+		&i.ExpiresAt,
+		&i.SharedWith,
 	)
 	return i, err
 }
 
 const getVariableByChannelName = `-- name: GetVariableByChannelName :one
-SELECT variables.id, variables.created_at, variables.updated_at, variables.channel_id, variables.name, variables.value
+SELECT variables.id, variables.created_at, variables.updated_at, variables.channel_id, variables.name, variables.value, variables.user_id, variables.kind, variables.expires_at, variables.shared_with
 FROM variables
 JOIN channels ON channels.id = variables.channel_id
 WHERE channels.name = $1
+  AND variables.user_id = 0
   AND variables.name = $2
 `
 
@@ -74,17 +85,127 @@ func (q *Queries) GetVariableByChannelName(ctx context.Context, arg GetVariableB
 		&i.ChannelID,
 		&i.Name,
 		&i.Value,
+		&i.UserID,
+		&i.Kind,
+		&i.ExpiresAt,
+		&i.SharedWith,
 	)
 	return i, err
 }
 
-const listVariables = `-- name: ListVariables :many
-SELECT id, created_at, updated_at, channel_id, name, value
+const listChannelVariables = `-- name: ListChannelVariables :many
+SELECT id, created_at, updated_at, channel_id, name, value, user_id, kind, expires_at, shared_with
 FROM variables
 WHERE channel_id = $1
+  AND user_id = 0
+  AND (expires_at IS NULL OR expires_at > $2)
 ORDER BY name
 `
 
+type ListChannelVariablesParams struct {
+	ChannelID int64              `json:"channel_id"`
+	Now       pgtype.Timestamptz `json:"now"`
+}
+
+func (q *Queries) ListChannelVariables(ctx context.Context, arg ListChannelVariablesParams) ([]Variable, error) {
+	rows, err := q.db.Query(ctx, listChannelVariables, arg.ChannelID, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Variable{}
+	for rows.Next() {
+		var i Variable
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ChannelID,
+			&i.Name,
+			&i.Value,
+			&i.UserID,
+			&i.Kind,
+			&i.ExpiresAt,
+			&i.SharedWith,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserVariables = `-- name: ListUserVariables :many
+SELECT variables.id, variables.created_at, variables.updated_at, variables.channel_id, variables.name, variables.value, variables.user_id, variables.kind, variables.expires_at, variables.shared_with, COALESCE(chatters.user_name, '')::text AS user_name
+FROM variables
+LEFT JOIN chatters ON chatters.channel_id = variables.channel_id AND chatters.user_id = variables.user_id
+WHERE variables.channel_id = $1
+  AND variables.user_id != 0
+  AND (variables.expires_at IS NULL OR variables.expires_at > $2)
+ORDER BY variables.name, variables.user_id
+`
+
+type ListUserVariablesParams struct {
+	ChannelID int64              `json:"channel_id"`
+	Now       pgtype.Timestamptz `json:"now"`
+}
+
+type ListUserVariablesRow struct {
+	ID         int64              `json:"id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	ChannelID  int64              `json:"channel_id"`
+	Name       string             `json:"name"`
+	Value      string             `json:"value"`
+	UserID     int64              `json:"user_id"`
+	Kind       string             `json:"kind"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	SharedWith []string           `json:"shared_with"`
+	UserName   string             `json:"user_name"`
+}
+
+func (q *Queries) ListUserVariables(ctx context.Context, arg ListUserVariablesParams) ([]ListUserVariablesRow, error) {
+	rows, err := q.db.Query(ctx, listUserVariables, arg.ChannelID, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserVariablesRow{}
+	for rows.Next() {
+		var i ListUserVariablesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ChannelID,
+			&i.Name,
+			&i.Value,
+			&i.UserID,
+			&i.Kind,
+			&i.ExpiresAt,
+			&i.SharedWith,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listVariables = `-- name: ListVariables :many
+SELECT id, created_at, updated_at, channel_id, name, value, user_id, kind, expires_at, shared_with
+FROM variables
+WHERE channel_id = $1
+ORDER BY name, user_id
+`
+
 func (q *Queries) ListVariables(ctx context.Context, channelID int64) ([]Variable, error) {
 	rows, err := q.db.Query(ctx, listVariables, channelID)
 	if err != nil {
@@ -101,6 +222,10 @@ func (q *Queries) ListVariables(ctx context.Context, channelID int64) ([]Variabl
 			&i.ChannelID,
 			&i.Name,
 			&i.Value,
+			&i.UserID,
+			&i.Kind,
+			&i.ExpiresAt,
+			&i.SharedWith,
 		); err != nil {
 			return nil, err
 		}
@@ -112,6 +237,101 @@ func (q *Queries) ListVariables(ctx context.Context, channelID int64) ([]Variabl
 	return items, nil
 }
 
+const saveVariable = `-- name: SaveVariable :exec
+INSERT INTO variables (channel_id, user_id, name, kind, value, expires_at, shared_with)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+)
+ON CONFLICT (channel_id, user_id, name) DO UPDATE
+SET kind = excluded.kind,
+    value = excluded.value,
+    expires_at = excluded.expires_at,
+    shared_with = excluded.shared_with,
+    updated_at = statement_timestamp()
+`
+
+type SaveVariableParams struct {
+	ChannelID  int64              `json:"channel_id"`
+	UserID     int64              `json:"user_id"`
+	Name       string             `json:"name"`
+	Kind       string             `json:"kind"`
+	Value      string             `json:"value"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
+	SharedWith []string           `json:"shared_with"`
+}
+
+func (q *Queries) SaveVariable(ctx context.Context, arg SaveVariableParams) error {
+	_, err := q.db.Exec(ctx, saveVariable,
+		arg.ChannelID,
+		arg.UserID,
+		arg.Name,
+		arg.Kind,
+		arg.Value,
+		arg.ExpiresAt,
+		arg.SharedWith,
+	)
+	return err
+}
+
+const updateVariableExpiry = `-- name: UpdateVariableExpiry :exec
+UPDATE variables
+SET expires_at = $1,
+    updated_at = statement_timestamp()
+WHERE id = $2
+`
+
+type UpdateVariableExpiryParams struct {
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	ID        int64              `json:"id"`
+}
+
+func (q *Queries) UpdateVariableExpiry(ctx context.Context, arg UpdateVariableExpiryParams) error {
+	_, err := q.db.Exec(ctx, updateVariableExpiry, arg.ExpiresAt, arg.ID)
+	return err
+}
+
+const updateVariableKind = `-- name: UpdateVariableKind :exec
+UPDATE variables
+SET kind = $1,
+    value = $2,
+    updated_at = statement_timestamp()
+WHERE id = $3
+`
+
+type UpdateVariableKindParams struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+	ID    int64  `json:"id"`
+}
+
+func (q *Queries) UpdateVariableKind(ctx context.Context, arg UpdateVariableKindParams) error {
+	_, err := q.db.Exec(ctx, updateVariableKind, arg.Kind, arg.Value, arg.ID)
+	return err
+}
+
+const updateVariableSharing = `-- name: UpdateVariableSharing :exec
+UPDATE variables
+SET shared_with = $1,
+    updated_at = statement_timestamp()
+WHERE id = $2
+`
+
+type UpdateVariableSharingParams struct {
+	SharedWith []string `json:"shared_with"`
+	ID         int64    `json:"id"`
+}
+
+func (q *Queries) UpdateVariableSharing(ctx context.Context, arg UpdateVariableSharingParams) error {
+	_, err := q.db.Exec(ctx, updateVariableSharing, arg.SharedWith, arg.ID)
+	return err
+}
+
 const updateVariableValue = `-- name: UpdateVariableValue :exec
 UPDATE variables
 SET value = $1,
@@ -130,20 +350,29 @@ func (q *Queries) UpdateVariableValue(ctx context.Context, arg UpdateVariableVal
 }
 
 const upsertVariable = `-- name: UpsertVariable :exec
-INSERT INTO variables (channel_id, name, value)
-VALUES ($1, $2, $3)
-ON CONFLICT (channel_id, name) DO UPDATE
-SET value = excluded.value,
+INSERT INTO variables (channel_id, user_id, name, kind, value)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (channel_id, user_id, name) DO UPDATE
+SET kind = excluded.kind,
+    value = excluded.value,
     updated_at = statement_timestamp()
 `
 
 type UpsertVariableParams struct {
 	ChannelID int64  `json:"channel_id"`
+	UserID    int64  `json:"user_id"`
 	Name      string `json:"name"`
+	Kind      string `json:"kind"`
 	Value     string `json:"value"`
 }
 
 func (q *Queries) UpsertVariable(ctx context.Context, arg UpsertVariableParams) error {
-	_, err := q.db.Exec(ctx, upsertVariable, arg.ChannelID, arg.Name, arg.Value)
+	_, err := q.db.Exec(ctx, upsertVariable,
+		arg.ChannelID,
+		arg.UserID,
+		arg.Name,
+		arg.Kind,
+		arg.Value,
+	)
 	return err
 }
@@ -43,6 +43,27 @@ func allTables() []string {
 		"chat_message_queue",
 		"eventsub_sync_requests",
 		"web_auth_states",
+		"queue_entries",
+		"raffles",
+		"raffle_entries",
+		"raffle_winners",
+		"command_aliases",
+		"api_hosts",
+		"bot_api_cache",
+		"chatters",
+		"channel_backups",
+		"command_libraries",
+		"command_library_installs",
+		"filter_shadow_hits",
+		"channel_filters",
+		"channel_emote_sets",
+		"channel_emote_usage",
+		"blocked_domains",
+		"ban_lists",
+		"ban_list_entries",
+		"ban_list_subscriptions",
+		"ban_list_bans",
+		"chat_mode_rules",
 	}
 }
 
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE channels ADD COLUMN timezone text DEFAULT '' NOT NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE twitch_tokens DROP COLUMN token_key_id;
+
+COMMIT;
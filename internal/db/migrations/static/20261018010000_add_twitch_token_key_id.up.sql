@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE twitch_tokens ADD COLUMN token_key_id text DEFAULT '' NOT NULL;
+
+COMMIT;
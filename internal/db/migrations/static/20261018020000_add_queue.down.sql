@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE queue_entries;
+
+ALTER TABLE channels
+    DROP COLUMN queue_open,
+    DROP COLUMN queue_sub_priority,
+    DROP COLUMN queue_vip_priority;
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+
+ALTER TABLE channels
+    ADD COLUMN queue_open boolean DEFAULT false NOT NULL,
+    ADD COLUMN queue_sub_priority boolean DEFAULT false NOT NULL,
+    ADD COLUMN queue_vip_priority boolean DEFAULT false NOT NULL;
+
+CREATE TABLE queue_entries (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT NOW() NOT NULL,
+    updated_at timestamptz DEFAULT NOW() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+
+    user_id bigint NOT NULL,
+    user_name text NOT NULL,
+    user_display text NOT NULL,
+    game_name text NOT NULL,
+    priority int NOT NULL,
+
+    UNIQUE (channel_id, user_id)
+);
+
+CREATE INDEX queue_entries_channel_id_priority_id_idx ON queue_entries (channel_id, priority DESC, id);
+
+COMMIT;
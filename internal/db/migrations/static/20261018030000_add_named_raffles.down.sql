@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP TABLE raffle_winners;
+DROP TABLE raffle_entries;
+DROP TABLE raffles;
+
+ALTER TABLE channels
+    DROP COLUMN raffle_sub_weight,
+    DROP COLUMN raffle_vip_weight,
+    DROP COLUMN raffle_exclude_winners,
+    DROP COLUMN raffle_claim_seconds;
+
+COMMIT;
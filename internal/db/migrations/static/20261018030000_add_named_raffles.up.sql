@@ -0,0 +1,60 @@
+BEGIN;
+
+ALTER TABLE channels
+    ADD COLUMN raffle_sub_weight int DEFAULT 1 NOT NULL,
+    ADD COLUMN raffle_vip_weight int DEFAULT 1 NOT NULL,
+    ADD COLUMN raffle_exclude_winners boolean DEFAULT false NOT NULL,
+    ADD COLUMN raffle_claim_seconds int DEFAULT 0 NOT NULL;
+
+CREATE TABLE raffles (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT NOW() NOT NULL,
+    updated_at timestamptz DEFAULT NOW() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+
+    name text NOT NULL,
+    keyword text NOT NULL,
+    open boolean NOT NULL,
+    closes_at timestamptz,
+
+    UNIQUE (channel_id, name)
+);
+
+CREATE INDEX raffles_channel_id_keyword_idx ON raffles (channel_id, keyword) WHERE open;
+
+CREATE TABLE raffle_entries (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT NOW() NOT NULL,
+
+    raffle_id bigint REFERENCES raffles (id) ON DELETE CASCADE NOT NULL,
+
+    user_id bigint NOT NULL,
+    user_name text NOT NULL,
+    user_display text NOT NULL,
+    weight int NOT NULL,
+
+    UNIQUE (raffle_id, user_id)
+);
+
+CREATE TABLE raffle_winners (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT NOW() NOT NULL,
+    updated_at timestamptz DEFAULT NOW() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+    raffle_id bigint REFERENCES raffles (id) ON DELETE SET NULL,
+    raffle_name text NOT NULL,
+
+    user_id bigint NOT NULL,
+    user_name text NOT NULL,
+    user_display text NOT NULL,
+
+    status text NOT NULL,
+    claim_deadline timestamptz
+);
+
+CREATE INDEX raffle_winners_channel_id_created_at_idx ON raffle_winners (channel_id, created_at DESC);
+CREATE INDEX raffle_winners_raffle_id_idx ON raffle_winners (raffle_id) WHERE status = 'pending';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE command_aliases;
+
+ALTER TABLE command_infos DROP COLUMN enabled;
+
+COMMIT;
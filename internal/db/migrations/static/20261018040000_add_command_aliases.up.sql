@@ -0,0 +1,20 @@
+BEGIN;
+
+ALTER TABLE command_infos ADD COLUMN enabled boolean DEFAULT true NOT NULL;
+
+CREATE TABLE command_aliases (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT NOW() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+    command_info_id bigint REFERENCES command_infos (id) ON DELETE CASCADE NOT NULL,
+
+    name text NOT NULL,
+    creator text NOT NULL,
+
+    UNIQUE (channel_id, name)
+);
+
+CREATE INDEX command_aliases_command_info_id_idx ON command_aliases (command_info_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE bot_api_cache;
+DROP TABLE api_hosts;
+
+ALTER TABLE channels DROP COLUMN api_cache_seconds;
+
+COMMIT;
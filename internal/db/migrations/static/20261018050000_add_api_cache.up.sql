@@ -0,0 +1,21 @@
+BEGIN;
+
+ALTER TABLE channels ADD COLUMN api_cache_seconds integer DEFAULT 0 NOT NULL;
+
+CREATE TABLE api_hosts (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT NOW() NOT NULL,
+
+    host text NOT NULL UNIQUE
+);
+
+CREATE TABLE bot_api_cache (
+    channel text NOT NULL,
+    cache_key text NOT NULL,
+    body text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (channel, cache_key)
+);
+CREATE INDEX bot_api_cache_expires_at_idx ON bot_api_cache (expires_at);
+
+COMMIT;
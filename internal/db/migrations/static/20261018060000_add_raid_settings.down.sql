@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE channels DROP COLUMN raid_shoutout;
+ALTER TABLE channels DROP COLUMN raid_threshold;
+ALTER TABLE channels DROP COLUMN raid_message;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE channels ADD COLUMN raid_message text DEFAULT '' NOT NULL;
+ALTER TABLE channels ADD COLUMN raid_threshold integer DEFAULT 0 NOT NULL;
+ALTER TABLE channels ADD COLUMN raid_shoutout boolean DEFAULT false NOT NULL;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE command_infos DROP COLUMN reply_mode;
+
+ALTER TABLE channels DROP COLUMN whisper_builtins;
+ALTER TABLE channels DROP COLUMN threaded_replies;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE channels ADD COLUMN threaded_replies boolean DEFAULT false NOT NULL;
+ALTER TABLE channels ADD COLUMN whisper_builtins text[] DEFAULT '{}' NOT NULL;
+
+ALTER TABLE command_infos ADD COLUMN reply_mode text DEFAULT '' NOT NULL;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE chatters;
+
+ALTER TABLE channels DROP COLUMN first_chat_cooldown;
+ALTER TABLE channels DROP COLUMN first_chat_command;
+
+COMMIT;
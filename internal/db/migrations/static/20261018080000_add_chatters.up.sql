@@ -0,0 +1,24 @@
+BEGIN;
+
+ALTER TABLE channels ADD COLUMN first_chat_command text DEFAULT '' NOT NULL;
+ALTER TABLE channels ADD COLUMN first_chat_cooldown integer DEFAULT 0 NOT NULL;
+
+CREATE TABLE chatters (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+
+    user_id bigint NOT NULL,
+    user_name text NOT NULL,
+    user_display text NOT NULL,
+
+    first_seen timestamptz NOT NULL,
+    last_seen timestamptz NOT NULL,
+    message_count bigint DEFAULT 0 NOT NULL,
+
+    UNIQUE (channel_id, user_id)
+);
+
+CREATE INDEX chatters_channel_id_user_name_idx ON chatters (channel_id, user_name);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DELETE FROM variables WHERE user_id != 0;
+
+ALTER TABLE variables DROP CONSTRAINT variables_channel_id_user_id_name_key;
+ALTER TABLE variables ADD CONSTRAINT variables_channel_id_name_key UNIQUE (channel_id, name);
+
+ALTER TABLE variables DROP COLUMN shared_with;
+ALTER TABLE variables DROP COLUMN expires_at;
+ALTER TABLE variables DROP COLUMN kind;
+ALTER TABLE variables DROP COLUMN user_id;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+ALTER TABLE variables ADD COLUMN user_id bigint DEFAULT 0 NOT NULL;
+ALTER TABLE variables ADD COLUMN kind text DEFAULT 'text' NOT NULL;
+ALTER TABLE variables ADD COLUMN expires_at timestamptz;
+ALTER TABLE variables ADD COLUMN shared_with text[] DEFAULT '{}' NOT NULL;
+
+-- Every variable used to be readable from any channel; keep existing ones that way.
+UPDATE variables SET shared_with = '{*}';
+
+ALTER TABLE variables DROP CONSTRAINT variables_channel_id_name_key;
+ALTER TABLE variables ADD CONSTRAINT variables_channel_id_user_id_name_key UNIQUE (channel_id, user_id, name);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE channel_backups;
+
+COMMIT;
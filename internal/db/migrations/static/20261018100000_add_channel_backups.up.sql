@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE channel_backups (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+
+    creator text NOT NULL,
+    kind text NOT NULL,
+    version integer NOT NULL,
+    data jsonb NOT NULL
+);
+
+CREATE INDEX channel_backups_channel_id_created_at_idx ON channel_backups (channel_id, created_at);
+CREATE INDEX channel_backups_created_at_idx ON channel_backups (created_at);
+
+COMMIT;
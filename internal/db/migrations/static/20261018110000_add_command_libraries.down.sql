@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE command_library_installs;
+DROP TABLE command_libraries;
+
+COMMIT;
@@ -0,0 +1,41 @@
+BEGIN;
+
+CREATE TABLE command_libraries (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+
+    name text NOT NULL UNIQUE,
+    description text DEFAULT '' NOT NULL,
+    version integer NOT NULL,
+    parameters text[] DEFAULT '{}' NOT NULL,
+    creator text NOT NULL,
+    editor text NOT NULL,
+    data jsonb NOT NULL
+);
+
+CREATE INDEX command_libraries_channel_id_idx ON command_libraries (channel_id);
+
+CREATE TABLE command_library_installs (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+    library_id bigint REFERENCES command_libraries (id) ON DELETE CASCADE NOT NULL,
+
+    version integer NOT NULL,
+    arguments jsonb NOT NULL,
+    command_info_ids bigint[] DEFAULT '{}' NOT NULL,
+    autoreply_ids bigint[] DEFAULT '{}' NOT NULL,
+    creator text NOT NULL,
+    editor text NOT NULL,
+
+    UNIQUE (channel_id, library_id)
+);
+
+CREATE INDEX command_library_installs_library_id_idx ON command_library_installs (library_id);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE command_infos DROP COLUMN hidden;
+ALTER TABLE command_infos DROP COLUMN category;
+ALTER TABLE command_infos DROP COLUMN usage;
+ALTER TABLE command_infos DROP COLUMN description;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE command_infos ADD COLUMN description text DEFAULT '' NOT NULL;
+ALTER TABLE command_infos ADD COLUMN usage text DEFAULT '' NOT NULL;
+ALTER TABLE command_infos ADD COLUMN category text DEFAULT '' NOT NULL;
+ALTER TABLE command_infos ADD COLUMN hidden boolean DEFAULT false NOT NULL;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE autoreplies DROP COLUMN games;
+ALTER TABLE autoreplies DROP COLUMN live_only;
+ALTER TABLE autoreplies DROP COLUMN user_cooldown;
+ALTER TABLE autoreplies DROP COLUMN cooldown;
+ALTER TABLE autoreplies DROP COLUMN max_access_level;
+ALTER TABLE autoreplies DROP COLUMN access_level;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE autoreplies ADD COLUMN access_level access_level DEFAULT 'everyone' NOT NULL;
+ALTER TABLE autoreplies ADD COLUMN max_access_level access_level DEFAULT 'admin' NOT NULL;
+ALTER TABLE autoreplies ADD COLUMN cooldown integer DEFAULT 30 NOT NULL;
+ALTER TABLE autoreplies ADD COLUMN user_cooldown integer DEFAULT 0 NOT NULL;
+ALTER TABLE autoreplies ADD COLUMN live_only boolean DEFAULT false NOT NULL;
+ALTER TABLE autoreplies ADD COLUMN games text[] DEFAULT '{}' NOT NULL;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TRIGGER channels_notify_matchers ON channels;
+DROP TRIGGER autoreplies_notify_matchers ON autoreplies;
+DROP FUNCTION notify_banned_phrase_matchers();
+DROP FUNCTION notify_autoreply_matchers();
+
+COMMIT;
@@ -0,0 +1,34 @@
+BEGIN;
+
+CREATE FUNCTION notify_autoreply_matchers() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('hortbot_channel_matchers', OLD.channel_id::text);
+    ELSE
+        PERFORM pg_notify('hortbot_channel_matchers', NEW.channel_id::text);
+    END IF;
+    RETURN NULL;
+END;
+$$;
+
+CREATE FUNCTION notify_banned_phrase_matchers() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    PERFORM pg_notify('hortbot_channel_matchers', NEW.id::text);
+    RETURN NULL;
+END;
+$$;
+
+-- Usage counts change on every match, and are not part of the matcher.
+CREATE TRIGGER autoreplies_notify_matchers
+    AFTER INSERT OR DELETE OR UPDATE OF num, trigger, response, access_level, max_access_level, cooldown, user_cooldown, live_only, games
+    ON autoreplies
+    FOR EACH ROW EXECUTE FUNCTION notify_autoreply_matchers();
+
+CREATE TRIGGER channels_notify_matchers
+    AFTER UPDATE OF filter_banned_phrases_patterns
+    ON channels
+    FOR EACH ROW
+    WHEN (OLD.filter_banned_phrases_patterns IS DISTINCT FROM NEW.filter_banned_phrases_patterns)
+    EXECUTE FUNCTION notify_banned_phrase_matchers();
+
+COMMIT;
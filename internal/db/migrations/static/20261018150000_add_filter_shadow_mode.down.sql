@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE filter_shadow_hits;
+
+ALTER TABLE channels DROP COLUMN filter_shadow;
+
+COMMIT;
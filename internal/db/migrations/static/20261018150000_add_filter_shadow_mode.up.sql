@@ -0,0 +1,21 @@
+BEGIN;
+
+ALTER TABLE channels ADD COLUMN filter_shadow text[] DEFAULT '{}' NOT NULL;
+
+CREATE TABLE filter_shadow_hits (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+
+    filter text NOT NULL,
+    user_id bigint NOT NULL,
+    user_name text NOT NULL,
+    message text NOT NULL,
+    punishment text NOT NULL
+);
+
+CREATE INDEX filter_shadow_hits_channel_id_created_at_idx ON filter_shadow_hits (channel_id, created_at);
+CREATE INDEX filter_shadow_hits_created_at_idx ON filter_shadow_hits (created_at);
+
+COMMIT;
@@ -0,0 +1,43 @@
+BEGIN;
+
+CREATE TABLE channel_filters (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+    filter text NOT NULL,
+
+    exempt_level access_level NOT NULL,
+    action text NOT NULL,
+    duration integer NOT NULL,
+    message text NOT NULL,
+
+    UNIQUE (channel_id, filter)
+);
+
+-- Existing channels get a row for each filter from their channel-wide
+-- settings; links were exempt for mods, or subs if they were allowed to link.
+INSERT INTO channel_filters (channel_id, filter, exempt_level, action, duration, message)
+SELECT
+    c.id,
+    f.filter,
+    CASE
+        WHEN f.filter <> 'links' THEN c.filter_exempt_level
+        WHEN c.subs_may_link THEN 'subscriber'::access_level
+        ELSE 'moderator'::access_level
+    END,
+    'timeout',
+    c.timeout_duration,
+    ''
+FROM channels c
+CROSS JOIN (VALUES ('me'), ('max_length'), ('links'), ('emotes'), ('caps'), ('symbols'), ('banned_phrase')) AS f (filter);
+
+-- Filter settings are cached alongside the matchers; the function only needs
+-- the row's channel_id.
+CREATE TRIGGER channel_filters_notify_matchers
+    AFTER INSERT OR DELETE OR UPDATE
+    ON channel_filters
+    FOR EACH ROW EXECUTE FUNCTION notify_autoreply_matchers();
+
+COMMIT;
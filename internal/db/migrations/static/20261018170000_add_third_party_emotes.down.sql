@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE channel_emote_usage;
+DROP TABLE channel_emote_sets;
+
+ALTER TABLE channels DROP COLUMN track_emotes;
+
+COMMIT;
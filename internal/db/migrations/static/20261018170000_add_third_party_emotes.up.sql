@@ -0,0 +1,42 @@
+BEGIN;
+
+ALTER TABLE channels ADD COLUMN track_emotes boolean DEFAULT false NOT NULL;
+
+-- Each row holds the names of a provider's emotes usable in the channel,
+-- including the provider's global emotes.
+CREATE TABLE channel_emote_sets (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+    provider text NOT NULL,
+
+    emotes text[] NOT NULL,
+
+    UNIQUE (channel_id, provider)
+);
+
+-- Emote sets are cached alongside the matchers; the function only needs the
+-- row's channel_id.
+CREATE TRIGGER channel_emote_sets_notify_matchers
+    AFTER INSERT OR DELETE OR UPDATE
+    ON channel_emote_sets
+    FOR EACH ROW EXECUTE FUNCTION notify_autoreply_matchers();
+
+CREATE TABLE channel_emote_usage (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+    emote text NOT NULL,
+
+    uses bigint NOT NULL,
+
+    UNIQUE (channel_id, emote)
+);
+
+CREATE INDEX channel_emote_usage_channel_id_uses_idx ON channel_emote_usage (channel_id, uses DESC);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE blocked_domains;
+
+ALTER TABLE channels DROP COLUMN filter_links_first_message;
+ALTER TABLE channels DROP COLUMN filter_links_resolve;
+ALTER TABLE channels DROP COLUMN blocked_links;
+
+COMMIT;
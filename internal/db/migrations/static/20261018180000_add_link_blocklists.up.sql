@@ -0,0 +1,17 @@
+BEGIN;
+
+ALTER TABLE channels ADD COLUMN blocked_links text[] DEFAULT '{}' NOT NULL;
+ALTER TABLE channels ADD COLUMN filter_links_resolve boolean DEFAULT false NOT NULL;
+ALTER TABLE channels ADD COLUMN filter_links_first_message boolean DEFAULT false NOT NULL;
+
+-- Domains whose links are removed in every channel, regardless of the
+-- channel's link filter settings.
+CREATE TABLE blocked_domains (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+
+    domain text NOT NULL UNIQUE,
+    reason text DEFAULT '' NOT NULL
+);
+
+COMMIT;
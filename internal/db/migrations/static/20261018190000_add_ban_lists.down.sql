@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE ban_list_bans;
+DROP TABLE ban_list_subscriptions;
+DROP TABLE ban_list_entries;
+DROP TABLE ban_lists;
+
+COMMIT;
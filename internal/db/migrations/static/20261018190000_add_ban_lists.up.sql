@@ -0,0 +1,67 @@
+BEGIN;
+
+-- Ban lists are maintained by admins, and optionally by the moderators of a
+-- trusted channel.
+CREATE TABLE ban_lists (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    name text NOT NULL UNIQUE,
+    maintainer_channel_id bigint REFERENCES channels (id)
+);
+
+CREATE TABLE ban_list_entries (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    ban_list_id bigint REFERENCES ban_lists (id) ON DELETE CASCADE NOT NULL,
+    user_id bigint NOT NULL,
+
+    user_name text NOT NULL,
+    reason text NOT NULL,
+    added_by text NOT NULL,
+
+    UNIQUE (ban_list_id, user_id)
+);
+
+CREATE TABLE ban_list_subscriptions (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+    ban_list_id bigint REFERENCES ban_lists (id) ON DELETE CASCADE NOT NULL,
+
+    review boolean DEFAULT false NOT NULL,
+
+    UNIQUE (channel_id, ban_list_id)
+);
+
+CREATE INDEX ban_list_subscriptions_ban_list_id_idx ON ban_list_subscriptions (ban_list_id);
+
+-- The audit trail of ban list entries applied to (or awaiting review in)
+-- each subscribed channel. The list's name is kept so that the trail outlives
+-- the list.
+CREATE TABLE ban_list_bans (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+    ban_list_id bigint REFERENCES ban_lists (id) ON DELETE SET NULL,
+    list_name text NOT NULL,
+    user_id bigint NOT NULL,
+
+    user_name text NOT NULL,
+    reason text NOT NULL,
+    status text NOT NULL,
+    reviewed_by text DEFAULT '' NOT NULL,
+
+    UNIQUE (channel_id, ban_list_id, user_id)
+);
+
+CREATE INDEX ban_list_bans_channel_id_updated_at_idx ON ban_list_bans (channel_id, updated_at);
+
+COMMIT;
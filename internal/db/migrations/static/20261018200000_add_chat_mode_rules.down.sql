@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE chat_mode_rules;
+
+COMMIT;
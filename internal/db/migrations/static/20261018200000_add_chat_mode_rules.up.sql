@@ -0,0 +1,30 @@
+BEGIN;
+
+-- Chat mode rules change a channel's chat settings when something happens in
+-- the channel or on a schedule, optionally reverting them after a delay.
+CREATE TABLE chat_mode_rules (
+    id bigint GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+
+    channel_id bigint REFERENCES channels (id) NOT NULL,
+
+    trigger text NOT NULL,
+    threshold bigint DEFAULT 0 NOT NULL,
+    schedule text DEFAULT '' NOT NULL,
+    mode text NOT NULL,
+    mode_seconds bigint DEFAULT 0 NOT NULL,
+    revert_seconds bigint DEFAULT 0 NOT NULL,
+    revert_at timestamptz,
+    -- The mode's setting from before the rule applied it, restored on revert.
+    prior_enabled boolean DEFAULT false NOT NULL,
+    prior_seconds bigint DEFAULT 0 NOT NULL,
+
+    UNIQUE (channel_id, trigger, mode),
+    CHECK (trigger IN ('raid', 'offline', 'rate', 'schedule')),
+    CHECK (mode IN ('emoteonly', 'followers', 'slow', 'subscribers', 'uniquechat'))
+);
+
+CREATE INDEX chat_mode_rules_revert_at_idx ON chat_mode_rules (revert_at) WHERE revert_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TRIGGER raffles_notify_matchers ON raffles;
+
+COMMIT;
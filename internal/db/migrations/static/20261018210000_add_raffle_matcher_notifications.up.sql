@@ -0,0 +1,10 @@
+BEGIN;
+
+-- Open raffle keywords are cached alongside the matchers; the function only
+-- needs the row's channel_id.
+CREATE TRIGGER raffles_notify_matchers
+    AFTER INSERT OR DELETE OR UPDATE OF keyword, open
+    ON raffles
+    FOR EACH ROW EXECUTE FUNCTION notify_autoreply_matchers();
+
+COMMIT;
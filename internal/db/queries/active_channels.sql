@@ -8,6 +8,28 @@ LEFT JOIN moderated_channels m
 WHERE c.active
   AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL);
 
+-- name: ListActiveChannelIDsWithScope :many
+SELECT c.twitch_id
+FROM channels c
+JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
+LEFT JOIN moderated_channels m
+    ON m.broadcaster_id = c.twitch_id
+   AND m.bot_name = c.bot_name
+WHERE c.active
+  AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL)
+  AND sqlc.arg(scope)::text = ANY(tt.scopes);
+
+-- name: ListActiveRaidChannelIDs :many
+SELECT c.twitch_id
+FROM channels c
+LEFT JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
+LEFT JOIN moderated_channels m
+    ON m.broadcaster_id = c.twitch_id
+   AND m.bot_name = c.bot_name
+WHERE c.active
+  AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL)
+  AND (c.raid_message <> '' OR c.raid_shoutout);
+
 -- name: ListPublicActiveChannels :many
 SELECT c.name, c.display_name
 FROM channels c
@@ -29,3 +51,17 @@ LEFT JOIN moderated_channels m
    AND m.bot_name = c.bot_name
 WHERE c.active
   AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL);
+
+-- name: ListActiveChannelIDsWithChatModeTrigger :many
+SELECT c.twitch_id
+FROM channels c
+LEFT JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
+LEFT JOIN moderated_channels m
+    ON m.broadcaster_id = c.twitch_id
+   AND m.bot_name = c.bot_name
+WHERE c.active
+  AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL)
+  AND EXISTS (
+      SELECT 1 FROM chat_mode_rules r
+      WHERE r.channel_id = c.id AND r.trigger = sqlc.arg(trigger)
+  );
@@ -25,7 +25,8 @@ INSERT INTO twitch_tokens (
     token_type,
     refresh_token,
     expiry,
-    scopes
+    scopes,
+    token_key_id
 )
 VALUES (
     sqlc.arg(twitch_id),
@@ -34,7 +35,8 @@ VALUES (
     sqlc.arg(token_type),
     sqlc.arg(refresh_token),
     sqlc.arg(expiry),
-    sqlc.arg(scopes)::text[]
+    sqlc.arg(scopes)::text[],
+    sqlc.arg(token_key_id)
 )
 ON CONFLICT (twitch_id) DO UPDATE
 SET bot_name = excluded.bot_name,
@@ -43,6 +45,7 @@ SET bot_name = excluded.bot_name,
     refresh_token = excluded.refresh_token,
     expiry = excluded.expiry,
     scopes = excluded.scopes,
+    token_key_id = excluded.token_key_id,
     updated_at = statement_timestamp()
 RETURNING *;
 
@@ -52,23 +55,36 @@ INSERT INTO twitch_tokens (
     access_token,
     token_type,
     refresh_token,
-    expiry
+    expiry,
+    token_key_id
 )
 VALUES (
     sqlc.arg(twitch_id),
     sqlc.arg(access_token),
     sqlc.arg(token_type),
     sqlc.arg(refresh_token),
-    sqlc.arg(expiry)
+    sqlc.arg(expiry),
+    sqlc.arg(token_key_id)
 )
 ON CONFLICT (twitch_id) DO UPDATE
 SET access_token = excluded.access_token,
     token_type = excluded.token_type,
     refresh_token = excluded.refresh_token,
     expiry = excluded.expiry,
+    token_key_id = excluded.token_key_id,
     updated_at = statement_timestamp()
 RETURNING *;
 
+-- name: ListTwitchTokensNotOnKeyForUpdate :many
+SELECT * FROM twitch_tokens WHERE token_key_id != sqlc.arg(token_key_id) ORDER BY twitch_id FOR UPDATE;
+
+-- name: UpdateTwitchTokenSecrets :exec
+UPDATE twitch_tokens
+SET access_token = sqlc.arg(access_token),
+    refresh_token = sqlc.arg(refresh_token),
+    token_key_id = sqlc.arg(token_key_id)
+WHERE id = sqlc.arg(id);
+
 -- name: DeleteTwitchTokenByID :exec
 DELETE FROM twitch_tokens WHERE twitch_id = sqlc.arg(twitch_id);
 
@@ -85,6 +101,28 @@ ON CONFLICT (twitch_id) DO NOTHING;
 -- name: DeleteBlockedUser :exec
 DELETE FROM blocked_users WHERE twitch_id = sqlc.arg(twitch_id);
 
+-- name: ListAPIHosts :many
+SELECT host FROM api_hosts ORDER BY host;
+
+-- name: UpsertAPIHost :exec
+INSERT INTO api_hosts (host)
+VALUES (sqlc.arg(host))
+ON CONFLICT (host) DO NOTHING;
+
+-- name: DeleteAPIHost :execrows
+DELETE FROM api_hosts WHERE host = sqlc.arg(host);
+
+-- name: ListBlockedDomains :many
+SELECT * FROM blocked_domains ORDER BY domain;
+
+-- name: UpsertBlockedDomain :exec
+INSERT INTO blocked_domains (domain, reason)
+VALUES (sqlc.arg(domain), sqlc.arg(reason))
+ON CONFLICT (domain) DO UPDATE SET reason = EXCLUDED.reason;
+
+-- name: DeleteBlockedDomain :execrows
+DELETE FROM blocked_domains WHERE domain = sqlc.arg(domain);
+
 -- name: IsModeratedChannel :one
 SELECT EXISTS (
     SELECT 1
@@ -35,6 +35,11 @@ FOR UPDATE;
 -- name: DeleteAutoreply :exec
 DELETE FROM autoreplies WHERE id = sqlc.arg(id);
 
+-- name: DeleteAutorepliesByIDs :execrows
+DELETE FROM autoreplies
+WHERE channel_id = sqlc.arg(channel_id)
+  AND id = ANY(sqlc.arg(ids)::bigint[]);
+
 -- name: UpdateAutoreplyResponse :exec
 UPDATE autoreplies
 SET response = sqlc.arg(response),
@@ -42,6 +47,18 @@ SET response = sqlc.arg(response),
     updated_at = statement_timestamp()
 WHERE id = sqlc.arg(id);
 
+-- name: UpdateAutoreplyRestrictions :exec
+UPDATE autoreplies
+SET access_level = sqlc.arg(access_level),
+    max_access_level = sqlc.arg(max_access_level),
+    cooldown = sqlc.arg(cooldown),
+    user_cooldown = sqlc.arg(user_cooldown),
+    live_only = sqlc.arg(live_only),
+    games = sqlc.arg(games),
+    editor = sqlc.arg(editor),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
 -- name: UpdateAutoreplyPattern :exec
 UPDATE autoreplies
 SET trigger = sqlc.arg(trigger),
@@ -57,13 +74,13 @@ WHERE channel_id = sqlc.arg(channel_id)
 ORDER BY num;
 
 -- name: ListAutoreplyMatchers :many
-SELECT id, trigger, response, count
+SELECT id, num, trigger, response, count, access_level, max_access_level, cooldown, user_cooldown, live_only, games
 FROM autoreplies
 WHERE channel_id = sqlc.arg(channel_id)
 ORDER BY num;
 
 -- name: UpdateAutoreplyCount :exec
-UPDATE autoreplies SET count = sqlc.arg(count) WHERE id = sqlc.arg(id);
+UPDATE autoreplies SET count = count + 1 WHERE id = sqlc.arg(id);
 
 -- name: CompactAutoreplies :execrows
 UPDATE autoreplies a
@@ -0,0 +1,62 @@
+-- name: InsertChannelBackup :one
+INSERT INTO channel_backups (channel_id, creator, kind, version, data)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(creator),
+    sqlc.arg(kind),
+    sqlc.arg(version),
+    sqlc.arg(data)
+)
+RETURNING id;
+
+-- name: ListChannelBackups :many
+SELECT id, created_at, creator, kind, version, octet_length(data::text)::bigint AS size
+FROM channel_backups
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetChannelBackup :one
+SELECT * FROM channel_backups
+WHERE channel_id = sqlc.arg(channel_id) AND id = sqlc.arg(id);
+
+-- name: ListChannelsWithoutRecentBackup :many
+SELECT c.id
+FROM channels c
+WHERE c.active
+  AND NOT EXISTS (
+      SELECT 1
+      FROM channel_backups b
+      WHERE b.channel_id = c.id
+        AND b.kind = sqlc.arg(kind)
+        AND b.created_at > sqlc.arg(since)
+  )
+ORDER BY c.id;
+
+-- name: ListChannelNames :many
+SELECT name
+FROM channels
+WHERE active OR NOT sqlc.arg(active_only)::boolean
+ORDER BY name;
+
+-- name: DeleteChannelBackupsBefore :execrows
+DELETE FROM channel_backups WHERE kind = sqlc.arg(kind) AND created_at < sqlc.arg(before);
+
+-- name: DeleteChannelBackup :execrows
+DELETE FROM channel_backups
+WHERE channel_id = sqlc.arg(channel_id) AND id = sqlc.arg(id);
+
+-- name: DeleteExcessChannelBackups :execrows
+DELETE FROM channel_backups
+WHERE channel_id = sqlc.arg(channel_id)
+  AND kind <> 'automatic'
+  AND id NOT IN (
+      SELECT id
+      FROM channel_backups
+      WHERE channel_id = sqlc.arg(channel_id)
+        AND kind <> 'automatic'
+      ORDER BY created_at DESC, id DESC
+      LIMIT sqlc.arg(keep)::bigint
+  );
+
+-- name: DeleteChannelBackupsByChannel :exec
+DELETE FROM channel_backups WHERE channel_id = sqlc.arg(channel_id);
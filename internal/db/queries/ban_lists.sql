@@ -0,0 +1,134 @@
+-- name: GetBanListByName :one
+SELECT * FROM ban_lists WHERE name = sqlc.arg(name);
+
+-- name: InsertBanList :exec
+INSERT INTO ban_lists (name, maintainer_channel_id)
+VALUES (sqlc.arg(name), sqlc.narg(maintainer_channel_id));
+
+-- name: UpdateBanListMaintainer :exec
+UPDATE ban_lists
+SET maintainer_channel_id = sqlc.narg(maintainer_channel_id),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteBanList :exec
+DELETE FROM ban_lists WHERE id = sqlc.arg(id);
+
+-- name: ClearBanListMaintainerByChannel :exec
+UPDATE ban_lists
+SET maintainer_channel_id = NULL,
+    updated_at = statement_timestamp()
+WHERE maintainer_channel_id = sqlc.arg(channel_id)::bigint;
+
+-- name: ListBanLists :many
+SELECT l.id, l.name, c.name AS maintainer_name,
+    (SELECT count(*) FROM ban_list_entries e WHERE e.ban_list_id = l.id)::bigint AS entries
+FROM ban_lists l
+LEFT JOIN channels c ON c.id = l.maintainer_channel_id
+ORDER BY l.name;
+
+-- name: UpsertBanListEntry :exec
+INSERT INTO ban_list_entries (ban_list_id, user_id, user_name, reason, added_by)
+VALUES (
+    sqlc.arg(ban_list_id),
+    sqlc.arg(user_id),
+    sqlc.arg(user_name),
+    sqlc.arg(reason),
+    sqlc.arg(added_by)
+)
+ON CONFLICT (ban_list_id, user_id) DO UPDATE
+SET user_name = EXCLUDED.user_name,
+    reason = EXCLUDED.reason,
+    added_by = EXCLUDED.added_by,
+    updated_at = statement_timestamp();
+
+-- name: DeleteBanListEntry :execrows
+DELETE FROM ban_list_entries
+WHERE ban_list_id = sqlc.arg(ban_list_id) AND user_name = sqlc.arg(user_name);
+
+-- name: UpsertBanListSubscription :exec
+INSERT INTO ban_list_subscriptions (channel_id, ban_list_id, review)
+VALUES (sqlc.arg(channel_id), sqlc.arg(ban_list_id), sqlc.arg(review))
+ON CONFLICT (channel_id, ban_list_id) DO UPDATE
+SET review = EXCLUDED.review,
+    updated_at = statement_timestamp();
+
+-- name: DeleteBanListSubscription :execrows
+DELETE FROM ban_list_subscriptions
+WHERE channel_id = sqlc.arg(channel_id) AND ban_list_id = sqlc.arg(ban_list_id);
+
+-- name: ListChannelBanListSubscriptions :many
+SELECT s.ban_list_id, s.review
+FROM ban_list_subscriptions s
+WHERE s.channel_id = sqlc.arg(channel_id);
+
+-- name: ListBanListSubscribers :many
+SELECT s.channel_id, s.review
+FROM ban_list_subscriptions s
+WHERE s.ban_list_id = sqlc.arg(ban_list_id)
+ORDER BY s.channel_id;
+
+-- name: DeleteBanListSubscriptionsByChannel :exec
+DELETE FROM ban_list_subscriptions WHERE channel_id = sqlc.arg(channel_id);
+
+-- name: UpsertBanListBan :exec
+INSERT INTO ban_list_bans (channel_id, ban_list_id, list_name, user_id, user_name, reason, status)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(ban_list_id)::bigint,
+    sqlc.arg(list_name),
+    sqlc.arg(user_id),
+    sqlc.arg(user_name),
+    sqlc.arg(reason),
+    sqlc.arg(status)
+)
+ON CONFLICT (channel_id, ban_list_id, user_id) DO UPDATE
+SET list_name = EXCLUDED.list_name,
+    user_name = EXCLUDED.user_name,
+    reason = EXCLUDED.reason,
+    status = EXCLUDED.status,
+    reviewed_by = '',
+    updated_at = statement_timestamp();
+
+-- name: UpdateBanListBanStatus :exec
+UPDATE ban_list_bans
+SET status = sqlc.arg(status),
+    reviewed_by = sqlc.arg(reviewed_by),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: RejectUnappliedBanListBans :exec
+UPDATE ban_list_bans
+SET status = 'rejected',
+    reviewed_by = sqlc.arg(reviewed_by),
+    updated_at = statement_timestamp()
+WHERE ban_list_id = sqlc.arg(ban_list_id) AND status IN ('queued', 'pending');
+
+-- name: ListPendingBanListBans :many
+SELECT b.id, b.user_id, b.user_name, b.reason, b.list_name
+FROM ban_list_bans b
+WHERE b.channel_id = sqlc.arg(channel_id) AND b.status = 'pending'
+ORDER BY b.id;
+
+-- name: ListQueuedBanListBanChannels :many
+SELECT DISTINCT channel_id
+FROM ban_list_bans
+WHERE status = 'queued'
+ORDER BY channel_id;
+
+-- name: ListQueuedBanListBansForUpdate :many
+SELECT b.id, b.user_id, b.reason, b.list_name
+FROM ban_list_bans b
+WHERE b.channel_id = sqlc.arg(channel_id) AND b.status = 'queued'
+ORDER BY b.id
+FOR UPDATE;
+
+-- name: ListRecentBanListBans :many
+SELECT b.user_name, b.status, b.reviewed_by, b.list_name
+FROM ban_list_bans b
+WHERE b.channel_id = sqlc.arg(channel_id)
+ORDER BY b.updated_at DESC, b.id DESC
+LIMIT sqlc.arg(max_bans)::bigint;
+
+-- name: DeleteBanListBansByChannel :exec
+DELETE FROM ban_list_bans WHERE channel_id = sqlc.arg(channel_id);
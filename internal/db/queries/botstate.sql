@@ -30,6 +30,14 @@ SET expires_at = excluded.expires_at
 WHERE bot_command_cooldowns.expires_at <= sqlc.arg(now)
 RETURNING true;
 
+-- name: BotStateCommandCooldownActive :one
+SELECT EXISTS(
+  SELECT 1 FROM bot_command_cooldowns
+  WHERE channel = sqlc.arg(channel)
+    AND command_key = sqlc.arg(command_key)
+    AND expires_at > sqlc.arg(now)
+);
+
 -- name: BotStateCheckAndMarkRepeatCooldown :one
 INSERT INTO bot_repeat_cooldowns (channel, repeated_command_id, expires_at)
 VALUES (sqlc.arg(channel), sqlc.arg(repeated_command_id), sqlc.arg(expires_at))
@@ -108,6 +116,20 @@ VALUES (
 ON CONFLICT (channel, user_id, filter_name) DO UPDATE
 SET expires_at = excluded.expires_at;
 
+-- name: BotStateGetAPICache :one
+SELECT body
+FROM bot_api_cache
+WHERE channel = sqlc.arg(channel)
+  AND cache_key = sqlc.arg(cache_key)
+  AND expires_at > sqlc.arg(now);
+
+-- name: BotStateSetAPICache :exec
+INSERT INTO bot_api_cache (channel, cache_key, body, expires_at)
+VALUES (sqlc.arg(channel), sqlc.arg(cache_key), sqlc.arg(body), sqlc.arg(expires_at))
+ON CONFLICT (channel, cache_key) DO UPDATE
+SET body = excluded.body,
+    expires_at = excluded.expires_at;
+
 -- name: BotStateCleanupCommandCooldowns :exec
 DELETE FROM bot_command_cooldowns WHERE expires_at < now();
 
@@ -129,9 +151,15 @@ DELETE FROM bot_confirmations WHERE expires_at < now();
 -- name: BotStateCleanupFilterWarnings :exec
 DELETE FROM bot_filter_warnings WHERE expires_at < now();
 
+-- name: BotStateCleanupFilterShadowHits :exec
+DELETE FROM filter_shadow_hits WHERE created_at < now() - interval '7 days';
+
 -- name: BotStateCleanupAuthStates :exec
 DELETE FROM web_auth_states WHERE expires_at < now();
 
+-- name: BotStateCleanupAPICache :exec
+DELETE FROM bot_api_cache WHERE expires_at < now();
+
 -- name: BotStateDumpCommandCooldowns :many
 SELECT (channel || '/' || command_key)::text AS key, ''::text AS value, expires_at
 FROM bot_command_cooldowns
@@ -176,6 +204,29 @@ SELECT key, encode(value, 'escape') AS value, expires_at
 FROM web_auth_states
 ORDER BY key;
 
+-- name: BotStateDumpAPICache :many
+SELECT (channel || '/' || cache_key)::text AS key, body AS value, expires_at
+FROM bot_api_cache
+ORDER BY channel, cache_key;
+
+-- name: BotStateListChannelCommandCooldowns :many
+SELECT command_key, expires_at
+FROM bot_command_cooldowns
+WHERE channel = sqlc.arg(channel) AND expires_at > sqlc.arg(now)
+ORDER BY command_key;
+
+-- name: BotStateListChannelLinkPermits :many
+SELECT user_id, expires_at
+FROM bot_link_permits
+WHERE channel = sqlc.arg(channel) AND expires_at > sqlc.arg(now)
+ORDER BY user_id;
+
+-- name: BotStateListChannelFilterWarnings :many
+SELECT user_id, filter_name, expires_at
+FROM bot_filter_warnings
+WHERE channel = sqlc.arg(channel) AND expires_at > sqlc.arg(now)
+ORDER BY user_id, filter_name;
+
 -- name: BotStateRaffleAdd :exec
 INSERT INTO bot_raffle_entries (channel, user_id)
 VALUES (sqlc.arg(channel), sqlc.arg(user_id))
@@ -0,0 +1,36 @@
+-- name: ListChannelFilters :many
+SELECT * FROM channel_filters
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY filter;
+
+-- name: UpsertChannelFilter :exec
+INSERT INTO channel_filters (channel_id, filter, exempt_level, action, duration, message)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(filter),
+    sqlc.arg(exempt_level),
+    sqlc.arg(action),
+    sqlc.arg(duration),
+    sqlc.arg(message)
+)
+ON CONFLICT (channel_id, filter) DO UPDATE
+SET exempt_level = excluded.exempt_level,
+    action = excluded.action,
+    duration = excluded.duration,
+    message = excluded.message,
+    updated_at = statement_timestamp();
+
+-- name: UpdateChannelFiltersExemptLevel :exec
+UPDATE channel_filters
+SET exempt_level = sqlc.arg(exempt_level),
+    updated_at = statement_timestamp()
+WHERE channel_id = sqlc.arg(channel_id) AND filter = ANY(sqlc.arg(filters)::text[]);
+
+-- name: UpdateChannelFiltersDuration :exec
+UPDATE channel_filters
+SET duration = sqlc.arg(duration),
+    updated_at = statement_timestamp()
+WHERE channel_id = sqlc.arg(channel_id);
+
+-- name: DeleteChannelFiltersByChannel :exec
+DELETE FROM channel_filters WHERE channel_id = sqlc.arg(channel_id);
@@ -13,6 +13,12 @@ SET raffle_enabled = sqlc.arg(raffle_enabled),
     updated_at = statement_timestamp()
 WHERE id = sqlc.arg(id);
 
+-- name: UpdateChannelQueueOpen :exec
+UPDATE channels
+SET queue_open = sqlc.arg(queue_open),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
 -- name: UpdateChannelSettings :exec
 UPDATE channels
 SET prefix = sqlc.arg(prefix),
@@ -51,5 +57,25 @@ SET prefix = sqlc.arg(prefix),
     filter_banned_phrases = sqlc.arg(filter_banned_phrases),
     filter_banned_phrases_patterns = sqlc.arg(filter_banned_phrases_patterns)::text[],
     filter_exempt_level = sqlc.arg(filter_exempt_level),
+    timezone = sqlc.arg(timezone),
+    queue_sub_priority = sqlc.arg(queue_sub_priority),
+    queue_vip_priority = sqlc.arg(queue_vip_priority),
+    raffle_sub_weight = sqlc.arg(raffle_sub_weight),
+    raffle_vip_weight = sqlc.arg(raffle_vip_weight),
+    raffle_exclude_winners = sqlc.arg(raffle_exclude_winners),
+    raffle_claim_seconds = sqlc.arg(raffle_claim_seconds),
+    api_cache_seconds = sqlc.arg(api_cache_seconds),
+    raid_message = sqlc.arg(raid_message),
+    raid_threshold = sqlc.arg(raid_threshold),
+    raid_shoutout = sqlc.arg(raid_shoutout),
+    threaded_replies = sqlc.arg(threaded_replies),
+    whisper_builtins = sqlc.arg(whisper_builtins),
+    first_chat_command = sqlc.arg(first_chat_command),
+    first_chat_cooldown = sqlc.arg(first_chat_cooldown),
+    filter_shadow = sqlc.arg(filter_shadow),
+    track_emotes = sqlc.arg(track_emotes),
+    blocked_links = sqlc.arg(blocked_links),
+    filter_links_resolve = sqlc.arg(filter_links_resolve),
+    filter_links_first_message = sqlc.arg(filter_links_first_message),
     updated_at = statement_timestamp()
 WHERE id = sqlc.arg(id);
@@ -0,0 +1,62 @@
+-- name: ListChatModeRules :many
+SELECT * FROM chat_mode_rules
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY trigger, mode;
+
+-- name: ListChatModeRulesByTrigger :many
+SELECT * FROM chat_mode_rules
+WHERE channel_id = sqlc.arg(channel_id) AND trigger = sqlc.arg(trigger)
+ORDER BY mode;
+
+-- name: GetChatModeRule :one
+SELECT * FROM chat_mode_rules WHERE id = sqlc.arg(id);
+
+-- name: UpsertChatModeRule :one
+INSERT INTO chat_mode_rules (channel_id, trigger, threshold, schedule, mode, mode_seconds, revert_seconds)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(trigger),
+    sqlc.arg(threshold),
+    sqlc.arg(schedule),
+    sqlc.arg(mode),
+    sqlc.arg(mode_seconds),
+    sqlc.arg(revert_seconds)
+)
+ON CONFLICT (channel_id, trigger, mode) DO UPDATE
+SET threshold = EXCLUDED.threshold,
+    schedule = EXCLUDED.schedule,
+    mode_seconds = EXCLUDED.mode_seconds,
+    revert_seconds = EXCLUDED.revert_seconds,
+    updated_at = statement_timestamp()
+RETURNING id;
+
+-- name: DeleteChatModeRule :one
+DELETE FROM chat_mode_rules
+WHERE channel_id = sqlc.arg(channel_id) AND trigger = sqlc.arg(trigger) AND mode = sqlc.arg(mode)
+RETURNING *;
+
+-- name: UpdateChatModeRuleRevertAt :exec
+UPDATE chat_mode_rules
+SET revert_at = sqlc.narg(revert_at),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: SetChatModeRuleRevert :exec
+UPDATE chat_mode_rules
+SET revert_at = sqlc.arg(revert_at),
+    prior_enabled = sqlc.arg(prior_enabled),
+    prior_seconds = sqlc.arg(prior_seconds),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: ListChatModeRevertIDs :many
+SELECT id FROM chat_mode_rules WHERE revert_at IS NOT NULL;
+
+-- name: ListActiveScheduledChatModeRules :many
+SELECT sqlc.embed(r), c.timezone
+FROM chat_mode_rules r
+JOIN channels c ON r.channel_id = c.id
+WHERE r.trigger = 'schedule' AND c.active;
+
+-- name: DeleteChatModeRulesByChannel :exec
+DELETE FROM chat_mode_rules WHERE channel_id = sqlc.arg(channel_id);
@@ -0,0 +1,26 @@
+-- name: UpsertChatter :one
+INSERT INTO chatters (channel_id, user_id, user_name, user_display, first_seen, last_seen, message_count)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(user_id),
+    sqlc.arg(user_name),
+    sqlc.arg(user_display),
+    sqlc.arg(now),
+    sqlc.arg(now),
+    1
+)
+ON CONFLICT (channel_id, user_id) DO UPDATE
+SET user_name = EXCLUDED.user_name,
+    user_display = EXCLUDED.user_display,
+    last_seen = EXCLUDED.last_seen,
+    message_count = chatters.message_count + 1
+RETURNING message_count;
+
+-- name: GetChatterByName :one
+SELECT * FROM chatters
+WHERE channel_id = sqlc.arg(channel_id) AND user_name = sqlc.arg(user_name)
+ORDER BY last_seen DESC
+LIMIT 1;
+
+-- name: DeleteChattersByChannel :exec
+DELETE FROM chatters WHERE channel_id = sqlc.arg(channel_id);
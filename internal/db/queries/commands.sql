@@ -3,14 +3,26 @@ SELECT ci.*, cc.message
 FROM command_infos ci
 LEFT JOIN custom_commands cc ON cc.id = ci.custom_command_id
 WHERE ci.channel_id = sqlc.arg(channel_id)
-  AND ci.name = sqlc.arg(name);
+  AND (
+    ci.name = sqlc.arg(name)
+    OR ci.id = (
+      SELECT ca.command_info_id FROM command_aliases ca
+      WHERE ca.channel_id = sqlc.arg(channel_id) AND ca.name = sqlc.arg(name)
+    )
+  );
 
 -- name: FindCommandForUpdate :one
 SELECT ci.*, cc.message
 FROM command_infos ci
 LEFT JOIN custom_commands cc ON cc.id = ci.custom_command_id
 WHERE ci.channel_id = sqlc.arg(channel_id)
-  AND ci.name = sqlc.arg(name)
+  AND (
+    ci.name = sqlc.arg(name)
+    OR ci.id = (
+      SELECT ca.command_info_id FROM command_aliases ca
+      WHERE ca.channel_id = sqlc.arg(channel_id) AND ca.name = sqlc.arg(name)
+    )
+  )
 FOR UPDATE OF ci;
 
 -- name: GetCommandInfo :one
@@ -36,6 +48,10 @@ SELECT EXISTS(
   SELECT 1 FROM command_infos
   WHERE channel_id = sqlc.arg(channel_id)
     AND name = sqlc.arg(name)
+) OR EXISTS(
+  SELECT 1 FROM command_aliases
+  WHERE channel_id = sqlc.arg(channel_id)
+    AND name = sqlc.arg(name)
 );
 
 -- name: GetCustomCommand :one
@@ -59,14 +75,18 @@ SET message = sqlc.arg(message),
 WHERE id = sqlc.arg(id);
 
 -- name: ListCustomCommandsForWeb :many
-SELECT cc.message, cc.updated_at, ci.name, ci.access_level, ci.count, ci.editor
+SELECT cc.message, cc.updated_at, ci.name, ci.access_level, ci.count, ci.editor, ci.enabled,
+  ci.description, ci.usage, ci.category, ci.hidden,
+  ARRAY(SELECT ca.name FROM command_aliases ca WHERE ca.command_info_id = ci.id ORDER BY ca.name)::text[] AS aliases
 FROM custom_commands cc
 JOIN command_infos ci ON ci.custom_command_id = cc.id
 WHERE cc.channel_id = sqlc.arg(channel_id)
 ORDER BY ci.name;
 
 -- name: ListCommandListsForWeb :many
-SELECT cl.items, cl.updated_at, ci.name, ci.access_level, ci.count, ci.editor
+SELECT cl.items, cl.updated_at, ci.name, ci.access_level, ci.count, ci.editor, ci.enabled,
+  ci.description, ci.usage, ci.category, ci.hidden,
+  ARRAY(SELECT ca.name FROM command_aliases ca WHERE ca.command_info_id = ci.id ORDER BY ca.name)::text[] AS aliases
 FROM command_lists cl
 JOIN command_infos ci ON ci.command_list_id = cl.id
 WHERE cl.channel_id = sqlc.arg(channel_id)
@@ -153,3 +173,47 @@ SET name = sqlc.arg(name),
     editor = sqlc.arg(editor),
     updated_at = statement_timestamp()
 WHERE id = sqlc.arg(id);
+
+-- name: UpdateCommandInfoEnabled :exec
+UPDATE command_infos
+SET enabled = sqlc.arg(enabled),
+    editor = sqlc.arg(editor),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateCommandInfoMetadata :exec
+UPDATE command_infos
+SET description = sqlc.arg(description),
+    usage = sqlc.arg(usage),
+    category = sqlc.arg(category),
+    hidden = sqlc.arg(hidden),
+    editor = sqlc.arg(editor),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateCommandInfoReplyMode :exec
+UPDATE command_infos
+SET reply_mode = sqlc.arg(reply_mode),
+    editor = sqlc.arg(editor),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: InsertCommandAlias :one
+INSERT INTO command_aliases (channel_id, command_info_id, name, creator)
+VALUES (sqlc.arg(channel_id), sqlc.arg(command_info_id), sqlc.arg(name), sqlc.arg(creator))
+RETURNING *;
+
+-- name: DeleteCommandAlias :execrows
+DELETE FROM command_aliases
+WHERE channel_id = sqlc.arg(channel_id)
+  AND name = sqlc.arg(name);
+
+-- name: ListCommandAliases :many
+SELECT * FROM command_aliases
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY name;
+
+-- name: ListCommandAliasNames :many
+SELECT name FROM command_aliases
+WHERE command_info_id = sqlc.arg(command_info_id)
+ORDER BY name;
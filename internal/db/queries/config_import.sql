@@ -48,6 +48,11 @@ INSERT INTO command_infos OVERRIDING USER VALUE
 SELECT (jsonb_populate_record(NULL::command_infos, sqlc.arg(data)::jsonb)).*
 RETURNING id;
 
+-- name: InsertImportedCommandAlias :one
+INSERT INTO command_aliases OVERRIDING USER VALUE
+SELECT (jsonb_populate_record(NULL::command_aliases, sqlc.arg(data)::jsonb)).*
+RETURNING id;
+
 -- name: InsertImportedRepeatedCommand :one
 INSERT INTO repeated_commands OVERRIDING USER VALUE
 SELECT (jsonb_populate_record(NULL::repeated_commands, sqlc.arg(data)::jsonb)).*
@@ -67,3 +72,13 @@ RETURNING id;
 INSERT INTO variables OVERRIDING USER VALUE
 SELECT (jsonb_populate_record(NULL::variables, sqlc.arg(data)::jsonb)).*
 RETURNING id;
+
+-- name: InsertImportedHighlight :one
+INSERT INTO highlights OVERRIDING USER VALUE
+SELECT (jsonb_populate_record(NULL::highlights, sqlc.arg(data)::jsonb)).*
+RETURNING id;
+
+-- name: InsertImportedChannelFilter :one
+INSERT INTO channel_filters OVERRIDING USER VALUE
+SELECT (jsonb_populate_record(NULL::channel_filters, sqlc.arg(data)::jsonb)).*
+RETURNING id;
@@ -91,6 +91,9 @@ DELETE FROM scheduled_commands WHERE channel_id = sqlc.arg(channel_id);
 -- name: DeleteRepeatedCommandsByChannel :exec
 DELETE FROM repeated_commands WHERE channel_id = sqlc.arg(channel_id);
 
+-- name: DeleteCommandAliasesByChannel :exec
+DELETE FROM command_aliases WHERE channel_id = sqlc.arg(channel_id);
+
 -- name: DeleteCommandInfosByChannel :exec
 DELETE FROM command_infos WHERE channel_id = sqlc.arg(channel_id);
 
@@ -0,0 +1,42 @@
+-- name: ListChannelEmoteSets :many
+SELECT * FROM channel_emote_sets
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY provider;
+
+-- name: UpsertChannelEmoteSet :exec
+INSERT INTO channel_emote_sets (channel_id, provider, emotes)
+VALUES (sqlc.arg(channel_id), sqlc.arg(provider), sqlc.arg(emotes))
+ON CONFLICT (channel_id, provider) DO UPDATE
+SET emotes = excluded.emotes,
+    updated_at = statement_timestamp();
+
+-- name: ListChannelsForEmoteRefresh :many
+SELECT c.id, c.twitch_id
+FROM channels c
+WHERE c.active AND (c.filter_emotes OR c.track_emotes)
+    AND NOT EXISTS (
+        SELECT 1 FROM channel_emote_sets s
+        WHERE s.channel_id = c.id AND s.updated_at > sqlc.arg(updated_before)
+    )
+ORDER BY c.id;
+
+-- name: DeleteChannelEmoteSetsByChannel :exec
+DELETE FROM channel_emote_sets WHERE channel_id = sqlc.arg(channel_id);
+
+-- name: AddChannelEmoteUsage :exec
+INSERT INTO channel_emote_usage (channel_id, emote, uses)
+SELECT sqlc.arg(channel_id)::bigint, emote, count(*)
+FROM unnest(sqlc.arg(emotes)::text[]) AS emote
+GROUP BY emote
+ON CONFLICT (channel_id, emote) DO UPDATE
+SET uses = channel_emote_usage.uses + excluded.uses,
+    updated_at = statement_timestamp();
+
+-- name: ListTopChannelEmotes :many
+SELECT emote, uses FROM channel_emote_usage
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY uses DESC, emote
+LIMIT sqlc.arg(max_emotes)::bigint;
+
+-- name: DeleteChannelEmoteUsageByChannel :exec
+DELETE FROM channel_emote_usage WHERE channel_id = sqlc.arg(channel_id);
@@ -0,0 +1,26 @@
+-- name: InsertFilterShadowHit :exec
+INSERT INTO filter_shadow_hits (channel_id, filter, user_id, user_name, message, punishment)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(filter),
+    sqlc.arg(user_id),
+    sqlc.arg(user_name),
+    sqlc.arg(message),
+    sqlc.arg(punishment)
+);
+
+-- name: ListFilterShadowHits :many
+SELECT * FROM filter_shadow_hits
+WHERE channel_id = sqlc.arg(channel_id) AND created_at > now() - sqlc.arg(hours)::integer * interval '1 hour'
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(max_hits)::bigint;
+
+-- name: CountFilterShadowHits :many
+SELECT filter, count(*)::bigint AS hits, count(DISTINCT user_id)::bigint AS users
+FROM filter_shadow_hits
+WHERE channel_id = sqlc.arg(channel_id) AND created_at > now() - sqlc.arg(hours)::integer * interval '1 hour'
+GROUP BY filter
+ORDER BY hits DESC, filter;
+
+-- name: DeleteFilterShadowHitsByChannel :exec
+DELETE FROM filter_shadow_hits WHERE channel_id = sqlc.arg(channel_id);
@@ -0,0 +1,85 @@
+-- name: GetCommandLibraryByName :one
+SELECT * FROM command_libraries WHERE name = sqlc.arg(name);
+
+-- name: InsertCommandLibrary :one
+INSERT INTO command_libraries (channel_id, name, version, parameters, creator, editor, data)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(name),
+    1,
+    sqlc.arg(parameters),
+    sqlc.arg(creator),
+    sqlc.arg(creator),
+    sqlc.arg(data)
+)
+RETURNING id;
+
+-- name: UpdateCommandLibraryData :one
+UPDATE command_libraries
+SET version = version + 1,
+    parameters = sqlc.arg(parameters),
+    data = sqlc.arg(data),
+    editor = sqlc.arg(editor),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id)
+RETURNING version;
+
+-- name: UpdateCommandLibraryDescription :exec
+UPDATE command_libraries
+SET description = sqlc.arg(description),
+    editor = sqlc.arg(editor),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteCommandLibrary :exec
+DELETE FROM command_libraries WHERE id = sqlc.arg(id);
+
+-- name: ListCommandLibraries :many
+SELECT l.id, l.updated_at, l.name, l.description, l.version, l.parameters, c.name AS channel_name,
+    (SELECT count(*) FROM command_library_installs i WHERE i.library_id = l.id)::bigint AS installs
+FROM command_libraries l
+JOIN channels c ON c.id = l.channel_id
+ORDER BY l.name;
+
+-- name: GetCommandLibraryInstall :one
+SELECT * FROM command_library_installs
+WHERE channel_id = sqlc.arg(channel_id) AND library_id = sqlc.arg(library_id);
+
+-- name: InsertCommandLibraryInstall :exec
+INSERT INTO command_library_installs (channel_id, library_id, version, arguments, command_info_ids, autoreply_ids, creator, editor)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(library_id),
+    sqlc.arg(version),
+    sqlc.arg(arguments),
+    sqlc.arg(command_info_ids),
+    sqlc.arg(autoreply_ids),
+    sqlc.arg(creator),
+    sqlc.arg(creator)
+);
+
+-- name: UpdateCommandLibraryInstall :exec
+UPDATE command_library_installs
+SET version = sqlc.arg(version),
+    arguments = sqlc.arg(arguments),
+    command_info_ids = sqlc.arg(command_info_ids),
+    autoreply_ids = sqlc.arg(autoreply_ids),
+    editor = sqlc.arg(editor),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteCommandLibraryInstall :exec
+DELETE FROM command_library_installs WHERE id = sqlc.arg(id);
+
+-- name: ListCommandLibraryInstalls :many
+SELECT l.name, i.version, l.version AS latest_version
+FROM command_library_installs i
+JOIN command_libraries l ON l.id = i.library_id
+WHERE i.channel_id = sqlc.arg(channel_id)
+ORDER BY l.name;
+
+-- name: DeleteCommandLibraryInstallsByChannel :exec
+DELETE FROM command_library_installs WHERE channel_id = sqlc.arg(channel_id);
+
+-- name: DeleteCommandLibrariesByChannel :exec
+DELETE FROM command_libraries WHERE channel_id = sqlc.arg(channel_id);
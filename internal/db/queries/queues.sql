@@ -0,0 +1,58 @@
+-- name: InsertQueueEntry :execrows
+INSERT INTO queue_entries (channel_id, user_id, user_name, user_display, game_name, priority)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(user_id),
+    sqlc.arg(user_name),
+    sqlc.arg(user_display),
+    sqlc.arg(game_name),
+    sqlc.arg(priority)
+)
+ON CONFLICT (channel_id, user_id) DO NOTHING;
+
+-- name: UpdateQueueEntryGameName :exec
+UPDATE queue_entries
+SET game_name = sqlc.arg(game_name),
+    updated_at = statement_timestamp()
+WHERE channel_id = sqlc.arg(channel_id) AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteQueueEntryByUserID :execrows
+DELETE FROM queue_entries
+WHERE channel_id = sqlc.arg(channel_id) AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteQueueEntryByUserName :execrows
+DELETE FROM queue_entries
+WHERE channel_id = sqlc.arg(channel_id) AND user_name = sqlc.arg(user_name);
+
+-- name: GetQueuePosition :one
+SELECT ranked.position::bigint AS position
+FROM (
+    SELECT user_id, ROW_NUMBER() OVER (ORDER BY priority DESC, id) AS position
+    FROM queue_entries
+    WHERE channel_id = sqlc.arg(channel_id)
+) ranked
+WHERE ranked.user_id = sqlc.arg(user_id);
+
+-- name: CountQueueEntries :one
+SELECT count(*) FROM queue_entries WHERE channel_id = sqlc.arg(channel_id);
+
+-- name: ListQueueEntries :many
+SELECT * FROM queue_entries
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY priority DESC, id;
+
+-- name: ListNextQueueEntriesForUpdate :many
+SELECT * FROM queue_entries
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY priority DESC, id
+LIMIT sqlc.arg(count)
+FOR UPDATE;
+
+-- name: DeleteQueueEntries :exec
+DELETE FROM queue_entries WHERE id = ANY(sqlc.arg(ids)::bigint[]);
+
+-- name: ClearQueue :execrows
+DELETE FROM queue_entries WHERE channel_id = sqlc.arg(channel_id);
+
+-- name: DeleteQueueEntriesByChannel :exec
+DELETE FROM queue_entries WHERE channel_id = sqlc.arg(channel_id);
@@ -0,0 +1,128 @@
+-- name: InsertRaffle :one
+INSERT INTO raffles (channel_id, name, keyword, open, closes_at)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(name),
+    sqlc.arg(keyword),
+    true,
+    sqlc.narg(closes_at)
+)
+RETURNING *;
+
+-- name: GetRaffleByName :one
+SELECT * FROM raffles
+WHERE channel_id = sqlc.arg(channel_id) AND name = sqlc.arg(name);
+
+-- name: GetRaffle :one
+SELECT * FROM raffles WHERE id = sqlc.arg(id);
+
+-- name: GetOpenRaffleByKeyword :one
+SELECT * FROM raffles
+WHERE channel_id = sqlc.arg(channel_id) AND keyword = sqlc.arg(keyword) AND open
+LIMIT 1;
+
+-- name: ListOpenRaffleKeywords :many
+SELECT DISTINCT keyword FROM raffles
+WHERE channel_id = sqlc.arg(channel_id) AND open;
+
+-- name: ListRaffles :many
+SELECT * FROM raffles
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY name;
+
+-- name: ListRaffleTimerIDs :many
+SELECT id FROM raffles
+WHERE (open AND closes_at IS NOT NULL)
+    OR id IN (SELECT raffle_id FROM raffle_winners WHERE status = 'pending');
+
+-- name: UpdateRaffleOpen :exec
+UPDATE raffles
+SET open = sqlc.arg(open),
+    closes_at = sqlc.narg(closes_at),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteRaffle :exec
+DELETE FROM raffles WHERE id = sqlc.arg(id);
+
+-- name: DeleteRafflesByChannel :exec
+DELETE FROM raffles WHERE channel_id = sqlc.arg(channel_id);
+
+-- name: InsertRaffleEntry :execrows
+INSERT INTO raffle_entries (raffle_id, user_id, user_name, user_display, weight)
+VALUES (
+    sqlc.arg(raffle_id),
+    sqlc.arg(user_id),
+    sqlc.arg(user_name),
+    sqlc.arg(user_display),
+    sqlc.arg(weight)
+)
+ON CONFLICT (raffle_id, user_id) DO NOTHING;
+
+-- name: CountRaffleEntries :one
+SELECT count(*) FROM raffle_entries WHERE raffle_id = sqlc.arg(raffle_id);
+
+-- name: ListRaffleEntriesForUpdate :many
+SELECT * FROM raffle_entries e
+WHERE e.raffle_id = sqlc.arg(raffle_id)
+    AND NOT (sqlc.arg(exclude_winners)::boolean AND EXISTS (
+        SELECT 1 FROM raffle_winners w
+        WHERE w.channel_id = sqlc.arg(channel_id) AND w.user_id = e.user_id AND w.status <> 'forfeited'
+    ))
+ORDER BY e.id
+FOR UPDATE;
+
+-- name: DeleteRaffleEntries :exec
+DELETE FROM raffle_entries WHERE id = ANY(sqlc.arg(ids)::bigint[]);
+
+-- name: InsertRaffleWinner :one
+INSERT INTO raffle_winners (channel_id, raffle_id, raffle_name, user_id, user_name, user_display, status, claim_deadline)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.narg(raffle_id),
+    sqlc.arg(raffle_name),
+    sqlc.arg(user_id),
+    sqlc.arg(user_name),
+    sqlc.arg(user_display),
+    sqlc.arg(status),
+    sqlc.narg(claim_deadline)
+)
+RETURNING *;
+
+-- name: HasWonRaffle :one
+SELECT EXISTS (
+    SELECT 1 FROM raffle_winners
+    WHERE channel_id = sqlc.arg(channel_id) AND user_id = sqlc.arg(user_id) AND status <> 'forfeited'
+);
+
+-- name: GetPendingRaffleWinnerByUser :one
+SELECT * FROM raffle_winners
+WHERE channel_id = sqlc.arg(channel_id) AND user_id = sqlc.arg(user_id) AND status = 'pending'
+ORDER BY id
+LIMIT 1
+FOR UPDATE;
+
+-- name: ListExpiredRaffleWinnersForUpdate :many
+SELECT * FROM raffle_winners
+WHERE raffle_id = sqlc.arg(raffle_id) AND status = 'pending' AND claim_deadline <= sqlc.arg(now)
+ORDER BY id
+FOR UPDATE;
+
+-- name: NextRaffleClaimDeadline :one
+SELECT min(claim_deadline)::timestamptz AS claim_deadline FROM raffle_winners
+WHERE raffle_id = sqlc.arg(raffle_id) AND status = 'pending';
+
+-- name: UpdateRaffleWinnerStatus :exec
+UPDATE raffle_winners
+SET status = sqlc.arg(status),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: ListRaffleWinners :many
+SELECT * FROM raffle_winners
+WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(count);
+
+-- name: DeleteRaffleWinnersByChannel :exec
+DELETE FROM raffle_winners WHERE channel_id = sqlc.arg(channel_id);
@@ -98,7 +98,7 @@ LEFT JOIN moderated_channels m ON m.broadcaster_id = c.twitch_id AND m.bot_name
 WHERE r.enabled AND c.active AND ('channel:bot' = ANY(tt.scopes) OR m.id IS NOT NULL);
 
 -- name: ListActiveScheduledCommands :many
-SELECT s.*
+SELECT sqlc.embed(s), c.timezone
 FROM scheduled_commands s
 JOIN channels c ON s.channel_id = c.id
 LEFT JOIN twitch_tokens tt ON tt.twitch_id = c.twitch_id
@@ -2,6 +2,7 @@
 SELECT *
 FROM variables
 WHERE channel_id = sqlc.arg(channel_id)
+  AND user_id = sqlc.arg(user_id)
   AND name = sqlc.arg(name);
 
 -- name: GetVariableByChannelName :one
@@ -9,19 +10,56 @@ SELECT variables.*
 FROM variables
 JOIN channels ON channels.id = variables.channel_id
 WHERE channels.name = sqlc.arg(channel_name)
+  AND variables.user_id = 0
   AND variables.name = sqlc.arg(name);
 
 -- name: ListVariables :many
 SELECT *
 FROM variables
 WHERE channel_id = sqlc.arg(channel_id)
+ORDER BY name, user_id;
+
+-- name: ListChannelVariables :many
+SELECT *
+FROM variables
+WHERE channel_id = sqlc.arg(channel_id)
+  AND user_id = 0
+  AND (expires_at IS NULL OR expires_at > sqlc.arg(now))
 ORDER BY name;
 
+-- name: ListUserVariables :many
+SELECT variables.*, COALESCE(chatters.user_name, '')::text AS user_name
+FROM variables
+LEFT JOIN chatters ON chatters.channel_id = variables.channel_id AND chatters.user_id = variables.user_id
+WHERE variables.channel_id = sqlc.arg(channel_id)
+  AND variables.user_id != 0
+  AND (variables.expires_at IS NULL OR variables.expires_at > sqlc.arg(now))
+ORDER BY variables.name, variables.user_id;
+
 -- name: UpsertVariable :exec
-INSERT INTO variables (channel_id, name, value)
-VALUES (sqlc.arg(channel_id), sqlc.arg(name), sqlc.arg(value))
-ON CONFLICT (channel_id, name) DO UPDATE
-SET value = excluded.value,
+INSERT INTO variables (channel_id, user_id, name, kind, value)
+VALUES (sqlc.arg(channel_id), sqlc.arg(user_id), sqlc.arg(name), sqlc.arg(kind), sqlc.arg(value))
+ON CONFLICT (channel_id, user_id, name) DO UPDATE
+SET kind = excluded.kind,
+    value = excluded.value,
+    updated_at = statement_timestamp();
+
+-- name: SaveVariable :exec
+INSERT INTO variables (channel_id, user_id, name, kind, value, expires_at, shared_with)
+VALUES (
+    sqlc.arg(channel_id),
+    sqlc.arg(user_id),
+    sqlc.arg(name),
+    sqlc.arg(kind),
+    sqlc.arg(value),
+    sqlc.arg(expires_at),
+    sqlc.arg(shared_with)
+)
+ON CONFLICT (channel_id, user_id, name) DO UPDATE
+SET kind = excluded.kind,
+    value = excluded.value,
+    expires_at = excluded.expires_at,
+    shared_with = excluded.shared_with,
     updated_at = statement_timestamp();
 
 -- name: UpdateVariableValue :exec
@@ -30,7 +68,27 @@ SET value = sqlc.arg(value),
     updated_at = statement_timestamp()
 WHERE id = sqlc.arg(id);
 
+-- name: UpdateVariableKind :exec
+UPDATE variables
+SET kind = sqlc.arg(kind),
+    value = sqlc.arg(value),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateVariableExpiry :exec
+UPDATE variables
+SET expires_at = sqlc.arg(expires_at),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
+-- name: UpdateVariableSharing :exec
+UPDATE variables
+SET shared_with = sqlc.arg(shared_with),
+    updated_at = statement_timestamp()
+WHERE id = sqlc.arg(id);
+
 -- name: DeleteVariable :exec
 DELETE FROM variables
 WHERE channel_id = sqlc.arg(channel_id)
+  AND user_id = sqlc.arg(user_id)
   AND name = sqlc.arg(name);
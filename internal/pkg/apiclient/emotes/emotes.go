@@ -0,0 +1,195 @@
+// Package emotes provides a client for the third-party emote providers 7TV,
+// BetterTTV, and FrankerFaceZ.
+package emotes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/hortbot/hortbot/internal/pkg/httpx"
+)
+
+//go:generate go tool github.com/matryer/moq -fmt goimports -out emotesmocks/mocks.go -pkg emotesmocks . API
+
+// Provider is a third-party emote provider.
+type Provider string
+
+// Supported providers.
+const (
+	SevenTV      Provider = "7tv"
+	BetterTTV    Provider = "bttv"
+	FrankerFaceZ Provider = "ffz"
+)
+
+// Providers lists all supported providers.
+var Providers = []Provider{SevenTV, BetterTTV, FrankerFaceZ}
+
+// String returns the provider's display name.
+func (p Provider) String() string {
+	switch p {
+	case SevenTV:
+		return "7TV"
+	case BetterTTV:
+		return "BTTV"
+	case FrankerFaceZ:
+		return "FFZ"
+	default:
+		return string(p)
+	}
+}
+
+// API represents the supported API functions. It's defined for fake generation.
+type API interface {
+	ChannelEmotes(ctx context.Context, provider Provider, twitchID int64) ([]string, error)
+	GlobalEmotes(ctx context.Context, provider Provider) ([]string, error)
+}
+
+// Emotes is a third-party emote API client.
+type Emotes struct {
+	cli httpx.Client
+}
+
+var _ API = &Emotes{}
+
+// New creates a new third-party emote API client.
+func New(cli *http.Client) *Emotes {
+	return &Emotes{
+		cli: httpx.NewClient(cli, "emotes"),
+	}
+}
+
+type namedEmote struct {
+	Name string `json:"name"`
+}
+
+type bttvEmote struct {
+	Code string `json:"code"`
+}
+
+type ffzSet struct {
+	Emoticons []namedEmote `json:"emoticons"`
+}
+
+// ChannelEmotes gets the names of the emotes the provider has enabled for the
+// given Twitch channel. Channels unknown to the provider have no emotes.
+func (e *Emotes) ChannelEmotes(ctx context.Context, provider Provider, twitchID int64) ([]string, error) {
+	id := strconv.FormatInt(twitchID, 10)
+
+	var names []string
+	var err error
+
+	switch provider {
+	case SevenTV:
+		var v struct {
+			EmoteSet *struct {
+				Emotes []namedEmote `json:"emotes"`
+			} `json:"emote_set"`
+		}
+		err = e.fetch(ctx, provider, "https://7tv.io/v3/users/twitch/"+id, &v)
+		if err == nil && v.EmoteSet != nil {
+			names = emoteNames(v.EmoteSet.Emotes)
+		}
+
+	case BetterTTV:
+		var v struct {
+			ChannelEmotes []bttvEmote `json:"channelEmotes"`
+			SharedEmotes  []bttvEmote `json:"sharedEmotes"`
+		}
+		err = e.fetch(ctx, provider, "https://api.betterttv.net/3/cached/users/twitch/"+id, &v)
+		if err == nil {
+			names = append(bttvNames(v.ChannelEmotes), bttvNames(v.SharedEmotes)...)
+		}
+
+	case FrankerFaceZ:
+		var v struct {
+			Sets map[string]ffzSet `json:"sets"`
+		}
+		err = e.fetch(ctx, provider, "https://api.frankerfacez.com/v1/room/id/"+id, &v)
+		if err == nil {
+			for _, set := range v.Sets {
+				names = append(names, emoteNames(set.Emoticons)...)
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("emotes: unknown provider %q", provider)
+	}
+
+	if err != nil {
+		if apiErr, ok := apiclient.AsError(err); ok && apiErr.IsNotFound() {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// GlobalEmotes gets the names of the emotes the provider makes available in
+// every channel.
+func (e *Emotes) GlobalEmotes(ctx context.Context, provider Provider) ([]string, error) {
+	switch provider {
+	case SevenTV:
+		var v struct {
+			Emotes []namedEmote `json:"emotes"`
+		}
+		if err := e.fetch(ctx, provider, "https://7tv.io/v3/emote-sets/global", &v); err != nil {
+			return nil, err
+		}
+		return emoteNames(v.Emotes), nil
+
+	case BetterTTV:
+		var v []bttvEmote
+		if err := e.fetch(ctx, provider, "https://api.betterttv.net/3/cached/emotes/global", &v); err != nil {
+			return nil, err
+		}
+		return bttvNames(v), nil
+
+	case FrankerFaceZ:
+		var v struct {
+			DefaultSets []int             `json:"default_sets"`
+			Sets        map[string]ffzSet `json:"sets"`
+		}
+		if err := e.fetch(ctx, provider, "https://api.frankerfacez.com/v1/set/global", &v); err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, id := range v.DefaultSets {
+			names = append(names, emoteNames(v.Sets[strconv.Itoa(id)].Emoticons)...)
+		}
+		return names, nil
+
+	default:
+		return nil, fmt.Errorf("emotes: unknown provider %q", provider)
+	}
+}
+
+func (e *Emotes) fetch(ctx context.Context, provider Provider, url string, v any) error {
+	if err := e.cli.NewRequestToJSON(url, v).Fetch(ctx); err != nil {
+		return apiclient.WrapRequestErr(string(provider), err, nil)
+	}
+	return nil
+}
+
+func emoteNames(emotes []namedEmote) []string {
+	names := make([]string, 0, len(emotes))
+	for _, emote := range emotes {
+		if emote.Name != "" {
+			names = append(names, emote.Name)
+		}
+	}
+	return names
+}
+
+func bttvNames(emotes []bttvEmote) []string {
+	names := make([]string, 0, len(emotes))
+	for _, emote := range emotes {
+		if emote.Code != "" {
+			names = append(names, emote.Code)
+		}
+	}
+	return names
+}
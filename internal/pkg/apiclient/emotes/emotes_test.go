@@ -0,0 +1,206 @@
+package emotes_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/emotes"
+	"github.com/hortbot/hortbot/internal/pkg/httpmockx"
+	"github.com/jarcoal/httpmock"
+	"gotest.tools/v3/assert"
+)
+
+func TestChannelEmotes(t *testing.T) {
+	t.Parallel()
+	mt := httpmockx.NewMockTransport(t)
+
+	errTest := errors.New("test error")
+
+	mt.RegisterResponder(
+		"GET",
+		"https://7tv.io/v3/users/twitch/1",
+		httpmock.NewStringResponder(200, `{"emote_set": {"emotes": [{"name": "KEKW"}, {"name": "EZ"}]}}`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://7tv.io/v3/users/twitch/2",
+		httpmock.NewStringResponder(200, `{"emote_set": null}`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://api.betterttv.net/3/cached/users/twitch/1",
+		httpmock.NewStringResponder(200, `{"channelEmotes": [{"code": "catJAM"}], "sharedEmotes": [{"code": "monkaS"}]}`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://api.frankerfacez.com/v1/room/id/1",
+		httpmock.NewStringResponder(200, `{"room": {"set": 123}, "sets": {"123": {"emoticons": [{"name": "OMEGALUL"}]}}}`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://7tv.io/v3/users/twitch/404",
+		httpmock.NewStringResponder(404, `{}`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://api.betterttv.net/3/cached/users/twitch/500",
+		httpmock.NewStringResponder(500, `{}`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://api.frankerfacez.com/v1/room/id/777",
+		httpmock.NewStringResponder(200, `asdasd`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://7tv.io/v3/users/twitch/999",
+		httpmockx.ResponderFunc(func(_ *http.Request) (*http.Response, error) {
+			return nil, errTest
+		}),
+	)
+
+	t.Run("7TV", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		names, err := e.ChannelEmotes(t.Context(), emotes.SevenTV, 1)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, names, []string{"KEKW", "EZ"})
+	})
+
+	t.Run("7TV no set", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		names, err := e.ChannelEmotes(t.Context(), emotes.SevenTV, 2)
+		assert.NilError(t, err)
+		assert.Equal(t, len(names), 0)
+	})
+
+	t.Run("BTTV", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		names, err := e.ChannelEmotes(t.Context(), emotes.BetterTTV, 1)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, names, []string{"catJAM", "monkaS"})
+	})
+
+	t.Run("FFZ", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		names, err := e.ChannelEmotes(t.Context(), emotes.FrankerFaceZ, 1)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, names, []string{"OMEGALUL"})
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		names, err := e.ChannelEmotes(t.Context(), emotes.SevenTV, 404)
+		assert.NilError(t, err)
+		assert.Equal(t, len(names), 0)
+	})
+
+	t.Run("Server error", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		_, err := e.ChannelEmotes(t.Context(), emotes.BetterTTV, 500)
+		assert.Error(t, err, "bttv: ErrValidator: response error for https://api.betterttv.net/3/cached/users/twitch/500: unexpected status: 500")
+	})
+
+	t.Run("Decode error", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		_, err := e.ChannelEmotes(t.Context(), emotes.FrankerFaceZ, 777)
+		apiErr, ok := apiclient.AsError(err)
+		if !ok {
+			t.Fatalf("error has type %T", err)
+			return
+		}
+
+		assert.Equal(t, apiErr.API, "ffz")
+		assert.ErrorContains(t, apiErr.Err, "invalid character")
+	})
+
+	t.Run("Client error", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		_, err := e.ChannelEmotes(t.Context(), emotes.SevenTV, 999)
+		assert.ErrorContains(t, err, errTest.Error())
+	})
+
+	t.Run("Unknown provider", func(t *testing.T) {
+		t.Parallel()
+		e := emotes.New(&http.Client{Transport: mt})
+
+		_, err := e.ChannelEmotes(t.Context(), "unknown", 1)
+		assert.Error(t, err, `emotes: unknown provider "unknown"`)
+	})
+}
+
+func TestGlobalEmotes(t *testing.T) {
+	t.Parallel()
+	mt := httpmockx.NewMockTransport(t)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://7tv.io/v3/emote-sets/global",
+		httpmock.NewStringResponder(200, `{"emotes": [{"name": "EZ"}, {"name": ""}]}`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://api.betterttv.net/3/cached/emotes/global",
+		httpmock.NewStringResponder(200, `[{"code": "FeelsBadMan"}, {"code": "FeelsGoodMan"}]`),
+	)
+
+	mt.RegisterResponder(
+		"GET",
+		"https://api.frankerfacez.com/v1/set/global",
+		httpmock.NewStringResponder(200, `{"default_sets": [3], "sets": {"3": {"emoticons": [{"name": "ZreknarF"}]}, "4": {"emoticons": [{"name": "NotGlobal"}]}}}`),
+	)
+
+	e := emotes.New(&http.Client{Transport: mt})
+
+	names, err := e.GlobalEmotes(t.Context(), emotes.SevenTV)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"EZ"})
+
+	names, err = e.GlobalEmotes(t.Context(), emotes.BetterTTV)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"FeelsBadMan", "FeelsGoodMan"})
+
+	names, err = e.GlobalEmotes(t.Context(), emotes.FrankerFaceZ)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, names, []string{"ZreknarF"})
+
+	_, err = e.GlobalEmotes(t.Context(), "unknown")
+	assert.Error(t, err, `emotes: unknown provider "unknown"`)
+}
+
+func TestProviderString(t *testing.T) {
+	t.Parallel()
+
+	var names []string
+	for _, provider := range emotes.Providers {
+		names = append(names, provider.String())
+	}
+
+	assert.DeepEqual(t, names, []string{"7TV", "BTTV", "FFZ"})
+	assert.Equal(t, emotes.Provider("other").String(), "other")
+}
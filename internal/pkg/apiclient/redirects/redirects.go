@@ -0,0 +1,109 @@
+// Package redirects resolves the redirects of well known link shorteners.
+package redirects
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/carlmjohnson/requests"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/hortbot/hortbot/internal/pkg/httpx"
+)
+
+//go:generate go tool github.com/matryer/moq -fmt goimports -out redirectsmocks/mocks.go -pkg redirectsmocks . API
+
+// ErrNotShortener is returned when asked to resolve a link which is not from
+// a known link shortener.
+var ErrNotShortener = errors.New("redirects: not a known link shortener")
+
+// shorteners are the hosts of known link shorteners.
+var shorteners = map[string]bool{
+	"bit.ly":      true,
+	"buff.ly":     true,
+	"cutt.ly":     true,
+	"goo.gl":      true,
+	"is.gd":       true,
+	"ow.ly":       true,
+	"rb.gy":       true,
+	"rebrand.ly":  true,
+	"shorturl.at": true,
+	"t.co":        true,
+	"t.ly":        true,
+	"tiny.cc":     true,
+	"tinyurl.com": true,
+	"v.gd":        true,
+}
+
+// IsShortener returns true if the URL is from a known link shortener.
+func IsShortener(u *url.URL) bool {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	return shorteners[host]
+}
+
+// API represents the supported API functions. It's defined for fake generation.
+type API interface {
+	Resolve(ctx context.Context, link string) (string, error)
+}
+
+// Redirects is a link shortener redirect resolver.
+type Redirects struct {
+	cli httpx.Client
+}
+
+var _ API = &Redirects{}
+
+// New creates a new redirect resolver. Redirects are not followed by the
+// client; each call resolves a single redirect.
+func New(cli *http.Client) *Redirects {
+	noFollow := *cli
+	noFollow.CheckRedirect = requests.NoFollow
+
+	return &Redirects{
+		cli: httpx.NewClient(&noFollow, "redirects"),
+	}
+}
+
+// Resolve returns the URL the given link from a known link shortener
+// redirects to.
+func (r *Redirects) Resolve(ctx context.Context, link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", apiclient.NewNonStatusError("redirects", err)
+	}
+
+	if !IsShortener(u) {
+		return "", ErrNotShortener
+	}
+
+	header := make(http.Header)
+
+	req := r.cli.NewRequest(u.String()).
+		Head().
+		CheckStatus(
+			http.StatusMovedPermanently,
+			http.StatusFound,
+			http.StatusSeeOther,
+			http.StatusTemporaryRedirect,
+			http.StatusPermanentRedirect,
+		).
+		CopyHeaders(header)
+
+	if err := req.Fetch(ctx); err != nil {
+		return "", apiclient.WrapRequestErr("redirects", err, nil)
+	}
+
+	location := header.Get("Location")
+	if location == "" {
+		return "", apiclient.NewNonStatusError("redirects", errors.New("missing location"))
+	}
+
+	resolved, err := u.Parse(location)
+	if err != nil {
+		return "", apiclient.NewNonStatusError("redirects", err)
+	}
+
+	return resolved.String(), nil
+}
@@ -0,0 +1,105 @@
+package redirects_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/redirects"
+	"github.com/hortbot/hortbot/internal/pkg/httpmockx"
+	"github.com/jarcoal/httpmock"
+	"gotest.tools/v3/assert"
+)
+
+func redirectResponder(status int, location string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(status, "")
+		resp.Request = req
+		if location != "" {
+			resp.Header.Set("Location", location)
+		}
+		return resp, nil
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+	mt := httpmockx.NewMockTransport(t)
+
+	errTest := errors.New("test error")
+
+	mt.RegisterResponder("HEAD", "https://bit.ly/abc", redirectResponder(301, "https://example.com/some/page"))
+	mt.RegisterResponder("HEAD", "https://tinyurl.com/rel", redirectResponder(302, "/app/other"))
+	mt.RegisterResponder("HEAD", "https://t.co/none", redirectResponder(301, ""))
+	mt.RegisterResponder("HEAD", "https://is.gd/ok", redirectResponder(200, ""))
+	mt.RegisterResponder("HEAD", "https://goo.gl/missing", redirectResponder(404, ""))
+	mt.RegisterResponder("HEAD", "https://ow.ly/err", httpmockx.ResponderFunc(func(_ *http.Request) (*http.Response, error) {
+		return nil, errTest
+	}))
+
+	r := redirects.New(&http.Client{Transport: mt})
+
+	t.Run("OK", func(t *testing.T) {
+		t.Parallel()
+		resolved, err := r.Resolve(t.Context(), "https://bit.ly/abc")
+		assert.NilError(t, err)
+		assert.Equal(t, resolved, "https://example.com/some/page")
+	})
+
+	t.Run("Relative", func(t *testing.T) {
+		t.Parallel()
+		resolved, err := r.Resolve(t.Context(), "https://tinyurl.com/rel")
+		assert.NilError(t, err)
+		assert.Equal(t, resolved, "https://tinyurl.com/app/other")
+	})
+
+	t.Run("Missing location", func(t *testing.T) {
+		t.Parallel()
+		_, err := r.Resolve(t.Context(), "https://t.co/none")
+		assert.Error(t, err, "redirects: missing location")
+	})
+
+	t.Run("Not a redirect", func(t *testing.T) {
+		t.Parallel()
+		_, err := r.Resolve(t.Context(), "https://is.gd/ok")
+		assert.Error(t, err, "redirects: ErrValidator: response error for https://is.gd/ok: unexpected status: 200")
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		_, err := r.Resolve(t.Context(), "https://goo.gl/missing")
+		assert.Error(t, err, "redirects: ErrValidator: response error for https://goo.gl/missing: unexpected status: 404")
+	})
+
+	t.Run("Client error", func(t *testing.T) {
+		t.Parallel()
+		_, err := r.Resolve(t.Context(), "https://ow.ly/err")
+		assert.ErrorContains(t, err, errTest.Error())
+	})
+
+	t.Run("Not a shortener", func(t *testing.T) {
+		t.Parallel()
+		_, err := r.Resolve(t.Context(), "https://example.com/abc")
+		assert.Equal(t, err, redirects.ErrNotShortener)
+	})
+}
+
+func TestIsShortener(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"https://bit.ly/abc":          true,
+		"https://BIT.LY/abc":          true,
+		"https://www.tinyurl.com/abc": true,
+		"https://t.co:443/abc":        true,
+		"https://example.com/abc":     false,
+		"https://notbit.ly/abc":       false,
+	}
+
+	for link, want := range tests {
+		u, err := url.Parse(link)
+		assert.NilError(t, err)
+		assert.Equal(t, redirects.IsShortener(u), want, link)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 const (
 	plaintextLimit = 512
+	jsonLimit      = 64 * 1024
 )
 
 //go:generate go tool github.com/matryer/moq -fmt goimports -out simplemocks/mocks.go -pkg simplemocks . API
@@ -19,6 +20,7 @@ const (
 // API represents the supported API functions. It's defined for fake generation.
 type API interface {
 	Plaintext(ctx context.Context, u string) (body string, err error)
+	JSON(ctx context.Context, u string) (body []byte, err error)
 }
 
 // Client is a simple HTTP client to fetch URLs.
@@ -59,3 +61,25 @@ func (c *Client) Plaintext(ctx context.Context, u string) (body string, err erro
 
 	return s, nil
 }
+
+// JSON gets the specified URL as JSON. Unlike Plaintext, non-2xx responses
+// are returned as errors, as their bodies are unlikely to be useful.
+func (c *Client) JSON(ctx context.Context, u string) (body []byte, err error) {
+	var b []byte
+
+	req := c.cli.NewRequest(u).Accept("application/json").Handle(func(r *http.Response) error {
+		lr := &io.LimitedReader{
+			R: r.Body,
+			N: jsonLimit,
+		}
+
+		var err error
+		b, err = io.ReadAll(lr)
+		return err //nolint:wrapcheck
+	})
+	if err := req.Fetch(ctx); err != nil {
+		return nil, apiclient.WrapRequestErr("simple", err, nil)
+	}
+
+	return b, nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/simple"
 	"github.com/hortbot/hortbot/internal/pkg/httpmockx"
 	"github.com/jarcoal/httpmock"
@@ -95,6 +96,78 @@ func TestPlaintext(t *testing.T) {
 	})
 }
 
+func TestJSON(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	const apiURL = "https://example.com/something.json"
+
+	t.Run("Good", func(t *testing.T) {
+		t.Parallel()
+		mt := httpmockx.NewMockTransport(t)
+		mt.RegisterResponder("GET", apiURL, httpmock.NewStringResponder(200, `{"foo": "bar"}`))
+
+		sc := simple.New(&http.Client{Transport: mt})
+
+		body, err := sc.JSON(ctx, apiURL)
+		assert.NilError(t, err)
+		assert.Equal(t, string(body), `{"foo": "bar"}`)
+	})
+
+	t.Run("Request error", func(t *testing.T) {
+		t.Parallel()
+		testErr := errors.New("testing error")
+
+		mt := httpmockx.NewMockTransport(t)
+		mt.RegisterResponder("GET", apiURL, httpmock.NewErrorResponder(testErr))
+
+		sc := simple.New(&http.Client{Transport: mt})
+
+		_, err := sc.JSON(ctx, apiURL)
+		assert.ErrorContains(t, err, testErr.Error())
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		t.Parallel()
+		mt := httpmockx.NewMockTransport(t)
+		mt.RegisterResponder("GET", apiURL, httpmock.NewStringResponder(404, `{"error": "not found"}`))
+
+		sc := simple.New(&http.Client{Transport: mt})
+
+		_, err := sc.JSON(ctx, apiURL)
+		apiErr, ok := apiclient.AsError(err)
+		assert.Assert(t, ok)
+		assert.Equal(t, apiErr.StatusCode, 404)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		t.Parallel()
+		text := strings.Repeat("x", 64*1024+1)
+
+		mt := httpmockx.NewMockTransport(t)
+		mt.RegisterResponder("GET", apiURL, httpmock.NewStringResponder(200, text))
+
+		sc := simple.New(&http.Client{Transport: mt})
+
+		body, err := sc.JSON(ctx, apiURL)
+		assert.NilError(t, err)
+		assert.Equal(t, len(body), 64*1024)
+	})
+
+	t.Run("ReadAll error", func(t *testing.T) {
+		t.Parallel()
+		response := httpmock.NewStringResponse(200, "") //nolint:bodyclose
+		response.Body = (*badBody)(nil)
+
+		mt := httpmockx.NewMockTransport(t)
+		mt.RegisterResponder("GET", apiURL, httpmock.ResponderFromResponse(response))
+
+		sc := simple.New(&http.Client{Transport: mt})
+
+		_, err := sc.JSON(ctx, apiURL)
+		assert.ErrorIs(t, err, errBadBody)
+	})
+}
+
 var errBadBody = errors.New("bad body")
 
 type badBody struct{}
@@ -20,6 +20,9 @@ var _ simple.API = &APIMock{}
 //
 //		// make and configure a mocked simple.API
 //		mockedAPI := &APIMock{
+//			JSONFunc: func(ctx context.Context, u string) ([]byte, error) {
+//				panic("mock out the JSON method")
+//			},
 //			PlaintextFunc: func(ctx context.Context, u string) (string, error) {
 //				panic("mock out the Plaintext method")
 //			},
@@ -30,11 +33,21 @@ var _ simple.API = &APIMock{}
 //
 //	}
 type APIMock struct {
+	// JSONFunc mocks the JSON method.
+	JSONFunc func(ctx context.Context, u string) ([]byte, error)
+
 	// PlaintextFunc mocks the Plaintext method.
 	PlaintextFunc func(ctx context.Context, u string) (string, error)
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// JSON holds details about calls to the JSON method.
+		JSON []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// U is the u argument value.
+			U string
+		}
 		// Plaintext holds details about calls to the Plaintext method.
 		Plaintext []struct {
 			// Ctx is the ctx argument value.
@@ -43,9 +56,46 @@ type APIMock struct {
 			U string
 		}
 	}
+	lockJSON      sync.RWMutex
 	lockPlaintext sync.RWMutex
 }
 
+// JSON calls JSONFunc.
+func (mock *APIMock) JSON(ctx context.Context, u string) ([]byte, error) {
+	if mock.JSONFunc == nil {
+		panic("APIMock.JSONFunc: method is nil but API.JSON was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+		U   string
+	}{
+		Ctx: ctx,
+		U:   u,
+	}
+	mock.lockJSON.Lock()
+	mock.calls.JSON = append(mock.calls.JSON, callInfo)
+	mock.lockJSON.Unlock()
+	return mock.JSONFunc(ctx, u)
+}
+
+// JSONCalls gets all the calls that were made to JSON.
+// Check the length with:
+//
+//	len(mockedAPI.JSONCalls())
+func (mock *APIMock) JSONCalls() []struct {
+	Ctx context.Context
+	U   string
+} {
+	var calls []struct {
+		Ctx context.Context
+		U   string
+	}
+	mock.lockJSON.RLock()
+	calls = mock.calls.JSON
+	mock.lockJSON.RUnlock()
+	return calls
+}
+
 // Plaintext calls PlaintextFunc.
 func (mock *APIMock) Plaintext(ctx context.Context, u string) (string, error) {
 	if mock.PlaintextFunc == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/pkg/apiclient"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/idstr"
@@ -30,6 +31,41 @@ func (t *Twitch) GetChannelModerators(ctx context.Context, id int64, userToken *
 	return mods, newToken, err
 }
 
+// ChannelFollower is a user who follows a channel.
+type ChannelFollower struct {
+	UserID     idstr.IDStr `json:"user_id"`
+	UserLogin  string      `json:"user_login"`
+	UserName   string      `json:"user_name"`
+	FollowedAt time.Time   `json:"followed_at"`
+}
+
+// GetChannelFollower gets a user's follow of the broadcaster's channel. If the
+// user does not follow the channel, a not found error is returned. The token
+// must belong to the broadcaster or one of their moderators.
+//
+// GET https://api.twitch.tv/helix/channels/followers
+func (t *Twitch) GetChannelFollower(ctx context.Context, broadcasterID int64, userID int64, modToken *oauth2.Token) (follower *ChannelFollower, newToken *oauth2.Token, err error) {
+	if userID == 0 {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	if modToken == nil || modToken.AccessToken == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, modToken, setToken(&newToken))
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/channels/followers")
+	if err != nil {
+		return nil, newToken, err
+	}
+	req.Param("broadcaster_id", strconv.FormatInt(broadcasterID, 10))
+	req.Param("user_id", strconv.FormatInt(userID, 10))
+
+	follower, err = fetchFirstFromList[*ChannelFollower](ctx, req)
+	return follower, newToken, err
+}
+
 // ModifyChannel modifies a channel. Either or both of the title and game ID must be provided.
 // The title must not be empty. If zero, the game will be unset.
 //
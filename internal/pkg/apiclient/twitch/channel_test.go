@@ -288,3 +288,57 @@ func TestGetChannelByID(t *testing.T) {
 		assert.ErrorContains(t, err, errTestBadRequest.Error())
 	})
 }
+
+func TestGetChannelFollower(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	follower, newToken, err := tw.GetChannelFollower(ctx, broadcasterID, 2, tok)
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+	assert.DeepEqual(t, follower, &twitch.ChannelFollower{
+		UserID:     2,
+		UserLogin:  "random",
+		UserName:   "Random",
+		FollowedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	})
+
+	_, _, err = tw.GetChannelFollower(ctx, broadcasterID, 3, tok)
+	assert.Error(t, err, "twitch: unexpected status: 404")
+
+	_, _, err = tw.GetChannelFollower(ctx, broadcasterID, 0, tok)
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.GetChannelFollower(ctx, broadcasterID, 2, nil)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	_, _, err = tw.GetChannelFollower(ctx, broadcasterID, 2, &oauth2.Token{})
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestGetChannelFollowerErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	tok := tokFor(ctx, t, tw, ft, 777)
+	_, _, err := tw.GetChannelFollower(ctx, 777, 2, tok)
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		_, newToken, err := tw.GetChannelFollower(ctx, id, 2, tok)
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/hortbot/hortbot/internal/pkg/apiclient"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/idstr"
+	"github.com/hortbot/hortbot/internal/pkg/stringsx"
 	"golang.org/x/oauth2"
 )
 
@@ -79,6 +80,42 @@ func (t *Twitch) Unban(ctx context.Context, broadcasterID int64, modID int64, mo
 	return newToken, nil
 }
 
+// ChatSettings are a channel's chat settings.
+type ChatSettings struct {
+	EmoteMode bool `json:"emote_mode"`
+
+	FollowerMode         bool  `json:"follower_mode"`
+	FollowerModeDuration int64 `json:"follower_mode_duration"`
+
+	SlowMode         bool  `json:"slow_mode"`
+	SlowModeWaitTime int64 `json:"slow_mode_wait_time"`
+
+	SubscriberMode bool `json:"subscriber_mode"`
+
+	UniqueChatMode bool `json:"unique_chat_mode"`
+}
+
+// GetChatSettings gets the current chat settings.
+//
+// GET https://api.twitch.tv/helix/chat/settings
+func (t *Twitch) GetChatSettings(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (settings *ChatSettings, newToken *oauth2.Token, err error) {
+	if modToken == nil || modToken.AccessToken == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, modToken, setToken(&newToken))
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/chat/settings")
+	if err != nil {
+		return nil, newToken, err
+	}
+	req.Param("broadcaster_id", strconv.FormatInt(broadcasterID, 10))
+	req.Param("moderator_id", strconv.FormatInt(modID, 10))
+
+	settings, err = fetchFirstFromList[*ChatSettings](ctx, req)
+	return settings, newToken, err
+}
+
 type ChatSettingsPatch struct {
 	EmoteMode *bool `json:"emote_mode,omitempty"`
 
@@ -245,17 +282,98 @@ func (t *Twitch) Announce(ctx context.Context, broadcasterID int64, modID int64,
 	return newToken, nil
 }
 
-// SendChatMessage sends a chat message as the given user.
+// SendShoutout sends a shoutout for another channel.
+//
+// POST https://api.twitch.tv/helix/chat/shoutouts
+func (t *Twitch) SendShoutout(ctx context.Context, broadcasterID int64, toID int64, modID int64, modToken *oauth2.Token) (newToken *oauth2.Token, err error) {
+	if toID == 0 {
+		return nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	if modToken == nil || modToken.AccessToken == "" {
+		return nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, modToken, setToken(&newToken))
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/chat/shoutouts")
+	if err != nil {
+		return nil, err
+	}
+	req.Param("from_broadcaster_id", strconv.FormatInt(broadcasterID, 10))
+	req.Param("to_broadcaster_id", strconv.FormatInt(toID, 10))
+	req.Param("moderator_id", strconv.FormatInt(modID, 10))
+
+	if err := req.Post().Fetch(ctx); err != nil {
+		return newToken, apiclient.WrapRequestErr("twitch", err, nil)
+	}
+
+	return newToken, nil
+}
+
+// StartRaid starts a raid of another channel. The token must belong to the
+// raiding broadcaster.
+//
+// POST https://api.twitch.tv/helix/raids
+func (t *Twitch) StartRaid(ctx context.Context, broadcasterID int64, toID int64, userToken *oauth2.Token) (newToken *oauth2.Token, err error) {
+	if toID == 0 {
+		return nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	if userToken == nil || userToken.AccessToken == "" {
+		return nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, userToken, setToken(&newToken))
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/raids")
+	if err != nil {
+		return nil, err
+	}
+	req.Param("from_broadcaster_id", strconv.FormatInt(broadcasterID, 10))
+	req.Param("to_broadcaster_id", strconv.FormatInt(toID, 10))
+
+	if err := req.Post().Fetch(ctx); err != nil {
+		return newToken, apiclient.WrapRequestErr("twitch", err, nil)
+	}
+
+	return newToken, nil
+}
+
+// CancelRaid cancels a pending raid. The token must belong to the raiding
+// broadcaster.
+//
+// DELETE https://api.twitch.tv/helix/raids
+func (t *Twitch) CancelRaid(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (newToken *oauth2.Token, err error) {
+	if userToken == nil || userToken.AccessToken == "" {
+		return nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, userToken, setToken(&newToken))
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/raids")
+	if err != nil {
+		return nil, err
+	}
+	req.Param("broadcaster_id", strconv.FormatInt(broadcasterID, 10))
+
+	if err := req.Delete().Fetch(ctx); err != nil {
+		return newToken, apiclient.WrapRequestErr("twitch", err, nil)
+	}
+
+	return newToken, nil
+}
+
+// SendChatMessage sends a chat message as the given user. If replyParentID is
+// set, the message is sent as a threaded reply to that message.
 //
 // POST https://api.twitch.tv/helix/chat/messages
-func (t *Twitch) SendChatMessage(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string) (newToken *oauth2.Token, err error) {
+func (t *Twitch) SendChatMessage(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string, replyParentID string) (newToken *oauth2.Token, err error) {
 	if message == "" {
 		return nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
 	}
 
-	if len(message) > 500 {
-		message = message[:500]
-	}
+	message = stringsx.Truncate(message, 500)
 
 	if senderToken == nil || senderToken.AccessToken == "" {
 		return nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
@@ -269,13 +387,52 @@ func (t *Twitch) SendChatMessage(ctx context.Context, broadcasterID int64, sende
 	}
 
 	body := &struct {
-		BroadcasterID idstr.IDStr `json:"broadcaster_id"`
-		SenderID      idstr.IDStr `json:"sender_id"`
-		Message       string      `json:"message"`
+		BroadcasterID        idstr.IDStr `json:"broadcaster_id"`
+		SenderID             idstr.IDStr `json:"sender_id"`
+		Message              string      `json:"message"`
+		ReplyParentMessageID string      `json:"reply_parent_message_id,omitempty"`
 	}{
-		BroadcasterID: idstr.IDStr(broadcasterID),
-		SenderID:      idstr.IDStr(senderID),
-		Message:       message,
+		BroadcasterID:        idstr.IDStr(broadcasterID),
+		SenderID:             idstr.IDStr(senderID),
+		Message:              message,
+		ReplyParentMessageID: replyParentID,
+	}
+
+	if err := req.BodyJSON(body).Post().Fetch(ctx); err != nil {
+		return newToken, apiclient.WrapRequestErr("twitch", err, nil)
+	}
+
+	return newToken, nil
+}
+
+// SendWhisper sends a whisper from one user to another. The sender must have
+// a verified phone number.
+//
+// POST https://api.twitch.tv/helix/whispers
+func (t *Twitch) SendWhisper(ctx context.Context, fromID int64, toID int64, fromToken *oauth2.Token, message string) (newToken *oauth2.Token, err error) {
+	if message == "" || toID == 0 {
+		return nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	message = stringsx.Truncate(message, 500)
+
+	if fromToken == nil || fromToken.AccessToken == "" {
+		return nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, fromToken, setToken(&newToken))
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/whispers")
+	if err != nil {
+		return nil, err
+	}
+	req.Param("from_user_id", strconv.FormatInt(fromID, 10))
+	req.Param("to_user_id", strconv.FormatInt(toID, 10))
+
+	body := &struct {
+		Message string `json:"message"`
+	}{
+		Message: message,
 	}
 
 	if err := req.BodyJSON(body).Post().Fetch(ctx); err != nil {
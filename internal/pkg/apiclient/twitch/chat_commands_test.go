@@ -386,6 +386,63 @@ func TestClearChatErrors(t *testing.T) {
 	}
 }
 
+func TestGetChatSettings(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1
+	const modID = 123
+	tok := tokFor(ctx, t, tw, ft, modID)
+
+	settings, newToken, err := tw.GetChatSettings(ctx, broadcasterID, modID, tok)
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+	assert.DeepEqual(t, settings, &twitch.ChatSettings{
+		FollowerMode:         true,
+		FollowerModeDuration: 10,
+		SlowMode:             true,
+		SlowModeWaitTime:     30,
+	})
+
+	_, _, err = tw.GetChatSettings(ctx, broadcasterID, modID, nil)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	_, _, err = tw.GetChatSettings(ctx, broadcasterID, modID, &oauth2.Token{})
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestGetChatSettingsErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const modID = 123
+	tok := tokFor(ctx, t, tw, ft, modID)
+
+	_, _, err := tw.GetChatSettings(ctx, 777, modID, tok)
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		_, newToken, err := tw.GetChatSettings(ctx, id, modID, tok)
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
 func TestUpdateChatSettings(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := testContext(t)
@@ -563,7 +620,27 @@ func TestSendChatMessage(t *testing.T) {
 	const modID = 123
 	tok := tokFor(ctx, t, tw, ft, modID)
 
-	newToken, err := tw.SendChatMessage(ctx, broadcasterID, modID, tok, "Some announcement!")
+	newToken, err := tw.SendChatMessage(ctx, broadcasterID, modID, tok, "Some announcement!", "")
+
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+}
+
+func TestSendChatMessageReply(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1
+	const modID = 125
+	tok := tokFor(ctx, t, tw, ft, modID)
+
+	newToken, err := tw.SendChatMessage(ctx, broadcasterID, modID, tok, "Some reply!", "parent-id")
 
 	assert.NilError(t, err)
 	assert.Assert(t, newToken == nil)
@@ -583,7 +660,28 @@ func TestSendChatMessageTruncate(t *testing.T) {
 	const modID = 124
 	tok := tokFor(ctx, t, tw, ft, modID)
 
-	newToken, err := tw.SendChatMessage(ctx, broadcasterID, modID, tok, strings.Repeat("A", 1000))
+	newToken, err := tw.SendChatMessage(ctx, broadcasterID, modID, tok, strings.Repeat("A", 1000), "")
+
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+}
+
+func TestSendChatMessageTruncateMultibyte(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1
+	const modID = 126
+	tok := tokFor(ctx, t, tw, ft, modID)
+
+	// The 500th byte is the middle of a character, which must not be split.
+	newToken, err := tw.SendChatMessage(ctx, broadcasterID, modID, tok, "A"+strings.Repeat("é", 300), "")
 
 	assert.NilError(t, err)
 	assert.Assert(t, newToken == nil)
@@ -603,13 +701,13 @@ func TestSendChatMessageBadParameters(t *testing.T) {
 	const modID = 123
 	tok := tokFor(ctx, t, tw, ft, modID)
 
-	_, err := tw.SendChatMessage(ctx, broadcasterID, modID, tok, "")
+	_, err := tw.SendChatMessage(ctx, broadcasterID, modID, tok, "", "")
 	assert.Error(t, err, "twitch: unexpected status: 400")
 
-	_, err = tw.SendChatMessage(ctx, broadcasterID, modID, nil, "Some announcement!")
+	_, err = tw.SendChatMessage(ctx, broadcasterID, modID, nil, "Some announcement!", "")
 	assert.Error(t, err, "twitch: unexpected status: 401")
 
-	_, err = tw.SendChatMessage(ctx, broadcasterID, modID, &oauth2.Token{}, "Some announcement!")
+	_, err = tw.SendChatMessage(ctx, broadcasterID, modID, &oauth2.Token{}, "Some announcement!", "")
 	assert.Error(t, err, "twitch: unexpected status: 401")
 }
 
@@ -626,14 +724,290 @@ func TestSendChatMessageErrors(t *testing.T) {
 	const modID = 123
 	tok := tokFor(ctx, t, tw, ft, modID)
 
-	_, err := tw.SendChatMessage(ctx, 777, modID, tok, "Some announcement!")
+	_, err := tw.SendChatMessage(ctx, 777, modID, tok, "Some announcement!", "")
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		newToken, err := tw.SendChatMessage(ctx, id, modID, tok, "Some announcement!", "")
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestSendWhisper(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const fromID = 123
+	tok := tokFor(ctx, t, tw, ft, fromID)
+
+	newToken, err := tw.SendWhisper(ctx, fromID, 1, tok, "Some whisper!")
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+
+	newToken, err = tw.SendWhisper(ctx, fromID, 2, tok, strings.Repeat("A", 1000))
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+}
+
+func TestSendWhisperBadParameters(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const fromID = 123
+	tok := tokFor(ctx, t, tw, ft, fromID)
+
+	_, err := tw.SendWhisper(ctx, fromID, 1, tok, "")
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, err = tw.SendWhisper(ctx, fromID, 0, tok, "Some whisper!")
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, err = tw.SendWhisper(ctx, fromID, 1, nil, "Some whisper!")
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	_, err = tw.SendWhisper(ctx, fromID, 1, &oauth2.Token{}, "Some whisper!")
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestSendWhisperErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const fromID = 123
+	tok := tokFor(ctx, t, tw, ft, fromID)
+
+	_, err := tw.SendWhisper(ctx, fromID, 777, tok, "Some whisper!")
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		newToken, err := tw.SendWhisper(ctx, fromID, int64(status), tok, "Some whisper!")
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestSendShoutout(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1234
+	const toID = 5678
+	const modID = 3141
+	tok := tokFor(ctx, t, tw, ft, modID)
+
+	newToken, err := tw.SendShoutout(ctx, broadcasterID, toID, modID, tok)
+
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+}
+
+func TestSendShoutoutBadParameters(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1234
+	const toID = 5678
+	const modID = 3141
+	tok := tokFor(ctx, t, tw, ft, modID)
+
+	_, err := tw.SendShoutout(ctx, broadcasterID, 0, modID, tok)
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, err = tw.SendShoutout(ctx, broadcasterID, toID, modID, nil)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	_, err = tw.SendShoutout(ctx, broadcasterID, toID, modID, &oauth2.Token{})
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestSendShoutoutErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const toID = 5678
+	const modID = 3141
+	tok := tokFor(ctx, t, tw, ft, modID)
+
+	_, err := tw.SendShoutout(ctx, 777, toID, modID, tok)
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		newToken, err := tw.SendShoutout(ctx, id, toID, modID, tok)
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestStartRaid(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1234
+	const toID = 5678
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	newToken, err := tw.StartRaid(ctx, broadcasterID, toID, tok)
+
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+}
+
+func TestStartRaidBadParameters(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1234
+	const toID = 5678
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	_, err := tw.StartRaid(ctx, broadcasterID, 0, tok)
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, err = tw.StartRaid(ctx, broadcasterID, toID, nil)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	_, err = tw.StartRaid(ctx, broadcasterID, toID, &oauth2.Token{})
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestStartRaidErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const toID = 5678
+
+	tok := tokFor(ctx, t, tw, ft, 777)
+	_, err := tw.StartRaid(ctx, 777, toID, tok)
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		newToken, err := tw.StartRaid(ctx, id, toID, tok)
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestCancelRaid(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	newToken, err := tw.CancelRaid(ctx, broadcasterID, tok)
+
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+}
+
+func TestCancelRaidBadParameters(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	const broadcasterID = 1234
+
+	_, err := tw.CancelRaid(ctx, broadcasterID, nil)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	_, err = tw.CancelRaid(ctx, broadcasterID, &oauth2.Token{})
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestCancelRaidErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft := newFakeTwitch(t)
+	cli := ft.client()
+
+	tw := twitch.New(clientID, clientSecret, redirectURL, cli)
+
+	tok := tokFor(ctx, t, tw, ft, 777)
+	_, err := tw.CancelRaid(ctx, 777, tok)
 	assert.ErrorContains(t, err, errTestBadRequest.Error())
 
 	for status := range expectedErrors {
 		id := int64(status)
 		tok := tokFor(ctx, t, tw, ft, id)
 
-		newToken, err := tw.SendChatMessage(ctx, id, modID, tok, "Some announcement!")
+		newToken, err := tw.CancelRaid(ctx, id, tok)
 		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
 		assert.Assert(t, newToken == nil)
 	}
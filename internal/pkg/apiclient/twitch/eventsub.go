@@ -144,3 +144,62 @@ func (t *Twitch) CreateChatSubscription(ctx context.Context, conduitID string, b
 	}
 	return nil
 }
+
+func (t *Twitch) CreateRaidSubscription(ctx context.Context, conduitID string, broadcasterID int64) error {
+	body := struct {
+		Type      string                                    `json:"type"`
+		Version   string                                    `json:"version"`
+		Condition eventsub.ChannelRaidSubscriptionCondition `json:"condition"`
+		Transport eventsub.Transport                        `json:"transport"`
+	}{
+		Type:    eventsub.ChannelRaidSubscriptionType,
+		Version: "1",
+		Condition: eventsub.ChannelRaidSubscriptionCondition{
+			ToBroadcasterUserID: idstr.IDStr(broadcasterID),
+		},
+		Transport: eventsub.Transport{
+			Method:    "conduit",
+			ConduitID: conduitID,
+		},
+	}
+
+	req, err := t.helixCli.NewRequest(ctx, helixEventsubSubscriptions)
+	if err != nil {
+		return err
+	}
+	if err := req.BodyJSON(body).Post().Fetch(ctx); err != nil {
+		return apiclient.WrapRequestErr("twitch", err, nil)
+	}
+	return nil
+}
+
+// CreateBroadcasterSubscription creates a subscription whose condition is only
+// the broadcaster, such as channel.poll.end. The broadcaster must have
+// authorized the client with the scopes the subscription type requires.
+func (t *Twitch) CreateBroadcasterSubscription(ctx context.Context, conduitID string, subscriptionType string, broadcasterID int64) error {
+	body := struct {
+		Type      string                                    `json:"type"`
+		Version   string                                    `json:"version"`
+		Condition eventsub.BroadcasterSubscriptionCondition `json:"condition"`
+		Transport eventsub.Transport                        `json:"transport"`
+	}{
+		Type:    subscriptionType,
+		Version: "1",
+		Condition: eventsub.BroadcasterSubscriptionCondition{
+			BroadcasterUserID: idstr.IDStr(broadcasterID),
+		},
+		Transport: eventsub.Transport{
+			Method:    "conduit",
+			ConduitID: conduitID,
+		},
+	}
+
+	req, err := t.helixCli.NewRequest(ctx, helixEventsubSubscriptions)
+	if err != nil {
+		return err
+	}
+	if err := req.BodyJSON(body).Post().Fetch(ctx); err != nil {
+		return apiclient.WrapRequestErr("twitch", err, nil)
+	}
+	return nil
+}
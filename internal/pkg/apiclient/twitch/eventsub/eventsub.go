@@ -122,8 +122,29 @@ type ChatMessageSubscriptionCondition struct {
 
 const ChatMessageSubscriptionType = "channel.chat.message"
 
+type ChannelRaidSubscriptionCondition struct {
+	FromBroadcasterUserID idstr.IDStr `json:"from_broadcaster_user_id,omitempty"`
+	ToBroadcasterUserID   idstr.IDStr `json:"to_broadcaster_user_id,omitempty"`
+}
+
+const ChannelRaidSubscriptionType = "channel.raid"
+
+type BroadcasterSubscriptionCondition struct {
+	BroadcasterUserID idstr.IDStr `json:"broadcaster_user_id"`
+}
+
+const (
+	ChannelPollEndSubscriptionType       = "channel.poll.end"
+	ChannelPredictionEndSubscriptionType = "channel.prediction.end"
+	StreamOfflineSubscriptionType        = "stream.offline"
+)
+
 var subscriptionConditionFuncs = map[string]func([]byte, *any) error{
-	ChatMessageSubscriptionType: unmarshallPointerToAny[ChatMessageSubscriptionCondition],
+	ChatMessageSubscriptionType:          unmarshallPointerToAny[ChatMessageSubscriptionCondition],
+	ChannelRaidSubscriptionType:          unmarshallPointerToAny[ChannelRaidSubscriptionCondition],
+	ChannelPollEndSubscriptionType:       unmarshallPointerToAny[BroadcasterSubscriptionCondition],
+	ChannelPredictionEndSubscriptionType: unmarshallPointerToAny[BroadcasterSubscriptionCondition],
+	StreamOfflineSubscriptionType:        unmarshallPointerToAny[BroadcasterSubscriptionCondition],
 }
 
 type Transport struct {
@@ -161,7 +182,11 @@ func (n *NotificationPayload) UnmarshalJSON(data []byte) error {
 }
 
 var subscriptionEventFuncs = map[string]func([]byte, *any) error{
-	ChatMessageSubscriptionType: unmarshallPointerToAny[ChatMessageEvent],
+	ChatMessageSubscriptionType:          unmarshallPointerToAny[ChatMessageEvent],
+	ChannelRaidSubscriptionType:          unmarshallPointerToAny[ChannelRaidEvent],
+	ChannelPollEndSubscriptionType:       unmarshallPointerToAny[ChannelPollEndEvent],
+	ChannelPredictionEndSubscriptionType: unmarshallPointerToAny[ChannelPredictionEndEvent],
+	StreamOfflineSubscriptionType:        unmarshallPointerToAny[StreamOfflineEvent],
 }
 
 type ChatMessageEvent struct {
@@ -235,3 +260,60 @@ type ChatMessageEventReply struct {
 	ThreadUserLogin   string      `json:"thread_user_login"`
 	ThreadUserName    string      `json:"thread_user_name"`
 }
+
+type ChannelRaidEvent struct {
+	FromBroadcasterUserID    idstr.IDStr `json:"from_broadcaster_user_id"`
+	FromBroadcasterUserLogin string      `json:"from_broadcaster_user_login"`
+	FromBroadcasterUserName  string      `json:"from_broadcaster_user_name"`
+	ToBroadcasterUserID      idstr.IDStr `json:"to_broadcaster_user_id"`
+	ToBroadcasterUserLogin   string      `json:"to_broadcaster_user_login"`
+	ToBroadcasterUserName    string      `json:"to_broadcaster_user_name"`
+	Viewers                  int         `json:"viewers"`
+}
+
+type ChannelPollEndEvent struct {
+	ID                   string                      `json:"id"`
+	BroadcasterUserID    idstr.IDStr                 `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                      `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                      `json:"broadcaster_user_name"`
+	Title                string                      `json:"title"`
+	Choices              []ChannelPollEndEventChoice `json:"choices"`
+	Status               string                      `json:"status"`
+	StartedAt            time.Time                   `json:"started_at"`
+	EndedAt              time.Time                   `json:"ended_at"`
+}
+
+type ChannelPollEndEventChoice struct {
+	ID                 string `json:"id"`
+	Title              string `json:"title"`
+	BitsVotes          int    `json:"bits_votes"`
+	ChannelPointsVotes int    `json:"channel_points_votes"`
+	Votes              int    `json:"votes"`
+}
+
+type ChannelPredictionEndEvent struct {
+	ID                   string                             `json:"id"`
+	BroadcasterUserID    idstr.IDStr                        `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string                             `json:"broadcaster_user_login"`
+	BroadcasterUserName  string                             `json:"broadcaster_user_name"`
+	Title                string                             `json:"title"`
+	WinningOutcomeID     string                             `json:"winning_outcome_id"`
+	Outcomes             []ChannelPredictionEndEventOutcome `json:"outcomes"`
+	Status               string                             `json:"status"`
+	StartedAt            time.Time                          `json:"started_at"`
+	EndedAt              time.Time                          `json:"ended_at"`
+}
+
+type ChannelPredictionEndEventOutcome struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Color         string `json:"color"`
+	Users         int    `json:"users"`
+	ChannelPoints int    `json:"channel_points"`
+}
+
+type StreamOfflineEvent struct {
+	BroadcasterUserID    idstr.IDStr `json:"broadcaster_user_id"`
+	BroadcasterUserLogin string      `json:"broadcaster_user_login"`
+	BroadcasterUserName  string      `json:"broadcaster_user_name"`
+}
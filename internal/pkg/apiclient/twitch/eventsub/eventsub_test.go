@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/eventsub"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/idstr"
 	"gotest.tools/v3/assert"
 )
 
@@ -28,3 +30,114 @@ func TestUnmarshalBroken(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalChannelRaid(t *testing.T) {
+	t.Parallel()
+	const raw = `{"metadata":{"message_id":"befa7b53-d79d-478f-86b9-120f112b044e","message_type":"notification","message_timestamp":"2024-06-01T18:25:01.123456789Z","subscription_type":"channel.raid","subscription_version":"1"},"payload":{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"enabled","type":"channel.raid","version":"1","condition":{"from_broadcaster_user_id":"","to_broadcaster_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"896f2a0e-5ba9-430c-87ff-edfca4850479"},"created_at":"2024-06-01T18:00:00.000000000Z","cost":0},"event":{"from_broadcaster_user_id":"1234","from_broadcaster_user_login":"cool_user","from_broadcaster_user_name":"Cool_User","to_broadcaster_user_id":"1337","to_broadcaster_user_login":"cooler_user","to_broadcaster_user_name":"Cooler_User","viewers":9001}}}`
+
+	var msg eventsub.WebsocketMessage
+	assert.NilError(t, json.Unmarshal([]byte(raw), &msg))
+
+	notification, ok := msg.Payload.(*eventsub.NotificationPayload)
+	assert.Assert(t, ok)
+
+	condition, ok := notification.Subscription.Condition.(*eventsub.ChannelRaidSubscriptionCondition)
+	assert.Assert(t, ok)
+	assert.Equal(t, condition.ToBroadcasterUserID, idstr.IDStr(1337))
+
+	event, ok := notification.Event.(*eventsub.ChannelRaidEvent)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, event, &eventsub.ChannelRaidEvent{
+		FromBroadcasterUserID:    1234,
+		FromBroadcasterUserLogin: "cool_user",
+		FromBroadcasterUserName:  "Cool_User",
+		ToBroadcasterUserID:      1337,
+		ToBroadcasterUserLogin:   "cooler_user",
+		ToBroadcasterUserName:    "Cooler_User",
+		Viewers:                  9001,
+	})
+}
+
+func TestUnmarshalChannelPollEnd(t *testing.T) {
+	t.Parallel()
+	const raw = `{"metadata":{"message_id":"8f2b7a4e-4c0d-4a8e-9b4c-3c1c6d7e8f90","message_type":"notification","message_timestamp":"2024-06-01T18:27:01.123456789Z","subscription_type":"channel.poll.end","subscription_version":"1"},"payload":{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"enabled","type":"channel.poll.end","version":"1","condition":{"broadcaster_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"896f2a0e-5ba9-430c-87ff-edfca4850479"},"created_at":"2024-06-01T18:00:00.000000000Z","cost":0},"event":{"id":"1243456","broadcaster_user_id":"1337","broadcaster_user_login":"cool_user","broadcaster_user_name":"Cool_User","title":"Aren't shoes just really hard socks?","choices":[{"id":"123","title":"Blue","bits_votes":0,"channel_points_votes":0,"votes":10},{"id":"124","title":"Yellow","bits_votes":0,"channel_points_votes":0,"votes":14}],"bits_voting":{"is_enabled":false,"amount_per_vote":0},"channel_points_voting":{"is_enabled":false,"amount_per_vote":0},"status":"completed","started_at":"2024-06-01T18:25:00Z","ended_at":"2024-06-01T18:27:00Z"}}}`
+
+	var msg eventsub.WebsocketMessage
+	assert.NilError(t, json.Unmarshal([]byte(raw), &msg))
+
+	notification, ok := msg.Payload.(*eventsub.NotificationPayload)
+	assert.Assert(t, ok)
+
+	condition, ok := notification.Subscription.Condition.(*eventsub.BroadcasterSubscriptionCondition)
+	assert.Assert(t, ok)
+	assert.Equal(t, condition.BroadcasterUserID, idstr.IDStr(1337))
+
+	event, ok := notification.Event.(*eventsub.ChannelPollEndEvent)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, event, &eventsub.ChannelPollEndEvent{
+		ID:                   "1243456",
+		BroadcasterUserID:    1337,
+		BroadcasterUserLogin: "cool_user",
+		BroadcasterUserName:  "Cool_User",
+		Title:                "Aren't shoes just really hard socks?",
+		Choices: []eventsub.ChannelPollEndEventChoice{
+			{ID: "123", Title: "Blue", Votes: 10},
+			{ID: "124", Title: "Yellow", Votes: 14},
+		},
+		Status:    "completed",
+		StartedAt: time.Date(2024, 6, 1, 18, 25, 0, 0, time.UTC),
+		EndedAt:   time.Date(2024, 6, 1, 18, 27, 0, 0, time.UTC),
+	})
+}
+
+func TestUnmarshalChannelPredictionEnd(t *testing.T) {
+	t.Parallel()
+	const raw = `{"metadata":{"message_id":"9a2b7a4e-4c0d-4a8e-9b4c-3c1c6d7e8f90","message_type":"notification","message_timestamp":"2024-06-01T18:30:01.123456789Z","subscription_type":"channel.prediction.end","subscription_version":"1"},"payload":{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"enabled","type":"channel.prediction.end","version":"1","condition":{"broadcaster_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"896f2a0e-5ba9-430c-87ff-edfca4850479"},"created_at":"2024-06-01T18:00:00.000000000Z","cost":0},"event":{"id":"1243456","broadcaster_user_id":"1337","broadcaster_user_login":"cool_user","broadcaster_user_name":"Cool_User","title":"Aren't shoes just really hard socks?","winning_outcome_id":"12345","outcomes":[{"id":"12345","title":"Yeah!","color":"blue","users":2,"channel_points":15000,"top_predictors":[]},{"id":"22435","title":"No!","color":"pink","users":2,"channel_points":200,"top_predictors":[]}],"status":"resolved","started_at":"2024-06-01T18:25:00Z","ended_at":"2024-06-01T18:30:00Z"}}}`
+
+	var msg eventsub.WebsocketMessage
+	assert.NilError(t, json.Unmarshal([]byte(raw), &msg))
+
+	notification, ok := msg.Payload.(*eventsub.NotificationPayload)
+	assert.Assert(t, ok)
+
+	event, ok := notification.Event.(*eventsub.ChannelPredictionEndEvent)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, event, &eventsub.ChannelPredictionEndEvent{
+		ID:                   "1243456",
+		BroadcasterUserID:    1337,
+		BroadcasterUserLogin: "cool_user",
+		BroadcasterUserName:  "Cool_User",
+		Title:                "Aren't shoes just really hard socks?",
+		WinningOutcomeID:     "12345",
+		Outcomes: []eventsub.ChannelPredictionEndEventOutcome{
+			{ID: "12345", Title: "Yeah!", Color: "blue", Users: 2, ChannelPoints: 15000},
+			{ID: "22435", Title: "No!", Color: "pink", Users: 2, ChannelPoints: 200},
+		},
+		Status:    "resolved",
+		StartedAt: time.Date(2024, 6, 1, 18, 25, 0, 0, time.UTC),
+		EndedAt:   time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC),
+	})
+}
+
+func TestUnmarshalStreamOffline(t *testing.T) {
+	t.Parallel()
+	const raw = `{"metadata":{"message_id":"7c2b7a4e-4c0d-4a8e-9b4c-3c1c6d7e8f90","message_type":"notification","message_timestamp":"2024-06-01T20:00:01.123456789Z","subscription_type":"stream.offline","subscription_version":"1"},"payload":{"subscription":{"id":"f1c2a387-161a-49f9-a165-0f21d7a4e1c4","status":"enabled","type":"stream.offline","version":"1","condition":{"broadcaster_user_id":"1337"},"transport":{"method":"conduit","conduit_id":"896f2a0e-5ba9-430c-87ff-edfca4850479"},"created_at":"2024-06-01T18:00:00.000000000Z","cost":0},"event":{"broadcaster_user_id":"1337","broadcaster_user_login":"cool_user","broadcaster_user_name":"Cool_User"}}}`
+
+	var msg eventsub.WebsocketMessage
+	assert.NilError(t, json.Unmarshal([]byte(raw), &msg))
+
+	notification, ok := msg.Payload.(*eventsub.NotificationPayload)
+	assert.Assert(t, ok)
+
+	condition, ok := notification.Subscription.Condition.(*eventsub.BroadcasterSubscriptionCondition)
+	assert.Assert(t, ok)
+	assert.Equal(t, condition.BroadcasterUserID, idstr.IDStr(1337))
+
+	event, ok := notification.Event.(*eventsub.StreamOfflineEvent)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, event, &eventsub.StreamOfflineEvent{
+		BroadcasterUserID:    1337,
+		BroadcasterUserLogin: "cool_user",
+		BroadcasterUserName:  "Cool_User",
+	})
+}
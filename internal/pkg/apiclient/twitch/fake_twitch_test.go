@@ -149,9 +149,16 @@ func (f *fakeTwitch) route() {
 	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/users", "login=servererror", httpmock.NewStringResponder(500, ""))
 	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/users", "login=decodeerror", httpmock.NewStringResponder(200, "}"))
 	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/users", "login=requesterror", httpmock.NewErrorResponder(errTestBadRequest))
-	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/users", "id=1234", httpmock.NewStringResponder(200, `{"data": [{"id": 1234, "login": "foobar", "display_name": "Foobar"}]}`))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/users", "id=1234", httpmock.NewStringResponder(200, `{"data": [{"id": 1234, "login": "foobar", "display_name": "Foobar", "created_at": "2016-12-14T20:32:28Z"}]}`))
 
 	f.mt.RegisterResponder("GET", "https://api.twitch.tv/helix/moderation/moderators", httpmockx.ResponderFunc(f.helixModerationModerators))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/channels/followers", "broadcaster_id=1234&user_id=2", httpmock.NewStringResponder(200, `{"data": [{"user_id": "2", "user_login": "random", "user_name": "Random", "followed_at": "2024-05-01T12:00:00Z"}], "total": 10}`))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/channels/followers", "broadcaster_id=1234&user_id=3", httpmock.NewStringResponder(200, `{"data": [], "total": 10}`))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/channels/followers", "broadcaster_id=401&user_id=2", httpmock.NewStringResponder(401, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/channels/followers", "broadcaster_id=404&user_id=2", httpmock.NewStringResponder(404, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/channels/followers", "broadcaster_id=418&user_id=2", httpmock.NewStringResponder(418, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/channels/followers", "broadcaster_id=500&user_id=2", httpmock.NewStringResponder(500, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/channels/followers", "broadcaster_id=777&user_id=2", httpmock.NewErrorResponder(errTestBadRequest))
 	f.mt.RegisterResponder("GET", "https://api.twitch.tv/helix/moderation/channels", httpmockx.ResponderFunc(f.helixModerationChannels))
 
 	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/search/categories", "query=pubg", httpmock.NewStringResponder(200, `{"data": [{"id": "287491", "name": "PLAYERUNKNOWN's BATTLEGROUNDS"}, {"id": "58730284", "name": "PUBG MOBILE"}]}`))
@@ -215,9 +222,57 @@ func (f *fakeTwitch) route() {
 	f.mt.RegisterResponderWithQuery("DELETE", "https://api.twitch.tv/helix/moderation/chat", "broadcaster_id=500&moderator_id=3141", httpmock.NewStringResponder(500, ""))
 	f.mt.RegisterResponderWithQuery("DELETE", "https://api.twitch.tv/helix/moderation/chat", "broadcaster_id=777&moderator_id=3141", httpmock.NewErrorResponder(errTestBadRequest))
 
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/chat/shoutouts", "from_broadcaster_id=1234&to_broadcaster_id=5678&moderator_id=3141", httpmock.NewStringResponder(200, ``))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/chat/shoutouts", "from_broadcaster_id=401&to_broadcaster_id=5678&moderator_id=3141", httpmock.NewStringResponder(401, ``))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/chat/shoutouts", "from_broadcaster_id=404&to_broadcaster_id=5678&moderator_id=3141", httpmock.NewStringResponder(404, ``))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/chat/shoutouts", "from_broadcaster_id=418&to_broadcaster_id=5678&moderator_id=3141", httpmock.NewStringResponder(418, ``))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/chat/shoutouts", "from_broadcaster_id=500&to_broadcaster_id=5678&moderator_id=3141", httpmock.NewStringResponder(500, ""))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/chat/shoutouts", "from_broadcaster_id=777&to_broadcaster_id=5678&moderator_id=3141", httpmock.NewErrorResponder(errTestBadRequest))
+
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/raids", "from_broadcaster_id=1234&to_broadcaster_id=5678", httpmock.NewStringResponder(200, ``))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/raids", "from_broadcaster_id=401&to_broadcaster_id=5678", httpmock.NewStringResponder(401, ``))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/raids", "from_broadcaster_id=404&to_broadcaster_id=5678", httpmock.NewStringResponder(404, ``))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/raids", "from_broadcaster_id=418&to_broadcaster_id=5678", httpmock.NewStringResponder(418, ``))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/raids", "from_broadcaster_id=500&to_broadcaster_id=5678", httpmock.NewStringResponder(500, ""))
+	f.mt.RegisterResponderWithQuery("POST", "https://api.twitch.tv/helix/raids", "from_broadcaster_id=777&to_broadcaster_id=5678", httpmock.NewErrorResponder(errTestBadRequest))
+
+	f.mt.RegisterResponderWithQuery("DELETE", "https://api.twitch.tv/helix/raids", "broadcaster_id=1234", httpmock.NewStringResponder(200, ``))
+	f.mt.RegisterResponderWithQuery("DELETE", "https://api.twitch.tv/helix/raids", "broadcaster_id=401", httpmock.NewStringResponder(401, ``))
+	f.mt.RegisterResponderWithQuery("DELETE", "https://api.twitch.tv/helix/raids", "broadcaster_id=404", httpmock.NewStringResponder(404, ``))
+	f.mt.RegisterResponderWithQuery("DELETE", "https://api.twitch.tv/helix/raids", "broadcaster_id=418", httpmock.NewStringResponder(418, ``))
+	f.mt.RegisterResponderWithQuery("DELETE", "https://api.twitch.tv/helix/raids", "broadcaster_id=500", httpmock.NewStringResponder(500, ""))
+	f.mt.RegisterResponderWithQuery("DELETE", "https://api.twitch.tv/helix/raids", "broadcaster_id=777", httpmock.NewErrorResponder(errTestBadRequest))
+
+	f.mt.RegisterResponder("POST", `=~https://api.twitch.tv/helix/polls$`, httpmockx.ResponderFunc(f.helixPolls))
+	f.mt.RegisterResponder("PATCH", `=~https://api.twitch.tv/helix/polls$`, httpmockx.ResponderFunc(f.helixPolls))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/polls", "broadcaster_id=1234&first=1", httpmock.NewStringResponder(200, `{"data": [`+testPollJSON+`]}`))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/polls", "broadcaster_id=444&first=1", httpmock.NewStringResponder(200, `{"data": []}`))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/polls", "broadcaster_id=401&first=1", httpmock.NewStringResponder(401, ``))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/polls", "broadcaster_id=404&first=1", httpmock.NewStringResponder(404, ``))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/polls", "broadcaster_id=418&first=1", httpmock.NewStringResponder(418, ``))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/polls", "broadcaster_id=500&first=1", httpmock.NewStringResponder(500, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/polls", "broadcaster_id=777&first=1", httpmock.NewErrorResponder(errTestBadRequest))
+
+	f.mt.RegisterResponder("POST", `=~https://api.twitch.tv/helix/predictions$`, httpmockx.ResponderFunc(f.helixPredictions))
+	f.mt.RegisterResponder("PATCH", `=~https://api.twitch.tv/helix/predictions$`, httpmockx.ResponderFunc(f.helixPredictions))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/predictions", "broadcaster_id=1234&first=1", httpmock.NewStringResponder(200, `{"data": [`+testPredictionJSON+`]}`))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/predictions", "broadcaster_id=444&first=1", httpmock.NewStringResponder(200, `{"data": []}`))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/predictions", "broadcaster_id=401&first=1", httpmock.NewStringResponder(401, ``))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/predictions", "broadcaster_id=404&first=1", httpmock.NewStringResponder(404, ``))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/predictions", "broadcaster_id=418&first=1", httpmock.NewStringResponder(418, ``))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/predictions", "broadcaster_id=500&first=1", httpmock.NewStringResponder(500, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/predictions", "broadcaster_id=777&first=1", httpmock.NewErrorResponder(errTestBadRequest))
+
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/chat/settings", "broadcaster_id=1&moderator_id=123", httpmock.NewStringResponder(200, `{"data": [{"broadcaster_id": "1", "emote_mode": false, "follower_mode": true, "follower_mode_duration": 10, "moderator_id": "123", "non_moderator_chat_delay": false, "non_moderator_chat_delay_duration": null, "slow_mode": true, "slow_mode_wait_time": 30, "subscriber_mode": false, "unique_chat_mode": false}]}`))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/chat/settings", "broadcaster_id=401&moderator_id=123", httpmock.NewStringResponder(401, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/chat/settings", "broadcaster_id=404&moderator_id=123", httpmock.NewStringResponder(404, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/chat/settings", "broadcaster_id=418&moderator_id=123", httpmock.NewStringResponder(418, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/chat/settings", "broadcaster_id=500&moderator_id=123", httpmock.NewStringResponder(500, ""))
+	f.mt.RegisterResponderWithQuery("GET", "https://api.twitch.tv/helix/chat/settings", "broadcaster_id=777&moderator_id=123", httpmock.NewErrorResponder(errTestBadRequest))
 	f.mt.RegisterResponder("PATCH", `=~https://api.twitch.tv/helix/chat/settings$`, httpmockx.ResponderFunc(f.helixChatSettings))
 	f.mt.RegisterResponder("POST", `=~https://api.twitch.tv/helix/chat/announcements$`, httpmockx.ResponderFunc(f.helixChatAnnouncements))
 	f.mt.RegisterResponder("POST", `=~https://api.twitch.tv/helix/chat/messages$`, httpmockx.ResponderFunc(f.helixChatMessages))
+	f.mt.RegisterResponder("POST", `=~https://api.twitch.tv/helix/whispers\?`, httpmockx.ResponderFunc(f.helixWhispers))
 
 	f.mt.RegisterResponder("POST", "https://api.igdb.com/v4/games", httpmockx.ResponderFunc(f.igdbGames))
 
@@ -852,9 +907,10 @@ func (f *fakeTwitch) helixChatMessages(req *http.Request) (*http.Response, error
 	f.assertNilError(err)
 
 	var body struct {
-		BroadcasterID idstr.IDStr `json:"broadcaster_id"`
-		SenderID      idstr.IDStr `json:"sender_id"`
-		Message       string      `json:"message"`
+		BroadcasterID        idstr.IDStr `json:"broadcaster_id"`
+		SenderID             idstr.IDStr `json:"sender_id"`
+		Message              string      `json:"message"`
+		ReplyParentMessageID *string     `json:"reply_parent_message_id"`
 	}
 
 	f.assertNilError(jsonx.DecodeSingle(bytes.NewReader(bodyBytes), &body))
@@ -862,8 +918,14 @@ func (f *fakeTwitch) helixChatMessages(req *http.Request) (*http.Response, error
 	switch {
 	case body.BroadcasterID == 1 && body.SenderID == 123:
 		f.assertEqual(body.Message, "Some announcement!")
+		f.assert(body.ReplyParentMessageID == nil)
 	case body.BroadcasterID == 1 && body.SenderID == 124:
 		f.assertEqual(body.Message, strings.Repeat("A", 500))
+	case body.BroadcasterID == 1 && body.SenderID == 126:
+		f.assertEqual(body.Message, "A"+strings.Repeat("é", 249))
+	case body.BroadcasterID == 1 && body.SenderID == 125:
+		f.assertEqual(body.Message, "Some reply!")
+		f.assert(body.ReplyParentMessageID != nil && *body.ReplyParentMessageID == "parent-id")
 	case body.BroadcasterID == 404:
 		return httpmock.NewStringResponse(404, ""), nil
 	case body.BroadcasterID == 401:
@@ -879,6 +941,143 @@ func (f *fakeTwitch) helixChatMessages(req *http.Request) (*http.Response, error
 	return httpmock.NewStringResponse(200, "{}"), nil
 }
 
+func (f *fakeTwitch) helixWhispers(req *http.Request) (*http.Response, error) {
+	f.assertEqual(req.Method, "POST")
+	f.checkHeaders(req)
+
+	auth := req.Header.Get("Authorization")
+	f.assert(strings.HasPrefix(auth, "Bearer "))
+
+	q := req.URL.Query()
+	f.assertEqual(q.Get("from_user_id"), "123")
+
+	bodyBytes, err := io.ReadAll(req.Body)
+	f.assertNilError(err)
+
+	var body struct {
+		Message string `json:"message"`
+	}
+
+	f.assertNilError(jsonx.DecodeSingle(bytes.NewReader(bodyBytes), &body))
+
+	switch q.Get("to_user_id") {
+	case "1":
+		f.assertEqual(body.Message, "Some whisper!")
+	case "2":
+		f.assertEqual(body.Message, strings.Repeat("A", 500))
+	case "404":
+		return httpmock.NewStringResponse(404, ""), nil
+	case "401":
+		return httpmock.NewStringResponse(401, ""), nil
+	case "418":
+		return httpmock.NewStringResponse(418, ""), nil
+	case "500":
+		return httpmock.NewStringResponse(500, ""), nil
+	default:
+		return nil, errTestBadRequest
+	}
+
+	return httpmock.NewStringResponse(204, ""), nil
+}
+
+const (
+	testPollJSON       = `{"id": "poll-id", "title": "Best fruit?", "choices": [{"id": "c1", "title": "Apple", "votes": 3}, {"id": "c2", "title": "Banana", "votes": 5}], "status": "ACTIVE", "duration": 120, "started_at": "2026-10-01T03:11:00Z"}`
+	testPredictionJSON = `{"id": "prediction-id", "title": "Will we win?", "winning_outcome_id": "", "outcomes": [{"id": "o1", "title": "Yes", "users": 2, "channel_points": 500, "color": "BLUE"}, {"id": "o2", "title": "No", "users": 1, "channel_points": 100, "color": "PINK"}], "prediction_window": 60, "status": "ACTIVE", "created_at": "2026-10-01T03:11:00Z"}`
+)
+
+func (f *fakeTwitch) helixPolls(req *http.Request) (*http.Response, error) {
+	f.checkHeaders(req)
+
+	auth := req.Header.Get("Authorization")
+	f.assert(strings.HasPrefix(auth, "Bearer "))
+
+	bodyBytes, err := io.ReadAll(req.Body)
+	f.assertNilError(err)
+
+	var body struct {
+		BroadcasterID idstr.IDStr `json:"broadcaster_id"`
+		Title         string      `json:"title"`
+		Choices       []struct {
+			Title string `json:"title"`
+		} `json:"choices"`
+		Duration int    `json:"duration"`
+		ID       string `json:"id"`
+		Status   string `json:"status"`
+	}
+
+	f.assertNilError(jsonx.DecodeSingle(bytes.NewReader(bodyBytes), &body))
+
+	switch {
+	case body.BroadcasterID == 1234 && req.Method == "POST":
+		f.assertEqual(body.Title, "Best fruit?")
+		f.assertEqual(len(body.Choices), 2)
+		f.assertEqual(body.Duration, 120)
+	case body.BroadcasterID == 1234 && req.Method == "PATCH":
+		f.assertEqual(body.ID, "poll-id")
+		f.assertEqual(body.Status, "TERMINATED")
+	case body.BroadcasterID == 404:
+		return httpmock.NewStringResponse(404, ""), nil
+	case body.BroadcasterID == 401:
+		return httpmock.NewStringResponse(401, ""), nil
+	case body.BroadcasterID == 418:
+		return httpmock.NewStringResponse(418, ""), nil
+	case body.BroadcasterID == 500:
+		return httpmock.NewStringResponse(500, ""), nil
+	default:
+		return nil, errTestBadRequest
+	}
+
+	return httpmock.NewStringResponse(200, `{"data": [`+testPollJSON+`]}`), nil
+}
+
+func (f *fakeTwitch) helixPredictions(req *http.Request) (*http.Response, error) {
+	f.checkHeaders(req)
+
+	auth := req.Header.Get("Authorization")
+	f.assert(strings.HasPrefix(auth, "Bearer "))
+
+	bodyBytes, err := io.ReadAll(req.Body)
+	f.assertNilError(err)
+
+	var body struct {
+		BroadcasterID idstr.IDStr `json:"broadcaster_id"`
+		Title         string      `json:"title"`
+		Outcomes      []struct {
+			Title string `json:"title"`
+		} `json:"outcomes"`
+		PredictionWindow int    `json:"prediction_window"`
+		ID               string `json:"id"`
+		Status           string `json:"status"`
+		WinningOutcomeID string `json:"winning_outcome_id"`
+	}
+
+	f.assertNilError(jsonx.DecodeSingle(bytes.NewReader(bodyBytes), &body))
+
+	switch {
+	case body.BroadcasterID == 1234 && req.Method == "POST":
+		f.assertEqual(body.Title, "Will we win?")
+		f.assertEqual(len(body.Outcomes), 2)
+		f.assertEqual(body.PredictionWindow, 60)
+	case body.BroadcasterID == 1234 && req.Method == "PATCH":
+		f.assertEqual(body.ID, "prediction-id")
+		if body.Status == twitch.PredictionResolved {
+			f.assertEqual(body.WinningOutcomeID, "o1")
+		}
+	case body.BroadcasterID == 404:
+		return httpmock.NewStringResponse(404, ""), nil
+	case body.BroadcasterID == 401:
+		return httpmock.NewStringResponse(401, ""), nil
+	case body.BroadcasterID == 418:
+		return httpmock.NewStringResponse(418, ""), nil
+	case body.BroadcasterID == 500:
+		return httpmock.NewStringResponse(500, ""), nil
+	default:
+		return nil, errTestBadRequest
+	}
+
+	return httpmock.NewStringResponse(200, `{"data": [`+testPredictionJSON+`]}`), nil
+}
+
 func (f *fakeTwitch) dumpAndFail(req *http.Request, dumped []byte) (*http.Response, error) {
 	f.t.Helper()
 	if len(dumped) == 0 {
@@ -6,7 +6,7 @@ import (
 )
 
 // IDStr is an int64 that is represented as a string in JSON, but can be
-// parsed as either a string or a raw integer.
+// parsed as either a string or a raw integer. An empty string is parsed as zero.
 //
 // https://stackoverflow.com/a/31625512
 type IDStr int64
@@ -20,6 +20,10 @@ func (v IDStr) MarshalJSON() ([]byte, error) {
 func (v *IDStr) UnmarshalJSON(data []byte) error {
 	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
 		data = data[1 : len(data)-1]
+		if len(data) == 0 {
+			*v = 0
+			return nil
+		}
 	}
 	return json.Unmarshal(data, (*int64)(v)) //nolint:wrapcheck
 }
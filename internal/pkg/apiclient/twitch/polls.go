@@ -0,0 +1,261 @@
+package twitch
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/pkg/apiclient"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/idstr"
+	"golang.org/x/oauth2"
+)
+
+// Prediction statuses which may be passed to EndPrediction.
+const (
+	PredictionLocked   = "LOCKED"
+	PredictionResolved = "RESOLVED"
+	PredictionCanceled = "CANCELED"
+)
+
+// Poll is a channel poll.
+type Poll struct {
+	ID        string        `json:"id"`
+	Title     string        `json:"title"`
+	Choices   []*PollChoice `json:"choices"`
+	Status    string        `json:"status"`
+	Duration  int           `json:"duration"`
+	StartedAt time.Time     `json:"started_at"`
+}
+
+// PollChoice is a choice in a poll.
+type PollChoice struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Votes int    `json:"votes"`
+}
+
+// Prediction is a channel prediction.
+type Prediction struct {
+	ID               string               `json:"id"`
+	Title            string               `json:"title"`
+	WinningOutcomeID string               `json:"winning_outcome_id"`
+	Outcomes         []*PredictionOutcome `json:"outcomes"`
+	PredictionWindow int                  `json:"prediction_window"`
+	Status           string               `json:"status"`
+	CreatedAt        time.Time            `json:"created_at"`
+}
+
+// PredictionOutcome is a possible outcome of a prediction.
+type PredictionOutcome struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	Users         int    `json:"users"`
+	ChannelPoints int    `json:"channel_points"`
+	Color         string `json:"color"`
+}
+
+type titleOnly struct {
+	Title string `json:"title"`
+}
+
+func titles(v []string) []titleOnly {
+	out := make([]titleOnly, len(v))
+	for i, title := range v {
+		out[i].Title = title
+	}
+	return out
+}
+
+// CreatePoll starts a poll in the broadcaster's channel. Polls must have
+// between two and five choices.
+//
+// POST https://api.twitch.tv/helix/polls
+func (t *Twitch) CreatePoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, choices []string, duration time.Duration) (poll *Poll, newToken *oauth2.Token, err error) {
+	if title == "" || len(choices) < 2 || len(choices) > 5 {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	if userToken == nil || userToken.AccessToken == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, userToken, setToken(&newToken))
+
+	body := &struct {
+		BroadcasterID idstr.IDStr `json:"broadcaster_id"`
+		Title         string      `json:"title"`
+		Choices       []titleOnly `json:"choices"`
+		Duration      int         `json:"duration"`
+	}{
+		BroadcasterID: idstr.IDStr(broadcasterID),
+		Title:         title,
+		Choices:       titles(choices),
+		Duration:      int(duration.Seconds()),
+	}
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/polls")
+	if err != nil {
+		return nil, newToken, err
+	}
+
+	poll, err = fetchFirstFromList[*Poll](ctx, req.BodyJSON(body).Post())
+	return poll, newToken, err
+}
+
+// GetLatestPoll gets the most recent poll in the broadcaster's channel.
+//
+// GET https://api.twitch.tv/helix/polls
+func (t *Twitch) GetLatestPoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (poll *Poll, newToken *oauth2.Token, err error) {
+	if userToken == nil || userToken.AccessToken == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, userToken, setToken(&newToken))
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/polls")
+	if err != nil {
+		return nil, newToken, err
+	}
+	req.Param("broadcaster_id", strconv.FormatInt(broadcasterID, 10))
+	req.Param("first", "1")
+
+	poll, err = fetchFirstFromList[*Poll](ctx, req)
+	return poll, newToken, err
+}
+
+// EndPoll ends an active poll, leaving its results visible.
+//
+// PATCH https://api.twitch.tv/helix/polls
+func (t *Twitch) EndPoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string) (poll *Poll, newToken *oauth2.Token, err error) {
+	if id == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	if userToken == nil || userToken.AccessToken == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, userToken, setToken(&newToken))
+
+	body := &struct {
+		BroadcasterID idstr.IDStr `json:"broadcaster_id"`
+		ID            string      `json:"id"`
+		Status        string      `json:"status"`
+	}{
+		BroadcasterID: idstr.IDStr(broadcasterID),
+		ID:            id,
+		Status:        "TERMINATED",
+	}
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/polls")
+	if err != nil {
+		return nil, newToken, err
+	}
+
+	poll, err = fetchFirstFromList[*Poll](ctx, req.BodyJSON(body).Patch())
+	return poll, newToken, err
+}
+
+// CreatePrediction starts a prediction in the broadcaster's channel.
+// Predictions must have between two and ten outcomes.
+//
+// POST https://api.twitch.tv/helix/predictions
+func (t *Twitch) CreatePrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, outcomes []string, window time.Duration) (prediction *Prediction, newToken *oauth2.Token, err error) {
+	if title == "" || len(outcomes) < 2 || len(outcomes) > 10 {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	if userToken == nil || userToken.AccessToken == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, userToken, setToken(&newToken))
+
+	body := &struct {
+		BroadcasterID    idstr.IDStr `json:"broadcaster_id"`
+		Title            string      `json:"title"`
+		Outcomes         []titleOnly `json:"outcomes"`
+		PredictionWindow int         `json:"prediction_window"`
+	}{
+		BroadcasterID:    idstr.IDStr(broadcasterID),
+		Title:            title,
+		Outcomes:         titles(outcomes),
+		PredictionWindow: int(window.Seconds()),
+	}
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/predictions")
+	if err != nil {
+		return nil, newToken, err
+	}
+
+	prediction, err = fetchFirstFromList[*Prediction](ctx, req.BodyJSON(body).Post())
+	return prediction, newToken, err
+}
+
+// GetLatestPrediction gets the most recent prediction in the broadcaster's channel.
+//
+// GET https://api.twitch.tv/helix/predictions
+func (t *Twitch) GetLatestPrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (prediction *Prediction, newToken *oauth2.Token, err error) {
+	if userToken == nil || userToken.AccessToken == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, userToken, setToken(&newToken))
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/predictions")
+	if err != nil {
+		return nil, newToken, err
+	}
+	req.Param("broadcaster_id", strconv.FormatInt(broadcasterID, 10))
+	req.Param("first", "1")
+
+	prediction, err = fetchFirstFromList[*Prediction](ctx, req)
+	return prediction, newToken, err
+}
+
+// EndPrediction locks, resolves, or cancels a prediction. The winning outcome
+// must be provided when resolving.
+//
+// PATCH https://api.twitch.tv/helix/predictions
+func (t *Twitch) EndPrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string, status string, winningOutcomeID string) (prediction *Prediction, newToken *oauth2.Token, err error) {
+	if id == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	switch status {
+	case PredictionLocked, PredictionCanceled:
+	case PredictionResolved:
+		if winningOutcomeID == "" {
+			return nil, nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+		}
+	default:
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusBadRequest)
+	}
+
+	if userToken == nil || userToken.AccessToken == "" {
+		return nil, nil, apiclient.NewStatusError("twitch", http.StatusUnauthorized)
+	}
+
+	cli := t.clientForUser(ctx, userToken, setToken(&newToken))
+
+	body := &struct {
+		BroadcasterID    idstr.IDStr `json:"broadcaster_id"`
+		ID               string      `json:"id"`
+		Status           string      `json:"status"`
+		WinningOutcomeID string      `json:"winning_outcome_id,omitempty"`
+	}{
+		BroadcasterID:    idstr.IDStr(broadcasterID),
+		ID:               id,
+		Status:           status,
+		WinningOutcomeID: winningOutcomeID,
+	}
+
+	req, err := cli.NewRequest(ctx, helixRoot+"/predictions")
+	if err != nil {
+		return nil, newToken, err
+	}
+
+	prediction, err = fetchFirstFromList[*Prediction](ctx, req.BodyJSON(body).Patch())
+	return prediction, newToken, err
+}
@@ -0,0 +1,315 @@
+package twitch_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
+	"golang.org/x/oauth2"
+	"gotest.tools/v3/assert"
+)
+
+func TestCreatePoll(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	poll, newToken, err := tw.CreatePoll(ctx, broadcasterID, tok, "Best fruit?", []string{"Apple", "Banana"}, 2*time.Minute)
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+	assert.Equal(t, poll.ID, "poll-id")
+	assert.Equal(t, len(poll.Choices), 2)
+	assert.Equal(t, poll.Choices[1].Votes, 5)
+}
+
+func TestCreatePollBadParameters(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+	choices := []string{"Apple", "Banana"}
+
+	_, _, err := tw.CreatePoll(ctx, broadcasterID, tok, "", choices, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.CreatePoll(ctx, broadcasterID, tok, "Best fruit?", []string{"Apple"}, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.CreatePoll(ctx, broadcasterID, tok, "Best fruit?", []string{"a", "b", "c", "d", "e", "f"}, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.CreatePoll(ctx, broadcasterID, nil, "Best fruit?", choices, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	_, _, err = tw.CreatePoll(ctx, broadcasterID, &oauth2.Token{}, "Best fruit?", choices, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestCreatePollErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+	choices := []string{"Apple", "Banana"}
+
+	tok := tokFor(ctx, t, tw, ft, 777)
+	_, _, err := tw.CreatePoll(ctx, 777, tok, "Best fruit?", choices, time.Minute)
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		_, newToken, err := tw.CreatePoll(ctx, id, tok, "Best fruit?", choices, time.Minute)
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestGetLatestPoll(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	poll, newToken, err := tw.GetLatestPoll(ctx, broadcasterID, tok)
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+	assert.Equal(t, poll.Title, "Best fruit?")
+	assert.Equal(t, poll.Status, "ACTIVE")
+
+	tok = tokFor(ctx, t, tw, ft, 444)
+	_, _, err = tw.GetLatestPoll(ctx, 444, tok)
+	assert.Error(t, err, "twitch: unexpected status: 404")
+
+	_, _, err = tw.GetLatestPoll(ctx, broadcasterID, nil)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestGetLatestPollErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	tok := tokFor(ctx, t, tw, ft, 777)
+	_, _, err := tw.GetLatestPoll(ctx, 777, tok)
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		_, newToken, err := tw.GetLatestPoll(ctx, id, tok)
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestEndPoll(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	poll, newToken, err := tw.EndPoll(ctx, broadcasterID, tok, "poll-id")
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+	assert.Equal(t, poll.ID, "poll-id")
+
+	_, _, err = tw.EndPoll(ctx, broadcasterID, tok, "")
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.EndPoll(ctx, broadcasterID, nil, "poll-id")
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		_, newToken, err := tw.EndPoll(ctx, id, tok, "poll-id")
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestCreatePrediction(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	prediction, newToken, err := tw.CreatePrediction(ctx, broadcasterID, tok, "Will we win?", []string{"Yes", "No"}, time.Minute)
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+	assert.Equal(t, prediction.ID, "prediction-id")
+	assert.Equal(t, len(prediction.Outcomes), 2)
+	assert.Equal(t, prediction.Outcomes[0].ChannelPoints, 500)
+}
+
+func TestCreatePredictionBadParameters(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+	outcomes := []string{"Yes", "No"}
+
+	_, _, err := tw.CreatePrediction(ctx, broadcasterID, tok, "", outcomes, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.CreatePrediction(ctx, broadcasterID, tok, "Will we win?", []string{"Yes"}, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.CreatePrediction(ctx, broadcasterID, nil, "Will we win?", outcomes, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	_, _, err = tw.CreatePrediction(ctx, broadcasterID, &oauth2.Token{}, "Will we win?", outcomes, time.Minute)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestCreatePredictionErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+	outcomes := []string{"Yes", "No"}
+
+	tok := tokFor(ctx, t, tw, ft, 777)
+	_, _, err := tw.CreatePrediction(ctx, 777, tok, "Will we win?", outcomes, time.Minute)
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		_, newToken, err := tw.CreatePrediction(ctx, id, tok, "Will we win?", outcomes, time.Minute)
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestGetLatestPrediction(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	prediction, newToken, err := tw.GetLatestPrediction(ctx, broadcasterID, tok)
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+	assert.Equal(t, prediction.Title, "Will we win?")
+
+	tok = tokFor(ctx, t, tw, ft, 444)
+	_, _, err = tw.GetLatestPrediction(ctx, 444, tok)
+	assert.Error(t, err, "twitch: unexpected status: 404")
+
+	_, _, err = tw.GetLatestPrediction(ctx, broadcasterID, nil)
+	assert.Error(t, err, "twitch: unexpected status: 401")
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		_, newToken, err := tw.GetLatestPrediction(ctx, id, tok)
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
+
+func TestEndPrediction(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	_, newToken, err := tw.EndPrediction(ctx, broadcasterID, tok, "prediction-id", twitch.PredictionLocked, "")
+	assert.NilError(t, err)
+	assert.Assert(t, newToken == nil)
+
+	_, _, err = tw.EndPrediction(ctx, broadcasterID, tok, "prediction-id", twitch.PredictionResolved, "o1")
+	assert.NilError(t, err)
+
+	_, _, err = tw.EndPrediction(ctx, broadcasterID, tok, "prediction-id", twitch.PredictionCanceled, "")
+	assert.NilError(t, err)
+}
+
+func TestEndPredictionBadParameters(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	const broadcasterID = 1234
+	tok := tokFor(ctx, t, tw, ft, broadcasterID)
+
+	_, _, err := tw.EndPrediction(ctx, broadcasterID, tok, "", twitch.PredictionLocked, "")
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.EndPrediction(ctx, broadcasterID, tok, "prediction-id", twitch.PredictionResolved, "")
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.EndPrediction(ctx, broadcasterID, tok, "prediction-id", "ACTIVE", "")
+	assert.Error(t, err, "twitch: unexpected status: 400")
+
+	_, _, err = tw.EndPrediction(ctx, broadcasterID, nil, "prediction-id", twitch.PredictionLocked, "")
+	assert.Error(t, err, "twitch: unexpected status: 401")
+}
+
+func TestEndPredictionErrors(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	ft, tw := createTester(t)
+
+	tok := tokFor(ctx, t, tw, ft, 777)
+	_, _, err := tw.EndPrediction(ctx, 777, tok, "prediction-id", twitch.PredictionLocked, "")
+	assert.ErrorContains(t, err, errTestBadRequest.Error())
+
+	for status := range expectedErrors {
+		id := int64(status)
+		tok := tokFor(ctx, t, tw, ft, id)
+
+		_, newToken, err := tw.EndPrediction(ctx, id, tok, "prediction-id", twitch.PredictionLocked, "")
+		assert.ErrorContains(t, err, fmt.Sprintf("status: %d", status))
+		assert.Assert(t, newToken == nil)
+	}
+}
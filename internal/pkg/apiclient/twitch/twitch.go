@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/pkg/apiclient"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/eventsub"
@@ -27,6 +28,9 @@ var UserScopes = []string{
 	"channel:read:subscriptions", // Helix: get broadcaster subscriptions
 	"channel:read:editors",       // Helix: get channel editors
 	"channel:manage:broadcast",   // Helix: modify channel information
+	"channel:manage:raids",       // Helix: start and cancel raids
+	"channel:manage:polls",       // Helix: create and end polls
+	"channel:manage:predictions", // Helix: create and end predictions
 	"channel:bot",                // Chat: This token is a bot in the user's channel.
 }
 
@@ -42,6 +46,8 @@ var BotScopes = slices.Concat(UserScopes, []string{
 	"moderator:manage:chat_messages", // Helix: Delete messages
 	"moderator:read:chat_settings",   // Helix: Read chat settings, like emote only, slow mode
 	"moderator:manage:chat_settings", // Helix: Change chat settings, like emote only, slow mode
+	"moderator:manage:shoutouts",     // Helix: Send shoutouts
+	"moderator:read:followers",       // Helix: Get channel followers
 	"user:manage:chat_color",         // Helix: Change bot user color
 	"user:bot",                       // Chat: This is a bot
 	"user:read:chat",                 // Chat: Read chat via EventSub
@@ -79,15 +85,27 @@ type API interface {
 	GetStreamByUserID(ctx context.Context, id int64) (*Stream, error)
 	GetStreamByUsername(ctx context.Context, username string) (*Stream, error)
 	GetChannelByID(ctx context.Context, id int64) (*Channel, error)
+	GetChannelFollower(ctx context.Context, broadcasterID int64, userID int64, modToken *oauth2.Token) (follower *ChannelFollower, newToken *oauth2.Token, err error)
 	Ban(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, req *BanRequest) (newToken *oauth2.Token, err error)
 	Unban(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, userID int64) (newToken *oauth2.Token, err error)
+	GetChatSettings(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (settings *ChatSettings, newToken *oauth2.Token, err error)
 	UpdateChatSettings(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, patch *ChatSettingsPatch) (newToken *oauth2.Token, err error)
 	SetChatColor(ctx context.Context, userID int64, userToken *oauth2.Token, color string) (newToken *oauth2.Token, err error)
 	DeleteChatMessage(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, id string) (newToken *oauth2.Token, err error)
 	ClearChat(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (newToken *oauth2.Token, err error)
 	Announce(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, message string, color string) (newToken *oauth2.Token, err error)
 	GetModeratedChannels(ctx context.Context, modID int64, modToken *oauth2.Token) (channels []*ModeratedChannel, newToken *oauth2.Token, err error)
-	SendChatMessage(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string) (newToken *oauth2.Token, err error)
+	SendChatMessage(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string, replyParentID string) (newToken *oauth2.Token, err error)
+	SendWhisper(ctx context.Context, fromID int64, toID int64, fromToken *oauth2.Token, message string) (newToken *oauth2.Token, err error)
+	SendShoutout(ctx context.Context, broadcasterID int64, toID int64, modID int64, modToken *oauth2.Token) (newToken *oauth2.Token, err error)
+	StartRaid(ctx context.Context, broadcasterID int64, toID int64, userToken *oauth2.Token) (newToken *oauth2.Token, err error)
+	CancelRaid(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (newToken *oauth2.Token, err error)
+	CreatePoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, choices []string, duration time.Duration) (poll *Poll, newToken *oauth2.Token, err error)
+	GetLatestPoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (poll *Poll, newToken *oauth2.Token, err error)
+	EndPoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string) (poll *Poll, newToken *oauth2.Token, err error)
+	CreatePrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, outcomes []string, window time.Duration) (prediction *Prediction, newToken *oauth2.Token, err error)
+	GetLatestPrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (prediction *Prediction, newToken *oauth2.Token, err error)
+	EndPrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string, status string, winningOutcomeID string) (prediction *Prediction, newToken *oauth2.Token, err error)
 	GetConduits(ctx context.Context) ([]*Conduit, error)
 	CreateConduit(ctx context.Context, shardCount int) (*Conduit, error)
 	UpdateConduit(ctx context.Context, id string, shardCount int) (*Conduit, error)
@@ -96,6 +114,8 @@ type API interface {
 	GetSubscriptions(ctx context.Context) ([]*eventsub.Subscription, error)
 	DeleteSubscription(ctx context.Context, id string) error
 	CreateChatSubscription(ctx context.Context, conduitID string, broadcasterID int64, botID int64) error
+	CreateRaidSubscription(ctx context.Context, conduitID string, broadcasterID int64) error
+	CreateBroadcasterSubscription(ctx context.Context, conduitID string, subscriptionType string, broadcasterID int64) error
 
 	// IGDB
 	GetGameLinks(ctx context.Context, twitchCategory int64) ([]GameLink, error)
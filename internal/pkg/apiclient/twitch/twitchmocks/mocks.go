@@ -6,6 +6,7 @@ package twitchmocks
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/eventsub"
@@ -31,15 +32,30 @@ var _ twitch.API = &APIMock{}
 //			BanFunc: func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, req *twitch.BanRequest) (*oauth2.Token, error) {
 //				panic("mock out the Ban method")
 //			},
+//			CancelRaidFunc: func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*oauth2.Token, error) {
+//				panic("mock out the CancelRaid method")
+//			},
 //			ClearChatFunc: func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (*oauth2.Token, error) {
 //				panic("mock out the ClearChat method")
 //			},
+//			CreateBroadcasterSubscriptionFunc: func(ctx context.Context, conduitID string, subscriptionType string, broadcasterID int64) error {
+//				panic("mock out the CreateBroadcasterSubscription method")
+//			},
 //			CreateChatSubscriptionFunc: func(ctx context.Context, conduitID string, broadcasterID int64, botID int64) error {
 //				panic("mock out the CreateChatSubscription method")
 //			},
 //			CreateConduitFunc: func(ctx context.Context, shardCount int) (*twitch.Conduit, error) {
 //				panic("mock out the CreateConduit method")
 //			},
+//			CreatePollFunc: func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, choices []string, duration time.Duration) (*twitch.Poll, *oauth2.Token, error) {
+//				panic("mock out the CreatePoll method")
+//			},
+//			CreatePredictionFunc: func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, outcomes []string, window time.Duration) (*twitch.Prediction, *oauth2.Token, error) {
+//				panic("mock out the CreatePrediction method")
+//			},
+//			CreateRaidSubscriptionFunc: func(ctx context.Context, conduitID string, broadcasterID int64) error {
+//				panic("mock out the CreateRaidSubscription method")
+//			},
 //			DeleteChatMessageFunc: func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, id string) (*oauth2.Token, error) {
 //				panic("mock out the DeleteChatMessage method")
 //			},
@@ -49,15 +65,27 @@ var _ twitch.API = &APIMock{}
 //			DeleteSubscriptionFunc: func(ctx context.Context, id string) error {
 //				panic("mock out the DeleteSubscription method")
 //			},
+//			EndPollFunc: func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string) (*twitch.Poll, *oauth2.Token, error) {
+//				panic("mock out the EndPoll method")
+//			},
+//			EndPredictionFunc: func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string, status string, winningOutcomeID string) (*twitch.Prediction, *oauth2.Token, error) {
+//				panic("mock out the EndPrediction method")
+//			},
 //			ExchangeFunc: func(ctx context.Context, code string) (*oauth2.Token, error) {
 //				panic("mock out the Exchange method")
 //			},
 //			GetChannelByIDFunc: func(ctx context.Context, id int64) (*twitch.Channel, error) {
 //				panic("mock out the GetChannelByID method")
 //			},
+//			GetChannelFollowerFunc: func(ctx context.Context, broadcasterID int64, userID int64, modToken *oauth2.Token) (*twitch.ChannelFollower, *oauth2.Token, error) {
+//				panic("mock out the GetChannelFollower method")
+//			},
 //			GetChannelModeratorsFunc: func(ctx context.Context, id int64, userToken *oauth2.Token) ([]*twitch.ChannelModerator, *oauth2.Token, error) {
 //				panic("mock out the GetChannelModerators method")
 //			},
+//			GetChatSettingsFunc: func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (*twitch.ChatSettings, *oauth2.Token, error) {
+//				panic("mock out the GetChatSettings method")
+//			},
 //			GetConduitsFunc: func(ctx context.Context) ([]*twitch.Conduit, error) {
 //				panic("mock out the GetConduits method")
 //			},
@@ -70,6 +98,12 @@ var _ twitch.API = &APIMock{}
 //			GetGameLinksFunc: func(ctx context.Context, twitchCategory int64) ([]twitch.GameLink, error) {
 //				panic("mock out the GetGameLinks method")
 //			},
+//			GetLatestPollFunc: func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*twitch.Poll, *oauth2.Token, error) {
+//				panic("mock out the GetLatestPoll method")
+//			},
+//			GetLatestPredictionFunc: func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*twitch.Prediction, *oauth2.Token, error) {
+//				panic("mock out the GetLatestPrediction method")
+//			},
 //			GetModeratedChannelsFunc: func(ctx context.Context, modID int64, modToken *oauth2.Token) ([]*twitch.ModeratedChannel, *oauth2.Token, error) {
 //				panic("mock out the GetModeratedChannels method")
 //			},
@@ -97,12 +131,21 @@ var _ twitch.API = &APIMock{}
 //			SearchCategoriesFunc: func(ctx context.Context, query string) ([]*twitch.Category, error) {
 //				panic("mock out the SearchCategories method")
 //			},
-//			SendChatMessageFunc: func(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string) (*oauth2.Token, error) {
+//			SendChatMessageFunc: func(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
 //				panic("mock out the SendChatMessage method")
 //			},
+//			SendShoutoutFunc: func(ctx context.Context, broadcasterID int64, toID int64, modID int64, modToken *oauth2.Token) (*oauth2.Token, error) {
+//				panic("mock out the SendShoutout method")
+//			},
+//			SendWhisperFunc: func(ctx context.Context, fromID int64, toID int64, fromToken *oauth2.Token, message string) (*oauth2.Token, error) {
+//				panic("mock out the SendWhisper method")
+//			},
 //			SetChatColorFunc: func(ctx context.Context, userID int64, userToken *oauth2.Token, color string) (*oauth2.Token, error) {
 //				panic("mock out the SetChatColor method")
 //			},
+//			StartRaidFunc: func(ctx context.Context, broadcasterID int64, toID int64, userToken *oauth2.Token) (*oauth2.Token, error) {
+//				panic("mock out the StartRaid method")
+//			},
 //			UnbanFunc: func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, userID int64) (*oauth2.Token, error) {
 //				panic("mock out the Unban method")
 //			},
@@ -134,15 +177,30 @@ type APIMock struct {
 	// BanFunc mocks the Ban method.
 	BanFunc func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, req *twitch.BanRequest) (*oauth2.Token, error)
 
+	// CancelRaidFunc mocks the CancelRaid method.
+	CancelRaidFunc func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*oauth2.Token, error)
+
 	// ClearChatFunc mocks the ClearChat method.
 	ClearChatFunc func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (*oauth2.Token, error)
 
+	// CreateBroadcasterSubscriptionFunc mocks the CreateBroadcasterSubscription method.
+	CreateBroadcasterSubscriptionFunc func(ctx context.Context, conduitID string, subscriptionType string, broadcasterID int64) error
+
 	// CreateChatSubscriptionFunc mocks the CreateChatSubscription method.
 	CreateChatSubscriptionFunc func(ctx context.Context, conduitID string, broadcasterID int64, botID int64) error
 
 	// CreateConduitFunc mocks the CreateConduit method.
 	CreateConduitFunc func(ctx context.Context, shardCount int) (*twitch.Conduit, error)
 
+	// CreatePollFunc mocks the CreatePoll method.
+	CreatePollFunc func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, choices []string, duration time.Duration) (*twitch.Poll, *oauth2.Token, error)
+
+	// CreatePredictionFunc mocks the CreatePrediction method.
+	CreatePredictionFunc func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, outcomes []string, window time.Duration) (*twitch.Prediction, *oauth2.Token, error)
+
+	// CreateRaidSubscriptionFunc mocks the CreateRaidSubscription method.
+	CreateRaidSubscriptionFunc func(ctx context.Context, conduitID string, broadcasterID int64) error
+
 	// DeleteChatMessageFunc mocks the DeleteChatMessage method.
 	DeleteChatMessageFunc func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, id string) (*oauth2.Token, error)
 
@@ -152,15 +210,27 @@ type APIMock struct {
 	// DeleteSubscriptionFunc mocks the DeleteSubscription method.
 	DeleteSubscriptionFunc func(ctx context.Context, id string) error
 
+	// EndPollFunc mocks the EndPoll method.
+	EndPollFunc func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string) (*twitch.Poll, *oauth2.Token, error)
+
+	// EndPredictionFunc mocks the EndPrediction method.
+	EndPredictionFunc func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string, status string, winningOutcomeID string) (*twitch.Prediction, *oauth2.Token, error)
+
 	// ExchangeFunc mocks the Exchange method.
 	ExchangeFunc func(ctx context.Context, code string) (*oauth2.Token, error)
 
 	// GetChannelByIDFunc mocks the GetChannelByID method.
 	GetChannelByIDFunc func(ctx context.Context, id int64) (*twitch.Channel, error)
 
+	// GetChannelFollowerFunc mocks the GetChannelFollower method.
+	GetChannelFollowerFunc func(ctx context.Context, broadcasterID int64, userID int64, modToken *oauth2.Token) (*twitch.ChannelFollower, *oauth2.Token, error)
+
 	// GetChannelModeratorsFunc mocks the GetChannelModerators method.
 	GetChannelModeratorsFunc func(ctx context.Context, id int64, userToken *oauth2.Token) ([]*twitch.ChannelModerator, *oauth2.Token, error)
 
+	// GetChatSettingsFunc mocks the GetChatSettings method.
+	GetChatSettingsFunc func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (*twitch.ChatSettings, *oauth2.Token, error)
+
 	// GetConduitsFunc mocks the GetConduits method.
 	GetConduitsFunc func(ctx context.Context) ([]*twitch.Conduit, error)
 
@@ -173,6 +243,12 @@ type APIMock struct {
 	// GetGameLinksFunc mocks the GetGameLinks method.
 	GetGameLinksFunc func(ctx context.Context, twitchCategory int64) ([]twitch.GameLink, error)
 
+	// GetLatestPollFunc mocks the GetLatestPoll method.
+	GetLatestPollFunc func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*twitch.Poll, *oauth2.Token, error)
+
+	// GetLatestPredictionFunc mocks the GetLatestPrediction method.
+	GetLatestPredictionFunc func(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*twitch.Prediction, *oauth2.Token, error)
+
 	// GetModeratedChannelsFunc mocks the GetModeratedChannels method.
 	GetModeratedChannelsFunc func(ctx context.Context, modID int64, modToken *oauth2.Token) ([]*twitch.ModeratedChannel, *oauth2.Token, error)
 
@@ -201,11 +277,20 @@ type APIMock struct {
 	SearchCategoriesFunc func(ctx context.Context, query string) ([]*twitch.Category, error)
 
 	// SendChatMessageFunc mocks the SendChatMessage method.
-	SendChatMessageFunc func(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string) (*oauth2.Token, error)
+	SendChatMessageFunc func(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error)
+
+	// SendShoutoutFunc mocks the SendShoutout method.
+	SendShoutoutFunc func(ctx context.Context, broadcasterID int64, toID int64, modID int64, modToken *oauth2.Token) (*oauth2.Token, error)
+
+	// SendWhisperFunc mocks the SendWhisper method.
+	SendWhisperFunc func(ctx context.Context, fromID int64, toID int64, fromToken *oauth2.Token, message string) (*oauth2.Token, error)
 
 	// SetChatColorFunc mocks the SetChatColor method.
 	SetChatColorFunc func(ctx context.Context, userID int64, userToken *oauth2.Token, color string) (*oauth2.Token, error)
 
+	// StartRaidFunc mocks the StartRaid method.
+	StartRaidFunc func(ctx context.Context, broadcasterID int64, toID int64, userToken *oauth2.Token) (*oauth2.Token, error)
+
 	// UnbanFunc mocks the Unban method.
 	UnbanFunc func(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, userID int64) (*oauth2.Token, error)
 
@@ -258,6 +343,15 @@ type APIMock struct {
 			// Req is the req argument value.
 			Req *twitch.BanRequest
 		}
+		// CancelRaid holds details about calls to the CancelRaid method.
+		CancelRaid []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// UserToken is the userToken argument value.
+			UserToken *oauth2.Token
+		}
 		// ClearChat holds details about calls to the ClearChat method.
 		ClearChat []struct {
 			// Ctx is the ctx argument value.
@@ -269,6 +363,17 @@ type APIMock struct {
 			// ModToken is the modToken argument value.
 			ModToken *oauth2.Token
 		}
+		// CreateBroadcasterSubscription holds details about calls to the CreateBroadcasterSubscription method.
+		CreateBroadcasterSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConduitID is the conduitID argument value.
+			ConduitID string
+			// SubscriptionType is the subscriptionType argument value.
+			SubscriptionType string
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+		}
 		// CreateChatSubscription holds details about calls to the CreateChatSubscription method.
 		CreateChatSubscription []struct {
 			// Ctx is the ctx argument value.
@@ -287,6 +392,45 @@ type APIMock struct {
 			// ShardCount is the shardCount argument value.
 			ShardCount int
 		}
+		// CreatePoll holds details about calls to the CreatePoll method.
+		CreatePoll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// UserToken is the userToken argument value.
+			UserToken *oauth2.Token
+			// Title is the title argument value.
+			Title string
+			// Choices is the choices argument value.
+			Choices []string
+			// Duration is the duration argument value.
+			Duration time.Duration
+		}
+		// CreatePrediction holds details about calls to the CreatePrediction method.
+		CreatePrediction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// UserToken is the userToken argument value.
+			UserToken *oauth2.Token
+			// Title is the title argument value.
+			Title string
+			// Outcomes is the outcomes argument value.
+			Outcomes []string
+			// Window is the window argument value.
+			Window time.Duration
+		}
+		// CreateRaidSubscription holds details about calls to the CreateRaidSubscription method.
+		CreateRaidSubscription []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// ConduitID is the conduitID argument value.
+			ConduitID string
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+		}
 		// DeleteChatMessage holds details about calls to the DeleteChatMessage method.
 		DeleteChatMessage []struct {
 			// Ctx is the ctx argument value.
@@ -314,6 +458,32 @@ type APIMock struct {
 			// ID is the id argument value.
 			ID string
 		}
+		// EndPoll holds details about calls to the EndPoll method.
+		EndPoll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// UserToken is the userToken argument value.
+			UserToken *oauth2.Token
+			// Id is the id argument value.
+			Id string
+		}
+		// EndPrediction holds details about calls to the EndPrediction method.
+		EndPrediction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// UserToken is the userToken argument value.
+			UserToken *oauth2.Token
+			// Id is the id argument value.
+			Id string
+			// Status is the status argument value.
+			Status string
+			// WinningOutcomeID is the winningOutcomeID argument value.
+			WinningOutcomeID string
+		}
 		// Exchange holds details about calls to the Exchange method.
 		Exchange []struct {
 			// Ctx is the ctx argument value.
@@ -328,6 +498,17 @@ type APIMock struct {
 			// ID is the id argument value.
 			ID int64
 		}
+		// GetChannelFollower holds details about calls to the GetChannelFollower method.
+		GetChannelFollower []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// UserID is the userID argument value.
+			UserID int64
+			// ModToken is the modToken argument value.
+			ModToken *oauth2.Token
+		}
 		// GetChannelModerators holds details about calls to the GetChannelModerators method.
 		GetChannelModerators []struct {
 			// Ctx is the ctx argument value.
@@ -337,6 +518,17 @@ type APIMock struct {
 			// UserToken is the userToken argument value.
 			UserToken *oauth2.Token
 		}
+		// GetChatSettings holds details about calls to the GetChatSettings method.
+		GetChatSettings []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// ModID is the modID argument value.
+			ModID int64
+			// ModToken is the modToken argument value.
+			ModToken *oauth2.Token
+		}
 		// GetConduits holds details about calls to the GetConduits method.
 		GetConduits []struct {
 			// Ctx is the ctx argument value.
@@ -363,6 +555,24 @@ type APIMock struct {
 			// TwitchCategory is the twitchCategory argument value.
 			TwitchCategory int64
 		}
+		// GetLatestPoll holds details about calls to the GetLatestPoll method.
+		GetLatestPoll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// UserToken is the userToken argument value.
+			UserToken *oauth2.Token
+		}
+		// GetLatestPrediction holds details about calls to the GetLatestPrediction method.
+		GetLatestPrediction []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// UserToken is the userToken argument value.
+			UserToken *oauth2.Token
+		}
 		// GetModeratedChannels holds details about calls to the GetModeratedChannels method.
 		GetModeratedChannels []struct {
 			// Ctx is the ctx argument value.
@@ -444,6 +654,34 @@ type APIMock struct {
 			SenderToken *oauth2.Token
 			// Message is the message argument value.
 			Message string
+			// ReplyParentID is the replyParentID argument value.
+			ReplyParentID string
+		}
+		// SendShoutout holds details about calls to the SendShoutout method.
+		SendShoutout []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// ToID is the toID argument value.
+			ToID int64
+			// ModID is the modID argument value.
+			ModID int64
+			// ModToken is the modToken argument value.
+			ModToken *oauth2.Token
+		}
+		// SendWhisper holds details about calls to the SendWhisper method.
+		SendWhisper []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// FromID is the fromID argument value.
+			FromID int64
+			// ToID is the toID argument value.
+			ToID int64
+			// FromToken is the fromToken argument value.
+			FromToken *oauth2.Token
+			// Message is the message argument value.
+			Message string
 		}
 		// SetChatColor holds details about calls to the SetChatColor method.
 		SetChatColor []struct {
@@ -456,6 +694,17 @@ type APIMock struct {
 			// Color is the color argument value.
 			Color string
 		}
+		// StartRaid holds details about calls to the StartRaid method.
+		StartRaid []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// BroadcasterID is the broadcasterID argument value.
+			BroadcasterID int64
+			// ToID is the toID argument value.
+			ToID int64
+			// UserToken is the userToken argument value.
+			UserToken *oauth2.Token
+		}
 		// Unban holds details about calls to the Unban method.
 		Unban []struct {
 			// Ctx is the ctx argument value.
@@ -508,38 +757,52 @@ type APIMock struct {
 			Tok *oauth2.Token
 		}
 	}
-	lockAnnounce               sync.RWMutex
-	lockAuthCodeURL            sync.RWMutex
-	lockBan                    sync.RWMutex
-	lockClearChat              sync.RWMutex
-	lockCreateChatSubscription sync.RWMutex
-	lockCreateConduit          sync.RWMutex
-	lockDeleteChatMessage      sync.RWMutex
-	lockDeleteConduit          sync.RWMutex
-	lockDeleteSubscription     sync.RWMutex
-	lockExchange               sync.RWMutex
-	lockGetChannelByID         sync.RWMutex
-	lockGetChannelModerators   sync.RWMutex
-	lockGetConduits            sync.RWMutex
-	lockGetGameByID            sync.RWMutex
-	lockGetGameByName          sync.RWMutex
-	lockGetGameLinks           sync.RWMutex
-	lockGetModeratedChannels   sync.RWMutex
-	lockGetStreamByUserID      sync.RWMutex
-	lockGetStreamByUsername    sync.RWMutex
-	lockGetSubscriptions       sync.RWMutex
-	lockGetUserByID            sync.RWMutex
-	lockGetUserByToken         sync.RWMutex
-	lockGetUserByUsername      sync.RWMutex
-	lockModifyChannel          sync.RWMutex
-	lockSearchCategories       sync.RWMutex
-	lockSendChatMessage        sync.RWMutex
-	lockSetChatColor           sync.RWMutex
-	lockUnban                  sync.RWMutex
-	lockUpdateChatSettings     sync.RWMutex
-	lockUpdateConduit          sync.RWMutex
-	lockUpdateShards           sync.RWMutex
-	lockValidate               sync.RWMutex
+	lockAnnounce                      sync.RWMutex
+	lockAuthCodeURL                   sync.RWMutex
+	lockBan                           sync.RWMutex
+	lockCancelRaid                    sync.RWMutex
+	lockClearChat                     sync.RWMutex
+	lockCreateBroadcasterSubscription sync.RWMutex
+	lockCreateChatSubscription        sync.RWMutex
+	lockCreateConduit                 sync.RWMutex
+	lockCreatePoll                    sync.RWMutex
+	lockCreatePrediction              sync.RWMutex
+	lockCreateRaidSubscription        sync.RWMutex
+	lockDeleteChatMessage             sync.RWMutex
+	lockDeleteConduit                 sync.RWMutex
+	lockDeleteSubscription            sync.RWMutex
+	lockEndPoll                       sync.RWMutex
+	lockEndPrediction                 sync.RWMutex
+	lockExchange                      sync.RWMutex
+	lockGetChannelByID                sync.RWMutex
+	lockGetChannelFollower            sync.RWMutex
+	lockGetChannelModerators          sync.RWMutex
+	lockGetChatSettings               sync.RWMutex
+	lockGetConduits                   sync.RWMutex
+	lockGetGameByID                   sync.RWMutex
+	lockGetGameByName                 sync.RWMutex
+	lockGetGameLinks                  sync.RWMutex
+	lockGetLatestPoll                 sync.RWMutex
+	lockGetLatestPrediction           sync.RWMutex
+	lockGetModeratedChannels          sync.RWMutex
+	lockGetStreamByUserID             sync.RWMutex
+	lockGetStreamByUsername           sync.RWMutex
+	lockGetSubscriptions              sync.RWMutex
+	lockGetUserByID                   sync.RWMutex
+	lockGetUserByToken                sync.RWMutex
+	lockGetUserByUsername             sync.RWMutex
+	lockModifyChannel                 sync.RWMutex
+	lockSearchCategories              sync.RWMutex
+	lockSendChatMessage               sync.RWMutex
+	lockSendShoutout                  sync.RWMutex
+	lockSendWhisper                   sync.RWMutex
+	lockSetChatColor                  sync.RWMutex
+	lockStartRaid                     sync.RWMutex
+	lockUnban                         sync.RWMutex
+	lockUpdateChatSettings            sync.RWMutex
+	lockUpdateConduit                 sync.RWMutex
+	lockUpdateShards                  sync.RWMutex
+	lockValidate                      sync.RWMutex
 }
 
 // Announce calls AnnounceFunc.
@@ -678,6 +941,46 @@ func (mock *APIMock) BanCalls() []struct {
 	return calls
 }
 
+// CancelRaid calls CancelRaidFunc.
+func (mock *APIMock) CancelRaid(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*oauth2.Token, error) {
+	if mock.CancelRaidFunc == nil {
+		panic("APIMock.CancelRaidFunc: method is nil but API.CancelRaid was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		UserToken:     userToken,
+	}
+	mock.lockCancelRaid.Lock()
+	mock.calls.CancelRaid = append(mock.calls.CancelRaid, callInfo)
+	mock.lockCancelRaid.Unlock()
+	return mock.CancelRaidFunc(ctx, broadcasterID, userToken)
+}
+
+// CancelRaidCalls gets all the calls that were made to CancelRaid.
+// Check the length with:
+//
+//	len(mockedAPI.CancelRaidCalls())
+func (mock *APIMock) CancelRaidCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	UserToken     *oauth2.Token
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+	}
+	mock.lockCancelRaid.RLock()
+	calls = mock.calls.CancelRaid
+	mock.lockCancelRaid.RUnlock()
+	return calls
+}
+
 // ClearChat calls ClearChatFunc.
 func (mock *APIMock) ClearChat(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (*oauth2.Token, error) {
 	if mock.ClearChatFunc == nil {
@@ -722,6 +1025,50 @@ func (mock *APIMock) ClearChatCalls() []struct {
 	return calls
 }
 
+// CreateBroadcasterSubscription calls CreateBroadcasterSubscriptionFunc.
+func (mock *APIMock) CreateBroadcasterSubscription(ctx context.Context, conduitID string, subscriptionType string, broadcasterID int64) error {
+	if mock.CreateBroadcasterSubscriptionFunc == nil {
+		panic("APIMock.CreateBroadcasterSubscriptionFunc: method is nil but API.CreateBroadcasterSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx              context.Context
+		ConduitID        string
+		SubscriptionType string
+		BroadcasterID    int64
+	}{
+		Ctx:              ctx,
+		ConduitID:        conduitID,
+		SubscriptionType: subscriptionType,
+		BroadcasterID:    broadcasterID,
+	}
+	mock.lockCreateBroadcasterSubscription.Lock()
+	mock.calls.CreateBroadcasterSubscription = append(mock.calls.CreateBroadcasterSubscription, callInfo)
+	mock.lockCreateBroadcasterSubscription.Unlock()
+	return mock.CreateBroadcasterSubscriptionFunc(ctx, conduitID, subscriptionType, broadcasterID)
+}
+
+// CreateBroadcasterSubscriptionCalls gets all the calls that were made to CreateBroadcasterSubscription.
+// Check the length with:
+//
+//	len(mockedAPI.CreateBroadcasterSubscriptionCalls())
+func (mock *APIMock) CreateBroadcasterSubscriptionCalls() []struct {
+	Ctx              context.Context
+	ConduitID        string
+	SubscriptionType string
+	BroadcasterID    int64
+} {
+	var calls []struct {
+		Ctx              context.Context
+		ConduitID        string
+		SubscriptionType string
+		BroadcasterID    int64
+	}
+	mock.lockCreateBroadcasterSubscription.RLock()
+	calls = mock.calls.CreateBroadcasterSubscription
+	mock.lockCreateBroadcasterSubscription.RUnlock()
+	return calls
+}
+
 // CreateChatSubscription calls CreateChatSubscriptionFunc.
 func (mock *APIMock) CreateChatSubscription(ctx context.Context, conduitID string, broadcasterID int64, botID int64) error {
 	if mock.CreateChatSubscriptionFunc == nil {
@@ -802,6 +1149,150 @@ func (mock *APIMock) CreateConduitCalls() []struct {
 	return calls
 }
 
+// CreatePoll calls CreatePollFunc.
+func (mock *APIMock) CreatePoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, choices []string, duration time.Duration) (*twitch.Poll, *oauth2.Token, error) {
+	if mock.CreatePollFunc == nil {
+		panic("APIMock.CreatePollFunc: method is nil but API.CreatePoll was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+		Title         string
+		Choices       []string
+		Duration      time.Duration
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		UserToken:     userToken,
+		Title:         title,
+		Choices:       choices,
+		Duration:      duration,
+	}
+	mock.lockCreatePoll.Lock()
+	mock.calls.CreatePoll = append(mock.calls.CreatePoll, callInfo)
+	mock.lockCreatePoll.Unlock()
+	return mock.CreatePollFunc(ctx, broadcasterID, userToken, title, choices, duration)
+}
+
+// CreatePollCalls gets all the calls that were made to CreatePoll.
+// Check the length with:
+//
+//	len(mockedAPI.CreatePollCalls())
+func (mock *APIMock) CreatePollCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	UserToken     *oauth2.Token
+	Title         string
+	Choices       []string
+	Duration      time.Duration
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+		Title         string
+		Choices       []string
+		Duration      time.Duration
+	}
+	mock.lockCreatePoll.RLock()
+	calls = mock.calls.CreatePoll
+	mock.lockCreatePoll.RUnlock()
+	return calls
+}
+
+// CreatePrediction calls CreatePredictionFunc.
+func (mock *APIMock) CreatePrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, title string, outcomes []string, window time.Duration) (*twitch.Prediction, *oauth2.Token, error) {
+	if mock.CreatePredictionFunc == nil {
+		panic("APIMock.CreatePredictionFunc: method is nil but API.CreatePrediction was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+		Title         string
+		Outcomes      []string
+		Window        time.Duration
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		UserToken:     userToken,
+		Title:         title,
+		Outcomes:      outcomes,
+		Window:        window,
+	}
+	mock.lockCreatePrediction.Lock()
+	mock.calls.CreatePrediction = append(mock.calls.CreatePrediction, callInfo)
+	mock.lockCreatePrediction.Unlock()
+	return mock.CreatePredictionFunc(ctx, broadcasterID, userToken, title, outcomes, window)
+}
+
+// CreatePredictionCalls gets all the calls that were made to CreatePrediction.
+// Check the length with:
+//
+//	len(mockedAPI.CreatePredictionCalls())
+func (mock *APIMock) CreatePredictionCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	UserToken     *oauth2.Token
+	Title         string
+	Outcomes      []string
+	Window        time.Duration
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+		Title         string
+		Outcomes      []string
+		Window        time.Duration
+	}
+	mock.lockCreatePrediction.RLock()
+	calls = mock.calls.CreatePrediction
+	mock.lockCreatePrediction.RUnlock()
+	return calls
+}
+
+// CreateRaidSubscription calls CreateRaidSubscriptionFunc.
+func (mock *APIMock) CreateRaidSubscription(ctx context.Context, conduitID string, broadcasterID int64) error {
+	if mock.CreateRaidSubscriptionFunc == nil {
+		panic("APIMock.CreateRaidSubscriptionFunc: method is nil but API.CreateRaidSubscription was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		ConduitID     string
+		BroadcasterID int64
+	}{
+		Ctx:           ctx,
+		ConduitID:     conduitID,
+		BroadcasterID: broadcasterID,
+	}
+	mock.lockCreateRaidSubscription.Lock()
+	mock.calls.CreateRaidSubscription = append(mock.calls.CreateRaidSubscription, callInfo)
+	mock.lockCreateRaidSubscription.Unlock()
+	return mock.CreateRaidSubscriptionFunc(ctx, conduitID, broadcasterID)
+}
+
+// CreateRaidSubscriptionCalls gets all the calls that were made to CreateRaidSubscription.
+// Check the length with:
+//
+//	len(mockedAPI.CreateRaidSubscriptionCalls())
+func (mock *APIMock) CreateRaidSubscriptionCalls() []struct {
+	Ctx           context.Context
+	ConduitID     string
+	BroadcasterID int64
+} {
+	var calls []struct {
+		Ctx           context.Context
+		ConduitID     string
+		BroadcasterID int64
+	}
+	mock.lockCreateRaidSubscription.RLock()
+	calls = mock.calls.CreateRaidSubscription
+	mock.lockCreateRaidSubscription.RUnlock()
+	return calls
+}
+
 // DeleteChatMessage calls DeleteChatMessageFunc.
 func (mock *APIMock) DeleteChatMessage(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, id string) (*oauth2.Token, error) {
 	if mock.DeleteChatMessageFunc == nil {
@@ -922,6 +1413,102 @@ func (mock *APIMock) DeleteSubscriptionCalls() []struct {
 	return calls
 }
 
+// EndPoll calls EndPollFunc.
+func (mock *APIMock) EndPoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string) (*twitch.Poll, *oauth2.Token, error) {
+	if mock.EndPollFunc == nil {
+		panic("APIMock.EndPollFunc: method is nil but API.EndPoll was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+		Id            string
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		UserToken:     userToken,
+		Id:            id,
+	}
+	mock.lockEndPoll.Lock()
+	mock.calls.EndPoll = append(mock.calls.EndPoll, callInfo)
+	mock.lockEndPoll.Unlock()
+	return mock.EndPollFunc(ctx, broadcasterID, userToken, id)
+}
+
+// EndPollCalls gets all the calls that were made to EndPoll.
+// Check the length with:
+//
+//	len(mockedAPI.EndPollCalls())
+func (mock *APIMock) EndPollCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	UserToken     *oauth2.Token
+	Id            string
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+		Id            string
+	}
+	mock.lockEndPoll.RLock()
+	calls = mock.calls.EndPoll
+	mock.lockEndPoll.RUnlock()
+	return calls
+}
+
+// EndPrediction calls EndPredictionFunc.
+func (mock *APIMock) EndPrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token, id string, status string, winningOutcomeID string) (*twitch.Prediction, *oauth2.Token, error) {
+	if mock.EndPredictionFunc == nil {
+		panic("APIMock.EndPredictionFunc: method is nil but API.EndPrediction was just called")
+	}
+	callInfo := struct {
+		Ctx              context.Context
+		BroadcasterID    int64
+		UserToken        *oauth2.Token
+		Id               string
+		Status           string
+		WinningOutcomeID string
+	}{
+		Ctx:              ctx,
+		BroadcasterID:    broadcasterID,
+		UserToken:        userToken,
+		Id:               id,
+		Status:           status,
+		WinningOutcomeID: winningOutcomeID,
+	}
+	mock.lockEndPrediction.Lock()
+	mock.calls.EndPrediction = append(mock.calls.EndPrediction, callInfo)
+	mock.lockEndPrediction.Unlock()
+	return mock.EndPredictionFunc(ctx, broadcasterID, userToken, id, status, winningOutcomeID)
+}
+
+// EndPredictionCalls gets all the calls that were made to EndPrediction.
+// Check the length with:
+//
+//	len(mockedAPI.EndPredictionCalls())
+func (mock *APIMock) EndPredictionCalls() []struct {
+	Ctx              context.Context
+	BroadcasterID    int64
+	UserToken        *oauth2.Token
+	Id               string
+	Status           string
+	WinningOutcomeID string
+} {
+	var calls []struct {
+		Ctx              context.Context
+		BroadcasterID    int64
+		UserToken        *oauth2.Token
+		Id               string
+		Status           string
+		WinningOutcomeID string
+	}
+	mock.lockEndPrediction.RLock()
+	calls = mock.calls.EndPrediction
+	mock.lockEndPrediction.RUnlock()
+	return calls
+}
+
 // Exchange calls ExchangeFunc.
 func (mock *APIMock) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
 	if mock.ExchangeFunc == nil {
@@ -994,6 +1581,50 @@ func (mock *APIMock) GetChannelByIDCalls() []struct {
 	return calls
 }
 
+// GetChannelFollower calls GetChannelFollowerFunc.
+func (mock *APIMock) GetChannelFollower(ctx context.Context, broadcasterID int64, userID int64, modToken *oauth2.Token) (*twitch.ChannelFollower, *oauth2.Token, error) {
+	if mock.GetChannelFollowerFunc == nil {
+		panic("APIMock.GetChannelFollowerFunc: method is nil but API.GetChannelFollower was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserID        int64
+		ModToken      *oauth2.Token
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		UserID:        userID,
+		ModToken:      modToken,
+	}
+	mock.lockGetChannelFollower.Lock()
+	mock.calls.GetChannelFollower = append(mock.calls.GetChannelFollower, callInfo)
+	mock.lockGetChannelFollower.Unlock()
+	return mock.GetChannelFollowerFunc(ctx, broadcasterID, userID, modToken)
+}
+
+// GetChannelFollowerCalls gets all the calls that were made to GetChannelFollower.
+// Check the length with:
+//
+//	len(mockedAPI.GetChannelFollowerCalls())
+func (mock *APIMock) GetChannelFollowerCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	UserID        int64
+	ModToken      *oauth2.Token
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserID        int64
+		ModToken      *oauth2.Token
+	}
+	mock.lockGetChannelFollower.RLock()
+	calls = mock.calls.GetChannelFollower
+	mock.lockGetChannelFollower.RUnlock()
+	return calls
+}
+
 // GetChannelModerators calls GetChannelModeratorsFunc.
 func (mock *APIMock) GetChannelModerators(ctx context.Context, id int64, userToken *oauth2.Token) ([]*twitch.ChannelModerator, *oauth2.Token, error) {
 	if mock.GetChannelModeratorsFunc == nil {
@@ -1034,6 +1665,50 @@ func (mock *APIMock) GetChannelModeratorsCalls() []struct {
 	return calls
 }
 
+// GetChatSettings calls GetChatSettingsFunc.
+func (mock *APIMock) GetChatSettings(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token) (*twitch.ChatSettings, *oauth2.Token, error) {
+	if mock.GetChatSettingsFunc == nil {
+		panic("APIMock.GetChatSettingsFunc: method is nil but API.GetChatSettings was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		ModID         int64
+		ModToken      *oauth2.Token
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		ModID:         modID,
+		ModToken:      modToken,
+	}
+	mock.lockGetChatSettings.Lock()
+	mock.calls.GetChatSettings = append(mock.calls.GetChatSettings, callInfo)
+	mock.lockGetChatSettings.Unlock()
+	return mock.GetChatSettingsFunc(ctx, broadcasterID, modID, modToken)
+}
+
+// GetChatSettingsCalls gets all the calls that were made to GetChatSettings.
+// Check the length with:
+//
+//	len(mockedAPI.GetChatSettingsCalls())
+func (mock *APIMock) GetChatSettingsCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	ModID         int64
+	ModToken      *oauth2.Token
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		ModID         int64
+		ModToken      *oauth2.Token
+	}
+	mock.lockGetChatSettings.RLock()
+	calls = mock.calls.GetChatSettings
+	mock.lockGetChatSettings.RUnlock()
+	return calls
+}
+
 // GetConduits calls GetConduitsFunc.
 func (mock *APIMock) GetConduits(ctx context.Context) ([]*twitch.Conduit, error) {
 	if mock.GetConduitsFunc == nil {
@@ -1174,6 +1849,86 @@ func (mock *APIMock) GetGameLinksCalls() []struct {
 	return calls
 }
 
+// GetLatestPoll calls GetLatestPollFunc.
+func (mock *APIMock) GetLatestPoll(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*twitch.Poll, *oauth2.Token, error) {
+	if mock.GetLatestPollFunc == nil {
+		panic("APIMock.GetLatestPollFunc: method is nil but API.GetLatestPoll was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		UserToken:     userToken,
+	}
+	mock.lockGetLatestPoll.Lock()
+	mock.calls.GetLatestPoll = append(mock.calls.GetLatestPoll, callInfo)
+	mock.lockGetLatestPoll.Unlock()
+	return mock.GetLatestPollFunc(ctx, broadcasterID, userToken)
+}
+
+// GetLatestPollCalls gets all the calls that were made to GetLatestPoll.
+// Check the length with:
+//
+//	len(mockedAPI.GetLatestPollCalls())
+func (mock *APIMock) GetLatestPollCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	UserToken     *oauth2.Token
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+	}
+	mock.lockGetLatestPoll.RLock()
+	calls = mock.calls.GetLatestPoll
+	mock.lockGetLatestPoll.RUnlock()
+	return calls
+}
+
+// GetLatestPrediction calls GetLatestPredictionFunc.
+func (mock *APIMock) GetLatestPrediction(ctx context.Context, broadcasterID int64, userToken *oauth2.Token) (*twitch.Prediction, *oauth2.Token, error) {
+	if mock.GetLatestPredictionFunc == nil {
+		panic("APIMock.GetLatestPredictionFunc: method is nil but API.GetLatestPrediction was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		UserToken:     userToken,
+	}
+	mock.lockGetLatestPrediction.Lock()
+	mock.calls.GetLatestPrediction = append(mock.calls.GetLatestPrediction, callInfo)
+	mock.lockGetLatestPrediction.Unlock()
+	return mock.GetLatestPredictionFunc(ctx, broadcasterID, userToken)
+}
+
+// GetLatestPredictionCalls gets all the calls that were made to GetLatestPrediction.
+// Check the length with:
+//
+//	len(mockedAPI.GetLatestPredictionCalls())
+func (mock *APIMock) GetLatestPredictionCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	UserToken     *oauth2.Token
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		UserToken     *oauth2.Token
+	}
+	mock.lockGetLatestPrediction.RLock()
+	calls = mock.calls.GetLatestPrediction
+	mock.lockGetLatestPrediction.RUnlock()
+	return calls
+}
+
 // GetModeratedChannels calls GetModeratedChannelsFunc.
 func (mock *APIMock) GetModeratedChannels(ctx context.Context, modID int64, modToken *oauth2.Token) ([]*twitch.ModeratedChannel, *oauth2.Token, error) {
 	if mock.GetModeratedChannelsFunc == nil {
@@ -1511,7 +2266,7 @@ func (mock *APIMock) SearchCategoriesCalls() []struct {
 }
 
 // SendChatMessage calls SendChatMessageFunc.
-func (mock *APIMock) SendChatMessage(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string) (*oauth2.Token, error) {
+func (mock *APIMock) SendChatMessage(ctx context.Context, broadcasterID int64, senderID int64, senderToken *oauth2.Token, message string, replyParentID string) (*oauth2.Token, error) {
 	if mock.SendChatMessageFunc == nil {
 		panic("APIMock.SendChatMessageFunc: method is nil but API.SendChatMessage was just called")
 	}
@@ -1521,17 +2276,19 @@ func (mock *APIMock) SendChatMessage(ctx context.Context, broadcasterID int64, s
 		SenderID      int64
 		SenderToken   *oauth2.Token
 		Message       string
+		ReplyParentID string
 	}{
 		Ctx:           ctx,
 		BroadcasterID: broadcasterID,
 		SenderID:      senderID,
 		SenderToken:   senderToken,
 		Message:       message,
+		ReplyParentID: replyParentID,
 	}
 	mock.lockSendChatMessage.Lock()
 	mock.calls.SendChatMessage = append(mock.calls.SendChatMessage, callInfo)
 	mock.lockSendChatMessage.Unlock()
-	return mock.SendChatMessageFunc(ctx, broadcasterID, senderID, senderToken, message)
+	return mock.SendChatMessageFunc(ctx, broadcasterID, senderID, senderToken, message, replyParentID)
 }
 
 // SendChatMessageCalls gets all the calls that were made to SendChatMessage.
@@ -1544,6 +2301,7 @@ func (mock *APIMock) SendChatMessageCalls() []struct {
 	SenderID      int64
 	SenderToken   *oauth2.Token
 	Message       string
+	ReplyParentID string
 } {
 	var calls []struct {
 		Ctx           context.Context
@@ -1551,6 +2309,7 @@ func (mock *APIMock) SendChatMessageCalls() []struct {
 		SenderID      int64
 		SenderToken   *oauth2.Token
 		Message       string
+		ReplyParentID string
 	}
 	mock.lockSendChatMessage.RLock()
 	calls = mock.calls.SendChatMessage
@@ -1558,6 +2317,102 @@ func (mock *APIMock) SendChatMessageCalls() []struct {
 	return calls
 }
 
+// SendShoutout calls SendShoutoutFunc.
+func (mock *APIMock) SendShoutout(ctx context.Context, broadcasterID int64, toID int64, modID int64, modToken *oauth2.Token) (*oauth2.Token, error) {
+	if mock.SendShoutoutFunc == nil {
+		panic("APIMock.SendShoutoutFunc: method is nil but API.SendShoutout was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		ToID          int64
+		ModID         int64
+		ModToken      *oauth2.Token
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		ToID:          toID,
+		ModID:         modID,
+		ModToken:      modToken,
+	}
+	mock.lockSendShoutout.Lock()
+	mock.calls.SendShoutout = append(mock.calls.SendShoutout, callInfo)
+	mock.lockSendShoutout.Unlock()
+	return mock.SendShoutoutFunc(ctx, broadcasterID, toID, modID, modToken)
+}
+
+// SendShoutoutCalls gets all the calls that were made to SendShoutout.
+// Check the length with:
+//
+//	len(mockedAPI.SendShoutoutCalls())
+func (mock *APIMock) SendShoutoutCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	ToID          int64
+	ModID         int64
+	ModToken      *oauth2.Token
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		ToID          int64
+		ModID         int64
+		ModToken      *oauth2.Token
+	}
+	mock.lockSendShoutout.RLock()
+	calls = mock.calls.SendShoutout
+	mock.lockSendShoutout.RUnlock()
+	return calls
+}
+
+// SendWhisper calls SendWhisperFunc.
+func (mock *APIMock) SendWhisper(ctx context.Context, fromID int64, toID int64, fromToken *oauth2.Token, message string) (*oauth2.Token, error) {
+	if mock.SendWhisperFunc == nil {
+		panic("APIMock.SendWhisperFunc: method is nil but API.SendWhisper was just called")
+	}
+	callInfo := struct {
+		Ctx       context.Context
+		FromID    int64
+		ToID      int64
+		FromToken *oauth2.Token
+		Message   string
+	}{
+		Ctx:       ctx,
+		FromID:    fromID,
+		ToID:      toID,
+		FromToken: fromToken,
+		Message:   message,
+	}
+	mock.lockSendWhisper.Lock()
+	mock.calls.SendWhisper = append(mock.calls.SendWhisper, callInfo)
+	mock.lockSendWhisper.Unlock()
+	return mock.SendWhisperFunc(ctx, fromID, toID, fromToken, message)
+}
+
+// SendWhisperCalls gets all the calls that were made to SendWhisper.
+// Check the length with:
+//
+//	len(mockedAPI.SendWhisperCalls())
+func (mock *APIMock) SendWhisperCalls() []struct {
+	Ctx       context.Context
+	FromID    int64
+	ToID      int64
+	FromToken *oauth2.Token
+	Message   string
+} {
+	var calls []struct {
+		Ctx       context.Context
+		FromID    int64
+		ToID      int64
+		FromToken *oauth2.Token
+		Message   string
+	}
+	mock.lockSendWhisper.RLock()
+	calls = mock.calls.SendWhisper
+	mock.lockSendWhisper.RUnlock()
+	return calls
+}
+
 // SetChatColor calls SetChatColorFunc.
 func (mock *APIMock) SetChatColor(ctx context.Context, userID int64, userToken *oauth2.Token, color string) (*oauth2.Token, error) {
 	if mock.SetChatColorFunc == nil {
@@ -1602,6 +2457,50 @@ func (mock *APIMock) SetChatColorCalls() []struct {
 	return calls
 }
 
+// StartRaid calls StartRaidFunc.
+func (mock *APIMock) StartRaid(ctx context.Context, broadcasterID int64, toID int64, userToken *oauth2.Token) (*oauth2.Token, error) {
+	if mock.StartRaidFunc == nil {
+		panic("APIMock.StartRaidFunc: method is nil but API.StartRaid was just called")
+	}
+	callInfo := struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		ToID          int64
+		UserToken     *oauth2.Token
+	}{
+		Ctx:           ctx,
+		BroadcasterID: broadcasterID,
+		ToID:          toID,
+		UserToken:     userToken,
+	}
+	mock.lockStartRaid.Lock()
+	mock.calls.StartRaid = append(mock.calls.StartRaid, callInfo)
+	mock.lockStartRaid.Unlock()
+	return mock.StartRaidFunc(ctx, broadcasterID, toID, userToken)
+}
+
+// StartRaidCalls gets all the calls that were made to StartRaid.
+// Check the length with:
+//
+//	len(mockedAPI.StartRaidCalls())
+func (mock *APIMock) StartRaidCalls() []struct {
+	Ctx           context.Context
+	BroadcasterID int64
+	ToID          int64
+	UserToken     *oauth2.Token
+} {
+	var calls []struct {
+		Ctx           context.Context
+		BroadcasterID int64
+		ToID          int64
+		UserToken     *oauth2.Token
+	}
+	mock.lockStartRaid.RLock()
+	calls = mock.calls.StartRaid
+	mock.lockStartRaid.RUnlock()
+	return calls
+}
+
 // Unban calls UnbanFunc.
 func (mock *APIMock) Unban(ctx context.Context, broadcasterID int64, modID int64, modToken *oauth2.Token, userID int64) (*oauth2.Token, error) {
 	if mock.UnbanFunc == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 	"strconv"
+	"time"
 
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch/idstr"
 	"golang.org/x/oauth2"
@@ -14,6 +15,7 @@ type User struct {
 	ID          idstr.IDStr `json:"id"`
 	Name        string      `json:"login"`
 	DisplayName string      `json:"display_name,omitempty"`
+	CreatedAt   time.Time   `json:"created_at,omitzero"`
 }
 
 // DispName returns the display name for the user if provided, otherwise the username.
@@ -272,6 +272,7 @@ func TestGetUserForID(t *testing.T) {
 		ID:          1234,
 		Name:        "foobar",
 		DisplayName: "Foobar",
+		CreatedAt:   time.Date(2016, 12, 14, 20, 32, 28, 0, time.UTC),
 	})
 }
 
@@ -0,0 +1,236 @@
+// Package envelope implements envelope encryption of short secrets.
+//
+// Each value is encrypted with a fresh random data key, which is in turn
+// encrypted ("wrapped") with a long-lived key-encryption key from a Keyring.
+// The ID of the key-encryption key is stored alongside the ciphertext, so
+// that keys can be rotated without losing access to older values.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	prefix     = "enc:v1:"
+	keySize    = 32
+	keyIDLimit = 64
+)
+
+var (
+	// ErrUnknownKey is returned when a value was sealed with a key that is not
+	// in the keyring.
+	ErrUnknownKey = errors.New("envelope: unknown key")
+
+	// ErrMalformed is returned when a sealed value cannot be parsed.
+	ErrMalformed = errors.New("envelope: malformed value")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Keyring holds a set of key-encryption keys, one of which is the primary key
+// used for new values. A nil Keyring is valid and stores values in plaintext.
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring from the given 32 byte keys, indexed by ID.
+// The primary key must be present in keys.
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	k := &Keyring{
+		primary: primary,
+		keys:    make(map[string]cipher.AEAD, len(keys)),
+	}
+
+	for id, key := range keys {
+		if id == "" || len(id) > keyIDLimit || strings.ContainsAny(id, ":, ") {
+			return nil, fmt.Errorf("envelope: invalid key ID %q", id)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("envelope: key %q: %w", id, err)
+		}
+		k.keys[id] = aead
+	}
+
+	if _, ok := k.keys[primary]; !ok {
+		return nil, fmt.Errorf("envelope: primary key %q not provided", primary)
+	}
+
+	return k, nil
+}
+
+// ParseKeyring parses keys in the form "id:base64key". The first key is the
+// primary key. If specs is empty, ParseKeyring returns a nil Keyring.
+func ParseKeyring(specs []string) (*Keyring, error) {
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	keys := make(map[string][]byte, len(specs))
+	primary := ""
+
+	for _, spec := range specs {
+		id, encoded, ok := strings.Cut(spec, ":")
+		if !ok {
+			return nil, errors.New("envelope: keys must be in the form id:base64key")
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("envelope: decoding key %q: %w", id, err)
+		}
+
+		if _, ok := keys[id]; ok {
+			return nil, fmt.Errorf("envelope: duplicate key %q", id)
+		}
+		keys[id] = key
+
+		if primary == "" {
+			primary = id
+		}
+	}
+
+	return NewKeyring(primary, keys)
+}
+
+// PrimaryID returns the ID of the key used to seal new values, or an empty
+// string for a nil Keyring.
+func (k *Keyring) PrimaryID() string {
+	if k == nil {
+		return ""
+	}
+	return k.primary
+}
+
+// Seal encrypts plaintext with the primary key. Empty values and values
+// sealed by a nil Keyring are returned as-is.
+func (k *Keyring) Seal(plaintext string) (string, error) {
+	if k == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("envelope: generating data key: %w", err)
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	wrapped, err := seal(k.keys[k.primary], dataKey, []byte(k.primary))
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(data, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+
+	return prefix + k.primary + ":" + encoding.EncodeToString(wrapped) + ":" + encoding.EncodeToString(ciphertext), nil
+}
+
+// Open decrypts a value produced by Seal. Values which were not sealed are
+// returned unchanged, so that plaintext values written before encryption
+// was enabled remain readable.
+func (k *Keyring) Open(value string) (string, error) {
+	id, ok := KeyID(value)
+	if !ok {
+		return value, nil
+	}
+
+	if k == nil {
+		return "", fmt.Errorf("%w %q (no keys configured)", ErrUnknownKey, id)
+	}
+
+	kek := k.keys[id]
+	if kek == nil {
+		return "", fmt.Errorf("%w %q", ErrUnknownKey, id)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", ErrMalformed
+	}
+
+	wrapped, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	ciphertext, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return "", ErrMalformed
+	}
+
+	dataKey, err := open(kek, wrapped, []byte(id))
+	if err != nil {
+		return "", err
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(data, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// KeyID returns the ID of the key which sealed the value, and false if the
+// value was not sealed.
+func KeyID(value string) (string, bool) {
+	rest, ok := strings.CutPrefix(value, prefix)
+	if !ok {
+		return "", false
+	}
+	id, _, _ := strings.Cut(rest, ":")
+	return id, true
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext, additional []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("envelope: generating nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+func open(aead cipher.AEAD, ciphertext, additional []byte) ([]byte, error) {
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additional)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: decrypting: %w", err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,152 @@
+package envelope_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/hortbot/hortbot/internal/pkg/envelope"
+	"gotest.tools/v3/assert"
+)
+
+var (
+	key1 = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	key2 = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32))
+)
+
+func TestSealOpen(t *testing.T) {
+	t.Parallel()
+
+	k, err := envelope.ParseKeyring([]string{"one:" + key1})
+	assert.NilError(t, err)
+	assert.Equal(t, k.PrimaryID(), "one")
+
+	sealed, err := k.Seal("some-access-token")
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(sealed, "some-access-token"))
+
+	id, ok := envelope.KeyID(sealed)
+	assert.Assert(t, ok)
+	assert.Equal(t, id, "one")
+
+	other, err := k.Seal("some-access-token")
+	assert.NilError(t, err)
+	assert.Assert(t, sealed != other)
+
+	opened, err := k.Open(sealed)
+	assert.NilError(t, err)
+	assert.Equal(t, opened, "some-access-token")
+}
+
+func TestSealEmpty(t *testing.T) {
+	t.Parallel()
+
+	k, err := envelope.ParseKeyring([]string{"one:" + key1})
+	assert.NilError(t, err)
+
+	sealed, err := k.Seal("")
+	assert.NilError(t, err)
+	assert.Equal(t, sealed, "")
+}
+
+func TestOpenPlaintext(t *testing.T) {
+	t.Parallel()
+
+	k, err := envelope.ParseKeyring([]string{"one:" + key1})
+	assert.NilError(t, err)
+
+	opened, err := k.Open("legacy-token")
+	assert.NilError(t, err)
+	assert.Equal(t, opened, "legacy-token")
+
+	_, ok := envelope.KeyID("legacy-token")
+	assert.Assert(t, !ok)
+}
+
+func TestNilKeyring(t *testing.T) {
+	t.Parallel()
+
+	var k *envelope.Keyring
+	assert.Equal(t, k.PrimaryID(), "")
+
+	sealed, err := k.Seal("token")
+	assert.NilError(t, err)
+	assert.Equal(t, sealed, "token")
+
+	other, err := envelope.ParseKeyring([]string{"one:" + key1})
+	assert.NilError(t, err)
+
+	sealed, err = other.Seal("token")
+	assert.NilError(t, err)
+
+	_, err = k.Open(sealed)
+	assert.ErrorIs(t, err, envelope.ErrUnknownKey)
+}
+
+func TestRotation(t *testing.T) {
+	t.Parallel()
+
+	oldRing, err := envelope.ParseKeyring([]string{"one:" + key1})
+	assert.NilError(t, err)
+
+	sealed, err := oldRing.Seal("token")
+	assert.NilError(t, err)
+
+	newRing, err := envelope.ParseKeyring([]string{"two:" + key2, "one:" + key1})
+	assert.NilError(t, err)
+	assert.Equal(t, newRing.PrimaryID(), "two")
+
+	opened, err := newRing.Open(sealed)
+	assert.NilError(t, err)
+	assert.Equal(t, opened, "token")
+
+	resealed, err := newRing.Seal(opened)
+	assert.NilError(t, err)
+
+	id, _ := envelope.KeyID(resealed)
+	assert.Equal(t, id, "two")
+
+	_, err = oldRing.Open(resealed)
+	assert.ErrorIs(t, err, envelope.ErrUnknownKey)
+}
+
+func TestOpenTampered(t *testing.T) {
+	t.Parallel()
+
+	k, err := envelope.ParseKeyring([]string{"one:" + key1})
+	assert.NilError(t, err)
+
+	sealed, err := k.Seal("token")
+	assert.NilError(t, err)
+
+	_, err = k.Open(sealed[:len(sealed)-2])
+	assert.ErrorContains(t, err, "envelope:")
+
+	_, err = k.Open("enc:v1:one:garbage")
+	assert.ErrorIs(t, err, envelope.ErrMalformed)
+}
+
+func TestParseKeyringErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]string{
+		"no separator":  {"one"},
+		"bad base64":    {"one:!!!"},
+		"short key":     {"one:" + base64.StdEncoding.EncodeToString([]byte("short"))},
+		"duplicate key": {"one:" + key1, "one:" + key2},
+		"empty ID":      {":" + key1},
+	}
+
+	for name, specs := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, err := envelope.ParseKeyring(specs)
+			assert.ErrorContains(t, err, "envelope:")
+		})
+	}
+
+	k, err := envelope.ParseKeyring(nil)
+	assert.NilError(t, err)
+	assert.Assert(t, k == nil)
+}
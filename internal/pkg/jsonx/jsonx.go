@@ -2,9 +2,12 @@
 package jsonx
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
+	"strconv"
+	"strings"
 )
 
 // ErrMoreThanOne is returned when DecodeOne decodes more than a single value.
@@ -38,3 +41,91 @@ type unmarshallable struct{}
 func (*unmarshallable) MarshalJSON() ([]byte, error) {
 	return nil, ErrUnmarshallable
 }
+
+// ErrPathNotFound is returned by Extract when the path does not exist.
+var ErrPathNotFound = errors.New("jsonx: path not found")
+
+// ErrBadPath is returned by Extract when the path cannot be parsed.
+var ErrBadPath = errors.New("jsonx: bad path")
+
+// Extract decodes a single JSON value from data and returns the value found
+// at path as text. Paths are a dot separated list of object keys and array
+// indexes, like "data.items[0].name", optionally prefixed with "$".
+//
+// Strings are returned without quotes, null is returned as an empty string,
+// and objects and arrays are returned as compact JSON.
+func Extract(data []byte, path string) (string, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if _, err := d.Token(); err != io.EOF { //nolint:errorlint
+		return "", ErrMoreThanOne
+	}
+
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			continue
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return "", ErrBadPath
+			}
+
+			i, err := strconv.Atoi(path[1:end])
+			if err != nil || i < 0 {
+				return "", ErrBadPath
+			}
+			path = path[end+1:]
+
+			arr, ok := v.([]any)
+			if !ok || i >= len(arr) {
+				return "", ErrPathNotFound
+			}
+			v = arr[i]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+
+			key := path[:end]
+			path = path[end:]
+
+			obj, ok := v.(map[string]any)
+			if !ok {
+				return "", ErrPathNotFound
+			}
+
+			v, ok = obj[key]
+			if !ok {
+				return "", ErrPathNotFound
+			}
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+		return string(b), nil
+	}
+}
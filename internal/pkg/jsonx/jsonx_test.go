@@ -56,3 +56,73 @@ func TestUnmarshallable(t *testing.T) {
 	_, err = json.Marshal(v)
 	assert.ErrorContains(t, err, jsonx.ErrUnmarshallable.Error())
 }
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	const data = `{
+		"name": "hortbot",
+		"count": 12345678901234567890,
+		"ratio": 0.5,
+		"live": true,
+		"game": null,
+		"tags": ["one", "two"],
+		"stream": {"viewers": 42, "title": "Hello world"},
+		"items": [{"id": 1}, {"id": 2, "nested": {"a": [1, 2]}}]
+	}`
+
+	tests := []struct {
+		path string
+		want string
+		err  error
+	}{
+		{path: "name", want: "hortbot"},
+		{path: "$.name", want: "hortbot"},
+		{path: " $name ", want: "hortbot"},
+		{path: "count", want: "12345678901234567890"},
+		{path: "ratio", want: "0.5"},
+		{path: "live", want: "true"},
+		{path: "game", want: ""},
+		{path: "tags", want: `["one","two"]`},
+		{path: "tags[1]", want: "two"},
+		{path: "stream", want: `{"title":"Hello world","viewers":42}`},
+		{path: "stream.viewers", want: "42"},
+		{path: "items[1].nested.a[0]", want: "1"},
+		{path: "$[\"name\"]", err: jsonx.ErrBadPath},
+		{path: "missing", err: jsonx.ErrPathNotFound},
+		{path: "tags[2]", err: jsonx.ErrPathNotFound},
+		{path: "tags[-1]", err: jsonx.ErrBadPath},
+		{path: "tags[1", err: jsonx.ErrBadPath},
+		{path: "name.first", err: jsonx.ErrPathNotFound},
+		{path: "stream[0]", err: jsonx.ErrPathNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			t.Parallel()
+			got, err := jsonx.Extract([]byte(data), test.path)
+			if test.err != nil {
+				assert.Equal(t, err, test.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, test.want)
+		})
+	}
+}
+
+func TestExtractWholeValue(t *testing.T) {
+	t.Parallel()
+	got, err := jsonx.Extract([]byte(`"just a string"`), "$")
+	assert.NilError(t, err)
+	assert.Equal(t, got, "just a string")
+}
+
+func TestExtractBadJSON(t *testing.T) {
+	t.Parallel()
+	_, err := jsonx.Extract([]byte(`{`), "foo")
+	assert.ErrorContains(t, err, "unexpected EOF")
+
+	_, err = jsonx.Extract([]byte(`{}{}`), "foo")
+	assert.Equal(t, err, jsonx.ErrMoreThanOne)
+}
@@ -0,0 +1,194 @@
+// Package rematch matches strings against many regular expressions at once.
+package rematch
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+)
+
+// Set is a precompiled, ordered set of regular expressions.
+//
+// Each pattern which requires some literal substring to match is only
+// evaluated if the string contains that substring. Patterns without such a
+// literal are combined into a single regular expression, which is used to
+// skip them all at once when none can match.
+type Set struct {
+	patterns []pattern
+	combined *regexp.Regexp
+}
+
+type pattern struct {
+	index   int
+	re      *regexp.Regexp
+	literal string
+	fold    bool
+}
+
+// New compiles a set from patterns using the given compile function, which
+// is typically backed by a cache. Patterns which fail to compile never match.
+func New(patterns []string, compile func(string) (*regexp.Regexp, error)) *Set {
+	s := &Set{
+		patterns: make([]pattern, 0, len(patterns)),
+	}
+
+	var unfiltered []string
+
+	for i, p := range patterns {
+		re, err := compile(p)
+		if err != nil {
+			continue
+		}
+
+		literal, fold := requiredLiteral(re.String())
+		if literal == "" {
+			unfiltered = append(unfiltered, `(?:`+re.String()+`)`)
+		}
+
+		s.patterns = append(s.patterns, pattern{
+			index:   i,
+			re:      re,
+			literal: literal,
+			fold:    fold,
+		})
+	}
+
+	if len(unfiltered) > 1 {
+		// If the combination can't be compiled, each pattern is tried alone.
+		s.combined, _ = regexp.Compile(strings.Join(unfiltered, "|"))
+	}
+
+	return s
+}
+
+// Len returns the number of patterns in the set which compiled.
+func (s *Set) Len() int {
+	return len(s.patterns)
+}
+
+// Each calls fn with the index (into the patterns passed to New), compiled
+// regexp, and submatches of each pattern which matches str, in order, until
+// fn returns false.
+func (s *Set) Each(str string, fn func(i int, re *regexp.Regexp, groups []string) bool) {
+	var (
+		folded         string
+		foldedDone     bool
+		unfiltered     bool
+		unfilteredDone bool
+	)
+
+	for _, p := range s.patterns {
+		switch {
+		case p.literal == "":
+			if !unfilteredDone {
+				unfiltered = s.combined == nil || s.combined.MatchString(str)
+				unfilteredDone = true
+			}
+			if !unfiltered {
+				continue
+			}
+		case p.fold:
+			if !foldedDone {
+				folded = foldASCII(str)
+				foldedDone = true
+			}
+			if !strings.Contains(folded, p.literal) {
+				continue
+			}
+		default:
+			if !strings.Contains(str, p.literal) {
+				continue
+			}
+		}
+
+		groups := p.re.FindStringSubmatch(str)
+		if groups == nil {
+			continue
+		}
+
+		if !fn(p.index, p.re, groups) {
+			return
+		}
+	}
+}
+
+// MatchString reports whether any pattern in the set matches str.
+func (s *Set) MatchString(str string) bool {
+	found := false
+	s.Each(str, func(int, *regexp.Regexp, []string) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// requiredLiteral finds the longest literal which any match of the pattern
+// must contain. If the literal is matched case-insensitively, it is returned
+// lowercased with fold set; only ASCII literals are folded.
+func requiredLiteral(expr string) (literal string, fold bool) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return "", false
+	}
+	return findLiteral(re.Simplify())
+}
+
+func findLiteral(re *syntax.Regexp) (literal string, fold bool) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		literal = string(re.Rune)
+		if re.Flags&syntax.FoldCase == 0 {
+			return literal, false
+		}
+		if !isASCII(literal) {
+			return "", false
+		}
+		return strings.ToLower(literal), true
+
+	case syntax.OpCapture, syntax.OpPlus:
+		return findLiteral(re.Sub[0])
+
+	case syntax.OpRepeat:
+		if re.Min >= 1 {
+			return findLiteral(re.Sub[0])
+		}
+
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if l, f := findLiteral(sub); len(l) > len(literal) {
+				literal, fold = l, f
+			}
+		}
+		return literal, fold
+	}
+
+	return "", false
+}
+
+// foldASCII lowercases ASCII letters, and maps the non-ASCII runes which
+// case-fold to ASCII letters onto them, so that a lowercased ASCII literal is
+// contained in the result exactly when it case-insensitively matches str.
+func foldASCII(str string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case 'A' <= r && r <= 'Z':
+			return r + 'a' - 'A'
+		case r == '\u212a': // Kelvin sign
+			return 'k'
+		case r == '\u017f': // Long s
+			return 's'
+		default:
+			return r
+		}
+	}, str)
+}
+
+func isASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
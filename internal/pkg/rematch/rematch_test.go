@@ -0,0 +1,140 @@
+package rematch
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hortbot/hortbot/internal/pkg/recache"
+	"gotest.tools/v3/assert"
+)
+
+func TestRequiredLiteral(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr    string
+		literal string
+		fold    bool
+	}{
+		{expr: `hello`, literal: "hello"},
+		{expr: `(?i)Hello`, literal: "hello", fold: true},
+		{expr: `(?i)^.*what game.*$`, literal: "what game", fold: true},
+		{expr: `^foo.*barbaz$`, literal: "barbaz"},
+		{expr: `(foo)+`, literal: "foo"},
+		{expr: `(?:foo){2,3}`, literal: "foo"},
+		{expr: `(?:foo)?bar`, literal: "bar"},
+		{expr: `(?:foo)*`},
+		{expr: `foo|bar`},
+		{expr: `\w+`},
+		{expr: `(?i)héllo`},
+		{expr: `(?i)ééé`},
+		{expr: `ééé`, literal: "ééé"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.expr, func(t *testing.T) {
+			t.Parallel()
+			literal, fold := requiredLiteral(test.expr)
+			assert.Equal(t, literal, test.literal)
+			assert.Equal(t, fold, test.fold)
+		})
+	}
+}
+
+func TestEach(t *testing.T) {
+	t.Parallel()
+
+	s := New([]string{
+		`^!hug (\w+)$`,
+		`(`,
+		`\d+`,
+		`(?i)hug`,
+		`[a-z]+`,
+	}, regexp.Compile)
+	assert.Equal(t, s.Len(), 4)
+
+	type match struct {
+		Index  int
+		Groups []string
+	}
+
+	collect := func(str string) []match {
+		var matches []match
+		s.Each(str, func(i int, re *regexp.Regexp, groups []string) bool {
+			matches = append(matches, match{Index: i, Groups: groups})
+			return true
+		})
+		return matches
+	}
+
+	assert.DeepEqual(t, collect("!hug zik"), []match{
+		{Index: 0, Groups: []string{"!hug zik", "zik"}},
+		{Index: 3, Groups: []string{"hug"}},
+		{Index: 4, Groups: []string{"hug"}},
+	})
+	assert.DeepEqual(t, collect("HUG 123"), []match{
+		{Index: 2, Groups: []string{"123"}},
+		{Index: 3, Groups: []string{"HUG"}},
+	})
+	assert.DeepEqual(t, collect("!!!"), []match(nil))
+
+	var first []int
+	s.Each("!hug zik", func(i int, re *regexp.Regexp, groups []string) bool {
+		first = append(first, i)
+		return false
+	})
+	assert.DeepEqual(t, first, []int{0})
+}
+
+func TestCaseFolding(t *testing.T) {
+	t.Parallel()
+
+	c := recache.New()
+	s := New([]string{"kiss"}, c.Compile)
+
+	assert.Assert(t, s.MatchString("KISS"))
+	assert.Assert(t, s.MatchString("a Kiss in the café"))
+	assert.Assert(t, s.MatchString("kiſs"))
+	assert.Assert(t, !s.MatchString("kis"))
+}
+
+func TestMatchStringUnfiltered(t *testing.T) {
+	t.Parallel()
+
+	s := New([]string{`^\d+$`, `^[xyz]{3}$`}, regexp.Compile)
+	assert.Assert(t, s.combined != nil)
+	assert.Assert(t, s.MatchString("123"))
+	assert.Assert(t, s.MatchString("xyz"))
+	assert.Assert(t, !s.MatchString("abc"))
+}
+
+func BenchmarkMatchString(b *testing.B) {
+	for _, n := range []int{1, 100, 1000} {
+		patterns := make([]string, n)
+		for i := range patterns {
+			patterns[i] = fmt.Sprintf(`^.*\Qphrase%d\E.*$`, i)
+		}
+
+		const message = "this is a perfectly normal chat message which matches nothing"
+
+		b.Run(fmt.Sprintf("set-%d", n), func(b *testing.B) {
+			s := New(patterns, recache.New().Compile)
+			for b.Loop() {
+				s.MatchString(message)
+			}
+		})
+
+		b.Run(fmt.Sprintf("loop-%d", n), func(b *testing.B) {
+			c := recache.New()
+			for b.Loop() {
+				for _, p := range patterns {
+					re, _ := c.Compile(p)
+					if re.MatchString(message) {
+						break
+					}
+				}
+			}
+		})
+	}
+}
@@ -20,6 +20,22 @@ func TestParseCron(t *testing.T) {
 	assert.Error(t, err, "empty spec string")
 }
 
+func TestParseCronIn(t *testing.T) {
+	berlin := must.Must(time.LoadLocation("Europe/Berlin"))
+	c := must.Must(repeat.ParseCronIn("0 9 * * *", berlin))
+
+	// Summer time (UTC+2).
+	assert.Equal(t, c.Next(mustParseTime("2021-03-27T12:00:00Z")).UTC(), mustParseTime("2021-03-28T07:00:00Z"))
+	// Winter time (UTC+1).
+	assert.Equal(t, c.Next(mustParseTime("2021-10-30T12:00:00Z")).UTC(), mustParseTime("2021-10-31T08:00:00Z"))
+
+	explicit := must.Must(repeat.ParseCronIn("CRON_TZ=UTC 0 9 * * *", berlin))
+	assert.Equal(t, explicit.Next(mustParseTime("2021-03-27T12:00:00Z")).UTC(), mustParseTime("2021-03-28T09:00:00Z"))
+
+	_, err := repeat.ParseCronIn("", berlin)
+	assert.Error(t, err, "empty spec string")
+}
+
 func TestCronAdd(t *testing.T) {
 	synctest.Test(t, func(t *testing.T) {
 		advanceToStartTime(t)
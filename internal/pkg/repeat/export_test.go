@@ -1,7 +1,15 @@
 package repeat
 
-import "github.com/robfig/cron/v3"
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
 
 func ToCron(s cron.Schedule) *Cron {
 	return &Cron{expr: s}
 }
+
+func (c *Cron) Next(t time.Time) time.Time {
+	return c.expr.Next(t)
+}
@@ -3,6 +3,7 @@ package repeat
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -74,3 +75,23 @@ func ParseCron(s string) (*Cron, error) {
 		expr: expr,
 	}, nil
 }
+
+// ParseCronIn parses a crontab line into a Cron which is evaluated in loc,
+// unless the line specifies its own time zone with a CRON_TZ or TZ prefix.
+// Daylight saving transitions are handled according to loc.
+func ParseCronIn(s string, loc *time.Location) (*Cron, error) {
+	c, err := ParseCron(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(s, "TZ=") || strings.HasPrefix(s, "CRON_TZ=") {
+		return c, nil
+	}
+
+	if spec, ok := c.expr.(*cron.SpecSchedule); ok {
+		spec.Location = loc
+	}
+
+	return c, nil
+}
@@ -1,7 +1,10 @@
 // Package stringsx contains extensions to the strings package.
 package stringsx
 
-import "strings"
+import (
+	"strings"
+	"unicode/utf8"
+)
 
 // Split splits a string in two by a separator. If the separator is not
 // present in s, then s and an empty string are returned.
@@ -31,3 +34,15 @@ func SplitByte(s string, sep byte) (string, string) {
 	}
 	return s[:i], s[i+1:]
 }
+
+// Truncate shortens s to at most n bytes without splitting a UTF-8 encoded
+// character.
+func Truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -68,3 +68,23 @@ func TestSplit(t *testing.T) {
 		})
 	}
 }
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		n     int
+		want  string
+	}{
+		{"", 3, ""},
+		{"foo", 3, "foo"},
+		{"foobar", 3, "foo"},
+		{"héllo", 2, "h"},
+		{"héllo", 3, "hé"},
+		{"日本語", 4, "日"},
+		{"日本語", 0, ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, stringsx.Truncate(test.input, test.n))
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/hortbot/hortbot/internal/confimport"
+	"github.com/hortbot/hortbot/internal/confimport/foreign"
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/pkg/dbx"
 	"github.com/hortbot/hortbot/internal/pkg/jsonx"
@@ -34,6 +35,7 @@ func (a *App) routeAdmin(r chi.Router) {
 
 	r.Get("/import", a.adminImport)
 	r.Post("/import", a.adminImportPost)
+	r.Post("/import/foreign", a.adminImportForeignPost)
 	r.Get("/export/{channel}", a.adminExport)
 	r.Get("/stats", a.adminStats)
 }
@@ -124,6 +126,75 @@ func (a *App) adminImportPost(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintln(w, "Successfully inserted channel", config.Channel.ID)
 }
 
+func (a *App) adminImportForeignPost(w http.ResponseWriter, r *http.Request) {
+	query := struct {
+		Format   string `queryparam:"format"`
+		TwitchID int64  `queryparam:"twitch_id"`
+		Name     string `queryparam:"name"`
+		BotName  string `queryparam:"bot_name"`
+		DryRun   bool   `queryparam:"dry_run"`
+	}{}
+
+	if err := queryparam.Parse(r.URL.Query(), &query); err != nil {
+		http.Error(w, "parsing query: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "reading body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := foreign.Convert(foreign.Format(query.Format), data, foreign.Options{
+		TwitchID: query.TwitchID,
+		Name:     query.Name,
+		BotName:  query.BotName,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if query.DryRun {
+		writeImportWarnings(w, result.Warnings)
+		fmt.Fprintln(w)
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(result.Config); err != nil {
+			ctxlog.Error(r.Context(), "error encoding converted config", zap.Error(err))
+		}
+		return
+	}
+
+	err = dbx.Transact(r.Context(), a.DB,
+		dbx.SetLocalLockTimeout(5*time.Second),
+		func(ctx context.Context, tx pgx.Tx) error {
+			return result.Config.Insert(ctx, dbsql.New(tx))
+		},
+	)
+	if err != nil {
+		http.Error(w, "inserting config: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	fmt.Fprintln(w, "Successfully inserted channel", result.Config.Channel.ID)
+	writeImportWarnings(w, result.Warnings)
+}
+
+func writeImportWarnings(w io.Writer, warnings []string) {
+	if len(warnings) == 0 {
+		fmt.Fprintln(w, "Everything was converted.")
+		return
+	}
+
+	fmt.Fprintln(w, "Not everything could be converted:")
+	for _, warning := range warnings {
+		fmt.Fprintln(w, "-", warning)
+	}
+}
+
 func (a *App) adminStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -37,6 +37,11 @@ func (a *App) apiV1VarsGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if variable.Expired(time.Now()) {
+		v1Error(w, http.StatusNotFound)
+		return
+	}
+
 	v := &struct {
 		Channel      string    `json:"channel"`
 		Var          string    `json:"var"`
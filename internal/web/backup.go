@@ -0,0 +1,269 @@
+package web
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hortbot/hortbot/internal/confimport"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/dbx"
+	"github.com/hortbot/hortbot/internal/pkg/jsonx"
+	"github.com/hortbot/hortbot/internal/web/templates"
+	"github.com/jackc/pgx/v5"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+const (
+	paramBackup   = "backup"
+	maxBackupSize = 32 << 20
+)
+
+func (a *App) routeChannelBackup(r chi.Router) {
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !a.canEditChannel(r, getChannel(r.Context())) {
+				a.notAuthorized(w, r, false)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	r.Get("/", a.channelBackup)
+	r.Post("/", a.channelBackupPost)
+	r.Get("/export", a.channelBackupExport)
+	r.Post("/upload", a.channelBackupUpload)
+	r.Get("/{"+paramBackup+"}", a.channelBackupRestore)
+	r.Get("/{"+paramBackup+"}/download", a.channelBackupDownload)
+	r.Post("/{"+paramBackup+"}/restore", a.channelBackupRestorePost)
+	r.Post("/{"+paramBackup+"}/delete", a.channelBackupDeletePost)
+}
+
+func (a *App) channelBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	backups, err := a.Queries.ListChannelBackups(ctx, channel.ID)
+	if err != nil {
+		ctxlog.Error(ctx, "error querying backups", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.ChannelBackupPage(channel, backups))
+}
+
+func (a *App) channelBackupPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	if _, err := confimport.SaveBackup(ctx, a.Queries, *channel, a.backupCreator(r, channel), dbsql.BackupKindManual); err != nil {
+		ctxlog.Error(ctx, "error saving backup", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/c/"+channel.Name+"/backup", http.StatusSeeOther)
+}
+
+func (a *App) channelBackupExport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	config, err := confimport.Export(ctx, a.Queries, *channel)
+	if err != nil {
+		ctxlog.Error(ctx, "error exporting channel", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	data, err := json.MarshalIndent(config, "", "    ")
+	if err != nil {
+		ctxlog.Error(ctx, "error encoding exported config", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	writeBackup(w, channel, time.Now(), data)
+}
+
+func (a *App) channelBackupUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBackupSize)
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		a.httpError(w, r, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	config := &confimport.Config{}
+	if err := jsonx.DecodeSingle(file, config); err != nil {
+		http.Error(w, "The uploaded file is not a valid backup.", http.StatusBadRequest)
+		return
+	}
+
+	if config.Version > confimport.Version || config.Channel == nil {
+		http.Error(w, "The uploaded file is not a valid backup, or is newer than this bot supports.", http.StatusBadRequest)
+		return
+	}
+
+	id, err := confimport.StoreBackup(ctx, a.Queries, channel.ID, config, a.backupCreator(r, channel), dbsql.BackupKindUpload)
+	if err != nil {
+		ctxlog.Error(ctx, "error storing uploaded backup", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/c/"+channel.Name+"/backup/"+strconv.FormatInt(id, 10), http.StatusSeeOther)
+}
+
+func (a *App) channelBackupRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	backup, ok := a.getBackup(w, r, channel)
+	if !ok {
+		return
+	}
+
+	config, err := confimport.LoadBackup(backup)
+	if err != nil {
+		http.Error(w, "The backup cannot be restored: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	renderTempl(w, r, templates.ChannelBackupRestorePage(channel, backup, config))
+}
+
+func (a *App) channelBackupDownload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	backup, ok := a.getBackup(w, r, channel)
+	if !ok {
+		return
+	}
+
+	writeBackup(w, channel, backup.CreatedAt.Time, backup.Data)
+}
+
+func (a *App) channelBackupRestorePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	mode := confimport.RestoreMode(r.PostFormValue("mode"))
+	if r.PostFormValue("confirm") != "true" || (mode != confimport.RestoreMerge && mode != confimport.RestoreReplace) {
+		a.httpError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	backup, ok := a.getBackup(w, r, channel)
+	if !ok {
+		return
+	}
+
+	config, err := confimport.LoadBackup(backup)
+	if err != nil {
+		http.Error(w, "The backup cannot be restored: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	creator := a.backupCreator(r, channel)
+
+	var warnings []string
+	err = dbx.Transact(ctx, a.DB,
+		dbx.SetLocalLockTimeout(5*time.Second),
+		func(ctx context.Context, tx pgx.Tx) error {
+			queries := dbsql.New(tx)
+			if _, err := confimport.SaveBackup(ctx, queries, *channel, creator, dbsql.BackupKindRestore); err != nil {
+				return err
+			}
+			warnings, err = config.Restore(ctx, queries, channel, mode)
+			return err
+		},
+	)
+	if err != nil {
+		ctxlog.Error(ctx, "error restoring backup", zap.Error(err), zap.Int64("backup_id", backup.ID))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.ChannelBackupRestoredPage(channel, warnings))
+}
+
+func (a *App) channelBackupDeletePost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	id, err := strconv.ParseInt(chi.URLParam(r, paramBackup), 10, 64)
+	if err != nil {
+		a.httpError(w, r, http.StatusNotFound)
+		return
+	}
+
+	deleted, err := a.Queries.DeleteChannelBackup(ctx, dbsql.DeleteChannelBackupParams{
+		ChannelID: channel.ID,
+		ID:        id,
+	})
+	if err != nil {
+		ctxlog.Error(ctx, "error deleting backup", zap.Error(err), zap.Int64("backup_id", id))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	if deleted == 0 {
+		a.httpError(w, r, http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, "/c/"+channel.Name+"/backup", http.StatusSeeOther)
+}
+
+func (a *App) getBackup(w http.ResponseWriter, r *http.Request, channel *dbsql.Channel) (*dbsql.ChannelBackup, bool) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, paramBackup), 10, 64)
+	if err != nil {
+		a.httpError(w, r, http.StatusNotFound)
+		return nil, false
+	}
+
+	backup, err := a.Queries.GetChannelBackup(ctx, dbsql.GetChannelBackupParams{
+		ChannelID: channel.ID,
+		ID:        id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			a.httpError(w, r, http.StatusNotFound)
+		} else {
+			ctxlog.Error(ctx, "error querying backup", zap.Error(err))
+			a.httpError(w, r, http.StatusInternalServerError)
+		}
+		return nil, false
+	}
+
+	return &backup, true
+}
+
+func (a *App) backupCreator(r *http.Request, channel *dbsql.Channel) string {
+	return cmp.Or(a.getSession(r).getUsername(), channel.Name)
+}
+
+func writeBackup(w http.ResponseWriter, channel *dbsql.Channel, createdAt time.Time, data []byte) {
+	filename := fmt.Sprintf("%s-backup-%s.json", channel.Name, createdAt.UTC().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,69 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hortbot/hortbot/internal/bot"
+	"github.com/hortbot/hortbot/internal/web/templates"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+// maxDryRunInput is the most text accepted for a dry run, a bit over the
+// length of a chat message.
+const maxDryRunInput = 1000
+
+func (a *App) routeChannelTest(r chi.Router) {
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !a.canEditChannel(r, getChannel(r.Context())) {
+				a.notAuthorized(w, r, false)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	r.Get("/", a.channelTest)
+	r.Post("/", a.channelTestPost)
+}
+
+func (a *App) channelTest(w http.ResponseWriter, r *http.Request) {
+	channel := getChannel(r.Context())
+	renderTempl(w, r, templates.ChannelTestPage(channel, a.DryRunner != nil, string(bot.DryRunCommand), "", ""))
+}
+
+func (a *App) channelTestPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	if a.DryRunner == nil {
+		a.httpError(w, r, http.StatusNotFound)
+		return
+	}
+
+	kind := bot.DryRunKind(r.PostFormValue("kind"))
+	switch kind {
+	case bot.DryRunCommand, bot.DryRunAutoreply, bot.DryRunFilter:
+	default:
+		a.httpError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	input := strings.TrimSpace(r.PostFormValue("input"))
+	if input == "" || len(input) > maxDryRunInput {
+		a.httpError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	result, err := a.DryRunner.DryRun(ctx, channel.ID, kind, input)
+	if err != nil {
+		ctxlog.Error(ctx, "error running dry run", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.ChannelTestPage(channel, true, string(kind), input, result))
+}
@@ -1,9 +1,19 @@
 package web
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
 
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
 	"github.com/hortbot/hortbot/internal/web/templates"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
 	"golang.org/x/net/publicsuffix"
 )
 
@@ -49,6 +59,66 @@ func (a *App) notAuthorized(w http.ResponseWriter, r *http.Request, header bool)
 	a.httpError(w, r, http.StatusUnauthorized)
 }
 
+// canEditChannel reports whether the logged in user owns the channel, either
+// as the broadcaster or as one of the channel's custom owners.
+func (a *App) canEditChannel(r *http.Request, channel *dbsql.Channel) bool {
+	session := a.getSession(r)
+
+	if id := session.getTwitchID(); id != 0 && id == channel.TwitchID {
+		return true
+	}
+
+	username := session.getUsername()
+	return username != "" && slices.Contains(channel.CustomOwners, username)
+}
+
+// canModerateChannel reports whether the logged in user may view the channel's
+// moderation pages: its owners, and its Twitch moderators.
+func (a *App) canModerateChannel(r *http.Request, channel *dbsql.Channel) bool {
+	if a.canEditChannel(r, channel) {
+		return true
+	}
+
+	id := a.getSession(r).getTwitchID()
+	if id == 0 {
+		return false
+	}
+
+	ctx := r.Context()
+
+	isMod, err := a.isChannelModerator(ctx, channel, id)
+	if err != nil {
+		ctxlog.Error(ctx, "error checking channel moderators", zap.Error(err))
+		return false
+	}
+	return isMod
+}
+
+// isChannelModerator checks the channel's moderator list with the
+// broadcaster's token. Without a token, nobody is a moderator.
+func (a *App) isChannelModerator(ctx context.Context, channel *dbsql.Channel, userID int64) (bool, error) {
+	tt, err := a.Queries.GetTwitchTokenByID(ctx, channel.TwitchID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("getting token: %w", err)
+	}
+
+	mods, newToken, err := a.Twitch.GetChannelModerators(ctx, channel.TwitchID, tt.OAuth2Token())
+	if newToken != nil {
+		tt := dbsql.NewTwitchToken(newToken, channel.TwitchID, pgtype.Text{}, nil)
+		if err := a.Queries.SaveTwitchTokenPreservingMetadata(ctx, tt); err != nil {
+			return false, fmt.Errorf("upserting token: %w", err)
+		}
+	}
+	if err != nil {
+		return false, fmt.Errorf("getting moderators: %w", err)
+	}
+
+	return slices.ContainsFunc(mods, func(m *twitch.ChannelModerator) bool { return int64(m.ID) == userID }), nil
+}
+
 func normalizeHost(host string) string {
 	if host == "" {
 		return host
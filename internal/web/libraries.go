@@ -0,0 +1,59 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hortbot/hortbot/internal/confimport"
+	"github.com/hortbot/hortbot/internal/web/templates"
+	"github.com/jackc/pgx/v5"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+const paramLibrary = "library"
+
+func (a *App) libraries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	libraries, err := a.Queries.ListCommandLibraries(ctx)
+	if err != nil {
+		ctxlog.Error(ctx, "error querying libraries", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.LibrariesPage(libraries))
+}
+
+func (a *App) library(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	library, err := a.Queries.GetCommandLibraryByName(ctx, chi.URLParam(r, paramLibrary))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			a.httpError(w, r, http.StatusNotFound)
+		} else {
+			ctxlog.Error(ctx, "error querying library", zap.Error(err))
+			a.httpError(w, r, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	publisher, err := a.Queries.GetChannelByID(ctx, library.ChannelID)
+	if err != nil {
+		ctxlog.Error(ctx, "error querying library publisher", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	contents, err := confimport.LoadLibrary(library.Data)
+	if err != nil {
+		ctxlog.Error(ctx, "error decoding library", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.LibraryPage(&library, publisher.Name, contents))
+}
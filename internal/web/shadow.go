@@ -0,0 +1,59 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hortbot/hortbot/internal/db/dbsql"
+	"github.com/hortbot/hortbot/internal/web/templates"
+	"github.com/zikaeroh/ctxlog"
+	"go.uber.org/zap"
+)
+
+const (
+	// shadowHitsHours matches how long shadow filter hits are kept.
+	shadowHitsHours = 7 * 24
+	maxShadowHits   = 1000
+)
+
+func (a *App) routeChannelShadow(r chi.Router) {
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !a.canModerateChannel(r, getChannel(r.Context())) {
+				a.notAuthorized(w, r, false)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+
+	r.Get("/", a.channelShadow)
+}
+
+func (a *App) channelShadow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	counts, err := a.Queries.CountFilterShadowHits(ctx, dbsql.CountFilterShadowHitsParams{
+		ChannelID: channel.ID,
+		Hours:     shadowHitsHours,
+	})
+	if err != nil {
+		ctxlog.Error(ctx, "error counting shadow filter hits", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	hits, err := a.Queries.ListFilterShadowHits(ctx, dbsql.ListFilterShadowHitsParams{
+		ChannelID: channel.ID,
+		Hours:     shadowHitsHours,
+		MaxHits:   maxShadowHits,
+	})
+	if err != nil {
+		ctxlog.Error(ctx, "error querying shadow filter hits", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.ChannelShadowPage(channel, counts, hits))
+}
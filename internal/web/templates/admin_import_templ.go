@@ -8,6 +8,8 @@ package templates
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
+import "github.com/hortbot/hortbot/internal/confimport/foreign"
+
 func adminImportMeta() templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
@@ -58,7 +60,7 @@ func adminImportScripts() templ.Component {
 			templ_7745c5c3_Var2 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<script>\n\tdocument.addEventListener('DOMContentLoaded', function() {\n\t\tdocument.getElementById('import-form').addEventListener('submit', function(event) {\n\t\t\tevent.preventDefault();\n\n\t\t\tfetch('/admin/import', {\n\t\t\t\tmethod: 'POST',\n\t\t\t\theaders: { 'Content-Type': 'application/json' },\n\t\t\t\tbody: document.getElementById('import-data').value\n\t\t\t}).then(function(res) {\n\t\t\t\treturn res.text().then(function(text) {\n\t\t\t\t\tvar pre = document.createElement('pre');\n\t\t\t\t\tpre.textContent = text;\n\t\t\t\t\tvar output = document.getElementById('output');\n\t\t\t\t\toutput.insertBefore(pre, output.firstChild);\n\t\t\t\t});\n\t\t\t});\n\t\t});\n\t});\n\t</script>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<script>\n\tdocument.addEventListener('DOMContentLoaded', function() {\n\t\tdocument.getElementById('import-form').addEventListener('submit', function(event) {\n\t\t\tevent.preventDefault();\n\n\t\t\tvar format = document.getElementById('import-format').value;\n\t\t\tvar url = '/admin/import';\n\t\t\tvar contentType = 'application/json';\n\n\t\t\tif (format !== '') {\n\t\t\t\tvar params = new URLSearchParams({\n\t\t\t\t\tformat: format,\n\t\t\t\t\ttwitch_id: document.getElementById('import-twitch-id').value,\n\t\t\t\t\tname: document.getElementById('import-name').value,\n\t\t\t\t\tbot_name: document.getElementById('import-bot-name').value,\n\t\t\t\t\tdry_run: document.getElementById('import-dry-run').checked ? 'true' : 'false'\n\t\t\t\t});\n\t\t\t\turl = '/admin/import/foreign?' + params.toString();\n\t\t\t\tcontentType = 'text/plain';\n\t\t\t}\n\n\t\t\tfetch(url, {\n\t\t\t\tmethod: 'POST',\n\t\t\t\theaders: { 'Content-Type': contentType },\n\t\t\t\tbody: document.getElementById('import-data').value\n\t\t\t}).then(function(res) {\n\t\t\t\treturn res.text().then(function(text) {\n\t\t\t\t\tvar pre = document.createElement('pre');\n\t\t\t\t\tpre.textContent = text;\n\t\t\t\t\tvar output = document.getElementById('output');\n\t\t\t\t\toutput.insertBefore(pre, output.firstChild);\n\t\t\t\t});\n\t\t\t});\n\t\t});\n\t});\n\t</script>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -87,7 +89,43 @@ func adminImportBody() templ.Component {
 			templ_7745c5c3_Var3 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<section class=\"section\"><div class=\"container content\"><h1 class=\"title has-text-centered\">Import config</h1><div class=\"columns\"><div class=\"column is-8 is-offset-2 has-text-centered\"><form id=\"import-form\" method=\"POST\" action=\"/admin/import\" autocomplete=\"off\"><div class=\"field\"><div class=\"control\"><textarea id=\"import-data\" class=\"textarea\" placeholder=\"{}\"></textarea></div></div><div class=\"field\"><div class=\"control\"><button class=\"button is-link\">Import</button></div></div></form><br><div id=\"output\" class=\"has-text-left\"></div></div></div></div></section>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "<section class=\"section\"><div class=\"container content\"><h1 class=\"title has-text-centered\">Import config</h1><div class=\"columns\"><div class=\"column is-8 is-offset-2 has-text-centered\"><form id=\"import-form\" method=\"POST\" action=\"/admin/import\" autocomplete=\"off\"><div class=\"field is-grouped is-grouped-multiline\"><div class=\"control\"><div class=\"select\"><select id=\"import-format\"><option value=\"\">HortBot export</option> ")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		for _, format := range foreign.Formats {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<option value=\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var4 string
+			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.ResolveAttributeValue(string(format))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/admin_import.templ`, Line: 67, Col: 41}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ_7745c5c3_Var4)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var5 string
+			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(string(format))
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/admin_import.templ`, Line: 67, Col: 60}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</option>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</select></div></div><div class=\"control\"><input id=\"import-name\" class=\"input\" type=\"text\" placeholder=\"Channel name\"></div><div class=\"control\"><input id=\"import-twitch-id\" class=\"input\" type=\"number\" placeholder=\"Twitch ID\"></div><div class=\"control\"><input id=\"import-bot-name\" class=\"input\" type=\"text\" placeholder=\"Bot name\"></div><div class=\"control\"><label class=\"checkbox\"><input id=\"import-dry-run\" type=\"checkbox\" checked> Dry run</label></div></div><div class=\"field\"><div class=\"control\"><textarea id=\"import-data\" class=\"textarea\" placeholder=\"{}\"></textarea></div></div><div class=\"field\"><div class=\"control\"><button class=\"button is-link\">Import</button></div></div></form><br><div id=\"output\" class=\"has-text-left\"></div></div></div></div></section>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -111,12 +149,12 @@ func AdminImportPage() templ.Component {
 			}()
 		}
 		ctx = templ.InitializeContext(ctx)
-		templ_7745c5c3_Var4 := templ.GetChildren(ctx)
-		if templ_7745c5c3_Var4 == nil {
-			templ_7745c5c3_Var4 = templ.NopComponent
+		templ_7745c5c3_Var6 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var6 == nil {
+			templ_7745c5c3_Var6 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Var5 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_Var7 := templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 			templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 			templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
 			if !templ_7745c5c3_IsBuffer {
@@ -134,7 +172,7 @@ func AdminImportPage() templ.Component {
 			}
 			return nil
 		})
-		templ_7745c5c3_Err = PageTemplate(getBrand(ctx)+" - Import config", adminImportMeta(), adminImportScripts()).Render(templ.WithChildren(ctx, templ_7745c5c3_Var5), templ_7745c5c3_Buffer)
+		templ_7745c5c3_Err = PageTemplate(getBrand(ctx)+" - Import config", adminImportMeta(), adminImportScripts()).Render(templ.WithChildren(ctx, templ_7745c5c3_Var7), templ_7745c5c3_Buffer)
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
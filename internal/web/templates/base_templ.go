@@ -130,7 +130,7 @@ func PageTemplate(title string, meta templ.Component, scripts templ.Component) t
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</a> <button type=\"button\" class=\"navbar-burger burger\" aria-label=\"menu\" aria-expanded=\"false\" data-target=\"navMenu\"><span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span></button></div><div class=\"navbar-menu\" id=\"navMenu\"><div class=\"navbar-start\"><a class=\"navbar-item\" href=\"/channels\">Channels</a> <a class=\"navbar-item\" href=\"/docs\">Documentation</a> <a class=\"navbar-item\" href=\"/help\">Help</a> <a class=\"navbar-item\" href=\"https://discord.gg/V9Eza32\">Discord</a> <a class=\"navbar-item\" href=\"/about\">About</a></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</a> <button type=\"button\" class=\"navbar-burger burger\" aria-label=\"menu\" aria-expanded=\"false\" data-target=\"navMenu\"><span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span> <span aria-hidden=\"true\"></span></button></div><div class=\"navbar-menu\" id=\"navMenu\"><div class=\"navbar-start\"><a class=\"navbar-item\" href=\"/channels\">Channels</a> <a class=\"navbar-item\" href=\"/libraries\">Libraries</a> <a class=\"navbar-item\" href=\"/docs\">Documentation</a> <a class=\"navbar-item\" href=\"/help\">Help</a> <a class=\"navbar-item\" href=\"https://discord.gg/V9Eza32\">Discord</a> <a class=\"navbar-item\" href=\"/about\">About</a></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -43,3 +43,16 @@ func buildStyle(font, color string) templ.SafeCSS {
 	}
 	return templ.SafeCSS(b.String())
 }
+
+func raffleWinnerStatus(status string) string {
+	switch status {
+	case "pending":
+		return "Waiting to claim"
+	case "claimed":
+		return "Claimed"
+	case "forfeited":
+		return "Not claimed"
+	default:
+		return "Won"
+	}
+}
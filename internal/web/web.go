@@ -2,19 +2,25 @@
 package web
 
 import (
+	"cmp"
 	"context"
 	"crypto/rand"
 	"embed"
+	"encoding/json"
 	"io/fs"
 	"net"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/a-h/templ"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/gorilla/sessions"
+	"github.com/hortbot/hortbot/internal/bot"
 	"github.com/hortbot/hortbot/internal/db/botstate"
 	"github.com/hortbot/hortbot/internal/db/dbsql"
 	"github.com/hortbot/hortbot/internal/pkg/apiclient/twitch"
@@ -51,6 +57,7 @@ type App struct {
 	Queries                *dbsql.Queries
 	Twitch                 twitch.API
 	EventsubUpdateNotifier EventsubUpdateNotifier
+	DryRunner              DryRunner
 
 	store *sessions.CookieStore
 }
@@ -59,6 +66,11 @@ type EventsubUpdateNotifier interface {
 	NotifyEventsubUpdates(ctx context.Context, queries *dbsql.Queries) error
 }
 
+// DryRunner tests commands, autoreplies, and filters without side effects.
+type DryRunner interface {
+	DryRun(ctx context.Context, channelID int64, kind bot.DryRunKind, input string) (string, error)
+}
+
 // Run runs the webapp until the context is canceled.
 func (a *App) Run(ctx context.Context) error {
 	requestCtx, cancelRequests := contextx.WithGracePeriod(ctx, 30*time.Second)
@@ -99,6 +111,8 @@ func (a *App) Run(ctx context.Context) error {
 		r.Get("/help", a.help)
 		r.Get("/docs", a.docs)
 		r.Get("/channels", a.channels)
+		r.Get("/libraries", a.libraries)
+		r.Get("/libraries/{"+paramLibrary+"}", a.library)
 
 		const paramChannel = "channel"
 		r.Route("/c/{"+paramChannel+"}", func(r chi.Router) {
@@ -124,6 +138,7 @@ func (a *App) Run(ctx context.Context) error {
 			r.Use(a.channelMiddleware(paramChannel))
 			r.Get("/", a.channel)
 			r.Get("/commands", a.channelCommands)
+			r.Get("/commands.json", a.channelCommandsJSON)
 			r.Get("/quotes", a.channelQuotes)
 			r.Get("/autoreplies", a.channelAutoreplies)
 			r.Get("/lists", a.channelLists)
@@ -131,7 +146,13 @@ func (a *App) Run(ctx context.Context) error {
 			r.Get("/chatrules", a.channelChatRules)
 			r.Get("/scheduled", a.channelScheduled)
 			r.Get("/variables", a.channelVariables)
+			r.Post("/variables", a.channelVariablesPost)
 			r.Get("/highlights", a.channelHighlights)
+			r.Get("/queue", a.channelQueue)
+			r.Get("/raffles", a.channelRaffles)
+			r.Route("/backup", a.routeChannelBackup)
+			r.Route("/test", a.routeChannelTest)
+			r.Route("/shadow", a.routeChannelShadow)
 		})
 
 		r.Route("/api/v1", a.routeAPIv1)
@@ -409,9 +430,94 @@ func (a *App) channelCommands(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !a.canEditChannel(r, channel) {
+		commands = slices.DeleteFunc(commands, func(c dbsql.ListCustomCommandsForWebRow) bool { return c.Hidden })
+	}
+
 	renderTempl(w, r, templates.ChannelCommandsPage(channel, commands))
 }
 
+type commandJSON struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases"`
+	AccessLevel string   `json:"access_level"`
+	Enabled     bool     `json:"enabled"`
+	Description string   `json:"description"`
+	Usage       string   `json:"usage"`
+	Category    string   `json:"category"`
+	Response    *string  `json:"response,omitempty"`
+	Items       []string `json:"items,omitempty"`
+}
+
+func (a *App) channelCommandsJSON(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	commands, err := a.Queries.ListCustomCommandsForWeb(ctx, channel.ID)
+	if err != nil {
+		ctxlog.Error(ctx, "error querying custom commands", zap.Error(err))
+		v1Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	lists, err := a.Queries.ListCommandListsForWeb(ctx, channel.ID)
+	if err != nil {
+		ctxlog.Error(ctx, "error querying command lists", zap.Error(err))
+		v1Error(w, http.StatusInternalServerError)
+		return
+	}
+
+	v := &struct {
+		Channel  string         `json:"channel"`
+		Prefix   string         `json:"prefix"`
+		Commands []*commandJSON `json:"commands"`
+	}{
+		Channel:  channel.Name,
+		Prefix:   channel.Prefix,
+		Commands: []*commandJSON{},
+	}
+
+	for _, c := range commands {
+		if c.Hidden {
+			continue
+		}
+		v.Commands = append(v.Commands, &commandJSON{
+			Name:        c.Name,
+			Aliases:     c.Aliases,
+			AccessLevel: string(c.AccessLevel),
+			Enabled:     c.Enabled,
+			Description: c.Description,
+			Usage:       c.Usage,
+			Category:    c.Category,
+			Response:    &c.Message,
+		})
+	}
+
+	for _, l := range lists {
+		if l.Hidden {
+			continue
+		}
+		v.Commands = append(v.Commands, &commandJSON{
+			Name:        l.Name,
+			Aliases:     l.Aliases,
+			AccessLevel: string(l.AccessLevel),
+			Enabled:     l.Enabled,
+			Description: l.Description,
+			Usage:       l.Usage,
+			Category:    l.Category,
+			Items:       l.Items,
+		})
+	}
+
+	slices.SortFunc(v.Commands, func(a, b *commandJSON) int { return strings.Compare(a.Name, b.Name) })
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		ctxlog.Error(ctx, "failed to write response", zap.Error(err))
+		return
+	}
+}
+
 func (a *App) channelQuotes(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	channel := getChannel(ctx)
@@ -451,6 +557,10 @@ func (a *App) channelLists(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !a.canEditChannel(r, channel) {
+		lists = slices.DeleteFunc(lists, func(l dbsql.ListCommandListsForWebRow) bool { return l.Hidden })
+	}
+
 	renderTempl(w, r, templates.ChannelListsPage(channel, lists))
 }
 
@@ -493,14 +603,113 @@ func (a *App) channelVariables(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	channel := getChannel(ctx)
 
-	variables, err := a.Queries.ListVariables(ctx, channel.ID)
+	now := dbsql.TimestamptzFrom(time.Now())
+
+	variables, err := a.Queries.ListChannelVariables(ctx, dbsql.ListChannelVariablesParams{
+		ChannelID: channel.ID,
+		Now:       now,
+	})
 	if err != nil {
 		ctxlog.Error(ctx, "error querying variables", zap.Error(err))
 		a.httpError(w, r, http.StatusInternalServerError)
 		return
 	}
 
-	renderTempl(w, r, templates.ChannelVariablesPage(channel, variables))
+	userVariables, err := a.Queries.ListUserVariables(ctx, dbsql.ListUserVariablesParams{
+		ChannelID: channel.ID,
+		Now:       now,
+	})
+	if err != nil {
+		ctxlog.Error(ctx, "error querying user variables", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.ChannelVariablesPage(channel, variables, userVariables, a.canEditChannel(r, channel)))
+}
+
+func (a *App) channelVariablesPost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	if !a.canEditChannel(r, channel) {
+		a.notAuthorized(w, r, false)
+		return
+	}
+
+	name := strings.TrimSpace(r.PostFormValue("name"))
+	if name == "" || strings.ContainsFunc(name, unicode.IsSpace) {
+		a.httpError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	userID, err := strconv.ParseInt(cmp.Or(r.PostFormValue("user_id"), "0"), 10, 64)
+	if err != nil || userID < 0 {
+		a.httpError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	switch r.PostFormValue("action") {
+	case "delete":
+		err = a.Queries.DeleteVariable(ctx, dbsql.DeleteVariableParams{
+			ChannelID: channel.ID,
+			UserID:    userID,
+			Name:      name,
+		})
+
+	case "save":
+		kind := cmp.Or(r.PostFormValue("kind"), dbsql.VariableKindText)
+		if !slices.Contains(dbsql.VariableKinds, kind) {
+			a.httpError(w, r, http.StatusBadRequest)
+			return
+		}
+
+		value, ok := dbsql.NormalizeVariableValue(kind, r.PostFormValue("value"))
+		if !ok {
+			a.httpError(w, r, http.StatusBadRequest)
+			return
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if expires := r.PostFormValue("expires"); expires != "" {
+			t, err := time.ParseInLocation("2006-01-02T15:04", expires, channel.Location())
+			if err != nil {
+				a.httpError(w, r, http.StatusBadRequest)
+				return
+			}
+			expiresAt = dbsql.TimestamptzFrom(t)
+		}
+
+		sharedWith := strings.Fields(strings.ToLower(r.PostFormValue("shared")))
+		if slices.Contains(sharedWith, dbsql.VariableSharedWithAll) {
+			sharedWith = []string{dbsql.VariableSharedWithAll}
+		}
+		if sharedWith == nil {
+			sharedWith = []string{}
+		}
+
+		err = a.Queries.SaveVariable(ctx, dbsql.SaveVariableParams{
+			ChannelID:  channel.ID,
+			UserID:     userID,
+			Name:       name,
+			Kind:       kind,
+			Value:      value,
+			ExpiresAt:  expiresAt,
+			SharedWith: sharedWith,
+		})
+
+	default:
+		a.httpError(w, r, http.StatusBadRequest)
+		return
+	}
+
+	if err != nil {
+		ctxlog.Error(ctx, "error updating variable", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/c/"+channel.Name+"/variables", http.StatusSeeOther)
 }
 
 func (a *App) channelHighlights(w http.ResponseWriter, r *http.Request) {
@@ -522,6 +731,37 @@ func (a *App) channelHighlights(w http.ResponseWriter, r *http.Request) {
 	renderTempl(w, r, templates.ChannelHighlightsPage(channel, highlights))
 }
 
+func (a *App) channelQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	entries, err := a.Queries.ListQueueEntries(ctx, channel.ID)
+	if err != nil {
+		ctxlog.Error(ctx, "error querying queue entries", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.ChannelQueuePage(channel, entries))
+}
+
+func (a *App) channelRaffles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	channel := getChannel(ctx)
+
+	winners, err := a.Queries.ListRaffleWinners(ctx, dbsql.ListRaffleWinnersParams{
+		ChannelID: channel.ID,
+		Count:     500,
+	})
+	if err != nil {
+		ctxlog.Error(ctx, "error querying raffle winners", zap.Error(err))
+		a.httpError(w, r, http.StatusInternalServerError)
+		return
+	}
+
+	renderTempl(w, r, templates.ChannelRafflesPage(channel, winners))
+}
+
 func (a *App) login(w http.ResponseWriter, r *http.Request) {
 	renderTempl(w, r, templates.LoginPage())
 }
@@ -8,7 +8,9 @@ import (
 
 	"github.com/hortbot/hortbot/internal/cli"
 	"github.com/hortbot/hortbot/internal/cli/subcommands/bot"
+	"github.com/hortbot/hortbot/internal/cli/subcommands/channelbackup"
 	"github.com/hortbot/hortbot/internal/cli/subcommands/conduit"
+	"github.com/hortbot/hortbot/internal/cli/subcommands/reencrypt"
 	"github.com/hortbot/hortbot/internal/cli/subcommands/web"
 	"github.com/hortbot/hortbot/internal/version"
 )
@@ -41,6 +43,9 @@ func main() {
 	addCommand(bot.Command())
 	addCommand(web.Command())
 	addCommand(conduit.Command())
+	addCommand(reencrypt.Command())
+	addCommand(channelbackup.ExportCommand())
+	addCommand(channelbackup.ImportCommand())
 
 	if len(args) == 0 {
 		fmt.Fprintln(os.Stderr, "Please specify a subcommand.")